@@ -50,10 +50,12 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	changefeedGroup := v2.Group("/changefeeds")
 	changefeedGroup.GET("/:changefeed_id", coordinatorMiddleware, api.getChangeFeed)
 	changefeedGroup.POST("", coordinatorMiddleware, api.createChangefeed)
+	changefeedGroup.POST("/batch", coordinatorMiddleware, api.batchCreateChangefeeds)
 	changefeedGroup.GET("", coordinatorMiddleware, api.listChangeFeeds)
 	changefeedGroup.PUT("/:changefeed_id", coordinatorMiddleware, api.updateChangefeed)
 	changefeedGroup.POST("/:changefeed_id/resume", coordinatorMiddleware, api.resumeChangefeed)
 	changefeedGroup.POST("/:changefeed_id/pause", coordinatorMiddleware, api.pauseChangefeed)
+	changefeedGroup.POST("/:changefeed_id/reset_tables", coordinatorMiddleware, api.resetTables)
 	changefeedGroup.DELETE("/:changefeed_id", coordinatorMiddleware, api.deleteChangefeed)
 
 	// capture apis
@@ -69,6 +71,19 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	ownerGroup.Use(coordinatorMiddleware)
 	ownerGroup.POST("/resign", api.resignOwner)
 
+	// unsafe apis
+	unsafeGroup := v2.Group("/unsafe")
+	unsafeGroup.Use(coordinatorMiddleware)
+	unsafeGroup.GET("/metadata", api.CDCMetaData)
+	unsafeGroup.POST("/resolve_lock", api.ResolveLock)
+	unsafeGroup.DELETE("/service_gc_safepoint", api.DeleteServiceGcSafePoint)
+
+	// log puller apis, they work on the server itself
+	logPullerGroup := v2.Group("/log_puller")
+	logPullerGroup.GET("/stalled_regions", api.getStalledRegions)
+	logPullerGroup.GET("/store_health", api.getStoreHealth)
+	logPullerGroup.POST("/resolve_locks", api.resolveLocks)
+
 	// common APIs
 	v2.POST("/tso", api.QueryTso)
 }
@@ -14,22 +14,30 @@
 package v2
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/schemastore"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columntransformer"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/txnutil/gc"
 	"github.com/pingcap/ticdc/version"
 	"github.com/pingcap/tiflow/cdc/api"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/owner"
 	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/tikv/client-go/v2/oracle"
 	pd "github.com/tikv/pd/client"
@@ -57,50 +65,25 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		return
 	}
 
-	// verify sinkURI
-	if cfg.SinkURI == "" {
-		_ = c.Error(errors.ErrSinkURIInvalid.GenWithStackByArgs(
-			"sink_uri is empty, cannot create a changefeed without sink_uri"))
-		return
-	}
-
-	var changefeedID common.ChangeFeedID
-	if cfg.ID == "" {
-		changefeedID = common.NewChangefeedID()
-	} else {
-		changefeedID = common.NewChangeFeedIDWithName(cfg.ID)
-	}
-	// verify changefeedID
-	if err := model.ValidateChangefeedID(changefeedID.Name()); err != nil {
-		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
-			"invalid changefeed_id: %s", cfg.ID))
-		return
-	}
 	if cfg.Namespace == "" {
 		cfg.Namespace = model.DefaultNamespace
 	}
-	changefeedID.DisplayName.Namespace = cfg.Namespace
-	// verify changefeed namespace
-	if err := model.ValidateNamespace(changefeedID.Namespace()); err != nil {
-		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
-			"invalid namespace: %s", cfg.ID))
-		return
-	}
-
 	ts, logical, err := h.server.GetPdClient().GetTS(ctx)
 	if err != nil {
 		_ = c.Error(errors.ErrPDEtcdAPIError.GenWithStackByArgs("fail to get ts from pd client"))
 		return
 	}
 	currentTSO := oracle.ComposeTS(ts, logical)
-	// verify start ts
 	if cfg.StartTs == 0 {
 		cfg.StartTs = currentTSO
-	} else if cfg.StartTs > currentTSO {
-		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
-			"invalid start-ts %v, larger than current tso %v", cfg.StartTs, currentTSO))
+	}
+	changefeedID, replicaCfg, err := verifyCreateChangefeedConfig(
+		cfg.ID, cfg.Namespace, cfg.SinkURI, cfg.StartTs, cfg.TargetTs, currentTSO, cfg.ReplicaConfig)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
+
 	// Ensure the start ts is valid in the next 3600 seconds, aka 1 hour
 	const ensureTTL = 60 * 60
 	if err = gc.EnsureChangefeedStartTsSafety(
@@ -117,27 +100,6 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		return
 	}
 
-	// verify target ts
-	if cfg.TargetTs > 0 && cfg.TargetTs <= cfg.StartTs {
-		_ = c.Error(errors.ErrTargetTsBeforeStartTs.GenWithStackByArgs(
-			cfg.TargetTs, cfg.StartTs))
-		return
-	}
-
-	// fill replicaConfig
-	replicaCfg := cfg.ReplicaConfig.ToInternalReplicaConfig()
-	// verify replicaConfig
-	sinkURIParsed, err := url.Parse(cfg.SinkURI)
-	if err != nil {
-		_ = c.Error(errors.WrapError(errors.ErrSinkURIInvalid, err))
-		return
-	}
-	err = replicaCfg.ValidateAndAdjust(sinkURIParsed)
-	if err != nil {
-		_ = c.Error(err)
-		return
-	}
-
 	pdClient := h.server.GetPdClient()
 	info := &config.ChangeFeedInfo{
 		UpstreamID:     pdClient.GetClusterID(ctx),
@@ -190,6 +152,228 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		nil))
 }
 
+// verifyCreateChangefeedConfig verifies the config of a changefeed to create,
+// it's shared by the single and the batch create, and returns the changefeed ID
+// and the internal replica config of the changefeed.
+func verifyCreateChangefeedConfig(
+	id, namespace, sinkURI string,
+	startTs, targetTs, currentTSO uint64,
+	replicaConfig *ReplicaConfig,
+) (common.ChangeFeedID, *config.ReplicaConfig, error) {
+	var changefeedID common.ChangeFeedID
+	// verify sinkURI
+	if sinkURI == "" {
+		return changefeedID, nil, errors.ErrSinkURIInvalid.GenWithStackByArgs(
+			"sink_uri is empty, cannot create a changefeed without sink_uri")
+	}
+
+	if id == "" {
+		changefeedID = common.NewChangefeedID()
+	} else {
+		changefeedID = common.NewChangeFeedIDWithName(id)
+	}
+	// verify changefeedID
+	if err := model.ValidateChangefeedID(changefeedID.Name()); err != nil {
+		return changefeedID, nil, errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid changefeed_id: %s", id)
+	}
+	changefeedID.DisplayName.Namespace = namespace
+	// verify changefeed namespace
+	if err := model.ValidateNamespace(changefeedID.Namespace()); err != nil {
+		return changefeedID, nil, errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid namespace: %s", namespace)
+	}
+
+	// verify start ts and target ts
+	if startTs > currentTSO {
+		return changefeedID, nil, errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid start-ts %v, larger than current tso %v", startTs, currentTSO)
+	}
+	if targetTs > 0 && targetTs <= startTs {
+		return changefeedID, nil, errors.ErrTargetTsBeforeStartTs.GenWithStackByArgs(
+			targetTs, startTs)
+	}
+
+	// verify replicaConfig
+	if replicaConfig == nil {
+		return changefeedID, nil, errors.ErrAPIInvalidParam.GenWithStack(
+			"replica_config is required")
+	}
+	replicaCfg := replicaConfig.ToInternalReplicaConfig().Clone()
+	sinkURIParsed, err := url.Parse(sinkURI)
+	if err != nil {
+		return changefeedID, nil, errors.WrapError(errors.ErrSinkURIInvalid, err)
+	}
+	if err := replicaCfg.ValidateAndAdjust(sinkURIParsed); err != nil {
+		return changefeedID, nil, err
+	}
+	if err := verifyColumnTransformers(replicaCfg, startTs); err != nil {
+		return changefeedID, nil, err
+	}
+	return changefeedID, replicaCfg, nil
+}
+
+// batchCreateChangefeeds handles batch create changefeed request,
+// it returns the result of every item, the changefeeds are created only if all items are valid.
+// @Summary Batch create changefeeds
+// @Description create a batch of changefeeds from a template replica config
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeeds body BatchCreateChangefeedConfig true "batch create changefeed config"
+// @Success 200 {object} BatchCreateChangefeedResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/changefeeds/batch [post]
+func (h *OpenAPIV2) batchCreateChangefeeds(c *gin.Context) {
+	ctx := c.Request.Context()
+	cfg := &BatchCreateChangefeedConfig{ReplicaConfig: GetDefaultReplicaConfig()}
+
+	if err := c.BindJSON(&cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	if len(cfg.Items) == 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("no changefeed to create"))
+		return
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = model.DefaultNamespace
+	}
+	if err := model.ValidateNamespace(cfg.Namespace); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid namespace: %s", cfg.Namespace))
+		return
+	}
+
+	pdClient := h.server.GetPdClient()
+	ts, logical, err := pdClient.GetTS(ctx)
+	if err != nil {
+		_ = c.Error(errors.ErrPDEtcdAPIError.GenWithStackByArgs("fail to get ts from pd client"))
+		return
+	}
+	currentTSO := oracle.ComposeTS(ts, logical)
+	if cfg.StartTs == 0 {
+		cfg.StartTs = currentTSO
+	}
+	if cfg.ReplicaConfig == nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("replica_config is required"))
+		return
+	}
+
+	resp := &BatchCreateChangefeedResponse{Items: make([]BatchCreateChangefeedResult, len(cfg.Items))}
+	infos := make([]*config.ChangeFeedInfo, len(cfg.Items))
+	valid := true
+	for i, item := range cfg.Items {
+		info, err := h.newBatchChangefeedInfo(ctx, cfg, item, currentTSO)
+		resp.Items[i].ID = item.ID
+		if err != nil {
+			resp.Items[i].Error = err.Error()
+			valid = false
+			continue
+		}
+		resp.Items[i].ID = info.ChangefeedID.Name()
+		infos[i] = info
+	}
+	if !valid {
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	// Ensure the start ts is valid in the next 3600 seconds, aka 1 hour
+	const ensureTTL = 60 * 60
+	gcServiceID := h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceCreating)
+	var ensured []*config.ChangeFeedInfo
+	created := false
+	defer func() {
+		if created {
+			return
+		}
+		for _, info := range ensured {
+			if err := gc.UndoEnsureChangefeedStartTsSafety(ctx, pdClient, gcServiceID, info.ChangefeedID); err != nil {
+				log.Warn("undo ensure changefeed start ts safety failed",
+					zap.String("changefeed", info.ChangefeedID.String()), zap.Error(err))
+			}
+		}
+	}()
+	for _, info := range infos {
+		if err := gc.EnsureChangefeedStartTsSafety(
+			ctx, pdClient, gcServiceID, info.ChangefeedID, ensureTTL, cfg.StartTs); err != nil {
+			if !errors.ErrStartTsBeforeGC.Equal(err) {
+				_ = c.Error(errors.ErrPDEtcdAPIError.Wrap(err))
+				return
+			}
+			_ = c.Error(err)
+			return
+		}
+		ensured = append(ensured, info)
+	}
+
+	co, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	errs, err := co.CreateChangefeeds(ctx, infos)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	for i, err := range errs {
+		if err != nil {
+			resp.Items[i].Error = err.Error()
+			valid = false
+		}
+	}
+	if !valid {
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	created = true
+	resp.Created = true
+	for i, info := range infos {
+		resp.Items[i].Info = toAPIModel(info, info.StartTs, info.StartTs, nil)
+	}
+	log.Info("Batch create changefeeds successfully!",
+		zap.String("namespace", cfg.Namespace),
+		zap.Int("count", len(infos)))
+	c.JSON(http.StatusOK, resp)
+}
+
+// newBatchChangefeedInfo builds and verifies the changefeed info of an item in the batch,
+// the item is verified in the same way as a single created changefeed.
+func (h *OpenAPIV2) newBatchChangefeedInfo(
+	ctx context.Context,
+	cfg *BatchCreateChangefeedConfig,
+	item BatchChangefeedItem,
+	currentTSO uint64,
+) (*config.ChangeFeedInfo, error) {
+	// substitute the filter of the template, the template itself is never modified
+	replicaConfig := *cfg.ReplicaConfig
+	if item.Filter != nil {
+		replicaConfig.Filter = item.Filter
+	}
+	changefeedID, replicaCfg, err := verifyCreateChangefeedConfig(
+		item.ID, cfg.Namespace, item.SinkURI, cfg.StartTs, cfg.TargetTs, currentTSO, &replicaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	pdClient := h.server.GetPdClient()
+	return &config.ChangeFeedInfo{
+		UpstreamID:     pdClient.GetClusterID(ctx),
+		ChangefeedID:   changefeedID,
+		SinkURI:        item.SinkURI,
+		CreateTime:     time.Now(),
+		StartTs:        cfg.StartTs,
+		TargetTs:       cfg.TargetTs,
+		Config:         replicaCfg,
+		State:          model.StateNormal,
+		CreatorVersion: version.ReleaseVersion,
+		Epoch:          owner.GenerateChangefeedEpoch(ctx, pdClient),
+	}, nil
+}
+
 // listChangeFeeds lists all changgefeeds in cdc cluster
 // @Summary List changefeed
 // @Description list all changefeeds in cdc cluster
@@ -268,9 +452,53 @@ func (h *OpenAPIV2) getChangeFeed(c *gin.Context) {
 		return
 	}
 
+	history, err := co.GetChangefeedErrorHistory(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	violations, err := co.GetChangefeedPlacementViolations(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	taskStatus := make([]model.CaptureTaskStatus, 0)
 	detail := toAPIModel(cfInfo, status.CheckpointTs,
 		status.CheckpointTs, taskStatus)
+	detail.PlacementViolations = violations
+	memoryUsage, err := co.GetChangefeedMemoryUsage(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if memoryUsage != nil {
+		detail.MemoryUsage = toAPIMemoryUsage(memoryUsage)
+	}
+	if cfInfo.NeedBlockGC() {
+		gcStatus, err := co.GetChangefeedGCStatus(c, changefeedDisplayName)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		detail.GCStatus = &ChangefeedGCStatus{
+			GCSafePoint:  gcStatus.GCSafePoint,
+			Distance:     int64(gcStatus.Distance.Seconds()),
+			HoldBack:     int64(gcStatus.HoldBack.Seconds()),
+			TTLRemaining: int64(gcStatus.TTLRemaining.Seconds()),
+		}
+	}
+	for _, decision := range history {
+		detail.ErrorHistory = append(detail.ErrorHistory, ErrorDecision{
+			Time:     model.JSONTime(decision.Time),
+			Code:     decision.Code,
+			Message:  decision.Message,
+			Category: string(decision.Category),
+			Action:   string(decision.Action),
+			Table:    decision.Table,
+		})
+	}
 	c.JSON(http.StatusOK, detail)
 }
 
@@ -469,6 +697,12 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
+	// the schemas may be changed since the column transformers are verified,
+	// and the checkpoint ts may be overwritten, so verify them again.
+	if err := verifyColumnTransformers(cfInfo.Config, newCheckpointTs); err != nil {
+		_ = c.Error(err)
+		return
+	}
 	needRemoveGCSafePoint := false
 	defer func() {
 		if !needRemoveGCSafePoint {
@@ -493,6 +727,207 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 	c.JSON(http.StatusOK, &EmptyResponse{})
 }
 
+// resetTables handles reset tables request.
+// @Summary Reset tables of a changefeed
+// @Description Recreate the dispatchers of the selected tables, which replay events from the reset ts in safe mode
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param namespace query string false "default"
+// @Param resetTablesConfig body ResetTablesConfig true "reset tables config"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/reset_tables [post]
+func (h *OpenAPIV2) resetTables(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), model.DefaultNamespace)
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+
+	cfg := new(ResetTablesConfig)
+	if err := c.BindJSON(&cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	if cfg.ResetTs == 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("reset_ts is required"))
+		return
+	}
+	if len(cfg.Tables) == 0 && len(cfg.Spans) == 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("no table or span to reset"))
+		return
+	}
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, status, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if cfInfo.State != model.StateNormal {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"can not reset tables of changefeed in state %s", cfInfo.State))
+		return
+	}
+	if cfInfo.Config.EnableSyncPoint != nil && *cfInfo.Config.EnableSyncPoint {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"can not reset tables of changefeed with sync point enabled"))
+		return
+	}
+	if cfg.ResetTs > status.CheckpointTs {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid reset_ts %d, larger than checkpoint ts %d", cfg.ResetTs, status.CheckpointTs))
+		return
+	}
+	spans, err := resolveResetSpans(cfInfo, status.CheckpointTs, cfg)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := verifyResumeChangefeedConfig(
+		ctx,
+		h.server.GetPdClient(),
+		h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceResuming),
+		cfInfo.ChangefeedID,
+		cfg.ResetTs); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := coordinator.ResetDispatchers(ctx, cfInfo.ChangefeedID, spans, cfg.ResetTs); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("reset tables of changefeed",
+		zap.String("changefeed", cfInfo.ChangefeedID.String()),
+		zap.Uint64("resetTs", cfg.ResetTs),
+		zap.Int("spans", len(spans)))
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// verifyColumnTransformers verifies the column transformers against the schemas of the tables
+// replicated by the changefeed at the start ts, the partitions of a table are verified once.
+// It's called when the changefeed is created, updated and resumed.
+func verifyColumnTransformers(replicaCfg *config.ReplicaConfig, startTs uint64) error {
+	if replicaCfg.Sink == nil || len(replicaCfg.Sink.ColumnTransformers) == 0 {
+		return nil
+	}
+	transformers, err := columntransformer.NewColumnTransformers(replicaCfg.Sink)
+	if err != nil {
+		return errors.WrapError(errors.ErrAPIInvalidParam, err)
+	}
+	f, err := filter.NewFilter(replicaCfg.Filter, "", replicaCfg.CaseSensitive)
+	if err != nil {
+		return errors.WrapError(errors.ErrAPIInvalidParam, err)
+	}
+	schemaStore := appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	tables, err := schemaStore.GetAllPhysicalTables(startTs, f)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	verified := make(map[Table]struct{})
+	tableInfos := make([]*common.TableInfo, 0)
+	for _, table := range tables {
+		name := Table{Schema: table.SchemaName, Name: table.TableName}
+		if _, ok := verified[name]; ok || !transformers.Match(table.SchemaName, table.TableName) {
+			continue
+		}
+		verified[name] = struct{}{}
+		if err := schemaStore.RegisterTable(table.TableID, startTs); err != nil {
+			return errors.Trace(err)
+		}
+		tableInfo, err := schemaStore.GetTableInfo(table.TableID, startTs)
+		if unregisterErr := schemaStore.UnregisterTable(table.TableID); unregisterErr != nil {
+			log.Warn("failed to unregister table after verifying the column transformers",
+				zap.Int64("tableID", table.TableID), zap.Error(unregisterErr))
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tableInfos = append(tableInfos, tableInfo)
+	}
+	return transformers.Verify(tableInfos)
+}
+
+// resolveResetSpans converts the tables and spans in the reset tables config to table spans.
+// Only the tables replicated by the changefeed can be reset, and a table can not be reset
+// if there is any ddl of the table after the reset ts, since replaying ddl is not supported.
+func resolveResetSpans(
+	cfInfo *config.ChangeFeedInfo,
+	checkpointTs uint64,
+	cfg *ResetTablesConfig,
+) ([]*heartbeatpb.TableSpan, error) {
+	f, err := filter.NewFilter(cfInfo.Config.Filter, "", cfInfo.Config.CaseSensitive)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrAPIInvalidParam, err)
+	}
+	schemaStore := appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	tables, err := schemaStore.GetAllPhysicalTables(checkpointTs, f)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tableIDs := make(map[int64]struct{}, len(tables))
+	tableIDsByName := make(map[Table][]int64)
+	for _, table := range tables {
+		tableIDs[table.TableID] = struct{}{}
+		name := Table{Schema: table.SchemaName, Name: table.TableName}
+		tableIDsByName[name] = append(tableIDsByName[name], table.TableID)
+	}
+
+	var spans []*heartbeatpb.TableSpan
+	for _, table := range cfg.Tables {
+		ids, ok := tableIDsByName[table]
+		if !ok {
+			return nil, errors.ErrAPIInvalidParam.GenWithStack(
+				"table %s.%s is not replicated by the changefeed", table.Schema, table.Name)
+		}
+		for _, id := range ids {
+			span := spanz.TableIDToComparableSpan(id)
+			spans = append(spans, &heartbeatpb.TableSpan{TableID: id, StartKey: span.StartKey, EndKey: span.EndKey})
+		}
+	}
+	for _, s := range cfg.Spans {
+		if _, ok := tableIDs[s.TableID]; !ok {
+			return nil, errors.ErrAPIInvalidParam.GenWithStack(
+				"table %d is not replicated by the changefeed", s.TableID)
+		}
+		span := spanz.TableIDToComparableSpan(s.TableID)
+		tableSpan := &heartbeatpb.TableSpan{TableID: s.TableID, StartKey: span.StartKey, EndKey: span.EndKey}
+		if s.StartKey != "" {
+			if tableSpan.StartKey, err = hex.DecodeString(s.StartKey); err != nil {
+				return nil, errors.WrapError(errors.ErrAPIInvalidParam, err)
+			}
+		}
+		if s.EndKey != "" {
+			if tableSpan.EndKey, err = hex.DecodeString(s.EndKey); err != nil {
+				return nil, errors.WrapError(errors.ErrAPIInvalidParam, err)
+			}
+		}
+		if bytes.Compare(tableSpan.StartKey, tableSpan.EndKey) >= 0 {
+			return nil, errors.ErrAPIInvalidParam.GenWithStack(
+				"invalid span [%s, %s) of table %d", s.StartKey, s.EndKey, s.TableID)
+		}
+		spans = append(spans, tableSpan)
+	}
+
+	for _, span := range spans {
+		state := schemaStore.GetTableDDLEventState(span.TableID)
+		if state.MaxEventCommitTs > cfg.ResetTs {
+			return nil, errors.ErrAPIInvalidParam.GenWithStack(
+				"can not reset table %d to %d, the table has ddl at %d after the reset ts",
+				span.TableID, cfg.ResetTs, state.MaxEventCommitTs)
+		}
+	}
+	return spans, nil
+}
+
 // updateChangefeed handles update changefeed request,
 // it returns the updated changefeedInfo
 // Can only update a changefeed's: TargetTs, SinkURI,
@@ -570,6 +1005,12 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 			GenWithStackByArgs(errors.Cause(err).Error()))
 		return
 	}
+	// the column transformers are verified against the schemas at the checkpoint ts,
+	// where the changefeed is resumed after the update
+	if err := verifyColumnTransformers(oldCfInfo.Config, status.CheckpointTs); err != nil {
+		_ = c.Error(err)
+		return
+	}
 	if err := coordinator.UpdateChangefeed(ctx, oldCfInfo); err != nil {
 		_ = c.Error(err)
 		return
@@ -619,3 +1060,19 @@ func verifyResumeChangefeedConfig(
 
 	return nil
 }
+
+func toAPIMemoryUsage(usage *heartbeatpb.MemoryUsage) *ChangefeedMemoryUsage {
+	res := &ChangefeedMemoryUsage{
+		Quota: usage.Quota,
+		Components: map[string]uint64{
+			string(memquota.EventCollector):    usage.EventCollector,
+			string(memquota.Sink):              usage.Sink,
+			string(memquota.Encoder):           usage.Encoder,
+			string(memquota.MySQLPreparedDMLs): usage.MysqlPreparedDmls,
+		},
+	}
+	for _, used := range res.Components {
+		res.Used += used
+	}
+	return res
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+// defaultStalledThreshold is the default threshold of the stalled regions
+const defaultStalledThreshold = time.Minute
+
+// getStalledRegions returns the stalled regions subscribed by the log puller of the server
+// @Summary Get the stalled regions
+// @Description get the subscribed regions whose resolved ts is stalled longer than the threshold
+// @Tags log_puller,v2
+// @Produce json
+// @Param threshold query integer false "the threshold in seconds, default 60"
+// @Success 200 {array} StalledRegion
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/log_puller/stalled_regions [get]
+func (h *OpenAPIV2) getStalledRegions(c *gin.Context) {
+	threshold := defaultStalledThreshold
+	if value := c.Query("threshold"); value != "" {
+		seconds, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid threshold: %s", value))
+			return
+		}
+		threshold = time.Duration(seconds) * time.Second
+	}
+
+	store := appcontext.GetService[eventstore.EventStore](appcontext.EventStore)
+	regions := store.GetStalledRegions(c, threshold)
+	resp := make([]StalledRegion, 0, len(regions))
+	for _, region := range regions {
+		stalled := StalledRegion{
+			SubscriptionID: uint64(region.SubscriptionID),
+			TableID:        region.Span.TableID,
+			RegionID:       region.RegionID,
+			StoreID:        region.StoreID,
+			StoreAddr:      region.StoreAddr,
+			StoreUnhealthy: region.StoreUnhealthy,
+			Initialized:    region.Initialized,
+			ResolvedTs:     region.ResolvedTs,
+			LockCount:      len(region.Locks),
+			Error:          region.Error,
+		}
+		// the locks of the same transaction are reported once
+		txns := make(map[uint64]struct{})
+		for _, lock := range region.Locks {
+			if _, ok := txns[lock.TxnID]; ok {
+				continue
+			}
+			txns[lock.TxnID] = struct{}{}
+			stalled.Locks = append(stalled.Locks, RegionLock{
+				StartTs: lock.TxnID,
+				Primary: hex.EncodeToString(lock.Primary),
+				TTL:     lock.TTL,
+			})
+		}
+		resp = append(resp, stalled)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// getStoreHealth returns the health of the TiKV stores requested by the log puller of the server
+// @Summary Get the health of the TiKV stores
+// @Description get the error rate, rtt, stream restarts and circuit state of the requested stores
+// @Tags log_puller,v2
+// @Produce json
+// @Success 200 {array} StoreHealth
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/log_puller/store_health [get]
+func (h *OpenAPIV2) getStoreHealth(c *gin.Context) {
+	store := appcontext.GetService[eventstore.EventStore](appcontext.EventStore)
+	stores := store.GetStoreHealth()
+	resp := make([]StoreHealth, 0, len(stores))
+	for _, s := range stores {
+		resp = append(resp, StoreHealth{
+			StoreID:             s.StoreID,
+			StoreAddr:           s.StoreAddr,
+			Unhealthy:           s.Unhealthy,
+			RegionCount:         s.RegionCount,
+			ErrorRate:           s.ErrorRate,
+			StreamRestarts:      s.StreamRestarts,
+			ConsecutiveFailures: s.ConsecutiveFailures,
+			RTTMs:               s.RTT.Milliseconds(),
+			LastReceive:         s.LastReceive,
+			BackoffMs:           s.Backoff.Milliseconds(),
+			LastError:           s.LastError,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// resolveLocks resolves the locks in the subscribed regions of a table or a region
+// @Summary Resolve the locks in the subscribed regions
+// @Description resolve the locks before the current time in the regions of the table or the region
+// @Tags log_puller,v2
+// @Accept json
+// @Produce json
+// @Param resolveLocksReq body ResolveLocksReq true "resolve locks request"
+// @Success 200 {object} ResolveLocksResp
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/log_puller/resolve_locks [post]
+func (h *OpenAPIV2) resolveLocks(c *gin.Context) {
+	var req ResolveLocksReq
+	if err := c.BindJSON(&req); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	if req.TableID == 0 && req.RegionID == 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("table_id or region_id is required"))
+		return
+	}
+
+	store := appcontext.GetService[eventstore.EventStore](appcontext.EventStore)
+	regions, err := store.ResolveLocks(c, req.TableID, req.RegionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &ResolveLocksResp{Regions: regions})
+}
@@ -82,6 +82,54 @@ type ResumeChangefeedConfig struct {
 	OverwriteCheckpointTs uint64 `json:"overwrite_checkpoint_ts"`
 }
 
+// BatchCreateChangefeedConfig is used by batch create changefeed api,
+// each item creates a changefeed from the template replica config with its own sink uri and filter.
+type BatchCreateChangefeedConfig struct {
+	Namespace     string                `json:"namespace"`
+	StartTs       uint64                `json:"start_ts"`
+	TargetTs      uint64                `json:"target_ts"`
+	ReplicaConfig *ReplicaConfig        `json:"replica_config"`
+	Items         []BatchChangefeedItem `json:"items"`
+}
+
+// BatchChangefeedItem is the substitution of a changefeed in the batch,
+// the filter of the template replica config is replaced if the filter is set.
+type BatchChangefeedItem struct {
+	ID      string        `json:"changefeed_id"`
+	SinkURI string        `json:"sink_uri"`
+	Filter  *FilterConfig `json:"filter,omitempty"`
+}
+
+// BatchCreateChangefeedResponse is the response of batch create changefeed api,
+// the changefeeds are created only if all items are valid.
+type BatchCreateChangefeedResponse struct {
+	Created bool                          `json:"created"`
+	Items   []BatchCreateChangefeedResult `json:"items"`
+}
+
+// BatchCreateChangefeedResult is the result of an item in the batch
+type BatchCreateChangefeedResult struct {
+	ID    string          `json:"changefeed_id"`
+	Info  *ChangeFeedInfo `json:"info,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// ResetTablesConfig is used by reset tables api,
+// the dispatchers of the tables and spans replay events from the reset ts.
+type ResetTablesConfig struct {
+	Tables  []Table     `json:"tables,omitempty"`
+	Spans   []TableSpan `json:"spans,omitempty"`
+	ResetTs uint64      `json:"reset_ts"`
+}
+
+// TableSpan represents a key range of a table, the keys are hex encoded.
+// An empty start key and end key means the whole table.
+type TableSpan struct {
+	TableID  int64  `json:"table_id"`
+	StartKey string `json:"start_key,omitempty"`
+	EndKey   string `json:"end_key,omitempty"`
+}
+
 // PDConfig is a configuration used to connect to pd
 type PDConfig struct {
 	PDAddrs       []string `json:"pd_addrs,omitempty"`
@@ -111,6 +159,46 @@ type SyncedStatusConfig struct {
 	CheckpointInterval int64 `json:"checkpoint_interval"`
 }
 
+// ErrorPolicyConfig represents the action of each error category for a changefeed,
+// the action is one of "retry", "pause", "fail", "skip-table" and "alert-only".
+type ErrorPolicyConfig struct {
+	DownstreamUnavailable string `json:"downstream_unavailable,omitempty"`
+	DataIncompatible      string `json:"data_incompatible,omitempty"`
+	UpstreamGC            string `json:"upstream_gc,omitempty"`
+	Internal              string `json:"internal,omitempty"`
+}
+
+// ErrorDecision is the action taken for an error of a changefeed
+type ErrorDecision struct {
+	Time     model.JSONTime `json:"time"`
+	Code     string         `json:"code"`
+	Message  string         `json:"message"`
+	Category string         `json:"category"`
+	Action   string         `json:"action"`
+	Table    string         `json:"table,omitempty"`
+}
+
+// ChangefeedGCStatus is the status of a changefeed against the GC safepoint,
+// the durations are in seconds.
+type ChangefeedGCStatus struct {
+	GCSafePoint uint64 `json:"gc_safepoint"`
+	// Distance is how far the checkpoint is ahead of the GC safepoint,
+	// it's negative if the data needed by the changefeed is already GCed.
+	Distance int64 `json:"gc_safepoint_distance"`
+	// HoldBack is how long the changefeed holds back the GC safepoint.
+	HoldBack int64 `json:"gc_hold_back"`
+	// TTLRemaining is the time left before the hold back exceeds the gc-ttl.
+	TTLRemaining int64 `json:"gc_ttl_remaining"`
+}
+
+// ChangefeedMemoryUsage is the memory used by a changefeed summed over all nodes, in bytes.
+type ChangefeedMemoryUsage struct {
+	Quota uint64 `json:"quota"`
+	Used  uint64 `json:"used"`
+	// Components is the memory used by each component, such as the event collector and the sink.
+	Components map[string]uint64 `json:"components"`
+}
+
 // MarshalJSON marshal changefeed common info to json
 // we need to set feed state to normal if it is uninitialized and pending to warning
 // to hide the detail of uninitialized and pending state from user
@@ -204,6 +292,7 @@ type ReplicaConfig struct {
 	Integrity                    *IntegrityConfig           `json:"integrity"`
 	ChangefeedErrorStuckDuration *JSONDuration              `json:"changefeed_error_stuck_duration,omitempty"`
 	SyncedStatus                 *SyncedStatusConfig        `json:"synced_status,omitempty"`
+	ErrorPolicy                  *ErrorPolicyConfig         `json:"error_policy,omitempty"`
 
 	// Deprecated: we don't use this field since v8.0.0.
 	SQLMode string `json:"sql_mode,omitempty"`
@@ -285,6 +374,17 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				Columns: selector.Columns,
 			})
 		}
+		var columnTransformers []*config.ColumnTransformer
+		for _, transformer := range c.Sink.ColumnTransformers {
+			columnTransformers = append(columnTransformers, &config.ColumnTransformer{
+				Matcher:    transformer.Matcher,
+				Column:     transformer.Column,
+				Function:   transformer.Function,
+				Value:      transformer.Value,
+				Length:     transformer.Length,
+				Expression: transformer.Expression,
+			})
+		}
 		var csvConfig *config.CSVConfig
 		if c.Sink.CSVConfig != nil {
 			csvConfig = &config.CSVConfig{
@@ -401,6 +501,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				Cert:                         c.Sink.KafkaConfig.Cert,
 				Key:                          c.Sink.KafkaConfig.Key,
 				InsecureSkipVerify:           c.Sink.KafkaConfig.InsecureSkipVerify,
+				ExactlyOnce:                  c.Sink.KafkaConfig.ExactlyOnce,
 				CodecConfig:                  codeConfig,
 				LargeMessageHandle:           largeMessageHandle,
 				GlueSchemaRegistryConfig:     glueSchemaRegistryConfig,
@@ -458,6 +559,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			Protocol:                         c.Sink.Protocol,
 			CSVConfig:                        csvConfig,
 			ColumnSelectors:                  columnSelectors,
+			ColumnTransformers:               columnTransformers,
 			SchemaRegistry:                   c.Sink.SchemaRegistry,
 			EncoderConcurrency:               c.Sink.EncoderConcurrency,
 			Terminator:                       c.Sink.Terminator,
@@ -502,6 +604,14 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 		if c.Sink.SendAllBootstrapAtStart != nil {
 			res.Sink.SendAllBootstrapAtStart = util.AddressOf(*c.Sink.SendAllBootstrapAtStart)
 		}
+
+		if c.Sink.SendWatermarkIntervalInMs != nil {
+			res.Sink.SendWatermarkIntervalInMs = util.AddressOf(*c.Sink.SendWatermarkIntervalInMs)
+		}
+
+		if c.Sink.SchemaChangeMessage != nil {
+			res.Sink.SchemaChangeMessage = util.AddressOf(*c.Sink.SchemaChangeMessage)
+		}
 	}
 	if c.Mounter != nil {
 		res.Mounter = &config.MounterConfig{
@@ -513,6 +623,13 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			EnableTableAcrossNodes: c.Scheduler.EnableTableAcrossNodes,
 			RegionThreshold:        c.Scheduler.RegionThreshold,
 			WriteKeyThreshold:      c.Scheduler.WriteKeyThreshold,
+			BalanceStrategy:        c.Scheduler.BalanceStrategy,
+		}
+		if c.Scheduler.Placement != nil {
+			res.Scheduler.Placement = &config.PlacementConfig{
+				NodeAffinity:     c.Scheduler.Placement.NodeAffinity,
+				NodeAntiAffinity: c.Scheduler.Placement.NodeAntiAffinity,
+			}
 		}
 	}
 	if c.Integrity != nil {
@@ -530,6 +647,14 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			CheckpointInterval:  c.SyncedStatus.CheckpointInterval,
 		}
 	}
+	if c.ErrorPolicy != nil {
+		res.ErrorPolicy = &config.ErrorPolicyConfig{
+			DownstreamUnavailable: config.ErrorAction(c.ErrorPolicy.DownstreamUnavailable),
+			DataIncompatible:      config.ErrorAction(c.ErrorPolicy.DataIncompatible),
+			UpstreamGC:            config.ErrorAction(c.ErrorPolicy.UpstreamGC),
+			Internal:              config.ErrorAction(c.ErrorPolicy.Internal),
+		}
+	}
 	return res
 }
 
@@ -589,6 +714,17 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				Columns: selector.Columns,
 			})
 		}
+		var columnTransformers []*ColumnTransformer
+		for _, transformer := range cloned.Sink.ColumnTransformers {
+			columnTransformers = append(columnTransformers, &ColumnTransformer{
+				Matcher:    transformer.Matcher,
+				Column:     transformer.Column,
+				Function:   transformer.Function,
+				Value:      transformer.Value,
+				Length:     transformer.Length,
+				Expression: transformer.Expression,
+			})
+		}
 		var csvConfig *CSVConfig
 		if cloned.Sink.CSVConfig != nil {
 			csvConfig = &CSVConfig{
@@ -672,6 +808,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				Cert:                         cloned.Sink.KafkaConfig.Cert,
 				Key:                          cloned.Sink.KafkaConfig.Key,
 				InsecureSkipVerify:           cloned.Sink.KafkaConfig.InsecureSkipVerify,
+				ExactlyOnce:                  cloned.Sink.KafkaConfig.ExactlyOnce,
 				CodecConfig:                  codeConfig,
 				LargeMessageHandle:           largeMessageHandle,
 				GlueSchemaRegistryConfig:     glueSchemaRegistryConfig,
@@ -761,6 +898,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			DispatchRules:                    dispatchRules,
 			CSVConfig:                        csvConfig,
 			ColumnSelectors:                  columnSelectors,
+			ColumnTransformers:               columnTransformers,
 			EncoderConcurrency:               cloned.Sink.EncoderConcurrency,
 			Terminator:                       cloned.Sink.Terminator,
 			DateSeparator:                    cloned.Sink.DateSeparator,
@@ -802,6 +940,14 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			res.Sink.SendAllBootstrapAtStart = util.AddressOf(*cloned.Sink.SendAllBootstrapAtStart)
 		}
 
+		if cloned.Sink.SendWatermarkIntervalInMs != nil {
+			res.Sink.SendWatermarkIntervalInMs = util.AddressOf(*cloned.Sink.SendWatermarkIntervalInMs)
+		}
+
+		if cloned.Sink.SchemaChangeMessage != nil {
+			res.Sink.SchemaChangeMessage = util.AddressOf(*cloned.Sink.SchemaChangeMessage)
+		}
+
 		if cloned.Sink.DebeziumDisableSchema != nil {
 			res.Sink.DebeziumDisableSchema = util.AddressOf(*cloned.Sink.DebeziumDisableSchema)
 		}
@@ -836,6 +982,13 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			EnableTableAcrossNodes: cloned.Scheduler.EnableTableAcrossNodes,
 			RegionThreshold:        cloned.Scheduler.RegionThreshold,
 			WriteKeyThreshold:      cloned.Scheduler.WriteKeyThreshold,
+			BalanceStrategy:        cloned.Scheduler.BalanceStrategy,
+		}
+		if cloned.Scheduler.Placement != nil {
+			res.Scheduler.Placement = &PlacementConfig{
+				NodeAffinity:     cloned.Scheduler.Placement.NodeAffinity,
+				NodeAntiAffinity: cloned.Scheduler.Placement.NodeAntiAffinity,
+			}
 		}
 	}
 
@@ -854,6 +1007,14 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			CheckpointInterval:  cloned.SyncedStatus.CheckpointInterval,
 		}
 	}
+	if cloned.ErrorPolicy != nil {
+		res.ErrorPolicy = &ErrorPolicyConfig{
+			DownstreamUnavailable: string(cloned.ErrorPolicy.DownstreamUnavailable),
+			DataIncompatible:      string(cloned.ErrorPolicy.DataIncompatible),
+			UpstreamGC:            string(cloned.ErrorPolicy.UpstreamGC),
+			Internal:              string(cloned.ErrorPolicy.Internal),
+		}
+	}
 	return res
 }
 
@@ -943,34 +1104,37 @@ type Table struct {
 // SinkConfig represents sink config for a changefeed
 // This is a duplicate of config.SinkConfig
 type SinkConfig struct {
-	Protocol                         *string             `json:"protocol,omitempty"`
-	SchemaRegistry                   *string             `json:"schema_registry,omitempty"`
-	CSVConfig                        *CSVConfig          `json:"csv,omitempty"`
-	DispatchRules                    []*DispatchRule     `json:"dispatchers,omitempty"`
-	ColumnSelectors                  []*ColumnSelector   `json:"column_selectors,omitempty"`
-	TxnAtomicity                     *string             `json:"transaction_atomicity,omitempty"`
-	EncoderConcurrency               *int                `json:"encoder_concurrency,omitempty"`
-	Terminator                       *string             `json:"terminator,omitempty"`
-	DateSeparator                    *string             `json:"date_separator,omitempty"`
-	EnablePartitionSeparator         *bool               `json:"enable_partition_separator,omitempty"`
-	FileIndexWidth                   *int                `json:"file_index_width,omitempty"`
-	EnableKafkaSinkV2                *bool               `json:"enable_kafka_sink_v2,omitempty"`
-	OnlyOutputUpdatedColumns         *bool               `json:"only_output_updated_columns,omitempty"`
-	DeleteOnlyOutputHandleKeyColumns *bool               `json:"delete_only_output_handle_key_columns"`
-	ContentCompatible                *bool               `json:"content_compatible"`
-	SafeMode                         *bool               `json:"safe_mode,omitempty"`
-	KafkaConfig                      *KafkaConfig        `json:"kafka_config,omitempty"`
-	PulsarConfig                     *PulsarConfig       `json:"pulsar_config,omitempty"`
-	MySQLConfig                      *MySQLConfig        `json:"mysql_config,omitempty"`
-	CloudStorageConfig               *CloudStorageConfig `json:"cloud_storage_config,omitempty"`
-	AdvanceTimeoutInSec              *uint               `json:"advance_timeout,omitempty"`
-	SendBootstrapIntervalInSec       *int64              `json:"send_bootstrap_interval_in_sec,omitempty"`
-	SendBootstrapInMsgCount          *int32              `json:"send_bootstrap_in_msg_count,omitempty"`
-	SendBootstrapToAllPartition      *bool               `json:"send_bootstrap_to_all_partition,omitempty"`
-	SendAllBootstrapAtStart          *bool               `json:"send-all-bootstrap-at-start,omitempty"`
-	DebeziumDisableSchema            *bool               `json:"debezium_disable_schema,omitempty"`
-	DebeziumConfig                   *DebeziumConfig     `json:"debezium,omitempty"`
-	OpenProtocolConfig               *OpenProtocolConfig `json:"open,omitempty"`
+	Protocol                         *string              `json:"protocol,omitempty"`
+	SchemaRegistry                   *string              `json:"schema_registry,omitempty"`
+	CSVConfig                        *CSVConfig           `json:"csv,omitempty"`
+	DispatchRules                    []*DispatchRule      `json:"dispatchers,omitempty"`
+	ColumnSelectors                  []*ColumnSelector    `json:"column_selectors,omitempty"`
+	ColumnTransformers               []*ColumnTransformer `json:"column_transformers,omitempty"`
+	TxnAtomicity                     *string              `json:"transaction_atomicity,omitempty"`
+	EncoderConcurrency               *int                 `json:"encoder_concurrency,omitempty"`
+	Terminator                       *string              `json:"terminator,omitempty"`
+	DateSeparator                    *string              `json:"date_separator,omitempty"`
+	EnablePartitionSeparator         *bool                `json:"enable_partition_separator,omitempty"`
+	FileIndexWidth                   *int                 `json:"file_index_width,omitempty"`
+	EnableKafkaSinkV2                *bool                `json:"enable_kafka_sink_v2,omitempty"`
+	OnlyOutputUpdatedColumns         *bool                `json:"only_output_updated_columns,omitempty"`
+	DeleteOnlyOutputHandleKeyColumns *bool                `json:"delete_only_output_handle_key_columns"`
+	ContentCompatible                *bool                `json:"content_compatible"`
+	SafeMode                         *bool                `json:"safe_mode,omitempty"`
+	KafkaConfig                      *KafkaConfig         `json:"kafka_config,omitempty"`
+	PulsarConfig                     *PulsarConfig        `json:"pulsar_config,omitempty"`
+	MySQLConfig                      *MySQLConfig         `json:"mysql_config,omitempty"`
+	CloudStorageConfig               *CloudStorageConfig  `json:"cloud_storage_config,omitempty"`
+	AdvanceTimeoutInSec              *uint                `json:"advance_timeout,omitempty"`
+	SendBootstrapIntervalInSec       *int64               `json:"send_bootstrap_interval_in_sec,omitempty"`
+	SendBootstrapInMsgCount          *int32               `json:"send_bootstrap_in_msg_count,omitempty"`
+	SendBootstrapToAllPartition      *bool                `json:"send_bootstrap_to_all_partition,omitempty"`
+	SendAllBootstrapAtStart          *bool                `json:"send-all-bootstrap-at-start,omitempty"`
+	SendWatermarkIntervalInMs        *int64               `json:"send_watermark_interval_in_ms,omitempty"`
+	SchemaChangeMessage              *string              `json:"schema_change_message,omitempty"`
+	DebeziumDisableSchema            *bool                `json:"debezium_disable_schema,omitempty"`
+	DebeziumConfig                   *DebeziumConfig      `json:"debezium,omitempty"`
+	OpenProtocolConfig               *OpenProtocolConfig  `json:"open,omitempty"`
 }
 
 // CSVConfig denotes the csv config
@@ -1011,6 +1175,17 @@ type ColumnSelector struct {
 	Columns []string `json:"columns,omitempty"`
 }
 
+// ColumnTransformer represents a rule to transform the value of a column.
+// This is a duplicate of config.ColumnTransformer
+type ColumnTransformer struct {
+	Matcher    []string `json:"matcher,omitempty"`
+	Column     string   `json:"column,omitempty"`
+	Function   string   `json:"function,omitempty"`
+	Value      *string  `json:"value,omitempty"`
+	Length     int      `json:"length,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+}
+
 // ConsistentConfig represents replication consistency config for a changefeed
 // This is a duplicate of config.ConsistentConfig
 type ConsistentConfig struct {
@@ -1043,6 +1218,17 @@ type ChangefeedSchedulerConfig struct {
 	RegionThreshold int `toml:"region_threshold" json:"region_threshold"`
 	// WriteKeyThreshold is the written keys threshold of splitting a table.
 	WriteKeyThreshold int `toml:"write_key_threshold" json:"write_key_threshold"`
+	// BalanceStrategy is the strategy to balance the table spans among nodes.
+	BalanceStrategy string `toml:"balance_strategy" json:"balance_strategy"`
+	// Placement constrains the nodes which the changefeed can be placed on.
+	Placement *PlacementConfig `toml:"placement" json:"placement,omitempty"`
+}
+
+// PlacementConfig selects the nodes by their labels.
+// This is a duplicate of config.PlacementConfig
+type PlacementConfig struct {
+	NodeAffinity     map[string]string `json:"node_affinity,omitempty"`
+	NodeAntiAffinity map[string]string `json:"node_anti_affinity,omitempty"`
 }
 
 // IntegrityConfig is the config for integrity check
@@ -1065,6 +1251,62 @@ type ResolveLockReq struct {
 	PDConfig
 }
 
+// StalledRegion is a subscribed region whose resolved ts doesn't advance
+// for longer than a threshold
+type StalledRegion struct {
+	SubscriptionID uint64 `json:"subscription_id"`
+	TableID        int64  `json:"table_id"`
+	RegionID       uint64 `json:"region_id"`
+	StoreID        uint64 `json:"store_id"`
+	StoreAddr      string `json:"store_addr"`
+	// StoreUnhealthy is true if the store fails consecutively
+	StoreUnhealthy bool   `json:"store_unhealthy"`
+	Initialized    bool   `json:"initialized"`
+	ResolvedTs     uint64 `json:"resolved_ts"`
+	// LockCount is the count of the scanned locks in the region
+	LockCount int `json:"lock_count"`
+	// Locks are the transactions holding the scanned locks
+	Locks []RegionLock `json:"locks,omitempty"`
+	Error string       `json:"error,omitempty"`
+}
+
+// RegionLock describes a transaction holding locks in a region
+type RegionLock struct {
+	StartTs uint64 `json:"start_ts"`
+	Primary string `json:"primary"`
+	TTL     uint64 `json:"ttl"`
+}
+
+// StoreHealth is the health of a TiKV store observed by the log puller
+type StoreHealth struct {
+	StoreID     uint64 `json:"store_id"`
+	StoreAddr   string `json:"store_addr"`
+	Unhealthy   bool   `json:"unhealthy"`
+	RegionCount int    `json:"region_count"`
+	// ErrorRate is the ratio of region errors to region requests recently
+	ErrorRate           float64 `json:"error_rate"`
+	StreamRestarts      uint64  `json:"stream_restarts"`
+	ConsecutiveFailures int     `json:"consecutive_failures"`
+	// RTTMs is the smoothed duration of establishing a stream to the store
+	RTTMs       int64     `json:"rtt_ms"`
+	LastReceive time.Time `json:"last_receive,omitempty"`
+	// BackoffMs is the interval before reconnecting the store
+	BackoffMs int64  `json:"backoff_ms"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// ResolveLocksReq contains request parameter to resolve locks in the
+// subscribed regions of a table or a region
+type ResolveLocksReq struct {
+	TableID  int64  `json:"table_id,omitempty"`
+	RegionID uint64 `json:"region_id,omitempty"`
+}
+
+// ResolveLocksResp is the response of resolving locks in the subscribed regions
+type ResolveLocksResp struct {
+	Regions []uint64 `json:"regions"`
+}
+
 // ChangeFeedInfo describes the detail of a ChangeFeed
 type ChangeFeedInfo struct {
 	UpstreamID uint64    `json:"upstream_id,omitempty"`
@@ -1087,6 +1329,16 @@ type ChangeFeedInfo struct {
 	CheckpointTs   uint64                    `json:"checkpoint_ts"`
 	CheckpointTime model.JSONTime            `json:"checkpoint_time"`
 	TaskStatus     []model.CaptureTaskStatus `json:"task_status,omitempty"`
+	// ErrorHistory is the recent decisions made for the errors of the changefeed
+	ErrorHistory []ErrorDecision `json:"error_history,omitempty"`
+	// PlacementViolations are the violations of the placement rules of the changefeed
+	PlacementViolations []string `json:"placement_violations,omitempty"`
+	// GCStatus is the status of the changefeed against the GC safepoint,
+	// it's empty if the changefeed doesn't block the GC safepoint
+	GCStatus *ChangefeedGCStatus `json:"gc_status,omitempty"`
+	// MemoryUsage is the memory used by the changefeed against its memory quota,
+	// it's empty if the changefeed doesn't report it yet
+	MemoryUsage *ChangefeedMemoryUsage `json:"memory_usage,omitempty"`
 }
 
 // SyncedStatus describes the detail of a changefeed's synced status
@@ -1259,6 +1511,7 @@ type KafkaConfig struct {
 	Cert                         *string                   `json:"cert,omitempty"`
 	Key                          *string                   `json:"key,omitempty"`
 	InsecureSkipVerify           *bool                     `json:"insecure_skip_verify,omitempty"`
+	ExactlyOnce                  *bool                     `json:"exactly_once,omitempty"`
 	CodecConfig                  *CodecConfig              `json:"codec_config,omitempty"`
 	LargeMessageHandle           *LargeMessageHandleConfig `json:"large_message_handle,omitempty"`
 	GlueSchemaRegistryConfig     *GlueSchemaRegistryConfig `json:"glue_schema_registry_config,omitempty"`
@@ -0,0 +1,159 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/logservice/txnutil"
+	"github.com/pingcap/ticdc/logservice/upstream"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/tikv/client-go/v2/tikv"
+	pd "github.com/tikv/pd/client"
+)
+
+// CDCMetaData returns all etcd key values used by cdc
+// @Summary Get the metadata of the cluster
+// @Description dump all etcd key values under the cluster prefix
+// @Tags unsafe,v2
+// @Produce json
+// @Success 200 {array} EtcdData
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/unsafe/metadata [get]
+func (h *OpenAPIV2) CDCMetaData(c *gin.Context) {
+	kvs, err := h.server.GetEtcdClient().GetAllCDCInfo(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	resp := make([]EtcdData, 0, len(kvs))
+	for _, pair := range kvs {
+		resp = append(resp, EtcdData{
+			Key:   string(pair.Key),
+			Value: string(pair.Value),
+		})
+	}
+	c.IndentedJSON(http.StatusOK, resp)
+}
+
+// ResolveLock resolves the locks in a region
+// @Summary Resolve the locks in a region
+// @Description resolve the locks before the ts in the region
+// @Tags unsafe,v2
+// @Accept json
+// @Produce json
+// @Param resolveLockReq body ResolveLockReq true "resolve lock request"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/unsafe/resolve_lock [post]
+func (h *OpenAPIV2) ResolveLock(c *gin.Context) {
+	var resolveLockReq ResolveLockReq
+	if err := c.BindJSON(&resolveLockReq); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	if resolveLockReq.RegionID == 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("region_id is required"))
+		return
+	}
+
+	kvStorage := h.server.GetKVStorage()
+	if len(resolveLockReq.PDAddrs) > 0 {
+		var err error
+		// the storage is shared by the same pd cluster, so it's not closed here
+		kvStorage, err = upstream.CreateTiStore(strings.Join(resolveLockReq.PDAddrs, ","),
+			&security.Credential{
+				CAPath:   resolveLockReq.CAPath,
+				CertPath: resolveLockReq.CertPath,
+				KeyPath:  resolveLockReq.KeyPath,
+			})
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+	tikvStorage, ok := kvStorage.(tikv.Storage)
+	if !ok {
+		c.Status(http.StatusServiceUnavailable)
+		return
+	}
+
+	err := txnutil.NewLockerResolver(tikvStorage).
+		Resolve(c, resolveLockReq.RegionID, resolveLockReq.Ts)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// DeleteServiceGcSafePoint deletes the CDC service GC safepoint in PD
+// @Summary Delete the CDC service GC safepoint
+// @Description delete the CDC service GC safepoint in PD
+// @Tags unsafe,v2
+// @Accept json
+// @Produce json
+// @Param upstreamConfig body UpstreamConfig true "upstream config"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/unsafe/service_gc_safepoint [delete]
+func (h *OpenAPIV2) DeleteServiceGcSafePoint(c *gin.Context) {
+	upstreamConfig := &UpstreamConfig{}
+	if err := c.BindJSON(upstreamConfig); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	err := h.withPDClient(c, &upstreamConfig.PDConfig, func(ctx context.Context, client pd.Client) error {
+		err := gc.RemoveServiceGCSafepoint(ctx, client, h.server.GetEtcdClient().GetGCServiceID())
+		if err != nil {
+			return errors.WrapError(errors.ErrInternalServerError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// withPDClient calls the function with the pd client of the server,
+// or a temporary one if the pd addresses are specified.
+func (h *OpenAPIV2) withPDClient(ctx context.Context,
+	pdConfig *PDConfig,
+	doWithClient func(ctx context.Context, client pd.Client) error,
+) error {
+	if len(pdConfig.PDAddrs) == 0 {
+		return doWithClient(ctx, h.server.GetPdClient())
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	client, err := pd.NewClientWithContext(timeoutCtx, pdConfig.PDAddrs,
+		pd.SecurityOption{
+			CAPath:   pdConfig.CAPath,
+			CertPath: pdConfig.CertPath,
+			KeyPath:  pdConfig.KeyPath,
+		})
+	if err != nil {
+		return errors.WrapError(errors.ErrInternalServerError, err)
+	}
+	defer client.Close()
+	return doWithClient(ctx, client)
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	mock_etcd "github.com/pingcap/ticdc/pkg/etcd/mock"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tidb/pkg/kv"
+	tigc "github.com/pingcap/tiflow/pkg/txnutil/gc"
+	"github.com/stretchr/testify/require"
+	pd "github.com/tikv/pd/client"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// mockServer is a coordinator server with the given etcd and pd clients.
+type mockServer struct {
+	node.Server
+	etcdClient etcd.CDCEtcdClient
+	pdClient   pd.Client
+}
+
+func (s *mockServer) IsCoordinator() bool {
+	return true
+}
+
+func (s *mockServer) GetEtcdClient() etcd.CDCEtcdClient {
+	return s.etcdClient
+}
+
+func (s *mockServer) GetPdClient() pd.Client {
+	return s.pdClient
+}
+
+func (s *mockServer) GetKVStorage() kv.Storage {
+	return nil
+}
+
+func newUnsafeRouter(server node.Server) *gin.Engine {
+	router := gin.New()
+	RegisterOpenAPIV2Routes(router, NewOpenAPIV2(server))
+	return router
+}
+
+func doRequest(router *gin.Engine, method, uri string, body []byte) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), method, uri, bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCDCMetaData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
+	router := newUnsafeRouter(&mockServer{etcdClient: etcdClient})
+
+	etcdClient.EXPECT().GetAllCDCInfo(gomock.Any()).Return([]*mvccpb.KeyValue{
+		{Key: []byte("/tidb/cdc/default/__cdc_meta__/owner"), Value: []byte("owner")},
+	}, nil)
+	w := doRequest(router, http.MethodGet, "/api/v2/unsafe/metadata", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []EtcdData
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, []EtcdData{{Key: "/tidb/cdc/default/__cdc_meta__/owner", Value: "owner"}}, resp)
+
+	etcdClient.EXPECT().GetAllCDCInfo(gomock.Any()).Return(nil, errors.New("etcd is unavailable"))
+	w = doRequest(router, http.MethodGet, "/api/v2/unsafe/metadata", nil)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Contains(t, w.Body.String(), "etcd is unavailable")
+}
+
+func TestResolveLock(t *testing.T) {
+	router := newUnsafeRouter(&mockServer{})
+
+	// the request is refused if it's invalid
+	w := doRequest(router, http.MethodPost, "/api/v2/unsafe/resolve_lock", []byte("{"))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	body, err := json.Marshal(&ResolveLockReq{Ts: 100})
+	require.NoError(t, err)
+	w = doRequest(router, http.MethodPost, "/api/v2/unsafe/resolve_lock", body)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "region_id is required")
+
+	// the server isn't connected to a tikv storage
+	body, err = json.Marshal(&ResolveLockReq{RegionID: 1, Ts: 100})
+	require.NoError(t, err)
+	w = doRequest(router, http.MethodPost, "/api/v2/unsafe/resolve_lock", body)
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestDeleteServiceGcSafePoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
+	var (
+		serviceID string
+		ttl       int64 = -1
+	)
+	pdClient := &tigc.MockPDClient{
+		UpdateServiceGCSafePointFunc: func(_ context.Context, id string, gcTTL int64, _ uint64) (uint64, error) {
+			serviceID = id
+			ttl = gcTTL
+			return 0, nil
+		},
+	}
+	router := newUnsafeRouter(&mockServer{etcdClient: etcdClient, pdClient: pdClient})
+
+	// the request is refused if it's invalid
+	w := doRequest(router, http.MethodDelete, "/api/v2/unsafe/service_gc_safepoint", []byte("{"))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, int64(-1), ttl)
+
+	// the service gc safepoint is deleted by setting the ttl to 0
+	etcdClient.EXPECT().GetGCServiceID().Return("ticdc-default-test")
+	body, err := json.Marshal(&UpstreamConfig{})
+	require.NoError(t, err)
+	w = doRequest(router, http.MethodDelete, "/api/v2/unsafe/service_gc_safepoint", body)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "ticdc-default-test", serviceID)
+	require.Equal(t, int64(0), ttl)
+}
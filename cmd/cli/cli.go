@@ -55,6 +55,8 @@ func NewCmdCli() *cobra.Command {
 	cmds.AddCommand(newCmdChangefeed(f))
 	cmds.AddCommand(newCmdCapture(f))
 	cmds.AddCommand(newCmdTso(f))
+	cmds.AddCommand(newCmdUnsafe(f))
+	cmds.AddCommand(newCmdLogPuller(f))
 
 	return cmds
 }
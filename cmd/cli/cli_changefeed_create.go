@@ -108,6 +108,7 @@ type createChangefeedOptions struct {
 	disableGCSafePointCheck bool
 	startTs                 uint64
 	timezone                string
+	from                    string
 
 	cfg *config.ReplicaConfig
 }
@@ -127,6 +128,8 @@ func (o *createChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
 	cmd.PersistentFlags().BoolVarP(&o.disableGCSafePointCheck, "disable-gc-check", "", false, "Disable GC safe point check")
 	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0, "Start ts of changefeed")
+	cmd.PersistentFlags().StringVar(&o.from, "from", "",
+		"ID of an existing changefeed in the same namespace to clone the sink uri and configuration from")
 	cmd.PersistentFlags().StringVar(&o.timezone, "tz", "SYSTEM", "timezone used when checking sink uri (changefeed timezone is determined by cdc server)")
 	// we don't support specify these flags below when cdc version >= 6.2.0
 	_ = cmd.PersistentFlags().MarkHidden("tz")
@@ -139,12 +142,57 @@ func (o *createChangefeedOptions) complete(f factory.Factory) error {
 		return err
 	}
 	o.apiClient = client
-	return o.completeReplicaCfg()
+	cfg := config.GetDefaultReplicaConfig()
+	if o.from != "" {
+		if cfg, err = o.getSourceReplicaCfg(cmdcontext.GetDefaultContext()); err != nil {
+			return err
+		}
+	}
+	return o.completeReplicaCfg(cfg)
 }
 
-// completeCfg complete the replica config from file and cmd flags.
-func (o *createChangefeedOptions) completeReplicaCfg() error {
-	cfg := config.GetDefaultReplicaConfig()
+// getSourceReplicaCfg gets the replica config of the changefeed to clone from,
+// the sink uri of the source changefeed is used if it's not specified.
+// The sink uri returned by the server has its credentials masked,
+// so it can't be cloned if it contains any credential.
+func (o *createChangefeedOptions) getSourceReplicaCfg(ctx context.Context) (*config.ReplicaConfig, error) {
+	source, err := o.apiClient.Changefeeds().Get(ctx, o.namespace, o.from)
+	if err != nil {
+		return nil, err
+	}
+	if o.commonChangefeedOptions.sinkURI == "" {
+		masked, err := hasMaskedCredential(source.SinkURI)
+		if err != nil {
+			return nil, err
+		}
+		if masked {
+			return nil, errors.Errorf("the sink uri of changefeed %s contains credentials, "+
+				"please specify it by --sink-uri", o.from)
+		}
+		o.commonChangefeedOptions.sinkURI = source.SinkURI
+	}
+	if source.Config == nil {
+		return config.GetDefaultReplicaConfig(), nil
+	}
+	return source.Config.ToInternalReplicaConfig(), nil
+}
+
+// hasMaskedCredential returns true if the sink uri contains a credential
+// which is masked by the server.
+func hasMaskedCredential(sinkURI string) (bool, error) {
+	uri, err := url.Parse(sinkURI)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := uri.User.Password(); ok {
+		return true, nil
+	}
+	return uri.Query().Has("sasl-password"), nil
+}
+
+// completeCfg complete the replica config from file and cmd flags,
+// the config file is applied on top of the given base config.
+func (o *createChangefeedOptions) completeReplicaCfg(cfg *config.ReplicaConfig) error {
 	if len(o.commonChangefeedOptions.configFile) > 0 {
 		if err := o.commonChangefeedOptions.strictDecodeConfig("TiCDC changefeed", cfg); err != nil {
 			return err
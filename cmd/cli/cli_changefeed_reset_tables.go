@@ -0,0 +1,116 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/ticdc/api/v2"
+	"github.com/pingcap/ticdc/cmd/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// resetTablesChangefeedOptions defines flags for the `cli changefeed reset-tables` command.
+type resetTablesChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID string
+	namespace    string
+	tables       []string
+	tableIDs     []int64
+	resetTs      uint64
+}
+
+// newResetTablesChangefeedOptions creates new options for the `cli changefeed reset-tables` command.
+func newResetTablesChangefeedOptions() *resetTablesChangefeedOptions {
+	return &resetTablesChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *resetTablesChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().StringSliceVar(&o.tables, "tables", nil,
+		"Tables to reset, in the format of 'schema.table', use ',' to separate multiple tables")
+	cmd.PersistentFlags().Int64SliceVar(&o.tableIDs, "table-ids", nil,
+		"IDs of the physical tables to reset, use ',' to separate multiple tables")
+	cmd.PersistentFlags().Uint64Var(&o.resetTs, "reset-ts", 0,
+		"The tso from which the tables replay events, should not be larger than the changefeed checkpoint ts")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+	_ = cmd.MarkPersistentFlagRequired("reset-ts")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *resetTablesChangefeedOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+
+	o.apiClient = apiClient
+	return nil
+}
+
+// toResetTablesConfig converts the flags to the reset tables config of the api.
+func (o *resetTablesChangefeedOptions) toResetTablesConfig() (*v2.ResetTablesConfig, error) {
+	if len(o.tables) == 0 && len(o.tableIDs) == 0 {
+		return nil, errors.New("no table to reset, please specify --tables or --table-ids")
+	}
+	cfg := &v2.ResetTablesConfig{ResetTs: o.resetTs}
+	for _, table := range o.tables {
+		schema, name, ok := strings.Cut(table, ".")
+		if !ok || schema == "" || name == "" {
+			return nil, errors.Errorf("invalid table %s, should be 'schema.table'", table)
+		}
+		cfg.Tables = append(cfg.Tables, v2.Table{Schema: schema, Name: name})
+	}
+	for _, id := range o.tableIDs {
+		cfg.Spans = append(cfg.Spans, v2.TableSpan{TableID: id})
+	}
+	return cfg, nil
+}
+
+// run the `cli changefeed reset-tables` command.
+func (o *resetTablesChangefeedOptions) run() error {
+	ctx := context.GetDefaultContext()
+	cfg, err := o.toResetTablesConfig()
+	if err != nil {
+		return err
+	}
+	return o.apiClient.Changefeeds().ResetTables(ctx, cfg, o.namespace, o.changefeedID)
+}
+
+// newCmdResetTablesChangefeed creates the `cli changefeed reset-tables` command.
+func newCmdResetTablesChangefeed(f factory.Factory) *cobra.Command {
+	o := newResetTablesChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "reset-tables",
+		Short: "Replay some tables of a replication task (changefeed) from the given tso",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run())
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,35 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/pingcap/ticdc/cmd/factory"
+	"github.com/spf13/cobra"
+)
+
+// newCmdLogPuller creates the `cli log-puller` command.
+func newCmdLogPuller(f factory.Factory) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "log-puller",
+		Short: "Diagnose the log puller of the TiCDC server specified by --server",
+		Args:  cobra.NoArgs,
+	}
+	cmds.AddCommand(
+		newCmdStalledRegions(f),
+		newCmdStoreHealth(f),
+		newCmdResolveLocks(f),
+	)
+
+	return cmds
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/ticdc/api/v2"
+	"github.com/pingcap/ticdc/cmd/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// resolveLocksOptions defines flags for the `cli log-puller resolve-locks` command.
+type resolveLocksOptions struct {
+	apiv2Client apiv2client.APIV2Interface
+
+	tableID  int64
+	regionID uint64
+}
+
+// newResolveLocksOptions creates new resolveLocksOptions
+// for the `cli log-puller resolve-locks` command.
+func newResolveLocksOptions() *resolveLocksOptions {
+	return &resolveLocksOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *resolveLocksOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64Var(&o.tableID, "table-id", 0, "resolve locks in the subscribed regions of the table")
+	cmd.Flags().Uint64Var(&o.regionID, "region", 0, "resolve locks in the subscribed region")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *resolveLocksOptions) complete(f factory.Factory) error {
+	if o.tableID == 0 && o.regionID == 0 {
+		return errors.New("--table-id or --region is required")
+	}
+	apiv2Client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiv2Client = apiv2Client
+	return nil
+}
+
+// run runs the `cli log-puller resolve-locks` command.
+func (o *resolveLocksOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	resp, err := o.apiv2Client.LogPuller().ResolveLocks(ctx, &v2.ResolveLocksReq{
+		TableID:  o.tableID,
+		RegionID: o.regionID,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cmd.Printf("Locks in %d regions are resolved: %v\n", len(resp.Regions), resp.Regions)
+	return nil
+}
+
+// newCmdResolveLocks creates the `cli log-puller resolve-locks` command.
+func newCmdResolveLocks(f factory.Factory) *cobra.Command {
+	o := newResolveLocksOptions()
+
+	command := &cobra.Command{
+		Use:   "resolve-locks",
+		Short: "Resolve locks in the subscribed regions of a table or a region now",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"time"
+
+	"github.com/pingcap/ticdc/cmd/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// stalledRegionsOptions defines flags for the `cli log-puller stalled-regions` command.
+type stalledRegionsOptions struct {
+	apiv2Client apiv2client.APIV2Interface
+
+	threshold time.Duration
+}
+
+// newStalledRegionsOptions creates new stalledRegionsOptions
+// for the `cli log-puller stalled-regions` command.
+func newStalledRegionsOptions() *stalledRegionsOptions {
+	return &stalledRegionsOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *stalledRegionsOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&o.threshold, "threshold", time.Minute,
+		"list the regions whose resolved ts is stalled longer than the threshold")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *stalledRegionsOptions) complete(f factory.Factory) error {
+	apiv2Client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiv2Client = apiv2Client
+	return nil
+}
+
+// run runs the `cli log-puller stalled-regions` command.
+func (o *stalledRegionsOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	regions, err := o.apiv2Client.LogPuller().StalledRegions(ctx, o.threshold)
+	if err != nil {
+		return err
+	}
+	return util.JSONPrint(cmd, regions)
+}
+
+// newCmdStalledRegions creates the `cli log-puller stalled-regions` command.
+func newCmdStalledRegions(f factory.Factory) *cobra.Command {
+	o := newStalledRegionsOptions()
+
+	command := &cobra.Command{
+		Use:   "stalled-regions",
+		Short: "List the subscribed regions whose resolved ts is stalled, with their stores and locks",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
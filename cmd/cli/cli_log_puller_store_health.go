@@ -0,0 +1,71 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/pingcap/ticdc/cmd/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// storeHealthOptions defines flags for the `cli log-puller store-health` command.
+type storeHealthOptions struct {
+	apiv2Client apiv2client.APIV2Interface
+}
+
+// newStoreHealthOptions creates new storeHealthOptions
+// for the `cli log-puller store-health` command.
+func newStoreHealthOptions() *storeHealthOptions {
+	return &storeHealthOptions{}
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *storeHealthOptions) complete(f factory.Factory) error {
+	apiv2Client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiv2Client = apiv2Client
+	return nil
+}
+
+// run runs the `cli log-puller store-health` command.
+func (o *storeHealthOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	stores, err := o.apiv2Client.LogPuller().StoreHealth(ctx)
+	if err != nil {
+		return err
+	}
+	return util.JSONPrint(cmd, stores)
+}
+
+// newCmdStoreHealth creates the `cli log-puller store-health` command.
+func newCmdStoreHealth(f factory.Factory) *cobra.Command {
+	o := newStoreHealthOptions()
+
+	command := &cobra.Command{
+		Use:   "store-health",
+		Short: "List the health of the TiKV stores requested by the log puller",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	return command
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/ticdc/api/v2"
+	"github.com/pingcap/ticdc/cmd/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// unsafeResolveLockOptions defines flags for the `cli unsafe resolve-lock` command.
+type unsafeResolveLockOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	regionID uint64
+	ts       uint64
+
+	upstreamPDAddrs  string
+	upstreamCaPath   string
+	upstreamCertPath string
+	upstreamKeyPath  string
+}
+
+// newUnsafeResolveLockOptions creates new unsafeResolveLockOptions
+// for the `cli unsafe resolve-lock` command.
+func newUnsafeResolveLockOptions() *unsafeResolveLockOptions {
+	return &unsafeResolveLockOptions{}
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *unsafeResolveLockOptions) complete(f factory.Factory) error {
+	ctx := context.GetDefaultContext()
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	if o.ts == 0 {
+		tso, err := apiClient.Tso().Query(ctx, &v2.UpstreamConfig{PDConfig: o.getPDConfig()})
+		if err != nil {
+			return err
+		}
+		now := oracle.GetTimeFromTS(oracle.ComposeTS(tso.Timestamp, tso.LogicTime))
+		// Try not kill active transaction, we only resolves lock 1 minute ago.
+		o.ts = oracle.GoTimeToTS(now.Add(-time.Minute))
+	}
+	return nil
+}
+
+// run runs the `cli unsafe resolve-lock` command.
+func (o *unsafeResolveLockOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	err := o.apiClient.Unsafe().ResolveLock(ctx, &v2.ResolveLockReq{
+		RegionID: o.regionID,
+		Ts:       o.ts,
+		PDConfig: o.getPDConfig(),
+	})
+	if err == nil {
+		cmd.Printf("Locks in region %d before %d are resolved\n", o.regionID, o.ts)
+	}
+	return errors.Trace(err)
+}
+
+func (o *unsafeResolveLockOptions) getPDConfig() v2.PDConfig {
+	var pdAddrs []string
+	if o.upstreamPDAddrs != "" {
+		pdAddrs = strings.Split(o.upstreamPDAddrs, ",")
+	}
+	return v2.PDConfig{
+		PDAddrs:  pdAddrs,
+		CAPath:   o.upstreamCaPath,
+		CertPath: o.upstreamCertPath,
+		KeyPath:  o.upstreamKeyPath,
+	}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *unsafeResolveLockOptions) addFlags(cmd *cobra.Command) {
+	if o == nil {
+		return
+	}
+
+	cmd.Flags().Uint64Var(&o.regionID, "region", 0, "Region ID")
+	cmd.Flags().Uint64Var(&o.ts, "ts", 0,
+		"resolve locks before the timestamp, default 1 minute ago from now")
+	_ = cmd.MarkFlagRequired("region")
+	addUpstreamFlags(cmd, &o.upstreamPDAddrs, &o.upstreamCaPath, &o.upstreamCertPath, &o.upstreamKeyPath)
+}
+
+// newCmdResolveLock creates the `cli unsafe resolve-lock` command.
+func newCmdResolveLock(f factory.Factory, commonOptions *unsafeCommonOptions) *cobra.Command {
+	o := newUnsafeResolveLockOptions()
+
+	command := &cobra.Command{
+		Use:   "resolve-lock",
+		Short: "Resolve locks in a region, confirm that you know what this command will do and use it at your own risk",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(commonOptions.confirmMetaDelete(cmd))
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v2 "github.com/pingcap/ticdc/api/v2"
+	"github.com/pingcap/ticdc/cmd/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+type mockUnsafe struct {
+	kvs               []v2.EtcdData
+	resolveLockReqs   []*v2.ResolveLockReq
+	deleteGcSafepoint []*v2.UpstreamConfig
+}
+
+func (u *mockUnsafe) Metadata(_ context.Context) (*[]v2.EtcdData, error) {
+	return &u.kvs, nil
+}
+
+func (u *mockUnsafe) ResolveLock(_ context.Context, req *v2.ResolveLockReq) error {
+	u.resolveLockReqs = append(u.resolveLockReqs, req)
+	return nil
+}
+
+func (u *mockUnsafe) DeleteServiceGcSafePoint(_ context.Context, config *v2.UpstreamConfig) error {
+	u.deleteGcSafepoint = append(u.deleteGcSafepoint, config)
+	return nil
+}
+
+type mockTso struct {
+	tso *v2.Tso
+}
+
+func (t *mockTso) Query(_ context.Context, _ *v2.UpstreamConfig) (*v2.Tso, error) {
+	return t.tso, nil
+}
+
+type mockUnsafeAPIClient struct {
+	apiv2client.APIV2Interface
+	unsafe *mockUnsafe
+	tso    *mockTso
+}
+
+func (c *mockUnsafeAPIClient) Unsafe() apiv2client.UnsafeInterface {
+	return c.unsafe
+}
+
+func (c *mockUnsafeAPIClient) Tso() apiv2client.TsoInterface {
+	return c.tso
+}
+
+type mockUnsafeFactory struct {
+	factory.Factory
+	client *mockUnsafeAPIClient
+}
+
+func (f *mockUnsafeFactory) APIV2Client() (apiv2client.APIV2Interface, error) {
+	return f.client, nil
+}
+
+func newMockUnsafeFactory() *mockUnsafeFactory {
+	return &mockUnsafeFactory{client: &mockUnsafeAPIClient{
+		unsafe: &mockUnsafe{},
+		tso:    &mockTso{},
+	}}
+}
+
+// setStdin replaces the stdin with the input until the test ends.
+func setStdin(t *testing.T, input string) {
+	path := filepath.Join(t.TempDir(), "confirm.txt")
+	require.NoError(t, os.WriteFile(path, []byte(input), 0o644))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	stdin := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		_ = f.Close()
+	})
+}
+
+func TestUnsafeShowMetadataCli(t *testing.T) {
+	f := newMockUnsafeFactory()
+	f.client.unsafe.kvs = []v2.EtcdData{{Key: "/tidb/cdc/default/__cdc_meta__/owner", Value: "owner"}}
+
+	cmd := newCmdUnsafe(f)
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"show-metadata"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), "Key: /tidb/cdc/default/__cdc_meta__/owner, Value: owner")
+	require.Contains(t, out.String(), "Show 1 KVs")
+}
+
+func TestUnsafeResolveLockCli(t *testing.T) {
+	f := newMockUnsafeFactory()
+	unsafe := f.client.unsafe
+
+	// the confirmation is skipped
+	cmd := newCmdUnsafe(f)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"resolve-lock", "--region=1", "--ts=10", "--no-confirm", "--upstream-pd=http://a:2379,http://b:2379"})
+	require.NoError(t, cmd.Execute())
+	require.Len(t, unsafe.resolveLockReqs, 1)
+	require.Equal(t, uint64(1), unsafe.resolveLockReqs[0].RegionID)
+	require.Equal(t, uint64(10), unsafe.resolveLockReqs[0].Ts)
+	require.Equal(t, []string{"http://a:2379", "http://b:2379"}, unsafe.resolveLockReqs[0].PDAddrs)
+
+	// the user confirms the command
+	setStdin(t, "Y")
+	cmd = newCmdUnsafe(f)
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"resolve-lock", "--region=2", "--ts=20"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), "[Y/N]")
+	require.Contains(t, out.String(), "Locks in region 2 before 20 are resolved")
+	require.Len(t, unsafe.resolveLockReqs, 2)
+	require.Equal(t, uint64(2), unsafe.resolveLockReqs[1].RegionID)
+
+	// the locks 1 minute ago are resolved by default
+	now := time.Now()
+	f.client.tso.tso = &v2.Tso{Timestamp: now.UnixMilli()}
+	o := newUnsafeResolveLockOptions()
+	o.regionID = 3
+	require.NoError(t, o.complete(f))
+	require.Equal(t, oracle.GoTimeToTS(time.UnixMilli(now.UnixMilli()).Add(-time.Minute)), o.ts)
+}
+
+func TestUnsafeDeleteServiceGcSafepointCli(t *testing.T) {
+	f := newMockUnsafeFactory()
+	unsafe := f.client.unsafe
+
+	cmd := newCmdUnsafe(f)
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"delete-service-gc-safepoint", "--no-confirm", "--upstream-pd=http://a:2379", "--upstream-ca=ca.pem"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), "CDC service GC safepoint truncated in PD!")
+	require.Len(t, unsafe.deleteGcSafepoint, 1)
+	require.Equal(t, []string{"http://a:2379"}, unsafe.deleteGcSafepoint[0].PDAddrs)
+	require.Equal(t, "ca.pem", unsafe.deleteGcSafepoint[0].CAPath)
+
+	// the user confirms the command
+	setStdin(t, "y")
+	cmd = newCmdUnsafe(f)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"delete-service-gc-safepoint"})
+	require.NoError(t, cmd.Execute())
+	require.Len(t, unsafe.deleteGcSafepoint, 2)
+	require.Empty(t, unsafe.deleteGcSafepoint[1].PDAddrs)
+}
+
+func TestUnsafeConfirmRefused(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	o := newUnsafeCommonOptions()
+
+	setStdin(t, "n")
+	require.ErrorContains(t, o.confirmMetaDelete(cmd), "abort meta command")
+
+	// no input is refused too
+	setStdin(t, "")
+	require.ErrorContains(t, o.confirmMetaDelete(cmd), "abort meta command")
+
+	o.noConfirm = true
+	require.NoError(t, o.confirmMetaDelete(cmd))
+}
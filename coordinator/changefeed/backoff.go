@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/tiflow/cdc/model"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
 	"go.uber.org/atomic"
@@ -64,10 +65,21 @@ type Backoff struct {
 	checkpointTs uint64
 
 	changefeedErrorStuckDuration time.Duration
+
+	// errorPolicy decides the action taken when the changefeed meets an error
+	errorPolicy *errorPolicy
+	// skippedTable is the table to be removed from the changefeed by the error policy,
+	// it's consumed by TakeSkippedTable
+	skippedTable *atomic.String
 }
 
-// NewBackoff creates Backoff and initialize the exponential backoff
-func NewBackoff(id common.ChangeFeedID, changefeedErrorStuckDuration time.Duration, checkpointTs uint64) *Backoff {
+// NewBackoff creates Backoff and initialize the exponential backoff,
+// the default error policy is used if errorPolicy is nil
+func NewBackoff(id common.ChangeFeedID,
+	changefeedErrorStuckDuration time.Duration,
+	checkpointTs uint64,
+	errorPolicy *config.ErrorPolicyConfig,
+) *Backoff {
 	m := &Backoff{
 		id:                           id,
 		errBackoff:                   backoff.NewExponentialBackOff(),
@@ -77,6 +89,8 @@ func NewBackoff(id common.ChangeFeedID, changefeedErrorStuckDuration time.Durati
 		retrying:                     atomic.NewBool(false),
 		nextRetryTime:                atomic.NewTime(time.Time{}),
 		checkpointTs:                 checkpointTs,
+		errorPolicy:                  newErrorPolicy(errorPolicy),
+		skippedTable:                 atomic.NewString(""),
 	}
 	m.errBackoff.InitialInterval = defaultBackoffInitInterval
 	m.errBackoff.MaxInterval = defaultBackoffMaxInterval
@@ -136,6 +150,41 @@ func (m *Backoff) CheckStatus(status *heartbeatpb.MaintainerStatus) (bool, model
 			)
 		}
 
+		action, err := m.errorPolicy.decide(status.Err)
+		switch action {
+		case config.ErrorActionAlertOnly:
+			// the dispatchers meeting the error are stuck, restart the changefeed to recover them,
+			// but the changefeed never fails no matter how long it has been stuck
+			m.isRestarting.Store(true)
+			m.nextRetry()
+			log.Warn("changefeed meets an error, only alert it by the error policy",
+				zap.String("namespace", m.id.Namespace()),
+				zap.String("changefeed", m.id.Name()),
+				zap.Time("nextRetryTime", m.nextRetryTime.Load()),
+				zap.Any("error", status.Err))
+			return true, model.StateWarning, err
+		case config.ErrorActionSkipTable:
+			log.Warn("changefeed meets an error, skip the table by the error policy",
+				zap.String("namespace", m.id.Namespace()),
+				zap.String("changefeed", m.id.Name()),
+				zap.String("table", err.Table),
+				zap.Any("error", err))
+			// the changefeed is restarted without the table at once
+			m.isRestarting.Store(true)
+			m.skippedTable.Store(err.Table)
+			return true, model.StateWarning, err
+		case config.ErrorActionPause:
+			log.Warn("changefeed meets an error, pause it by the error policy",
+				zap.String("namespace", m.id.Namespace()),
+				zap.String("changefeed", m.id.Name()),
+				zap.Any("error", err))
+			m.failed.Store(true)
+			return true, model.StateStopped, err
+		case config.ErrorActionFail:
+			m.failed.Store(true)
+			return true, model.StateFailed, err
+		}
+
 		// set the changefeed state to warning and waiting start the changefeed
 		m.isRestarting.Store(true)
 		// if the checkpointTs is not advanced for a long time, we should stop the changefeed
@@ -149,6 +198,11 @@ func (m *Backoff) CheckStatus(status *heartbeatpb.MaintainerStatus) (bool, model
 	return false, model.StateNormal, nil
 }
 
+// GetErrorHistory returns the recent error decisions of the changefeed
+func (m *Backoff) GetErrorHistory() []config.ErrorDecision {
+	return m.errorPolicy.getHistory()
+}
+
 func (m *Backoff) StartFinished() {
 	m.isRestarting.Store(false)
 }
@@ -158,6 +212,30 @@ func ShouldFailChangefeed(e *heartbeatpb.RunningError) bool {
 	return cerrors.ShouldFailChangefeed(errors.New(e.Message + e.Code))
 }
 
+// TakeSkippedTable returns the table to be removed from the changefeed
+// by the error policy and clears it, it returns an empty string if there is no such table.
+func (m *Backoff) TakeSkippedTable() string {
+	return m.skippedTable.Swap("")
+}
+
+// nextRetry sets the next retry time of the changefeed with the exponential backoff,
+// it returns false if the changefeed has been retried longer than the max elapsed time,
+// and the max interval is used as the next retry interval in this case.
+func (m *Backoff) nextRetry() bool {
+	if !m.retrying.Load() {
+		// errBackoff may be stopped, reset it before the first retry.
+		m.resetErrRetry()
+		m.retrying.Store(true)
+	}
+	m.backoffInterval = m.errBackoff.NextBackOff()
+	if m.shouldFailWhenRetry() {
+		m.nextRetryTime = atomic.NewTime(time.Now().Add(m.errBackoff.MaxInterval))
+		return false
+	}
+	m.nextRetryTime = atomic.NewTime(time.Now().Add(m.backoffInterval))
+	return true
+}
+
 func (m *Backoff) HandleError(errs []*heartbeatpb.RunningError) (bool, *heartbeatpb.RunningError) {
 	// if there are a fastFail error in errs, we can just fastFail the changefeed
 	for _, err := range errs {
@@ -169,17 +247,8 @@ func (m *Backoff) HandleError(errs []*heartbeatpb.RunningError) (bool, *heartbea
 
 	var lastError = errs[len(errs)-1]
 
-	if !m.retrying.Load() {
-		// errBackoff may be stopped, reset it before the first retry.
-		m.resetErrRetry()
-		m.retrying.Store(true)
-	}
-	// set the next retry time
-	m.backoffInterval = m.errBackoff.NextBackOff()
-	m.nextRetryTime = atomic.NewTime(time.Now().Add(m.backoffInterval))
-
 	// check if we exceed the maxElapsedTime
-	if m.shouldFailWhenRetry() {
+	if !m.nextRetry() {
 		log.Error("The changefeed won't be restarted as it has been experiencing failures for "+
 			"an extended duration",
 			zap.Duration("maxElapsedTime", m.errBackoff.MaxElapsedTime),
@@ -74,7 +74,7 @@ func NewChangefeed(cfID common.ChangeFeedID,
 				CheckpointTs: checkpointTs,
 				FeedState:    string(info.State),
 			}),
-		backoff: NewBackoff(cfID, *info.Config.ChangefeedErrorStuckDuration, checkpointTs),
+		backoff: NewBackoff(cfID, *info.Config.ChangefeedErrorStuckDuration, checkpointTs, info.Config.ErrorPolicy),
 	}
 }
 
@@ -101,7 +101,7 @@ func (c *Changefeed) GetNodeID() node.ID {
 
 func (c *Changefeed) UpdateStatus(newStatus *heartbeatpb.MaintainerStatus) (bool, model.FeedState, *heartbeatpb.RunningError) {
 	old := c.status.Load()
-	if newStatus != nil && newStatus.CheckpointTs >= old.CheckpointTs {
+	if newStatus != nil && (newStatus.CheckpointTs >= old.CheckpointTs || c.isReplayingTables(newStatus.CheckpointTs)) {
 		c.status.Store(newStatus)
 		info := c.GetInfo()
 		// the changefeed reaches the targetTs
@@ -113,6 +113,33 @@ func (c *Changefeed) UpdateStatus(newStatus *heartbeatpb.MaintainerStatus) (bool
 	return false, model.StateNormal, nil
 }
 
+// isReplayingTables returns true if the checkpoint ts falls back because some tables
+// replay events, the checkpoint ts never falls behind the replay ts.
+func (c *Changefeed) isReplayingTables(checkpointTs uint64) bool {
+	replay := c.GetInfo().TableReplay
+	return replay != nil && checkpointTs >= replay.ReplayTs
+}
+
+// GetErrorHistory returns the recent error decisions of the changefeed
+func (c *Changefeed) GetErrorHistory() []config.ErrorDecision {
+	return c.backoff.GetErrorHistory()
+}
+
+// TakeSkippedTable returns the table to be removed from the changefeed by the error policy,
+// it returns an empty string if there is no such table.
+func (c *Changefeed) TakeSkippedTable() string {
+	return c.backoff.TakeSkippedTable()
+}
+
+// GetPlacement returns the placement rules of the changefeed, it can be nil
+func (c *Changefeed) GetPlacement() *config.PlacementConfig {
+	info := c.GetInfo()
+	if info.Config == nil || info.Config.Scheduler == nil {
+		return nil
+	}
+	return info.Config.Scheduler.Placement
+}
+
 func (c *Changefeed) IsMQSink() bool {
 	return c.isMQSink
 }
@@ -129,6 +156,16 @@ func (c *Changefeed) GetLastSavedCheckPointTs() uint64 {
 	return c.lastSavedCheckpointTs.Load()
 }
 
+// GetCheckpointTsToSave returns the checkpoint ts to persist, the persisted checkpoint ts
+// never falls back even if the reported one does when some tables replay events.
+func (c *Changefeed) GetCheckpointTsToSave() uint64 {
+	checkpointTs := c.GetStatus().CheckpointTs
+	if saved := c.GetLastSavedCheckPointTs(); saved > checkpointTs {
+		return saved
+	}
+	return checkpointTs
+}
+
 func (c *Changefeed) NewAddMaintainerMessage(server node.ID) *messaging.TargetMessage {
 	return messaging.NewSingleTargetMessage(server,
 		messaging.MaintainerManagerTopic,
@@ -152,6 +189,26 @@ func (c *Changefeed) NewCheckpointTsMessage(ts uint64) *messaging.TargetMessage
 		})
 }
 
+func (c *Changefeed) NewResetDispatchersMessage(replay *config.TableReplay) *messaging.TargetMessage {
+	spans := make([]*heartbeatpb.TableSpan, 0, len(replay.Spans))
+	for _, span := range replay.Spans {
+		spans = append(spans, &heartbeatpb.TableSpan{
+			TableID:  span.TableID,
+			StartKey: span.StartKey,
+			EndKey:   span.EndKey,
+		})
+	}
+	return messaging.NewSingleTargetMessage(c.nodeID,
+		messaging.MaintainerManagerTopic,
+		&heartbeatpb.ResetDispatchersRequest{
+			ChangefeedID: c.ID.ToPB(),
+			Spans:        spans,
+			ResetTs:      replay.ReplayTs,
+			ReplayId:     replay.ID,
+			TargetTs:     replay.TargetTs,
+		})
+}
+
 func RemoveMaintainerMessage(id common.ChangeFeedID, server node.ID, caseCade bool, removed bool) *messaging.TargetMessage {
 	caseCade = caseCade || removed
 	return messaging.NewSingleTargetMessage(server,
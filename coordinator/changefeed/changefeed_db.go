@@ -330,6 +330,10 @@ func (db *ChangefeedDB) CalculateGCSafepoint() uint64 {
 			continue
 		}
 		checkpointTs := cf.GetLastSavedCheckPointTs()
+		// the replayed tables need the data from the replay ts
+		if info.TableReplay != nil && info.TableReplay.ReplayTs < checkpointTs {
+			checkpointTs = info.TableReplay.ReplayTs
+		}
 		if minCpts > checkpointTs {
 			minCpts = checkpointTs
 		}
@@ -77,7 +77,7 @@ func TestResume(t *testing.T) {
 	db := NewChangefeedDB()
 	cf := &Changefeed{ID: common.NewChangeFeedIDWithName("test")}
 	db.AddStoppedChangefeed(cf)
-	cf.backoff = NewBackoff(cf.ID, 0, 0)
+	cf.backoff = NewBackoff(cf.ID, 0, 0, nil)
 
 	db.Resume(cf.ID, true)
 
@@ -128,13 +128,13 @@ func TestGetWaitingSchedulingChangefeeds(t *testing.T) {
 	db := NewChangefeedDB()
 	cf1 := &Changefeed{ID: common.NewChangeFeedIDWithName("test1")}
 	cf2 := &Changefeed{ID: common.NewChangeFeedIDWithName("test2")}
-	cf1.backoff = NewBackoff(cf1.ID, 0, 0)
+	cf1.backoff = NewBackoff(cf1.ID, 0, 0, nil)
 	cf1.backoff.failed.Store(true)
-	cf2.backoff = NewBackoff(cf2.ID, 0, 0)
+	cf2.backoff = NewBackoff(cf2.ID, 0, 0, nil)
 	db.AddAbsentChangefeed(cf1)
 	db.AddReplicatingMaintainer(cf2, "node1")
 	cf3 := &Changefeed{ID: common.NewChangeFeedIDWithName("test3")}
-	cf3.backoff = NewBackoff(cf3.ID, 0, 0)
+	cf3.backoff = NewBackoff(cf3.ID, 0, 0, nil)
 	db.AddAbsentChangefeed(cf3)
 
 	result, nMap := db.GetWaitingSchedulingChangefeeds(nil, 3)
@@ -312,4 +312,15 @@ func TestCalculateGCSafepoint(t *testing.T) {
 		}, 7)
 	db.AddStoppedChangefeed(cf5)
 	require.Equal(t, uint64(7), db.CalculateGCSafepoint())
+
+	// the replayed tables need the data from the replay ts
+	cf6ID := common.NewChangeFeedIDWithName("test")
+	cf6 := NewChangefeed(cf6ID,
+		&config.ChangeFeedInfo{ChangefeedID: cf6ID,
+			Config:      config.GetDefaultReplicaConfig(),
+			State:       model.StateNormal,
+			TableReplay: &config.TableReplay{ID: 1, ReplayTs: 5, TargetTs: 20},
+		}, 20)
+	db.AddStoppedChangefeed(cf6)
+	require.Equal(t, uint64(5), db.CalculateGCSafepoint())
 }
@@ -88,6 +88,22 @@ func TestChangefeed_UpdateStatus(t *testing.T) {
 	require.Equal(t, model.StateNormal, state)
 	require.Nil(t, err)
 	require.Equal(t, newStatus, cf.GetStatus())
+
+	// the checkpoint ts never falls back if no table is replayed
+	updated, _, _ = cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 150})
+	require.False(t, updated)
+	require.Equal(t, uint64(200), cf.GetStatus().CheckpointTs)
+
+	// the checkpoint ts falls back to the replay ts at most
+	info.TableReplay = &config.TableReplay{ID: 1, ReplayTs: 120, TargetTs: 200}
+	cf.SetInfo(info)
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 110})
+	require.Equal(t, uint64(200), cf.GetStatus().CheckpointTs)
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 120})
+	require.Equal(t, uint64(120), cf.GetStatus().CheckpointTs)
+	// the persisted checkpoint ts never falls back
+	cf.SetLastSavedCheckPointTs(200)
+	require.Equal(t, uint64(200), cf.GetCheckpointTsToSave())
 }
 
 func TestChangefeed_IsMQSink(t *testing.T) {
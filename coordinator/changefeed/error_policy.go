@@ -0,0 +1,151 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// maxErrorDecisionHistory is the max number of error decisions kept for a changefeed
+const maxErrorDecisionHistory = 16
+
+var (
+	downstreamUnavailableErrors = newErrorCodeSet(
+		cerrors.ErrMySQLConnectionError,
+		cerrors.ErrKafkaNewProducer,
+		cerrors.ErrKafkaProducerClosed,
+		cerrors.ErrKafkaSendMessage,
+		cerrors.ErrKafkaAsyncSendMessage,
+		cerrors.ErrPulsarNewClient,
+		cerrors.ErrPulsarNewProducer,
+		cerrors.ErrPulsarProducerClosed,
+		cerrors.ErrPulsarSendMessage,
+		cerrors.ErrPulsarAsyncSendMessage,
+		cerrors.ErrStorageInitialize,
+	)
+	dataIncompatibleErrors = newErrorCodeSet(
+		cerrors.ErrMessageTooLarge,
+		cerrors.ErrMySQLDuplicateEntry,
+		cerrors.ErrEncodeFailed,
+		cerrors.ErrTableIneligible,
+		cerrors.ErrSinkIncompatibleConfig,
+		apperror.ErrDownstreamDataIncompatible,
+	)
+)
+
+func newErrorCodeSet(errs ...*errors.Error) map[errors.RFCErrorCode]struct{} {
+	set := make(map[errors.RFCErrorCode]struct{}, len(errs))
+	for _, err := range errs {
+		set[err.RFCCode()] = struct{}{}
+	}
+	return set
+}
+
+// ClassifyError returns the category of a running error reported by the maintainer,
+// the category is decided by the error code, see apperror.ErrorCode for how the code is reported.
+func ClassifyError(e *heartbeatpb.RunningError) config.ErrorCategory {
+	code := errors.RFCErrorCode(e.Code)
+	if cerrors.IsChangefeedGCFastFailErrorCode(code) {
+		return config.ErrorCategoryUpstreamGC
+	}
+	if _, ok := downstreamUnavailableErrors[code]; ok {
+		return config.ErrorCategoryDownstreamUnavailable
+	}
+	if _, ok := dataIncompatibleErrors[code]; ok {
+		return config.ErrorCategoryDataIncompatible
+	}
+	return config.ErrorCategoryInternal
+}
+
+// actionSeverity is used to choose the action when multiple errors are reported at the same time
+var actionSeverity = map[config.ErrorAction]int{
+	config.ErrorActionAlertOnly: 0,
+	config.ErrorActionRetry:     1,
+	config.ErrorActionSkipTable: 2,
+	config.ErrorActionPause:     3,
+	config.ErrorActionFail:      4,
+}
+
+// errorPolicy decides the action of the errors and keeps the recent decisions
+type errorPolicy struct {
+	cfg *config.ErrorPolicyConfig
+
+	lock    sync.RWMutex
+	history []config.ErrorDecision
+}
+
+func newErrorPolicy(cfg *config.ErrorPolicyConfig) *errorPolicy {
+	if cfg == nil {
+		cfg = config.GetDefaultReplicaConfig().ErrorPolicy
+	}
+	return &errorPolicy{cfg: cfg}
+}
+
+// decide returns the action of the most severe error and the error itself,
+// a not retryable error can never be retried, the changefeed fails instead.
+// The changefeed is paused if a table should be skipped but the error can't
+// be attributed to a single table, the data of other tables may be lost otherwise.
+func (p *errorPolicy) decide(errs []*heartbeatpb.RunningError) (config.ErrorAction, *heartbeatpb.RunningError) {
+	var (
+		action  config.ErrorAction
+		decided *heartbeatpb.RunningError
+	)
+	for _, err := range errs {
+		category := ClassifyError(err)
+		current := p.cfg.ActionOf(category)
+		if current == config.ErrorActionRetry && ShouldFailChangefeed(err) {
+			current = config.ErrorActionFail
+		}
+		if current == config.ErrorActionSkipTable && err.Table == "" {
+			current = config.ErrorActionPause
+		}
+		p.record(config.ErrorDecision{
+			Time:     time.Now(),
+			Code:     err.Code,
+			Message:  err.Message,
+			Category: category,
+			Action:   current,
+			Table:    err.Table,
+		})
+		if decided == nil || actionSeverity[current] > actionSeverity[action] {
+			action, decided = current, err
+		}
+	}
+	return action, decided
+}
+
+func (p *errorPolicy) record(decision config.ErrorDecision) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.history = append(p.history, decision)
+	if len(p.history) > maxErrorDecisionHistory {
+		p.history = p.history[len(p.history)-maxErrorDecisionHistory:]
+	}
+}
+
+// getHistory returns a copy of the recent decisions, the oldest one comes first
+func (p *errorPolicy) getHistory() []config.ErrorDecision {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	history := make([]config.ErrorDecision, len(p.history))
+	copy(history, p.history)
+	return history
+}
@@ -0,0 +1,209 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		err      *heartbeatpb.RunningError
+		category config.ErrorCategory
+	}{
+		{&heartbeatpb.RunningError{Code: "CDC:ErrSnapshotLostByGC"}, config.ErrorCategoryUpstreamGC},
+		{&heartbeatpb.RunningError{Code: "CDC:ErrGCTTLExceeded"}, config.ErrorCategoryUpstreamGC},
+		{&heartbeatpb.RunningError{Code: "CDC:ErrMySQLConnectionError"}, config.ErrorCategoryDownstreamUnavailable},
+		{&heartbeatpb.RunningError{Code: "CDC:ErrKafkaNewProducer"}, config.ErrorCategoryDownstreamUnavailable},
+		{&heartbeatpb.RunningError{Code: "CDC:ErrMessageTooLarge"}, config.ErrorCategoryDataIncompatible},
+		{&heartbeatpb.RunningError{Code: "CDC:ErrDownstreamDataIncompatible"}, config.ErrorCategoryDataIncompatible},
+		{&heartbeatpb.RunningError{Code: "CDC:ErrChangefeedRetryable", Message: "test"}, config.ErrorCategoryInternal},
+		// the message is never used to classify the error
+		{&heartbeatpb.RunningError{
+			Code:    "CDC:ErrChangefeedRetryable",
+			Message: "[CDC:ErrMessageTooLarge]message is too large",
+		}, config.ErrorCategoryInternal},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.category, ClassifyError(c.err), c.err.Code)
+	}
+}
+
+func TestErrorCodeOfRunningError(t *testing.T) {
+	cases := []struct {
+		err      error
+		category config.ErrorCategory
+	}{
+		{cerrors.ErrSnapshotLostByGC.GenWithStackByArgs(1, 2), config.ErrorCategoryUpstreamGC},
+		{cerrors.WrapError(cerrors.ErrMySQLTxnError,
+			&mysql.MySQLError{Number: 1045, Message: "Access denied"}), config.ErrorCategoryDownstreamUnavailable},
+		{cerrors.WrapError(cerrors.ErrMySQLTxnError, driver.ErrBadConn), config.ErrorCategoryDownstreamUnavailable},
+		{cerrors.WrapError(cerrors.ErrMySQLTxnError,
+			&mysql.MySQLError{Number: 1146, Message: "Table 'test.t' doesn't exist"}), config.ErrorCategoryDataIncompatible},
+		{apperror.WrapTableError(cerrors.ErrMessageTooLarge.GenWithStackByArgs(), "test", "t"), config.ErrorCategoryDataIncompatible},
+		{cerrors.WrapError(cerrors.ErrMySQLTxnError,
+			&mysql.MySQLError{Number: 1213, Message: "Deadlock found"}), config.ErrorCategoryInternal},
+		{errors.New("test"), config.ErrorCategoryInternal},
+	}
+	for _, c := range cases {
+		err := &heartbeatpb.RunningError{Code: string(apperror.ErrorCode(c.err)), Message: c.err.Error()}
+		require.Equal(t, c.category, ClassifyError(err), c.err.Error())
+	}
+
+	// the not retryable errors still fail the changefeed
+	err := cerrors.ErrSinkURIInvalid.GenWithStackByArgs()
+	require.True(t, ShouldFailChangefeed(&heartbeatpb.RunningError{
+		Code: string(apperror.ErrorCode(err)), Message: err.Error(),
+	}))
+
+	require.Equal(t, "`test`.`t``1`", apperror.ErrorTable(
+		errors.Trace(apperror.WrapTableError(errors.New("test"), "test", "t`1"))))
+	require.Equal(t, "", apperror.ErrorTable(errors.New("test")))
+}
+
+func TestErrorPolicyActions(t *testing.T) {
+	policy := &config.ErrorPolicyConfig{
+		DownstreamUnavailable: config.ErrorActionAlertOnly,
+		DataIncompatible:      config.ErrorActionPause,
+		UpstreamGC:            config.ErrorActionFail,
+		Internal:              config.ErrorActionRetry,
+	}
+	backoff := NewBackoff(common.NewChangeFeedIDWithName("test"), time.Minute*30, 1, policy)
+
+	// alert only, the changefeed is reported as warning and restarted, but never fails
+	changed, state, err := backoff.CheckStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 1,
+		Err: []*heartbeatpb.RunningError{
+			{Code: "CDC:ErrMySQLConnectionError", Message: "Error 1045 (28000): Access denied"}},
+	})
+	require.True(t, changed)
+	require.Equal(t, model.StateWarning, state)
+	require.Equal(t, "CDC:ErrMySQLConnectionError", err.Code)
+	require.False(t, backoff.failed.Load())
+	require.True(t, backoff.retrying.Load())
+	require.False(t, backoff.ShouldRun())
+	// the changefeed is stuck for a long time
+	backoff.errBackoff.MaxElapsedTime = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	changed, state, _ = backoff.CheckStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 1,
+		Err: []*heartbeatpb.RunningError{
+			{Code: "CDC:ErrMySQLConnectionError", Message: "Error 1045 (28000): Access denied"}},
+	})
+	require.True(t, changed)
+	require.Equal(t, model.StateWarning, state)
+	require.False(t, backoff.failed.Load())
+	backoff.errBackoff.MaxElapsedTime = time.Minute * 30
+	backoff.resetErrRetry()
+
+	// the most severe action is taken
+	changed, state, err = backoff.CheckStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 1,
+		Err: []*heartbeatpb.RunningError{
+			{Message: "test"},
+			{Code: "CDC:ErrDownstreamDataIncompatible", Message: "Error 1146 (42S02): Table 'test.t' doesn't exist"}},
+	})
+	require.True(t, changed)
+	require.Equal(t, model.StateStopped, state)
+	require.Equal(t, "Error 1146 (42S02): Table 'test.t' doesn't exist", err.Message)
+	require.False(t, backoff.ShouldRun())
+
+	history := backoff.GetErrorHistory()
+	require.Len(t, history, 4)
+	require.Equal(t, config.ErrorCategoryDownstreamUnavailable, history[0].Category)
+	require.Equal(t, config.ErrorActionAlertOnly, history[0].Action)
+	require.Equal(t, config.ErrorCategoryInternal, history[2].Category)
+	require.Equal(t, config.ErrorActionRetry, history[2].Action)
+	require.Equal(t, config.ErrorCategoryDataIncompatible, history[3].Category)
+	require.Equal(t, config.ErrorActionPause, history[3].Action)
+
+	// resumed
+	backoff.resetErrRetry()
+	// a not retryable error fails the changefeed even the action is retry
+	changed, state, _ = backoff.CheckStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 1,
+		Err: []*heartbeatpb.RunningError{
+			{Code: "CDC:ErrChangefeedUnretryable", Message: "test"}},
+	})
+	require.True(t, changed)
+	require.Equal(t, model.StateFailed, state)
+
+	// the history is bounded
+	for i := 0; i < maxErrorDecisionHistory*2; i++ {
+		backoff.errorPolicy.decide([]*heartbeatpb.RunningError{{Message: "test"}})
+	}
+	require.Len(t, backoff.GetErrorHistory(), maxErrorDecisionHistory)
+}
+
+func TestErrorPolicySkipTable(t *testing.T) {
+	policy := &config.ErrorPolicyConfig{DataIncompatible: config.ErrorActionSkipTable}
+	require.Nil(t, policy.ValidateAndAdjust())
+	backoff := NewBackoff(common.NewChangeFeedIDWithName("test"), time.Minute*30, 1, policy)
+
+	// the table is skipped and the changefeed is restarted at once
+	changed, state, err := backoff.CheckStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 1,
+		Err: []*heartbeatpb.RunningError{
+			{Code: "CDC:ErrMessageTooLarge", Message: "message is too large", Table: "`test`.`t`"}},
+	})
+	require.True(t, changed)
+	require.Equal(t, model.StateWarning, state)
+	require.Equal(t, "`test`.`t`", err.Table)
+	require.True(t, backoff.ShouldRun())
+	require.Equal(t, "`test`.`t`", backoff.TakeSkippedTable())
+	require.Equal(t, "", backoff.TakeSkippedTable())
+	history := backoff.GetErrorHistory()
+	require.Equal(t, config.ErrorActionSkipTable, history[len(history)-1].Action)
+	require.Equal(t, "`test`.`t`", history[len(history)-1].Table)
+
+	// the changefeed is paused if the error can't be attributed to a single table
+	changed, state, _ = backoff.CheckStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 1,
+		Err: []*heartbeatpb.RunningError{
+			{Code: "CDC:ErrMessageTooLarge", Message: "message is too large"}},
+	})
+	require.True(t, changed)
+	require.Equal(t, model.StateStopped, state)
+	require.Equal(t, "", backoff.TakeSkippedTable())
+}
+
+func TestValidateErrorPolicy(t *testing.T) {
+	policy := &config.ErrorPolicyConfig{}
+	require.Nil(t, policy.ValidateAndAdjust())
+	require.Equal(t, config.ErrorActionRetry, policy.Internal)
+	require.Equal(t, config.ErrorActionFail, policy.UpstreamGC)
+	policy.UpstreamGC = config.ErrorActionRetry
+	require.NotNil(t, policy.ValidateAndAdjust())
+	policy.UpstreamGC = config.ErrorActionPause
+	policy.Internal = "skip"
+	require.NotNil(t, policy.ValidateAndAdjust())
+	// skip-table is only valid for data-incompatible errors
+	policy.Internal = config.ErrorActionSkipTable
+	require.NotNil(t, policy.ValidateAndAdjust())
+	policy.Internal = config.ErrorActionRetry
+	policy.DataIncompatible = config.ErrorActionSkipTable
+	require.Nil(t, policy.ValidateAndAdjust())
+}
@@ -74,6 +74,14 @@ type ChangefeedStateChangeEvent struct {
 	ChangefeedID common.ChangeFeedID
 	State        model.FeedState
 	err          *model.RunningError
+	// warning is not nil if the event only reports a warning of the changefeed,
+	// the state and error of the changefeed are not changed
+	warning *model.RunningError
+	// skippedTable is the table removed from the changefeed by the error policy
+	skippedTable string
+	// tableReplayFinished is the id of the finished table replay, the state of the
+	// changefeed is not changed if it's not 0
+	tableReplayFinished uint64
 }
 
 func NewController(
@@ -84,7 +92,7 @@ func NewController(
 	backend changefeed.Backend,
 	stream dynstream.DynamicStream[int, string, *Event, *Controller, *StreamHandler],
 	taskScheduler threadpool.ThreadPool,
-	batchSize int, balanceInterval time.Duration) *Controller {
+	batchSize int, balanceInterval time.Duration, balanceStrategy string) *Controller {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
 	changefeedDB := changefeed.NewChangefeedDB()
 
@@ -94,7 +102,7 @@ func NewController(
 		version:             version,
 		batchSize:           batchSize,
 		bootstrapped:        atomic.NewBool(false),
-		cfScheduller:        scheduler.NewScheduler(batchSize, oc, changefeedDB, nodeManager, balanceInterval),
+		cfScheduller:        scheduler.NewScheduler(batchSize, oc, changefeedDB, nodeManager, balanceInterval, balanceStrategy),
 		operatorController:  oc,
 		messageCenter:       mc,
 		changefeedDB:        changefeedDB,
@@ -166,6 +174,7 @@ func (c *Controller) onMessage(msg *messaging.TargetMessage) {
 	case messaging.TypeMaintainerHeartbeatRequest:
 		if c.bootstrapper.CheckAllNodeInitialized() {
 			req := msg.Message[0].(*heartbeatpb.MaintainerHeartbeat)
+			c.cfScheduller.UpdateNodeLoad(msg.From, req.NodeLoad)
 			c.HandleStatus(msg.From, req.Statuses)
 		}
 	default:
@@ -299,6 +308,34 @@ func (c *Controller) HandleStatus(from node.ID, statusList []*heartbeatpb.Mainta
 				ChangefeedID: cfID,
 				State:        state,
 				err:          mErr,
+				skippedTable: cf.TakeSkippedTable(),
+			}
+		}
+		if replay := cf.GetInfo().TableReplay; replay != nil {
+			if status.TableReplayId != replay.ID {
+				// the maintainer doesn't receive the request or is restarted before bootstrapped
+				_ = c.messageCenter.SendCommand(cf.NewResetDispatchersMessage(replay))
+			} else if status.TableReplayFinished {
+				c.stateChangedCh <- &ChangefeedStateChangeEvent{
+					ChangefeedID:        cfID,
+					tableReplayFinished: replay.ID,
+				}
+			}
+		}
+		if len(status.Warning) > 0 {
+			// only the last warning is kept in the changefeed info
+			w := status.Warning[len(status.Warning)-1]
+			log.Warn("changefeed meets a warning",
+				zap.String("changefeed", cfID.Name()),
+				zap.String("warning", w.Message))
+			c.stateChangedCh <- &ChangefeedStateChangeEvent{
+				ChangefeedID: cfID,
+				warning: &model.RunningError{
+					Time:    time.Now(),
+					Addr:    w.Node,
+					Code:    w.Code,
+					Message: w.Message,
+				},
 			}
 		}
 		cfs[cfID] = cf
@@ -389,6 +426,55 @@ func (c *Controller) CreateChangefeed(ctx context.Context, info *config.ChangeFe
 	return nil
 }
 
+// CreateChangefeeds creates a batch of changefeeds, all changefeeds are validated
+// before any of them is created, and the created ones are removed from the backend if
+// one of them failed, so either all of them are created or none of them.
+// The returned errors are indexed the same as the infos, nil means the changefeed is valid.
+func (c *Controller) CreateChangefeeds(ctx context.Context, infos []*config.ChangeFeedInfo) ([]error, error) {
+	c.apiLock.Lock()
+	defer c.apiLock.Unlock()
+
+	if !c.bootstrapped.Load() {
+		return nil, errors.New("not initialized, wait a moment")
+	}
+	errs := make([]error, len(infos))
+	failed := false
+	names := make(map[common.ChangeFeedDisplayName]struct{}, len(infos))
+	for i, info := range infos {
+		if _, ok := names[info.ChangefeedID.DisplayName]; ok {
+			errs[i] = errors.New("duplicated changefeed in the batch")
+		} else if c.changefeedDB.GetByChangefeedDisplayName(info.ChangefeedID.DisplayName) != nil {
+			errs[i] = errors.New("changefeed already exists")
+		} else if c.operatorController.HasOperator(info.ChangefeedID.DisplayName) {
+			errs[i] = errors.New("changefeed is in scheduling")
+		}
+		names[info.ChangefeedID.DisplayName] = struct{}{}
+		failed = failed || errs[i] != nil
+	}
+	if failed {
+		return errs, nil
+	}
+
+	for i, info := range infos {
+		if err := c.backend.CreateChangefeed(ctx, info); err != nil {
+			errs[i] = errors.Trace(err)
+			// rollback the changefeeds created in this batch
+			for _, created := range infos[:i] {
+				if err := c.backend.DeleteChangefeed(ctx, created.ChangefeedID); err != nil {
+					log.Warn("remove changefeed failed when rollback batch creation",
+						zap.String("changefeed", created.ChangefeedID.String()),
+						zap.Error(err))
+				}
+			}
+			return errs, nil
+		}
+	}
+	for _, info := range infos {
+		c.changefeedDB.AddAbsentChangefeed(changefeed.NewChangefeed(info.ChangefeedID, info, info.StartTs))
+	}
+	return errs, nil
+}
+
 func (c *Controller) RemoveChangefeed(ctx context.Context, id common.ChangeFeedID) (uint64, error) {
 	c.apiLock.Lock()
 	defer c.apiLock.Unlock()
@@ -462,6 +548,48 @@ func (c *Controller) UpdateChangefeed(ctx context.Context, change *config.Change
 	return nil
 }
 
+// ResetDispatchers makes the dispatchers of the given spans replay events from the reset ts
+// until they catch up the current checkpoint ts of the changefeed. The replay is persisted
+// in the changefeed info, so it's continued after the changefeed is moved or restarted.
+func (c *Controller) ResetDispatchers(ctx context.Context, id common.ChangeFeedID, spans []*heartbeatpb.TableSpan, resetTs uint64) error {
+	c.apiLock.Lock()
+	defer c.apiLock.Unlock()
+
+	cf := c.changefeedDB.GetByID(id)
+	if cf == nil {
+		return errors.New("changefeed not found")
+	}
+	if cf.GetInfo().State != model.StateNormal || cf.GetNodeID() == "" {
+		return errors.New("changefeed is not running")
+	}
+	if cf.GetInfo().TableReplay != nil {
+		return errors.New("the tables of the changefeed are being replayed")
+	}
+	info, err := cf.GetInfo().Clone()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	replay := &config.TableReplay{
+		ID:       uint64(time.Now().UnixNano()),
+		Spans:    make([]config.TableReplaySpan, 0, len(spans)),
+		ReplayTs: resetTs,
+		TargetTs: cf.GetStatus().CheckpointTs,
+	}
+	for _, span := range spans {
+		replay.Spans = append(replay.Spans, config.TableReplaySpan{
+			TableID:  span.TableID,
+			StartKey: span.StartKey,
+			EndKey:   span.EndKey,
+		})
+	}
+	info.TableReplay = replay
+	if err := c.backend.UpdateChangefeed(ctx, info, cf.GetCheckpointTsToSave(), config.ProgressNone); err != nil {
+		return errors.Trace(err)
+	}
+	cf.SetInfo(info)
+	return errors.Trace(c.messageCenter.SendCommand(cf.NewResetDispatchersMessage(replay)))
+}
+
 func (c *Controller) ListChangefeeds(_ context.Context) ([]*config.ChangeFeedInfo, []*config.ChangeFeedStatus, error) {
 	c.apiLock.RLock()
 	defer c.apiLock.RUnlock()
@@ -487,6 +615,43 @@ func (c *Controller) GetChangefeed(_ context.Context, changefeedDisplayName comm
 	return cf.GetInfo(), &config.ChangeFeedStatus{CheckpointTs: cf.GetStatus().CheckpointTs}, nil
 }
 
+// GetChangefeedErrorHistory returns the recent error decisions of a changefeed
+func (c *Controller) GetChangefeedErrorHistory(_ context.Context, changefeedDisplayName common.ChangeFeedDisplayName) ([]config.ErrorDecision, error) {
+	c.apiLock.RLock()
+	defer c.apiLock.RUnlock()
+
+	cf := c.changefeedDB.GetByChangefeedDisplayName(changefeedDisplayName)
+	if cf == nil {
+		return nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedDisplayName.Name)
+	}
+	return cf.GetErrorHistory(), nil
+}
+
+// GetChangefeedPlacementViolations returns the violations of the placement rules of a changefeed
+func (c *Controller) GetChangefeedPlacementViolations(_ context.Context, changefeedDisplayName common.ChangeFeedDisplayName) ([]string, error) {
+	c.apiLock.RLock()
+	defer c.apiLock.RUnlock()
+
+	cf := c.changefeedDB.GetByChangefeedDisplayName(changefeedDisplayName)
+	if cf == nil {
+		return nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedDisplayName.Name)
+	}
+	return scheduler.PlacementViolations(cf, c.nodeManager.GetAliveNodes()), nil
+}
+
+// GetChangefeedMemoryUsage returns the memory usage of a changefeed reported by its maintainer,
+// it's nil if the maintainer doesn't report it yet
+func (c *Controller) GetChangefeedMemoryUsage(_ context.Context, changefeedDisplayName common.ChangeFeedDisplayName) (*heartbeatpb.MemoryUsage, error) {
+	c.apiLock.RLock()
+	defer c.apiLock.RUnlock()
+
+	cf := c.changefeedDB.GetByChangefeedDisplayName(changefeedDisplayName)
+	if cf == nil {
+		return nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedDisplayName.Name)
+	}
+	return cf.GetStatus().GetMemoryUsage(), nil
+}
+
 // GetTask queries a task by channgefeed ID, return nil if not found
 func (c *Controller) GetTask(id common.ChangeFeedID) *changefeed.Changefeed {
 	return c.changefeedDB.GetByID(id)
@@ -494,6 +659,7 @@ func (c *Controller) GetTask(id common.ChangeFeedID) *changefeed.Changefeed {
 
 // RemoveNode is called when a node is removed
 func (c *Controller) RemoveNode(id node.ID) {
+	c.cfScheduller.RemoveNode(id)
 	c.operatorController.OnNodeRemoved(id)
 }
 
@@ -265,3 +265,61 @@ func TestCreateChangefeed(t *testing.T) {
 	}
 	require.NotNil(t, controller.CreateChangefeed(context.Background(), cf2Config))
 }
+
+func TestCreateChangefeeds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB()
+	controller := &Controller{
+		backend:      backend,
+		changefeedDB: changefeedDB,
+		operatorController: operator.NewOperatorController(nil, node.NewInfo("node1", ""),
+			changefeedDB, backend, 10),
+		bootstrapped: atomic.NewBool(true),
+	}
+	newInfo := func(name string) *config.ChangeFeedInfo {
+		return &config.ChangeFeedInfo{
+			ChangefeedID: common.NewChangeFeedIDWithName(name),
+			State:        model.StateNormal,
+			Config:       config.GetDefaultReplicaConfig(),
+			SinkURI:      "kafka://127.0.0.1:9092",
+		}
+	}
+
+	// duplicated in the batch, nothing is created
+	errs, err := controller.CreateChangefeeds(context.Background(),
+		[]*config.ChangeFeedInfo{newInfo("a"), newInfo("b"), newInfo("a")})
+	require.Nil(t, err)
+	require.Nil(t, errs[0])
+	require.Nil(t, errs[1])
+	require.NotNil(t, errs[2])
+	require.Equal(t, 0, changefeedDB.GetSize())
+
+	// the second one failed, the first one is rolled back
+	infos := []*config.ChangeFeedInfo{newInfo("a"), newInfo("b")}
+	gomock.InOrder(
+		backend.EXPECT().CreateChangefeed(gomock.Any(), infos[0]).Return(nil).Times(1),
+		backend.EXPECT().CreateChangefeed(gomock.Any(), infos[1]).Return(errors.New("failed")).Times(1),
+		backend.EXPECT().DeleteChangefeed(gomock.Any(), infos[0].ChangefeedID).Return(nil).Times(1),
+	)
+	errs, err = controller.CreateChangefeeds(context.Background(), infos)
+	require.Nil(t, err)
+	require.Nil(t, errs[0])
+	require.NotNil(t, errs[1])
+	require.Equal(t, 0, changefeedDB.GetSize())
+
+	backend.EXPECT().CreateChangefeed(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	errs, err = controller.CreateChangefeeds(context.Background(), infos)
+	require.Nil(t, err)
+	require.Nil(t, errs[0])
+	require.Nil(t, errs[1])
+	require.Equal(t, 2, changefeedDB.GetAbsentSize())
+
+	// one of them already exists
+	errs, err = controller.CreateChangefeeds(context.Background(),
+		[]*config.ChangeFeedInfo{newInfo("a"), newInfo("c")})
+	require.Nil(t, err)
+	require.NotNil(t, errs[0])
+	require.Nil(t, errs[1])
+	require.Equal(t, 2, changefeedDB.GetSize())
+}
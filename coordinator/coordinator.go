@@ -20,18 +20,19 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/coordinator/changefeed"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
 	"github.com/pingcap/ticdc/utils/dynstream"
 	"github.com/pingcap/ticdc/utils/threadpool"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/pdutil"
-	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/tikv/client-go/v2/oracle"
 	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
@@ -50,8 +51,11 @@ type coordinator struct {
 	controller    *Controller
 
 	gcManager gc.Manager
+	gcPolicy  *config.GcPolicyConfig
 	pdClient  pd.Client
 	pdClock   pdutil.Clock
+	// gcReported is the changefeeds whose gc status metrics are reported
+	gcReported map[common.ChangeFeedID]struct{}
 
 	updatedChangefeedCh chan map[common.ChangeFeedID]*changefeed.Changefeed
 	stateChangedCh      chan *ChangefeedStateChangeEvent
@@ -64,16 +68,25 @@ func New(node *node.Info,
 	pdClient pd.Client,
 	pdClock pdutil.Clock,
 	backend changefeed.Backend,
-	clusterID string,
+	gcServiceID string,
+	legacyGCServiceID string,
 	version int64,
 	batchSize int,
-	balanceCheckInterval time.Duration) node.Coordinator {
+	balanceCheckInterval time.Duration,
+	balanceStrategy string) node.Coordinator {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
+	serverConfig := config.GetGlobalServerConfig()
+	gcPolicy := serverConfig.GcPolicy
+	if gcPolicy == nil {
+		gcPolicy = config.NewDefaultGcPolicyConfig()
+	}
 	c := &coordinator{
 		version:             version,
 		nodeInfo:            node,
 		lastTickTime:        time.Now(),
-		gcManager:           gc.NewManager(clusterID, pdClient, pdClock),
+		gcManager:           gc.NewManager(gcServiceID, legacyGCServiceID, pdClient, pdClock, serverConfig.GcTTL),
+		gcPolicy:            gcPolicy,
+		gcReported:          make(map[common.ChangeFeedID]struct{}),
 		pdClient:            pdClient,
 		pdClock:             pdClock,
 		mc:                  mc,
@@ -85,7 +98,7 @@ func New(node *node.Info,
 	c.stream.Start()
 	c.taskScheduler = threadpool.NewThreadPoolDefault()
 
-	ctl := NewController(c.version, c.nodeInfo, c.updatedChangefeedCh, c.stateChangedCh, backend, c.stream, c.taskScheduler, batchSize, balanceCheckInterval)
+	ctl := NewController(c.version, c.nodeInfo, c.updatedChangefeedCh, c.stateChangedCh, backend, c.stream, c.taskScheduler, batchSize, balanceCheckInterval, balanceStrategy)
 	c.controller = ctl
 	if err := c.stream.AddPath("coordinator", ctl); err != nil {
 		log.Panic("failed to add path",
@@ -121,6 +134,9 @@ func (c *coordinator) Run(ctx context.Context) error {
 				log.Warn("update gc safepoint failed",
 					zap.Error(err))
 			}
+			if err := c.checkChangefeedsGC(ctx); err != nil {
+				return errors.Trace(err)
+			}
 			now := time.Now()
 			metrics.CoordinatorCounter.Add(float64(now.Sub(c.lastTickTime)) / float64(time.Second))
 			c.lastTickTime = now
@@ -146,13 +162,54 @@ func (c *coordinator) handleStateChangedEvent(ctx context.Context, event *Change
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if event.tableReplayFinished != 0 {
+		if cfInfo.TableReplay == nil || cfInfo.TableReplay.ID != event.tableReplayFinished {
+			return nil
+		}
+		cfInfo.TableReplay = nil
+		if err := c.backend.UpdateChangefeed(ctx, cfInfo, cf.GetCheckpointTsToSave(), config.ProgressNone); err != nil {
+			log.Error("failed to clear the table replay of changefeed",
+				zap.Error(err))
+			return errors.Trace(err)
+		}
+		cf.SetInfo(cfInfo)
+		log.Info("table replay of changefeed finished",
+			zap.String("changefeed", event.ChangefeedID.String()),
+			zap.Uint64("id", event.tableReplayFinished))
+		return nil
+	}
+	if event.warning != nil {
+		cfInfo.Warning = event.warning
+		if err := c.backend.UpdateChangefeed(ctx, cfInfo, cf.GetCheckpointTsToSave(), config.ProgressNone); err != nil {
+			log.Error("failed to update changefeed warning",
+				zap.Error(err))
+			return errors.Trace(err)
+		}
+		cf.SetInfo(cfInfo)
+		return nil
+	}
 	cfInfo.State = event.State
 	cfInfo.Error = event.err
+	if event.skippedTable != "" {
+		// the table is filtered out after the changefeed is restarted
+		if cfInfo.Config.Filter == nil {
+			cfInfo.Config.Filter = &config.FilterConfig{}
+		}
+		if len(cfInfo.Config.Filter.Rules) == 0 {
+			// an empty rule list matches all tables
+			cfInfo.Config.Filter.Rules = []string{"*.*"}
+		}
+		cfInfo.Config.Filter.Rules = append(cfInfo.Config.Filter.Rules, "!"+event.skippedTable)
+		log.Warn("skip the table of the changefeed by the error policy",
+			zap.String("changefeed", event.ChangefeedID.String()),
+			zap.String("table", event.skippedTable),
+			zap.Strings("rules", cfInfo.Config.Filter.Rules))
+	}
 	progress := config.ProgressNone
-	if event.State == model.StateFailed || event.State == model.StateFinished {
+	if event.State == model.StateFailed || event.State == model.StateFinished || event.State == model.StateStopped {
 		progress = config.ProgressStopping
 	}
-	if err := c.backend.UpdateChangefeed(context.Background(), cfInfo, cf.GetStatus().CheckpointTs, progress); err != nil {
+	if err := c.backend.UpdateChangefeed(context.Background(), cfInfo, cf.GetCheckpointTsToSave(), progress); err != nil {
 		log.Error("failed to update changefeed state",
 			zap.Error(err))
 		return errors.Trace(err)
@@ -163,7 +220,7 @@ func (c *coordinator) handleStateChangedEvent(ctx context.Context, event *Change
 	case model.StateWarning:
 		c.controller.operatorController.StopChangefeed(ctx, event.ChangefeedID, false)
 		c.controller.changefeedDB.Resume(event.ChangefeedID, false)
-	case model.StateFailed, model.StateFinished:
+	case model.StateFailed, model.StateFinished, model.StateStopped:
 		c.controller.operatorController.StopChangefeed(ctx, event.ChangefeedID, false)
 	default:
 	}
@@ -205,6 +262,31 @@ func (c *coordinator) CreateChangefeed(ctx context.Context, info *config.ChangeF
 	return c.controller.CreateChangefeed(ctx, info)
 }
 
+func (c *coordinator) CreateChangefeeds(ctx context.Context, infos []*config.ChangeFeedInfo) ([]error, error) {
+	return c.controller.CreateChangefeeds(ctx, infos)
+}
+
+func (c *coordinator) GetChangefeedErrorHistory(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) ([]config.ErrorDecision, error) {
+	return c.controller.GetChangefeedErrorHistory(ctx, changefeedDisplayName)
+}
+
+func (c *coordinator) GetChangefeedPlacementViolations(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) ([]string, error) {
+	return c.controller.GetChangefeedPlacementViolations(ctx, changefeedDisplayName)
+}
+
+func (c *coordinator) GetChangefeedGCStatus(_ context.Context, changefeedDisplayName common.ChangeFeedDisplayName) (*gc.ChangefeedGCStatus, error) {
+	cf := c.controller.changefeedDB.GetByChangefeedDisplayName(changefeedDisplayName)
+	if cf == nil {
+		return nil, errors.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedDisplayName.Name)
+	}
+	status := c.gcManager.GetChangefeedGCStatus(cf.GetLastSavedCheckPointTs())
+	return &status, nil
+}
+
+func (c *coordinator) GetChangefeedMemoryUsage(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) (*heartbeatpb.MemoryUsage, error) {
+	return c.controller.GetChangefeedMemoryUsage(ctx, changefeedDisplayName)
+}
+
 func (c *coordinator) RemoveChangefeed(ctx context.Context, id common.ChangeFeedID) (uint64, error) {
 	return c.controller.RemoveChangefeed(ctx, id)
 }
@@ -221,6 +303,10 @@ func (c *coordinator) UpdateChangefeed(ctx context.Context, change *config.Chang
 	return c.controller.UpdateChangefeed(ctx, change)
 }
 
+func (c *coordinator) ResetDispatchers(ctx context.Context, id common.ChangeFeedID, spans []*heartbeatpb.TableSpan, resetTs uint64) error {
+	return c.controller.ResetDispatchers(ctx, id, spans, resetTs)
+}
+
 func (c *coordinator) ListChangefeeds(ctx context.Context) ([]*config.ChangeFeedInfo, []*config.ChangeFeedStatus, error) {
 	return c.controller.ListChangefeeds(ctx)
 }
@@ -271,3 +357,65 @@ func (c *coordinator) updateGCSafepoint(
 	err := c.gcManager.TryUpdateGCSafePoint(ctx, gcSafepointUpperBound, false)
 	return errors.Trace(err)
 }
+
+// checkChangefeedsGC reports the gc status of the changefeeds blocking the GC safepoint,
+// and applies the gc policy to the changefeeds holding back the GC safepoint
+// longer than the threshold.
+func (c *coordinator) checkChangefeedsGC(ctx context.Context) error {
+	reported := make(map[common.ChangeFeedID]struct{})
+	for _, cf := range c.controller.changefeedDB.GetAllChangefeeds() {
+		info := cf.GetInfo()
+		if info == nil || !info.NeedBlockGC() {
+			continue
+		}
+		checkpointTs := cf.GetLastSavedCheckPointTs()
+		status := c.gcManager.GetChangefeedGCStatus(checkpointTs)
+		namespace, name := cf.ID.Namespace(), cf.ID.Name()
+		metrics.ChangefeedGCSafepointDistanceGauge.WithLabelValues(namespace, name).Set(status.Distance.Seconds())
+		metrics.ChangefeedGCTTLRemainingGauge.WithLabelValues(namespace, name).Set(status.TTLRemaining.Seconds())
+		reported[cf.ID] = struct{}{}
+
+		exceeded := c.gcPolicy.Action != config.GcPolicyActionNone &&
+			info.Config != nil && info.Config.CheckGCSafePoint &&
+			status.HoldBack > time.Duration(c.gcPolicy.Threshold)
+		if !exceeded {
+			metrics.ChangefeedGCHoldBackAlertGauge.WithLabelValues(namespace, name).Set(0)
+			continue
+		}
+		metrics.ChangefeedGCHoldBackAlertGauge.WithLabelValues(namespace, name).Set(1)
+		log.Warn("changefeed holds back the gc safepoint longer than the threshold",
+			zap.String("changefeed", cf.ID.String()),
+			zap.Uint64("checkpointTs", checkpointTs),
+			zap.Uint64("gcSafepoint", status.GCSafePoint),
+			zap.Duration("holdBack", status.HoldBack),
+			zap.Duration("threshold", time.Duration(c.gcPolicy.Threshold)),
+			zap.String("action", c.gcPolicy.Action))
+		if c.gcPolicy.Action != config.GcPolicyActionFail || info.State == model.StateFailed {
+			continue
+		}
+		// handle the event directly, sending it to the stateChangedCh in the
+		// coordinator loop may block forever
+		err := c.handleStateChangedEvent(ctx, &ChangefeedStateChangeEvent{
+			ChangefeedID: cf.ID,
+			State:        model.StateFailed,
+			err: &model.RunningError{
+				Time:    time.Now(),
+				Addr:    c.nodeInfo.AdvertiseAddr,
+				Code:    string(errors.ErrGCTTLExceeded.RFCCode()),
+				Message: errors.ErrGCTTLExceeded.GenWithStackByArgs(checkpointTs, cf.ID.String()).Error(),
+			},
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for id := range c.gcReported {
+		if _, ok := reported[id]; !ok {
+			metrics.ChangefeedGCSafepointDistanceGauge.DeleteLabelValues(id.Namespace(), id.Name())
+			metrics.ChangefeedGCTTLRemainingGauge.DeleteLabelValues(id.Namespace(), id.Name())
+			metrics.ChangefeedGCHoldBackAlertGauge.DeleteLabelValues(id.Namespace(), id.Name())
+		}
+	}
+	c.gcReported = reported
+	return nil
+}
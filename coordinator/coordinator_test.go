@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/coordinator/changefeed"
 	mock_changefeed "github.com/pingcap/ticdc/coordinator/changefeed/mock"
+	"github.com/pingcap/ticdc/coordinator/operator"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
@@ -36,8 +37,10 @@ import (
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/messaging/proto"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/stretchr/testify/require"
@@ -268,7 +271,7 @@ func TestCoordinatorScheduling(t *testing.T) {
 		}
 	}
 
-	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, "default", 100, 10000, time.Minute)
+	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, "default", "", 100, 10000, time.Minute, config.BalanceStrategyTaskCount)
 	co := cr.(*coordinator)
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -317,7 +320,7 @@ func TestScaleNode(t *testing.T) {
 	}
 	backend.EXPECT().GetAllChangefeeds(gomock.Any()).Return(cfs, nil).AnyTimes()
 
-	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, serviceID, 100, 10000, time.Millisecond*10)
+	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, serviceID, "", 100, 10000, time.Millisecond*10, config.BalanceStrategyTaskCount)
 
 	// run coordinator
 	go func() { cr.Run(ctx) }()
@@ -427,7 +430,7 @@ func TestBootstrapWithUnStoppedChangefeed(t *testing.T) {
 	}, nil).AnyTimes()
 	backend.EXPECT().DeleteChangefeed(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	backend.EXPECT().SetChangefeedProgress(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, serviceID, 100, 10000, time.Millisecond*10)
+	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, serviceID, "", 100, 10000, time.Millisecond*10, config.BalanceStrategyTaskCount)
 
 	// run coordinator
 	go func() { cr.Run(ctx) }()
@@ -477,3 +480,148 @@ func startMaintainerNode(ctx context.Context,
 		manager: maintainerM,
 	}
 }
+
+type mockGCManager struct {
+	gc.Manager
+	status gc.ChangefeedGCStatus
+}
+
+func (m *mockGCManager) GetChangefeedGCStatus(_ uint64) gc.ChangefeedGCStatus {
+	return m.status
+}
+
+func TestCheckChangefeedsGC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB()
+	nodeInfo := node.NewInfo("127.0.0.1:8300", "")
+	gcManager := &mockGCManager{status: gc.ChangefeedGCStatus{HoldBack: 2 * time.Hour}}
+	co := &coordinator{
+		nodeInfo:   nodeInfo,
+		gcManager:  gcManager,
+		gcPolicy:   &config.GcPolicyConfig{Action: config.GcPolicyActionAlert, Threshold: config.TomlDuration(time.Hour)},
+		gcReported: make(map[common.ChangeFeedID]struct{}),
+		backend:    backend,
+		controller: &Controller{
+			backend:      backend,
+			changefeedDB: changefeedDB,
+			operatorController: operator.NewOperatorController(nil, nodeInfo,
+				changefeedDB, backend, 10),
+		},
+	}
+	cfID := common.NewChangeFeedIDWithName("test")
+	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{ChangefeedID: cfID,
+		Config:  config.GetDefaultReplicaConfig(),
+		State:   model.StateNormal,
+		SinkURI: "mysql://127.0.0.1:3306"},
+		1)
+	changefeedDB.AddReplicatingMaintainer(cf, nodeInfo.ID)
+
+	// alert only
+	require.Nil(t, co.checkChangefeedsGC(context.Background()))
+	require.Equal(t, model.StateNormal, cf.GetInfo().State)
+	require.Contains(t, co.gcReported, cfID)
+
+	// not exceeded
+	co.gcPolicy.Action = config.GcPolicyActionFail
+	gcManager.status.HoldBack = 30 * time.Minute
+	require.Nil(t, co.checkChangefeedsGC(context.Background()))
+	require.Equal(t, model.StateNormal, cf.GetInfo().State)
+
+	// check-gc-safe-point is disabled
+	gcManager.status.HoldBack = 2 * time.Hour
+	cf.GetInfo().Config.CheckGCSafePoint = false
+	require.Nil(t, co.checkChangefeedsGC(context.Background()))
+	require.Equal(t, model.StateNormal, cf.GetInfo().State)
+
+	// fail the changefeed
+	cf.GetInfo().Config.CheckGCSafePoint = true
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any(), config.ProgressStopping).Return(nil).Times(1)
+	require.Nil(t, co.checkChangefeedsGC(context.Background()))
+	require.Equal(t, model.StateFailed, cf.GetInfo().State)
+	require.Equal(t, string(cerror.ErrGCTTLExceeded.RFCCode()), cf.GetInfo().Error.Code)
+
+	// the failed changefeed doesn't block gc anymore
+	require.Nil(t, co.checkChangefeedsGC(context.Background()))
+	require.NotContains(t, co.gcReported, cfID)
+}
+
+func TestHandleWarningEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB()
+	nodeInfo := node.NewInfo("127.0.0.1:8300", "")
+	co := &coordinator{
+		nodeInfo: nodeInfo,
+		backend:  backend,
+		controller: &Controller{
+			backend:      backend,
+			changefeedDB: changefeedDB,
+			operatorController: operator.NewOperatorController(nil, nodeInfo,
+				changefeedDB, backend, 10),
+		},
+	}
+	cfID := common.NewChangeFeedIDWithName("test")
+	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{ChangefeedID: cfID,
+		Config:  config.GetDefaultReplicaConfig(),
+		State:   model.StateNormal,
+		SinkURI: "mysql://127.0.0.1:3306"},
+		1)
+	changefeedDB.AddReplicatingMaintainer(cf, nodeInfo.ID)
+
+	// the warning is saved without changing the state of the changefeed
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any(), config.ProgressNone).Return(nil).Times(1)
+	require.Nil(t, co.handleStateChangedEvent(context.Background(), &ChangefeedStateChangeEvent{
+		ChangefeedID: cfID,
+		warning: &model.RunningError{
+			Time:    time.Now(),
+			Addr:    nodeInfo.AdvertiseAddr,
+			Code:    "CDC:ErrDispatcherEvicted",
+			Message: "evicted",
+		},
+	}))
+	require.Equal(t, model.StateNormal, cf.GetInfo().State)
+	require.Nil(t, cf.GetInfo().Error)
+	require.Equal(t, "CDC:ErrDispatcherEvicted", cf.GetInfo().Warning.Code)
+	require.Equal(t, 1, changefeedDB.GetReplicatingSize())
+}
+
+func TestHandleSkippedTable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB()
+	nodeInfo := node.NewInfo("127.0.0.1:8300", "")
+	co := &coordinator{
+		nodeInfo: nodeInfo,
+		backend:  backend,
+		controller: &Controller{
+			backend:      backend,
+			changefeedDB: changefeedDB,
+			operatorController: operator.NewOperatorController(nil, nodeInfo,
+				changefeedDB, backend, 10),
+		},
+	}
+	cfID := common.NewChangeFeedIDWithName("test")
+	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{ChangefeedID: cfID,
+		Config:  config.GetDefaultReplicaConfig(),
+		State:   model.StateNormal,
+		SinkURI: "mysql://127.0.0.1:3306"},
+		1)
+	changefeedDB.AddReplicatingMaintainer(cf, nodeInfo.ID)
+
+	// the skipped table is filtered out and the changefeed is restarted
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any(), config.ProgressNone).Return(nil).Times(1)
+	require.Nil(t, co.handleStateChangedEvent(context.Background(), &ChangefeedStateChangeEvent{
+		ChangefeedID: cfID,
+		State:        model.StateWarning,
+		err: &model.RunningError{
+			Time:    time.Now(),
+			Addr:    nodeInfo.AdvertiseAddr,
+			Code:    "CDC:ErrMessageTooLarge",
+			Message: "message is too large",
+		},
+		skippedTable: "`test`.`t`",
+	}))
+	require.Equal(t, model.StateWarning, cf.GetInfo().State)
+	require.Equal(t, []string{"*.*", "!`test`.`t`"}, cf.GetInfo().Config.Filter.Rules)
+}
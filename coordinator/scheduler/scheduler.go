@@ -14,11 +14,16 @@
 package scheduler
 
 import (
+	"fmt"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/pingcap/ticdc/coordinator/changefeed"
 	"github.com/pingcap/ticdc/coordinator/operator"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler"
 	"github.com/pingcap/ticdc/server/watcher"
@@ -26,7 +31,7 @@ import (
 
 // Scheduler generates operators for the maintainers, and push them to the operator controller
 // it generates add operator for the absent maintainers, and move operator for the unbalanced replicating maintainer
-// currently, it only supports balance the maintainers by size
+// the maintainers are balanced by size, or by load if the balance strategy is load
 type Scheduler struct {
 	batchSize            int
 	random               *rand.Rand
@@ -39,6 +44,12 @@ type Scheduler struct {
 	// `Schedule`.
 	// It speeds up rebalance.
 	forceBalance bool
+	// balanceStrategy is the strategy to balance the maintainers, task-count or load.
+	balanceStrategy string
+
+	// nodeLoads is the resource usage reported by the nodes
+	nodeLoadsMu sync.Mutex
+	nodeLoads   map[node.ID]scheduler.NodeLoad
 
 	operatorController *operator.Controller
 	changefeedDB       *changefeed.ChangefeedDB
@@ -53,11 +64,14 @@ func NewScheduler(
 	oc *operator.Controller,
 	db *changefeed.ChangefeedDB,
 	nodeManager *watcher.NodeManager,
-	balanceInterval time.Duration) *Scheduler {
+	balanceInterval time.Duration,
+	balanceStrategy string) *Scheduler {
 	return &Scheduler{
 		batchSize:            batchSize,
 		random:               rand.New(rand.NewSource(time.Now().UnixNano())),
 		checkBalanceInterval: balanceInterval,
+		balanceStrategy:      balanceStrategy,
+		nodeLoads:            make(map[node.ID]scheduler.NodeLoad),
 		operatorController:   oc,
 		changefeedDB:         db,
 		nodeManager:          nodeManager,
@@ -85,7 +99,8 @@ func (s *Scheduler) Execute() time.Time {
 				nodeSize[id] = 0
 			}
 		}
-		scheduler.BasicSchedule(availableSize, absent, nodeSize, func(cf *changefeed.Changefeed, nodeID node.ID) bool {
+		allowed := allowedByPlacement(s.nodeManager.GetAliveNodes())
+		scheduler.PlacementSchedule(availableSize, absent, nodeSize, allowed, func(cf *changefeed.Changefeed, nodeID node.ID) bool {
 			return s.operatorController.AddOperator(operator.NewAddMaintainerOperator(s.changefeedDB, cf, nodeID))
 		})
 
@@ -107,17 +122,154 @@ func (s *Scheduler) balance() {
 		return
 	}
 
-	// check the balance status
-	moveSize := scheduler.CheckBalanceStatus(s.changefeedDB.GetTaskSizePerNode(), s.nodeManager.GetAliveNodes())
-	if moveSize <= 0 {
-		// fast check the balance status, no need to do the balance,skip
+	nodes := s.nodeManager.GetAliveNodes()
+	// move the misplaced maintainers first, the balance is done after all maintainers are placed properly.
+	if s.evictMisplaced(nodes) {
+		return
+	}
+
+	// the changefeeds with placement rules are balanced among the nodes allowed by the rules,
+	// and the others are balanced among all nodes.
+	movedSize, balanced := 0, true
+	groups := scheduler.GroupByPlacement(nodes, s.changefeedDB.GetReplicating(), (*changefeed.Changefeed).GetPlacement)
+	for _, group := range groups {
+		batchSize := s.batchSize - movedSize
+		if batchSize <= 0 {
+			break
+		}
+		if s.balanceStrategy == config.BalanceStrategyLoad {
+			balanced = false
+			movedSize += s.balanceByLoad(batchSize, group.Nodes, group.Tasks)
+			continue
+		}
+		// check the balance status
+		nodeTaskSize := make(map[node.ID]int, len(group.Nodes))
+		for _, cf := range group.Tasks {
+			nodeTaskSize[cf.GetNodeID()]++
+		}
+		if scheduler.CheckBalanceStatus(nodeTaskSize, group.Nodes) <= 0 {
+			// fast check the balance status, no need to do the balance,skip
+			continue
+		}
+		balanced = false
+		// balance changefeeds among the nodes of the group
+		movedSize += scheduler.Balance(batchSize, s.random, group.Nodes, group.Tasks, s.doMove)
+	}
+	if balanced {
 		return
 	}
-	// balance changefeeds among the active nodes
-	movedSize := scheduler.Balance(s.batchSize, s.random, s.nodeManager.GetAliveNodes(), s.changefeedDB.GetReplicating(),
-		func(cf *changefeed.Changefeed, nodeID node.ID) bool {
-			return s.operatorController.AddOperator(operator.NewMoveMaintainerOperator(s.changefeedDB, cf, cf.GetNodeID(), nodeID))
-		})
 	s.forceBalance = movedSize >= s.batchSize
 	s.lastRebalanceTime = time.Now()
 }
+
+// evictMisplaced moves the maintainers running on the nodes not allowed by the placement rules,
+// returns true if there are misplaced ones.
+func (s *Scheduler) evictMisplaced(nodes map[node.ID]*node.Info) bool {
+	allowed := allowedByPlacement(nodes)
+	var misplaced []*changefeed.Changefeed
+	for _, cf := range s.changefeedDB.GetReplicating() {
+		if !allowed(cf, cf.GetNodeID()) {
+			misplaced = append(misplaced, cf)
+		}
+	}
+	if len(misplaced) == 0 {
+		return false
+	}
+	nodeSize := s.changefeedDB.GetTaskSizePerNode()
+	for id := range nodes {
+		if _, ok := nodeSize[id]; !ok {
+			nodeSize[id] = 0
+		}
+	}
+	movedSize := scheduler.EvictMisplaced(s.batchSize, misplaced, nodeSize, allowed, s.doMove)
+	s.forceBalance = movedSize >= s.batchSize
+	s.lastRebalanceTime = time.Now()
+	return true
+}
+
+func (s *Scheduler) doMove(cf *changefeed.Changefeed, nodeID node.ID) bool {
+	return s.operatorController.AddOperator(operator.NewMoveMaintainerOperator(s.changefeedDB, cf, cf.GetNodeID(), nodeID))
+}
+
+// balanceByLoad balances the maintainers among the nodes by the throughput of the changefeeds
+// and the resource usage of the nodes, returns the number of the moved maintainers.
+func (s *Scheduler) balanceByLoad(batchSize int, nodes map[node.ID]*node.Info, replicating []*changefeed.Changefeed) int {
+	s.nodeLoadsMu.Lock()
+	nodeLoads := make(map[node.ID]scheduler.NodeLoad, len(s.nodeLoads))
+	for id, load := range s.nodeLoads {
+		nodeLoads[id] = load
+	}
+	s.nodeLoadsMu.Unlock()
+
+	model := &scheduler.CostModel[*changefeed.Changefeed]{
+		EventSizePerSecond: func(cf *changefeed.Changefeed) float64 {
+			return float64(cf.GetStatus().GetEventSizePerSecond())
+		},
+		NodeLoads: nodeLoads,
+	}
+	return scheduler.BalanceByLoad(batchSize, nodes, replicating, model, s.doMove)
+}
+
+// UpdateNodeLoad updates the resource usage reported by the node
+func (s *Scheduler) UpdateNodeLoad(id node.ID, load *heartbeatpb.NodeLoad) {
+	if load == nil {
+		return
+	}
+	s.nodeLoadsMu.Lock()
+	defer s.nodeLoadsMu.Unlock()
+	s.nodeLoads[id] = scheduler.NodeLoad{
+		CPUUsage:    float64(load.CpuUsage),
+		MemoryUsage: float64(load.MemoryUsage),
+	}
+}
+
+// RemoveNode removes the resource usage of the node
+func (s *Scheduler) RemoveNode(id node.ID) {
+	s.nodeLoadsMu.Lock()
+	defer s.nodeLoadsMu.Unlock()
+	delete(s.nodeLoads, id)
+}
+
+// allowedByPlacement returns a function checks whether the maintainer of the changefeed
+// can be placed on the node, all nodes are allowed if no alive node matches the placement rules,
+// so the changefeed keeps running and the violation is reported instead.
+func allowedByPlacement(nodes map[node.ID]*node.Info) func(*changefeed.Changefeed, node.ID) bool {
+	anyMatched := make(map[common.ChangeFeedID]bool)
+	return func(cf *changefeed.Changefeed, id node.ID) bool {
+		placement := cf.GetPlacement()
+		if placement.IsEmpty() {
+			return true
+		}
+		if info, ok := nodes[id]; ok && placement.Match(info.Labels) {
+			return true
+		}
+		matched, ok := anyMatched[cf.ID]
+		if !ok {
+			matched = len(scheduler.FilterNodes(nodes, placement)) > 0
+			anyMatched[cf.ID] = matched
+		}
+		return !matched
+	}
+}
+
+// PlacementViolations returns the violations of the placement rules of the changefeed
+func PlacementViolations(cf *changefeed.Changefeed, nodes map[node.ID]*node.Info) []string {
+	placement := cf.GetPlacement()
+	if placement.IsEmpty() {
+		return nil
+	}
+	var violations []string
+	if len(scheduler.FilterNodes(nodes, placement)) == 0 {
+		violations = append(violations, "no alive node matches the placement rules")
+	} else if nodeID := cf.GetNodeID(); nodeID != "" {
+		if info, ok := nodes[nodeID]; ok && !placement.Match(info.Labels) {
+			violations = append(violations,
+				fmt.Sprintf("maintainer is running on node %s which does not match the placement rules", nodeID))
+		}
+	}
+	if misplaced := cf.GetStatus().GetMisplacedDispatchers(); misplaced > 0 {
+		violations = append(violations,
+			fmt.Sprintf("%d dispatchers are running on nodes which do not match the placement rules", misplaced))
+	}
+	return violations
+}
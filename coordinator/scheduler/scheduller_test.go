@@ -43,7 +43,7 @@ func TestExecute(t *testing.T) {
 		changefeedDB, nil, 10)
 	nm := watcher.NewNodeManager(nil, nil)
 	nm.GetAliveNodes()[self.ID] = self
-	s := NewScheduler(4, operatorController, changefeedDB, nm, 0)
+	s := NewScheduler(4, operatorController, changefeedDB, nm, 0, config.BalanceStrategyTaskCount)
 	s.batchSize = 4
 	s.Execute()
 	require.Equal(t, 4, operatorController.OperatorSize())
@@ -53,3 +53,88 @@ func TestExecute(t *testing.T) {
 	s.Execute()
 	require.Equal(t, 4, operatorController.OperatorSize())
 }
+
+func TestExecutePlacement(t *testing.T) {
+	changefeedDB := changefeed.NewChangefeedDB()
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Scheduler.Placement = &config.PlacementConfig{
+		NodeAffinity: map[string]string{"zone": "a"},
+	}
+	var cfs []*changefeed.Changefeed
+	for i := 0; i < 3; i++ {
+		cfID := common.NewChangeFeedIDWithName("test")
+		cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{ChangefeedID: cfID,
+			Config:  replicaConfig,
+			State:   model.StateNormal,
+			SinkURI: "mysql://127.0.0.1:3306"},
+			1)
+		changefeedDB.AddAbsentChangefeed(cf)
+		cfs = append(cfs, cf)
+	}
+
+	node1 := node.NewInfo("node1", "")
+	node1.Labels = map[string]string{"zone": "a"}
+	node2 := node.NewInfo("node2", "")
+	node2.Labels = map[string]string{"zone": "b"}
+	operatorController := operator.NewOperatorController(nil, node1,
+		changefeedDB, nil, 10)
+	nm := watcher.NewNodeManager(nil, nil)
+	nm.GetAliveNodes()[node1.ID] = node1
+	nm.GetAliveNodes()[node2.ID] = node2
+	s := NewScheduler(10, operatorController, changefeedDB, nm, 0, config.BalanceStrategyTaskCount)
+	s.Execute()
+	require.Equal(t, 3, operatorController.OperatorSize())
+	require.Equal(t, map[node.ID]int{node1.ID: 3}, changefeedDB.GetTaskSizePerNode())
+	require.Empty(t, PlacementViolations(cfs[0], nm.GetAliveNodes()))
+
+	// the changefeed is still scheduled if no alive node matches, and the violation is reported
+	delete(nm.GetAliveNodes(), node1.ID)
+	allowed := allowedByPlacement(nm.GetAliveNodes())
+	require.True(t, allowed(cfs[0], node2.ID))
+	require.Equal(t, []string{"no alive node matches the placement rules"},
+		PlacementViolations(cfs[0], nm.GetAliveNodes()))
+}
+
+func TestBalancePlacement(t *testing.T) {
+	changefeedDB := changefeed.NewChangefeedDB()
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Scheduler.Placement = &config.PlacementConfig{
+		NodeAffinity: map[string]string{"zone": "a"},
+	}
+	node1 := node.NewInfo("node1", "")
+	node1.Labels = map[string]string{"zone": "a"}
+	node2 := node.NewInfo("node2", "")
+	node2.Labels = map[string]string{"zone": "a"}
+	node3 := node.NewInfo("node3", "")
+	node3.Labels = map[string]string{"zone": "b"}
+
+	var cfs []*changefeed.Changefeed
+	for i := 0; i < 4; i++ {
+		cfID := common.NewChangeFeedIDWithName("test")
+		cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{ChangefeedID: cfID,
+			Config:  replicaConfig,
+			State:   model.StateNormal,
+			SinkURI: "mysql://127.0.0.1:3306"},
+			1)
+		changefeedDB.AddReplicatingMaintainer(cf, node1.ID)
+		cfs = append(cfs, cf)
+	}
+
+	operatorController := operator.NewOperatorController(nil, node1,
+		changefeedDB, nil, 10)
+	nm := watcher.NewNodeManager(nil, nil)
+	nm.GetAliveNodes()[node1.ID] = node1
+	nm.GetAliveNodes()[node2.ID] = node2
+	nm.GetAliveNodes()[node3.ID] = node3
+	s := NewScheduler(10, operatorController, changefeedDB, nm, 0, config.BalanceStrategyTaskCount)
+	s.balance()
+	// the changefeeds are balanced between node1 and node2, node3 is not allowed
+	require.Equal(t, 2, operatorController.OperatorSize())
+	for _, cf := range cfs {
+		op := operatorController.GetOperator(cf.ID)
+		if op == nil {
+			continue
+		}
+		require.Contains(t, op.String(), "dest:"+string(node2.ID))
+	}
+}
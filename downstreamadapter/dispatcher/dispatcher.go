@@ -40,8 +40,14 @@ type EventDispatcher interface {
 	GetFilterConfig() *eventpb.FilterConfig
 	EnableSyncPoint() bool
 	GetSyncPointInterval() time.Duration
+	EnableBDRMode() bool
+	EnablePreTableInfo() bool
+	EnableIntegrityCheck() bool
 	GetResolvedTs() uint64
 	SetInitialTableInfo(tableInfo *common.TableInfo)
+	ResetForReplay(replayTs, replicatingTs uint64)
+	HandleError(err error)
+	HandleWarning(err error)
 	HandleEvents(events []DispatcherEvent, wakeCallback func()) (block bool)
 }
 
@@ -116,8 +122,20 @@ type Dispatcher struct {
 	// if syncPointInfo is not nil, means enable Sync Point feature,
 	syncPointConfig *syncpoint.SyncPointConfig
 
+	// bdrMode is true if the changefeed is a part of the bidirectional replication,
+	// the event service drops the events written by other changefeeds for the dispatcher.
+	bdrMode bool
+	// preTableInfo is true if the sink sends the schema change message,
+	// the event service attaches the table info before the ddl to the ddl events.
+	preTableInfo bool
+	// integrityCheck is true if the changefeed enables the integrity check,
+	// the event service verifies the row checksums encoded by the upstream TiDB.
+	integrityCheck bool
+
 	// the max resolvedTs received by the dispatcher
 	resolvedTs uint64
+	// replayedTs is the ts the dispatcher replays events from, it's 0 if the dispatcher never replays
+	replayedTs uint64
 
 	// blockEventStatus is used to store the current pending ddl/sync point event and its block status.
 	blockEventStatus BlockEventStatus
@@ -141,6 +159,10 @@ type Dispatcher struct {
 	// such as error of flush ddl events
 	// errCh is shared in the eventDispatcherManager
 	errCh chan error
+	// warnCh is used to collect the warnings that need to report to maintainer,
+	// such as the eviction of the dispatcher from the event store.
+	// warnCh is shared in the eventDispatcherManager
+	warnCh chan error
 }
 
 func NewDispatcher(
@@ -154,8 +176,12 @@ func NewDispatcher(
 	schemaIDToDispatchers *SchemaIDToDispatchers,
 	syncPointConfig *syncpoint.SyncPointConfig,
 	filterConfig *eventpb.FilterConfig,
+	bdrMode bool,
+	preTableInfo bool,
+	integrityCheck bool,
 	currentPdTs uint64,
-	errCh chan error) *Dispatcher {
+	errCh chan error,
+	warnCh chan error) *Dispatcher {
 	dispatcher := &Dispatcher{
 		changefeedID:          changefeedID,
 		id:                    id,
@@ -167,6 +193,9 @@ func NewDispatcher(
 		componentStatus:       newComponentStateWithMutex(heartbeatpb.ComponentState_Working),
 		resolvedTs:            startTs,
 		filterConfig:          filterConfig,
+		bdrMode:               bdrMode,
+		preTableInfo:          preTableInfo,
+		integrityCheck:        integrityCheck,
 		isRemoving:            atomic.Bool{},
 		blockEventStatus:      BlockEventStatus{blockPendingEvent: nil},
 		tableProgress:         types.NewTableProgress(),
@@ -175,6 +204,7 @@ func NewDispatcher(
 		resendTaskMap:         newResendTaskMap(),
 		creatationPDTs:        currentPdTs,
 		errCh:                 errCh,
+		warnCh:                warnCh,
 	}
 
 	dispatcher.addToStatusDynamicStream()
@@ -222,7 +252,7 @@ func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.Dispat
 		pendingEvent, blockStatus := d.blockEventStatus.getEventAndStage()
 		if pendingEvent != nil && action.CommitTs == pendingEvent.GetCommitTs() && blockStatus == heartbeatpb.BlockStage_WAITING {
 			d.blockEventStatus.updateBlockStage(heartbeatpb.BlockStage_WRITING)
-			if action.Action == heartbeatpb.Action_Write {
+			if action.Action == heartbeatpb.Action_Write && !isNotSyncEvent(pendingEvent) {
 				err := d.sink.WriteBlockEvent(pendingEvent, d.tableProgress)
 				if err != nil {
 					select {
@@ -348,6 +378,22 @@ func (d *Dispatcher) SetInitialTableInfo(tableInfo *common.TableInfo) {
 	}
 }
 
+// ResetForReplay rewinds the dispatcher to receive the events after the replay ts again,
+// the events before the replicating ts are written in safe mode since they may have been written.
+// It's called by the event collector before the replayed events are handled.
+func (d *Dispatcher) ResetForReplay(replayTs, replicatingTs uint64) {
+	d.creatationPDTs = replicatingTs
+	d.tableProgress.Reset()
+	atomic.StoreUint64(&d.resolvedTs, replayTs)
+	// set after the resolvedTs, so the checkpoint ts reported with the replayed ts is rewound
+	atomic.StoreUint64(&d.replayedTs, replayTs)
+}
+
+// GetReplayedTs returns the ts the dispatcher replays events from, it's 0 if the dispatcher never replays.
+func (d *Dispatcher) GetReplayedTs() uint64 {
+	return atomic.LoadUint64(&d.replayedTs)
+}
+
 func isCompleteSpan(tableSpan *heartbeatpb.TableSpan) bool {
 	spanz.TableIDToComparableSpan(tableSpan.TableID)
 	startKey, endKey := spanz.GetTableRange(tableSpan.TableID)
@@ -388,22 +434,33 @@ func (d *Dispatcher) shouldBlock(event commonEvent.BlockEvent) bool {
 	return false
 }
 
+// isNotSyncEvent returns true if the event is a ddl which should not be written to the downstream,
+// such as the ddl executed in the non-primary cluster in bdr mode.
+func isNotSyncEvent(event commonEvent.BlockEvent) bool {
+	ddl, ok := event.(*commonEvent.DDLEvent)
+	return ok && ddl.NotSync
+}
+
 // 1.If the event is a single table DDL, it will be added to the sink for writing to downstream.
 // If the ddl leads to add new tables or drop tables, it should send heartbeat to maintainer
 // 2. If the event is a multi-table DDL / sync point Event, it will generate a TableSpanBlockStatus message with ddl info to send to maintainer.
 func (d *Dispatcher) dealWithBlockEvent(event commonEvent.BlockEvent) {
 	if !d.shouldBlock(event) {
-		err := d.sink.WriteBlockEvent(event, d.tableProgress)
-		if err != nil {
-			select {
-			case d.errCh <- err:
-			default:
-				log.Error("error channel is full, discard error",
-					zap.Any("ChangefeedID", d.changefeedID.String()),
-					zap.Any("DispatcherID", d.id.String()),
-					zap.Error(err))
+		if isNotSyncEvent(event) {
+			d.sink.PassBlockEvent(event, d.tableProgress)
+		} else {
+			err := d.sink.WriteBlockEvent(event, d.tableProgress)
+			if err != nil {
+				select {
+				case d.errCh <- err:
+				default:
+					log.Error("error channel is full, discard error",
+						zap.Any("ChangefeedID", d.changefeedID.String()),
+						zap.Any("DispatcherID", d.id.String()),
+						zap.Error(err))
+				}
+				return
 			}
-			return
 		}
 		if event.GetNeedAddedTables() != nil || event.GetNeedDroppedTables() != nil {
 			message := &heartbeatpb.TableSpanBlockStatus{
@@ -501,6 +558,30 @@ func (d *Dispatcher) GetStartTs() uint64 {
 	return d.startTs
 }
 
+// HandleError reports the error that the dispatcher can't recover from to the changefeed.
+func (d *Dispatcher) HandleError(err error) {
+	select {
+	case d.errCh <- err:
+	default:
+		log.Error("error channel is full, discard error",
+			zap.Any("ChangefeedID", d.changefeedID.String()),
+			zap.Any("DispatcherID", d.id.String()),
+			zap.Error(err))
+	}
+}
+
+// HandleWarning reports the warning to the changefeed, the dispatcher keeps working.
+func (d *Dispatcher) HandleWarning(err error) {
+	select {
+	case d.warnCh <- err:
+	default:
+		log.Warn("warning channel is full, discard warning",
+			zap.Any("ChangefeedID", d.changefeedID.String()),
+			zap.Any("DispatcherID", d.id.String()),
+			zap.Error(err))
+	}
+}
+
 func (d *Dispatcher) GetResolvedTs() uint64 {
 	return atomic.LoadUint64(&d.resolvedTs)
 }
@@ -546,6 +627,18 @@ func (d *Dispatcher) EnableSyncPoint() bool {
 	return d.syncPointConfig != nil
 }
 
+func (d *Dispatcher) EnableBDRMode() bool {
+	return d.bdrMode
+}
+
+func (d *Dispatcher) EnablePreTableInfo() bool {
+	return d.preTableInfo
+}
+
+func (d *Dispatcher) EnableIntegrityCheck() bool {
+	return d.integrityCheck
+}
+
 func (d *Dispatcher) GetFilterConfig() *eventpb.FilterConfig {
 	return d.filterConfig
 }
@@ -632,6 +725,8 @@ func (d *Dispatcher) GetBlockEventStatus() *heartbeatpb.State {
 }
 
 func (d *Dispatcher) GetHeartBeatInfo(h *HeartBeatInfo) {
+	// get the replayed ts before the checkpoint ts, see ResetForReplay
+	h.ReplayedTs = d.GetReplayedTs()
 	h.Watermark.CheckpointTs = d.GetCheckpointTs()
 	h.Watermark.ResolvedTs = d.GetResolvedTs()
 	h.Id = d.GetId()
@@ -113,8 +113,12 @@ func newDispatcherForTest(sink sink.Sink, tableSpan *heartbeatpb.TableSpan) *Dis
 			SyncPointRetention: time.Duration(10 * time.Minute),
 		}, // syncPointConfig
 		nil,          //filterConfig
+		false,        //bdrMode
+		false,        //preTableInfo
+		false,        //integrityCheck
 		common.Ts(0), //pdTs
 		make(chan error, 1),
+		make(chan error, 1),
 	)
 }
 
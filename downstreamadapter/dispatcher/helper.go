@@ -190,6 +190,7 @@ type HeartBeatInfo struct {
 	TableSpan       *heartbeatpb.TableSpan
 	ComponentStatus heartbeatpb.ComponentState
 	IsRemoving      bool
+	ReplayedTs      uint64
 }
 
 // Resend Task is reponsible for resending the TableSpanBlockStatus message with ddl info to maintainer each 50ms.
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
@@ -89,11 +90,21 @@ type EventDispatcherManager struct {
 	// sink is used to send all the events to the downstream.
 	sink sink.Sink
 
+	// accountant tracks the memory held by the event collector and the sink for the changefeed,
+	// and applies backpressure to the dispatchers when the memory quota is exceeded.
+	accountant *memquota.Accountant
+
 	latestWatermark Watermark
 
+	// tableMonitor is only not nil when the table monitor is enabled
+	tableMonitor *tableMonitor
+
 	// collect the error in all the dispatchers and sink module
 	// when we get the error, we will report the error to the maintainer
 	errCh chan error
+	// collect the warnings in all the dispatchers, unlike the errors,
+	// every warning is reported to the maintainer and the changefeed keeps running
+	warnCh chan error
 
 	closing bool
 	closed  atomic.Bool
@@ -125,11 +136,13 @@ func NewEventDispatcherManager(
 		statusesChan:                           make(chan TableSpanStatusWithSeq, 8192),
 		blockStatusesChan:                      make(chan *heartbeatpb.TableSpanBlockStatus, 1024*1024),
 		errCh:                                  make(chan error, 1),
+		warnCh:                                 make(chan error, 16),
 		cancel:                                 cancel,
 		config:                                 cfConfig,
 		filterConfig:                           toFilterConfigPB(cfConfig.Filter),
 		schemaIDToDispatchers:                  dispatcher.NewSchemaIDToDispatchers(),
 		latestWatermark:                        NewWatermark(startTs),
+		accountant:                             memquota.NewAccountant(changefeedID, cfConfig.MemoryQuota),
 		metricTableTriggerEventDispatcherCount: metrics.TableTriggerEventDispatcherGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
 		metricEventDispatcherCount:             metrics.EventDispatcherGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
 		metricCreateDispatcherDuration:         metrics.CreateDispatcherDuration.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
@@ -167,6 +180,13 @@ func NewEventDispatcherManager(
 		manager.collectErrors(ctx)
 	}()
 
+	// collect warnings from warning channel
+	manager.wg.Add(1)
+	go func() {
+		defer manager.wg.Done()
+		manager.collectWarnings(ctx)
+	}()
+
 	// collect heart beat info from all dispatchers
 	manager.wg.Add(1)
 	go func() {
@@ -181,6 +201,10 @@ func NewEventDispatcherManager(
 		manager.collectBlockStatusRequest(ctx)
 	}()
 
+	if cfConfig.EnableTableMonitor {
+		manager.tableMonitor = newTableMonitor(ctx, manager)
+	}
+
 	var tableTriggerStartTs uint64 = 0
 	// init table trigger event dispatcher when tableTriggerEventDispatcherID is not nil
 	if tableTriggerEventDispatcherID != nil {
@@ -198,7 +222,7 @@ func NewEventDispatcherManager(
 }
 
 func (e *EventDispatcherManager) initSink(ctx context.Context) error {
-	sink, err := sink.NewSink(ctx, e.config, e.changefeedID, e.errCh)
+	sink, err := sink.NewSink(ctx, e.config, e.changefeedID, e.accountant, e.errCh)
 	if err != nil {
 		return err
 	}
@@ -243,6 +267,9 @@ func (e *EventDispatcherManager) close(remove bool) {
 	}
 
 	e.heartBeatTask.Cancel()
+	if e.tableMonitor != nil {
+		e.tableMonitor.close()
+	}
 	err := appcontext.GetService[*HeartBeatCollector](appcontext.HeartbeatCollector).RemoveEventDispatcherManager(e)
 	if err != nil {
 		log.Error("remove event dispatcher manager from heartbeat collector failed", zap.Error(err))
@@ -257,6 +284,7 @@ func (e *EventDispatcherManager) close(remove bool) {
 
 	e.cancel()
 	e.wg.Wait()
+	e.accountant.Close()
 
 	metrics.TableTriggerEventDispatcherGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 	metrics.EventDispatcherGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
@@ -306,7 +334,7 @@ func (e *EventDispatcherManager) InitalizeTableTriggerEventDispatcher(schemaInfo
 		return errors.Trace(err)
 	}
 	// table trigger event dispatcher can register to event collector to receive events after finish the initial table schema store from the maintainer.
-	appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).AddDispatcher(e.tableTriggerEventDispatcher, int(e.config.MemoryQuota))
+	appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).AddDispatcher(e.tableTriggerEventDispatcher, e.accountant)
 
 	// when sink is not mysql-class, table trigger event dispatcher need to receive the checkpointTs message from maintainer.
 	if e.sink.SinkType() != common.MysqlSinkType {
@@ -361,8 +389,12 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo) er
 			e.schemaIDToDispatchers,
 			e.syncPointConfig,
 			e.filterConfig,
+			e.config.BDRMode,
+			e.config.SinkConfig != nil && e.config.SinkConfig.SchemaChangeMessageEnabled(),
+			e.config.SinkConfig != nil && e.config.SinkConfig.Integrity != nil && e.config.SinkConfig.Integrity.Enabled(),
 			pdTsList[idx],
-			e.errCh)
+			e.errCh,
+			e.warnCh)
 
 		if e.heartBeatTask == nil {
 			e.heartBeatTask = newHeartBeatTask(e)
@@ -375,7 +407,7 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo) er
 			// we don't register table trigger event dispatcher in event collector, when created.
 			// Table trigger event dispatcher is a special dispatcher,
 			// it need to wait get the initial table schema store from the maintainer, then will register to event collector to receive events.
-			appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).AddDispatcher(d, int(e.config.MemoryQuota))
+			appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).AddDispatcher(d, e.accountant)
 		}
 
 		seq := e.dispatcherMap.Set(id, d)
@@ -430,6 +462,7 @@ func (e *EventDispatcherManager) collectErrors(ctx context.Context) {
 				Node:    appcontext.GetID(),
 				Code:    string(apperror.ErrorCode(err)),
 				Message: err.Error(),
+				Table:   apperror.ErrorTable(err),
 			}
 			e.heartbeatRequestQueue.Enqueue(&HeartBeatRequestWithTargetID{TargetID: e.GetMaintainerID(), Request: &message})
 
@@ -449,6 +482,29 @@ func (e *EventDispatcherManager) collectErrors(ctx context.Context) {
 	}
 }
 
+// collectWarnings collect the warnings from the warning channel and report them to the maintainer.
+func (e *EventDispatcherManager) collectWarnings(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-e.warnCh:
+			log.Warn("Event Dispatcher Manager Meets Warning",
+				zap.String("changefeedID", e.changefeedID.String()),
+				zap.Error(err))
+			var message heartbeatpb.HeartBeatRequest
+			message.ChangefeedID = e.changefeedID.ToPB()
+			message.Warning = &heartbeatpb.RunningError{
+				Time:    time.Now().String(),
+				Node:    appcontext.GetID(),
+				Code:    string(apperror.ErrorCode(err)),
+				Message: err.Error(),
+			}
+			e.heartbeatRequestQueue.Enqueue(&HeartBeatRequestWithTargetID{TargetID: e.GetMaintainerID(), Request: &message})
+		}
+	}
+}
+
 // collectBlockStatusRequest collect the block status from the block status channel and report to the maintainer.
 func (e *EventDispatcherManager) collectBlockStatusRequest(ctx context.Context) {
 	for {
@@ -556,6 +612,7 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 		ChangefeedID:    e.changefeedID.ToPB(),
 		CompeleteStatus: needCompleteStatus,
 		Watermark:       heartbeatpb.NewMaxWatermark(),
+		MemoryUsage:     e.accountant.Usage().ToPB(),
 	}
 
 	toRemoveDispatcherIDs := make([]common.DispatcherID, 0)
@@ -590,11 +647,16 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 				ComponentStatus:    heartBeatInfo.ComponentStatus,
 				CheckpointTs:       heartBeatInfo.Watermark.CheckpointTs,
 				EventSizePerSecond: dispatcherItem.GetEventSizePerSecond(),
+				ReplayedTs:         heartBeatInfo.ReplayedTs,
 			})
 		}
 	})
 	message.Watermark.Seq = seq
 	e.latestWatermark.Set(message.Watermark)
+	// the transactional sink only runs on the maintainer node with the table trigger event dispatcher
+	if txnSink, ok := e.sink.(sink.TransactionalSink); ok && e.tableTriggerEventDispatcher != nil {
+		message.CommittedTs = txnSink.GetCommittedTs()
+	}
 
 	for idx, id := range toRemoveDispatcherIDs {
 		e.cleanDispatcher(id, removedDispatcherSchemaIDs[idx])
@@ -637,6 +699,24 @@ func (e *EventDispatcherManager) removeDispatcher(id common.DispatcherID) {
 	}
 }
 
+// replayDispatcher makes the dispatcher replay the events from the replay ts,
+// the events before the replicating ts are written in safe mode.
+// It's a no-op if the dispatcher is removed or has started the same replay.
+func (e *EventDispatcherManager) replayDispatcher(id common.DispatcherID, replayTs, replicatingTs uint64) {
+	dispatcher, ok := e.dispatcherMap.Get(id)
+	if !ok || dispatcher.GetRemovingStatus() || dispatcher.IsTableTriggerEventDispatcher() {
+		log.Warn("dispatcher can not be replayed, ignore it",
+			zap.String("changefeed", e.changefeedID.Name()),
+			zap.Stringer("dispatcher", id),
+			zap.Bool("found", ok))
+		return
+	}
+	if dispatcher.GetReplayedTs() == replayTs {
+		return
+	}
+	appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).ReplayDispatcher(dispatcher, replayTs, replicatingTs)
+}
+
 // cleanDispatcher is called when the dispatcher is removed successfully.
 func (e *EventDispatcherManager) cleanDispatcher(id common.DispatcherID, schemaID int64) {
 	e.dispatcherMap.Delete(id)
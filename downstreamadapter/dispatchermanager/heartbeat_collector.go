@@ -22,6 +22,7 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
+	"github.com/pingcap/ticdc/downstreamadapter/sink"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
@@ -196,6 +197,8 @@ func (h *SchedulerDispatcherRequestHandler) Handle(eventDispatcherManager *Event
 				log.Error("invalid remove dispatcher request count in one batch", zap.Int("count", len(reqs)))
 			}
 			eventDispatcherManager.removeDispatcher(dispatcherID)
+		case heartbeatpb.ScheduleAction_Replay:
+			eventDispatcherManager.replayDispatcher(dispatcherID, config.StartTs, config.CurrentPdTs)
 		}
 	}
 	if len(infos) > 0 {
@@ -230,6 +233,8 @@ func (h *SchedulerDispatcherRequestHandler) GetType(event SchedulerDispatcherReq
 		return dynstream.EventType{DataGroup: 1, Property: dynstream.BatchableData}
 	case heartbeatpb.ScheduleAction_Remove:
 		return dynstream.EventType{DataGroup: 2, Property: dynstream.NonBatchable}
+	case heartbeatpb.ScheduleAction_Replay:
+		return dynstream.EventType{DataGroup: 3, Property: dynstream.NonBatchable}
 	default:
 		log.Panic("unknown schedule action", zap.Int("action", int(event.ScheduleAction)))
 	}
@@ -314,6 +319,12 @@ func (h *CheckpointTsMessageHandler) Handle(eventDispatcherManager *EventDispatc
 		panic("invalid message count")
 	}
 	checkpointTsMessage := messages[0]
+	if checkpointTsMessage.FlushedTs != 0 {
+		if txnSink, ok := eventDispatcherManager.sink.(sink.TransactionalSink); ok {
+			txnSink.CommitTo(checkpointTsMessage.FlushedTs)
+		}
+		return false
+	}
 	if eventDispatcherManager.tableTriggerEventDispatcher != nil && eventDispatcherManager.sink.SinkType() != common.MysqlSinkType {
 		tableTriggerEventDispatcher := eventDispatcherManager.tableTriggerEventDispatcher
 		tableTriggerEventDispatcher.HandleCheckpointTs(checkpointTsMessage.CheckpointTs)
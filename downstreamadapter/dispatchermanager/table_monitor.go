@@ -0,0 +1,143 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatchermanager
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
+	"github.com/pingcap/ticdc/downstreamadapter/sink"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// tableMonitorInterval is the interval to report the checkpoint of each table
+const tableMonitorInterval = 10 * time.Second
+
+// tableMonitor reports the checkpoint of each table in the event dispatcher manager periodically.
+// The checkpoint lag of each table is exported as a metric, and the checkpoints are written
+// to the downstream if the sink supports it, so the freshness of each table can be measured
+// end to end, including the tables without any writes.
+type tableMonitor struct {
+	manager *EventDispatcherManager
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	// the tables reported in the last round, used to clean the metrics of the removed tables
+	reported map[int64]struct{}
+}
+
+func newTableMonitor(ctx context.Context, manager *EventDispatcherManager) *tableMonitor {
+	ctx, cancel := context.WithCancel(ctx)
+	m := &tableMonitor{
+		manager:  manager,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		reported: make(map[int64]struct{}),
+	}
+	go m.run(ctx)
+	return m
+}
+
+func (m *tableMonitor) run(ctx context.Context) {
+	defer close(m.done)
+	ticker := time.NewTicker(tableMonitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			for tableID := range m.reported {
+				m.deleteMetric(tableID)
+			}
+			return
+		case <-ticker.C:
+			m.report(m.manager.collectTableCheckpoints())
+		}
+	}
+}
+
+func (m *tableMonitor) report(checkpoints []sinkutil.TableCheckpoint) {
+	changefeedID := m.manager.changefeedID
+	now := oracle.GetPhysical(time.Now())
+	current := make(map[int64]struct{}, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		current[checkpoint.TableID] = struct{}{}
+		lag := float64(now-oracle.ExtractPhysical(checkpoint.CheckpointTs)) / 1e3
+		metrics.TableMonitorCheckpointTsLagGauge.WithLabelValues(
+			changefeedID.Namespace(), changefeedID.Name(), strconv.FormatInt(checkpoint.TableID, 10)).Set(lag)
+	}
+	for tableID := range m.reported {
+		if _, ok := current[tableID]; !ok {
+			m.deleteMetric(tableID)
+		}
+	}
+	m.reported = current
+
+	if s, ok := m.manager.sink.(sink.TableMonitorSink); ok {
+		// the table monitor is only used for monitoring, the error should not affect the changefeed
+		if err := s.WriteTableCheckpoints(checkpoints); err != nil {
+			log.Warn("failed to write table checkpoints to downstream",
+				zap.Stringer("changefeedID", changefeedID),
+				zap.Error(err))
+		}
+	}
+}
+
+func (m *tableMonitor) deleteMetric(tableID int64) {
+	changefeedID := m.manager.changefeedID
+	metrics.TableMonitorCheckpointTsLagGauge.DeleteLabelValues(
+		changefeedID.Namespace(), changefeedID.Name(), strconv.FormatInt(tableID, 10))
+}
+
+// close stops the table monitor and waits for it to exit,
+// it must be called before closing the sink.
+func (m *tableMonitor) close() {
+	m.cancel()
+	<-m.done
+}
+
+// collectTableCheckpoints returns the checkpoint of each table in the event dispatcher manager,
+// a table may be split into multiple dispatchers, the minimum checkpoint of them is used.
+func (e *EventDispatcherManager) collectTableCheckpoints() []sinkutil.TableCheckpoint {
+	tableCheckpoints := make(map[int64]uint64)
+	e.dispatcherMap.ForEach(func(_ common.DispatcherID, d *dispatcher.Dispatcher) {
+		if d.IsTableTriggerEventDispatcher() || d.GetRemovingStatus() {
+			return
+		}
+		tableID := d.GetTableSpan().TableID
+		checkpointTs := d.GetCheckpointTs()
+		if ts, ok := tableCheckpoints[tableID]; !ok || checkpointTs < ts {
+			tableCheckpoints[tableID] = checkpointTs
+		}
+	})
+
+	checkpoints := make([]sinkutil.TableCheckpoint, 0, len(tableCheckpoints))
+	for tableID, checkpointTs := range tableCheckpoints {
+		checkpoints = append(checkpoints, sinkutil.TableCheckpoint{
+			TableID:      tableID,
+			CheckpointTs: checkpointTs,
+		})
+	}
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].TableID < checkpoints[j].TableID
+	})
+	return checkpoints
+}
@@ -24,11 +24,14 @@ import (
 	"github.com/pingcap/ticdc/logservice/logservicepb"
 	"github.com/pingcap/ticdc/pkg/node"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/eventpb"
+	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/utils/dynstream"
@@ -47,6 +50,8 @@ type DispatcherRequest struct {
 	ActionType eventpb.ActionType
 	StartTs    uint64
 	OnlyUse    bool
+	// Replay is true if the reset request rewinds the dispatcher to the StartTs
+	Replay bool
 }
 
 type TargetAndDispatcherRequest struct {
@@ -136,7 +141,9 @@ func New(ctx context.Context, globalMemoryQuota int64, serverId node.ID) *EventC
 	return &eventCollector
 }
 
-func (c *EventCollector) AddDispatcher(target dispatcher.EventDispatcher, memoryQuota int) {
+// AddDispatcher adds the dispatcher to receive events, the events pending in the event collector
+// are bounded by the memory quota of the accountant, which is shared by all dispatchers of the changefeed.
+func (c *EventCollector) AddDispatcher(target dispatcher.EventDispatcher, accountant *memquota.Accountant) {
 	log.Info("add dispatcher", zap.Stringer("dispatcher", target.GetId()))
 	defer func() {
 		log.Info("add dispatcher done", zap.Stringer("dispatcher", target.GetId()))
@@ -144,6 +151,7 @@ func (c *EventCollector) AddDispatcher(target dispatcher.EventDispatcher, memory
 	stat := &DispatcherStat{
 		dispatcherID: target.GetId(),
 		target:       target,
+		accountant:   accountant,
 	}
 	stat.reset()
 	stat.sendCommitTs.Store(target.GetStartTs())
@@ -151,11 +159,13 @@ func (c *EventCollector) AddDispatcher(target dispatcher.EventDispatcher, memory
 	metrics.EventCollectorRegisteredDispatcherCount.Inc()
 
 	areaSetting := dynstream.NewAreaSettings()
-	areaSetting.MaxPendingSize = memoryQuota
+	areaSetting.MaxPendingSize = int(accountant.Quota())
 	err := c.ds.AddPath(target.GetId(), stat, areaSetting)
 	if err != nil {
 		log.Error("add dispatcher to dynamic stream failed", zap.Error(err))
 	}
+	area := target.GetChangefeedID().ID()
+	accountant.SetCollectorUsageFunc(func() int64 { return c.ds.GetAreaPendingSize(area) })
 
 	// TODO: handle the return error(now even it return error, it will be retried later, we can just ignore it now)
 	c.mustSendDispatcherRequest(c.serverId, eventServiceTopic, DispatcherRequest{
@@ -194,6 +204,24 @@ func (c *EventCollector) WakeDispatcher(dispatcherID common.DispatcherID) {
 	c.ds.Wake(dispatcherID) <- dispatcherID
 }
 
+// wakeDispatcherWhenAvailable wakes the dispatcher to handle the next events, the wake is held back
+// when the memory of the changefeed exceeds the quota, until the sink releases enough memory.
+func (c *EventCollector) wakeDispatcherWhenAvailable(stat *DispatcherStat) {
+	stat.accountant.RunWhenAvailable(func() { c.WakeDispatcher(stat.dispatcherID) })
+}
+
+// ReplayDispatcher makes the dispatcher replay the events after the replay ts,
+// the events before the replicating ts are written in safe mode.
+// It returns false if the dispatcher is not found.
+func (c *EventCollector) ReplayDispatcher(target dispatcher.EventDispatcher, replayTs, replicatingTs uint64) bool {
+	value, ok := c.dispatcherMap.Load(target.GetId())
+	if !ok {
+		return false
+	}
+	value.(*DispatcherStat).replay(c, replayTs, replicatingTs)
+	return true
+}
+
 func (c *EventCollector) ResetDispatcherStat(stat *DispatcherStat) {
 	stat.reset()
 	stat.resetDispatcher(c)
@@ -273,6 +301,7 @@ func (c *EventCollector) mustSendDispatcherRequest(target node.ID, topic string,
 			TableSpan: req.Dispatcher.GetTableSpan(),
 			StartTs:   req.StartTs,
 			OnlyReuse: req.OnlyUse,
+			Replay:    req.Replay,
 		},
 	}
 
@@ -283,6 +312,9 @@ func (c *EventCollector) mustSendDispatcherRequest(target node.ID, topic string,
 		message.RegisterDispatcherRequest.EnableSyncPoint = req.Dispatcher.EnableSyncPoint()
 		message.RegisterDispatcherRequest.SyncPointInterval = uint64(req.Dispatcher.GetSyncPointInterval().Seconds())
 		message.RegisterDispatcherRequest.SyncPointTs = syncpoint.CalculateStartSyncPointTs(req.StartTs, req.Dispatcher.GetSyncPointInterval())
+		message.RegisterDispatcherRequest.BdrMode = req.Dispatcher.EnableBDRMode()
+		message.RegisterDispatcherRequest.NeedPreTableInfo = req.Dispatcher.EnablePreTableInfo()
+		message.RegisterDispatcherRequest.IntegrityCheck = req.Dispatcher.EnableIntegrityCheck()
 	}
 
 	err := c.mc.SendCommand(&messaging.TargetMessage{
@@ -382,6 +414,8 @@ func (c *EventCollector) updateResolvedTsMetric() {
 type DispatcherStat struct {
 	dispatcherID common.DispatcherID
 	target       dispatcher.EventDispatcher
+	// accountant accounts the memory of the changefeed the dispatcher belongs to
+	accountant *memquota.Accountant
 
 	eventServiceInfo struct {
 		sync.RWMutex
@@ -392,6 +426,8 @@ type DispatcherStat struct {
 		readyEventReceived bool
 		// the remote event services which may contain data this dispatcher needed
 		remoteCandiates []node.ID
+		// pendingReplay is the replay waiting for the handshake event from the event service
+		pendingReplay *pendingReplay
 	}
 
 	// lastEventSeq is the sequence number of the last received DML/DDL event.
@@ -406,6 +442,12 @@ type DispatcherStat struct {
 	sendCommitTs atomic.Uint64
 }
 
+// pendingReplay is a replay of the dispatcher which is not applied yet.
+type pendingReplay struct {
+	replayTs      uint64
+	replicatingTs uint64
+}
+
 func (d *DispatcherStat) reset() {
 	if d.waitHandshake.Load() {
 		return
@@ -495,7 +537,59 @@ func (d *DispatcherStat) handleHandshakeEvent(event dispatcher.DispatcherEvent,
 		return
 	}
 	d.waitHandshake.Store(false)
-	d.target.SetInitialTableInfo(event.Event.(*commonEvent.HandshakeEvent).TableInfo)
+	handshake := event.Event.(*commonEvent.HandshakeEvent)
+	if replay := d.eventServiceInfo.pendingReplay; replay != nil && handshake.ResolvedTs == replay.replayTs {
+		// the events after the replay ts are sent from now on
+		d.target.ResetForReplay(replay.replayTs, replay.replicatingTs)
+		d.eventServiceInfo.pendingReplay = nil
+		log.Info("dispatcher starts to replay events",
+			zap.Stringer("dispatcher", d.target.GetId()),
+			zap.Uint64("replayTs", replay.replayTs))
+	}
+	d.target.SetInitialTableInfo(handshake.TableInfo)
+}
+
+// replay makes the dispatcher read the events after the replay ts from the local event service,
+// the local event service registers the dispatcher again at the replay ts.
+// The dispatcher is rewound when the handshake event of the replay is received.
+func (d *DispatcherStat) replay(eventCollector *EventCollector, replayTs, replicatingTs uint64) {
+	d.eventServiceInfo.Lock()
+	defer d.eventServiceInfo.Unlock()
+	if replay := d.eventServiceInfo.pendingReplay; replay != nil && replay.replayTs == replayTs {
+		return
+	}
+	if d.eventServiceInfo.serverID != "" && d.eventServiceInfo.serverID != eventCollector.serverId {
+		eventCollector.addDispatcherRequestToSendingQueue(
+			d.eventServiceInfo.serverID,
+			eventServiceTopic,
+			DispatcherRequest{
+				Dispatcher: d.target,
+				ActionType: eventpb.ActionType_ACTION_TYPE_REMOVE,
+			},
+		)
+	}
+	d.eventServiceInfo.serverID = eventCollector.serverId
+	d.eventServiceInfo.readyEventReceived = true
+	d.eventServiceInfo.remoteCandiates = nil
+	d.eventServiceInfo.pendingReplay = &pendingReplay{replayTs: replayTs, replicatingTs: replicatingTs}
+	// drop the events sent before the replay even if the dispatcher is waiting for another handshake
+	d.lastEventSeq.Store(0)
+	d.waitHandshake.Store(true)
+	d.sendCommitTs.Store(replayTs)
+	eventCollector.addDispatcherRequestToSendingQueue(
+		eventCollector.serverId,
+		eventServiceTopic,
+		DispatcherRequest{
+			Dispatcher: d.target,
+			StartTs:    replayTs,
+			ActionType: eventpb.ActionType_ACTION_TYPE_RESET,
+			Replay:     true,
+		},
+	)
+	log.Info("replay dispatcher",
+		zap.Stringer("dispatcher", d.target.GetId()),
+		zap.Uint64("replayTs", replayTs),
+		zap.Uint64("replicatingTs", replicatingTs))
 }
 
 func (d *DispatcherStat) handleReadyEvent(event dispatcher.DispatcherEvent, eventCollector *EventCollector) {
@@ -511,7 +605,8 @@ func (d *DispatcherStat) handleReadyEvent(event dispatcher.DispatcherEvent, even
 			return
 		}
 		// case 2: first ready signal from the server
-		// (must be a remote candidate, because we won't set d.eventServiceInfo.serverID to local event service until we receive ready signal)
+		// (must be a remote candidate, because we won't set d.eventServiceInfo.serverID to local event service until we receive ready signal,
+		// or the local event service after the dispatcher is registered again because of eviction)
 		d.eventServiceInfo.serverID = server
 		d.eventServiceInfo.readyEventReceived = true
 		eventCollector.addDispatcherRequestToSendingQueue(
@@ -563,6 +658,30 @@ func (d *DispatcherStat) handleNotReusableEvent(event dispatcher.DispatcherEvent
 		log.Panic("should not happen")
 	}
 	if *event.From == d.eventServiceInfo.serverID {
+		if *event.From == eventCollector.serverId {
+			// The local event service never registers the dispatcher in only reuse mode,
+			// so the data of the dispatcher must be evicted from the local event store.
+			// Register it again to pull the data from upstream.
+			log.Warn("dispatcher is evicted from local event service, register it again",
+				zap.String("changefeedID", d.target.GetChangefeedID().ID().String()),
+				zap.Stringer("dispatcher", d.target.GetId()),
+				zap.Uint64("startTs", d.sendCommitTs.Load()))
+			changefeedID := d.target.GetChangefeedID()
+			metrics.EventCollectorEvictedDispatcherCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()).Inc()
+			d.target.HandleWarning(apperror.ErrDispatcherEvicted.GenWithStackByArgs(d.target.GetId(), d.sendCommitTs.Load()))
+			d.reset()
+			d.eventServiceInfo.readyEventReceived = false
+			eventCollector.addDispatcherRequestToSendingQueue(
+				eventCollector.serverId,
+				eventServiceTopic,
+				DispatcherRequest{
+					Dispatcher: d.target,
+					StartTs:    d.sendCommitTs.Load(),
+					ActionType: eventpb.ActionType_ACTION_TYPE_REGISTER,
+				},
+			)
+			return
+		}
 		if len(d.eventServiceInfo.remoteCandiates) > 0 {
 			eventCollector.addDispatcherRequestToSendingQueue(
 				d.eventServiceInfo.remoteCandiates[0],
@@ -580,6 +699,32 @@ func (d *DispatcherStat) handleNotReusableEvent(event dispatcher.DispatcherEvent
 	}
 }
 
+// handleErrorEvent reports the error of the event service to the dispatcher,
+// the error from a stale event service is ignored.
+func (d *DispatcherStat) handleErrorEvent(event dispatcher.DispatcherEvent) {
+	errorEvent, ok := event.Event.(*commonEvent.ErrorEvent)
+	if !ok {
+		log.Panic("should not happen")
+	}
+	d.eventServiceInfo.RLock()
+	serverID := d.eventServiceInfo.serverID
+	d.eventServiceInfo.RUnlock()
+	if *event.From != serverID {
+		log.Info("receive error event from stale event service, ignore it",
+			zap.String("changefeedID", d.target.GetChangefeedID().ID().String()),
+			zap.Stringer("dispatcher", d.target.GetId()),
+			zap.Stringer("from", event.From),
+			zap.String("error", errorEvent.Message))
+		return
+	}
+	log.Warn("event service fails to scan events of the dispatcher",
+		zap.String("changefeedID", d.target.GetChangefeedID().ID().String()),
+		zap.Stringer("dispatcher", d.target.GetId()),
+		zap.Stringer("from", event.From),
+		zap.String("error", errorEvent.Message))
+	d.target.HandleError(errors.New(errorEvent.Message))
+}
+
 func (d *DispatcherStat) unregisterDispatcher(eventCollector *EventCollector) {
 	d.eventServiceInfo.RLock()
 	defer d.eventServiceInfo.RUnlock()
@@ -84,7 +84,8 @@ func (h *EventsHandler) Handle(stat *DispatcherStat, events ...dispatcher.Dispat
 		commonEvent.TypeSyncPointEvent,
 		commonEvent.TypeHandshakeEvent,
 		commonEvent.TypeReadyEvent,
-		commonEvent.TypeNotReusableEvent:
+		commonEvent.TypeNotReusableEvent,
+		commonEvent.TypeErrorEvent:
 		if len(events) > 1 {
 			log.Panic("receive multiple non-batchable events",
 				zap.String("changefeedID", stat.target.GetChangefeedID().ID().String()),
@@ -124,7 +125,7 @@ func (h *EventsHandler) Handle(stat *DispatcherStat, events ...dispatcher.Dispat
 				continue
 			}
 		}
-		return stat.target.HandleEvents(events[validEventStart:], func() { h.eventCollector.WakeDispatcher(stat.dispatcherID) })
+		return stat.target.HandleEvents(events[validEventStart:], func() { h.eventCollector.wakeDispatcherWhenAvailable(stat) })
 	case commonEvent.TypeDDLEvent,
 		commonEvent.TypeSyncPointEvent:
 		if stat.shouldIgnoreDataEvent(events[0], h.eventCollector) {
@@ -140,6 +141,9 @@ func (h *EventsHandler) Handle(stat *DispatcherStat, events ...dispatcher.Dispat
 	case commonEvent.TypeNotReusableEvent:
 		stat.handleNotReusableEvent(events[0], h.eventCollector)
 		return false
+	case commonEvent.TypeErrorEvent:
+		stat.handleErrorEvent(events[0])
+		return false
 	default:
 		log.Panic("unknown event type", zap.Int("type", int(events[0].GetType())))
 	}
@@ -153,6 +157,7 @@ const (
 	DataGroupHandshake       = 4
 	DataGroupReady           = 5
 	DataGroupNotReusable     = 6
+	DataGroupError           = 7
 )
 
 func (h *EventsHandler) GetType(event dispatcher.DispatcherEvent) dynstream.EventType {
@@ -171,6 +176,8 @@ func (h *EventsHandler) GetType(event dispatcher.DispatcherEvent) dynstream.Even
 		return dynstream.EventType{DataGroup: DataGroupReady, Property: dynstream.NonBatchable}
 	case commonEvent.TypeNotReusableEvent:
 		return dynstream.EventType{DataGroup: DataGroupNotReusable, Property: dynstream.NonBatchable}
+	case commonEvent.TypeErrorEvent:
+		return dynstream.EventType{DataGroup: DataGroupError, Property: dynstream.NonBatchable}
 	default:
 		log.Panic("unknown event type", zap.Int("type", int(event.GetType())))
 	}
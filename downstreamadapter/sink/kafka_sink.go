@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/url"
 	"sync/atomic"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
@@ -29,7 +30,9 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	ticonfig "github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	pkafka "github.com/pingcap/ticdc/pkg/sink/kafka"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -45,13 +48,19 @@ type KafkaSink struct {
 
 	dmlWorker *worker.KafkaDMLWorker
 	ddlWorker *worker.KafkaDDLWorker
+	// dmlProducer is used to send the ddl events in the transactions in the exactly-once mode
+	dmlProducer producer.DMLProducer
+	// txnCommitter is not nil if the events are written in kafka transactions
+	txnCommitter *kafkaTxnCommitter
 
 	// the module used by dmlWorker and ddlWorker
 	// KafkaSink need to close it when Close() is called
 	adminClient  tikafka.ClusterAdminClient
 	topicManager topicmanager.TopicManager
 	statistics   *metrics.Statistics
+	accountant   *memquota.Accountant
 
+	ctx      context.Context
 	errgroup *errgroup.Group
 	errCh    chan error
 	isNormal uint32 // if sink is normal, isNormal is 1, otherwise is 0
@@ -61,7 +70,7 @@ func (s *KafkaSink) SinkType() common.SinkType {
 	return common.KafkaSinkType
 }
 
-func NewKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, sinkConfig *ticonfig.SinkConfig, errCh chan error) (*KafkaSink, error) {
+func NewKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, sinkConfig *ticonfig.SinkConfig, accountant *memquota.Accountant, errCh chan error) (*KafkaSink, error) {
 	errGroup, ctx := errgroup.WithContext(ctx)
 	statistics := metrics.NewStatistics(changefeedID, "KafkaSink")
 	kafkaComponent, protocol, err := worker.GetKafkaSinkComponent(ctx, changefeedID, sinkURI, sinkConfig)
@@ -92,9 +101,12 @@ func NewKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, sinkURI
 		dmlProducer,
 		kafkaComponent.EncoderGroup,
 		kafkaComponent.ColumnSelector,
+		kafkaComponent.ColumnTransformers,
 		kafkaComponent.EventRouter,
 		kafkaComponent.TopicManager,
 		statistics,
+		sinkConfig.Integrity,
+		accountant,
 		errGroup)
 
 	ddlSyncProducer, err := kafkaComponent.Factory.SyncProducer(ctx)
@@ -110,25 +122,47 @@ func NewKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, sinkURI
 		kafkaComponent.EventRouter,
 		kafkaComponent.TopicManager,
 		statistics,
+		time.Duration(utils.GetOrZero(sinkConfig.SendWatermarkIntervalInMs))*time.Millisecond,
+		utils.GetOrZero(sinkConfig.SchemaChangeMessage),
 		errGroup)
 
 	sink := &KafkaSink{
 		changefeedID: changefeedID,
 		dmlWorker:    dmlWorker,
 		ddlWorker:    ddlWorker,
+		dmlProducer:  dmlProducer,
 		adminClient:  kafkaComponent.AdminClient,
 		topicManager: kafkaComponent.TopicManager,
 		statistics:   statistics,
+		accountant:   accountant,
+		ctx:          ctx,
 		errgroup:     errGroup,
 		errCh:        errCh,
 	}
-	go sink.run()
+	if kafkaComponent.ExactlyOnce {
+		txnProducer, ok := dmlAsyncProducer.(pkafka.TransactionalProducer)
+		if !ok {
+			err = cerror.ErrKafkaInvalidConfig.GenWithStack("the kafka producer doesn't support transactions")
+			return nil, err
+		}
+		sink.txnCommitter, err = newKafkaTxnCommitter(changefeedID, txnProducer,
+			kafkaComponent.EventRouter.GetDefaultTopic())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	go sink.run(ctx)
 	return sink, nil
 }
 
-func (s *KafkaSink) run() {
+func (s *KafkaSink) run(ctx context.Context) {
 	s.dmlWorker.Run()
 	s.ddlWorker.Run()
+	if s.txnCommitter != nil {
+		s.errgroup.Go(func() error {
+			return s.txnCommitter.run(ctx)
+		})
+	}
 
 	err := s.errgroup.Wait()
 	if errors.Cause(err) != context.Canceled {
@@ -152,6 +186,24 @@ func (s *KafkaSink) AddDMLEvent(event *commonEvent.DMLEvent, tableProgress *type
 		return
 	}
 	tableProgress.Add(event)
+	if s.txnCommitter != nil {
+		// the memory of the event is not held, otherwise the dispatchers may be held back
+		// by the events waiting for the flushed ts, and the flushed ts can't advance.
+		s.addTxnEvent(event, false, func() error {
+			event.AddPostFlushFunc(s.txnCommitter.acked)
+			select {
+			case <-s.ctx.Done():
+				return errors.Trace(s.ctx.Err())
+			case s.dmlWorker.GetEventChan() <- event:
+			}
+			return nil
+		})
+		return
+	}
+	// the memory of the event is held until all its rows are acked by kafka
+	size := event.GetSize()
+	s.accountant.Acquire(memquota.Sink, size)
+	event.AddPostFlushFunc(func() { s.accountant.Release(memquota.Sink, size) })
 	s.dmlWorker.GetEventChan() <- event
 }
 
@@ -169,6 +221,12 @@ func (s *KafkaSink) WriteBlockEvent(event commonEvent.BlockEvent, tableProgress
 			event.PostFlush()
 			return nil
 		}
+		if s.txnCommitter != nil {
+			s.addTxnEvent(event, true, func() error {
+				return s.ddlWorker.AsyncWriteBlockEvent(event, s.dmlProducer, s.txnCommitter.acked)
+			})
+			return nil
+		}
 		err := s.ddlWorker.WriteBlockEvent(event)
 		if err != nil {
 			atomic.StoreUint32(&s.isNormal, 0)
@@ -188,6 +246,39 @@ func (s *KafkaSink) WriteBlockEvent(event commonEvent.BlockEvent, tableProgress
 	return nil
 }
 
+// addTxnEvent hands the event over to the transaction committer, the event is flushed at once,
+// and it's sent when the flushed ts of the changefeed passes it.
+func (s *KafkaSink) addTxnEvent(event commonEvent.FlushableEvent, isBlock bool, send func() error) {
+	event.PostFlush()
+	event.ClearPostFlushFunc()
+	added := s.txnCommitter.add(txnEvent{
+		commitTs: event.GetCommitTs(),
+		isBlock:  isBlock,
+		send:     send,
+	})
+	if !added {
+		log.Debug("drop the event committed before the sink is created",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Uint64("commitTs", event.GetCommitTs()))
+	}
+}
+
+// CommitTo implements the TransactionalSink interface.
+func (s *KafkaSink) CommitTo(flushedTs uint64) {
+	if s.txnCommitter != nil {
+		s.txnCommitter.advance(flushedTs)
+	}
+}
+
+// GetCommittedTs implements the TransactionalSink interface.
+func (s *KafkaSink) GetCommittedTs() uint64 {
+	if s.txnCommitter == nil {
+		return 0
+	}
+	return s.txnCommitter.getCommittedTs()
+}
+
 func (s *KafkaSink) AddCheckpointTs(ts uint64) {
 	s.ddlWorker.GetCheckpointTsChan() <- ts
 }
@@ -257,9 +348,12 @@ func newKafkaSinkForTest() (*KafkaSink, producer.DMLProducer, ddlproducer.DDLPro
 		dmlMockProducer,
 		kafkaComponent.EncoderGroup,
 		kafkaComponent.ColumnSelector,
+		kafkaComponent.ColumnTransformers,
 		kafkaComponent.EventRouter,
 		kafkaComponent.TopicManager,
 		statistics,
+		sinkConfig.Integrity,
+		nil,
 		errGroup)
 
 	ddlMockProducer := producer.NewMockDDLProducer()
@@ -271,6 +365,8 @@ func newKafkaSinkForTest() (*KafkaSink, producer.DMLProducer, ddlproducer.DDLPro
 		kafkaComponent.EventRouter,
 		kafkaComponent.TopicManager,
 		statistics,
+		time.Duration(utils.GetOrZero(sinkConfig.SendWatermarkIntervalInMs))*time.Millisecond,
+		utils.GetOrZero(sinkConfig.SchemaChangeMessage),
 		errGroup)
 
 	sink := &KafkaSink{
@@ -280,9 +376,10 @@ func newKafkaSinkForTest() (*KafkaSink, producer.DMLProducer, ddlproducer.DDLPro
 		adminClient:  kafkaComponent.AdminClient,
 		topicManager: kafkaComponent.TopicManager,
 		statistics:   statistics,
+		ctx:          ctx,
 		errgroup:     errGroup,
 		errCh:        errCh,
 	}
-	go sink.run()
+	go sink.run(ctx)
 	return sink, dmlMockProducer, ddlMockProducer, nil
 }
@@ -0,0 +1,189 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/sink/kafka"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+// txnEvent is an event flushed to the kafka sink but not sent yet.
+type txnEvent struct {
+	commitTs uint64
+	// isBlock is true for the ddl event, it's sent after all events before it are acknowledged.
+	isBlock bool
+	// send sends the event, the committer must be notified by acked after the event is acknowledged.
+	send func() error
+}
+
+// kafkaTxnCommitter writes the events of the kafka sink in transactions in the exactly-once mode.
+//
+// The events are flushed to the committer without waiting for them to be written, and they are
+// held until the flushed ts of the changefeed passes them. Then they are sent, and the transaction
+// is committed along with the flushed ts after all of them are acknowledged. So a transaction
+// contains exactly the events between two flushed ts, and the checkpoint ts of the changefeed,
+// which never exceeds the committed ts, is a transaction boundary.
+type kafkaTxnCommitter struct {
+	changefeedID common.ChangeFeedID
+	producer     kafka.TransactionalProducer
+	// topic is the topic keeping the committed ts
+	topic string
+
+	mu      sync.Mutex
+	pending []txnEvent
+	// flushedTs is the latest flushed ts of the changefeed, all events not larger than it
+	// have been flushed to the committer.
+	flushedTs uint64
+	// sentTs is the ts all events not larger than it have been sent.
+	sentTs uint64
+	// recoveredTs is the ts committed before the sink is created, the events not larger than
+	// it have been written to kafka, they are replayed since the checkpoint ts and dropped.
+	recoveredTs uint64
+
+	committedTs atomic.Uint64
+	inflight    atomic.Int64
+	notifyCh    chan struct{}
+}
+
+func newKafkaTxnCommitter(
+	changefeedID common.ChangeFeedID, producer kafka.TransactionalProducer, topic string,
+) (*kafkaTxnCommitter, error) {
+	recoveredTs, err := producer.GetCommittedCheckpoint(topic)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	log.Info("kafka transaction committer recovered the committed ts",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()),
+		zap.Uint64("committedTs", recoveredTs))
+	c := &kafkaTxnCommitter{
+		changefeedID: changefeedID,
+		producer:     producer,
+		topic:        topic,
+		flushedTs:    recoveredTs,
+		sentTs:       recoveredTs,
+		recoveredTs:  recoveredTs,
+		notifyCh:     make(chan struct{}, 1),
+	}
+	c.committedTs.Store(recoveredTs)
+	return c, nil
+}
+
+// add holds the event until it's sent in the transaction,
+// it returns false if the event has been committed before the sink is created.
+func (c *kafkaTxnCommitter) add(event txnEvent) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if event.commitTs <= c.recoveredTs {
+		return false
+	}
+	c.pending = append(c.pending, event)
+	c.notify()
+	return true
+}
+
+// advance updates the flushed ts, the transaction is committed at it.
+func (c *kafkaTxnCommitter) advance(flushedTs uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if flushedTs > c.flushedTs {
+		c.flushedTs = flushedTs
+		c.notify()
+	}
+}
+
+// acked is called after a sent event is acknowledged.
+func (c *kafkaTxnCommitter) acked() {
+	c.inflight.Dec()
+	c.notify()
+}
+
+func (c *kafkaTxnCommitter) notify() {
+	select {
+	case c.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (c *kafkaTxnCommitter) getCommittedTs() uint64 {
+	return c.committedTs.Load()
+}
+
+func (c *kafkaTxnCommitter) run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-c.notifyCh:
+		}
+		if err := c.sendAndCommit(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// sendAndCommit sends the pending events not larger than the flushed ts in order,
+// and commits the transaction after all of them are acknowledged.
+func (c *kafkaTxnCommitter) sendAndCommit() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	remaining := c.pending[:0]
+	blocked := false
+	for _, event := range c.pending {
+		// the events larger than the flushed ts belong to the next transaction,
+		// and the events after a blocked ddl wait for it.
+		if blocked || event.commitTs > c.flushedTs {
+			remaining = append(remaining, event)
+			continue
+		}
+		if event.isBlock && c.inflight.Load() > 0 {
+			blocked = true
+			remaining = append(remaining, event)
+			continue
+		}
+		c.inflight.Inc()
+		if err := event.send(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	// clear the references of the sent events
+	for i := len(remaining); i < len(c.pending); i++ {
+		c.pending[i] = txnEvent{}
+	}
+	c.pending = remaining
+	if blocked {
+		return nil
+	}
+	c.sentTs = c.flushedTs
+
+	if c.sentTs <= c.committedTs.Load() || c.inflight.Load() > 0 {
+		return nil
+	}
+	if err := c.producer.CommitCheckpoint(c.topic, c.sentTs); err != nil {
+		return errors.Trace(err)
+	}
+	c.committedTs.Store(c.sentTs)
+	log.Debug("kafka transaction committed",
+		zap.String("namespace", c.changefeedID.Namespace()),
+		zap.String("changefeed", c.changefeedID.Name()),
+		zap.Uint64("committedTs", c.sentTs))
+	return nil
+}
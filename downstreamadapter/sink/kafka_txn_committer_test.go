@@ -0,0 +1,79 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTxnProducer struct {
+	committed []uint64
+}
+
+func (p *mockTxnProducer) CommitCheckpoint(_ string, checkpointTs uint64) error {
+	p.committed = append(p.committed, checkpointTs)
+	return nil
+}
+
+func (p *mockTxnProducer) GetCommittedCheckpoint(_ string) (uint64, error) {
+	if len(p.committed) == 0 {
+		return 0, nil
+	}
+	return p.committed[len(p.committed)-1], nil
+}
+
+func TestKafkaTxnCommitter(t *testing.T) {
+	producer := &mockTxnProducer{committed: []uint64{10}}
+	c, err := newKafkaTxnCommitter(common.ChangefeedID4Test("test", "test"), producer, "topic")
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), c.getCommittedTs())
+
+	var sent []uint64
+	newEvent := func(commitTs uint64, isBlock bool) txnEvent {
+		return txnEvent{commitTs: commitTs, isBlock: isBlock, send: func() error {
+			sent = append(sent, commitTs)
+			return nil
+		}}
+	}
+	// the event committed before is dropped
+	require.False(t, c.add(newEvent(10, false)))
+	require.True(t, c.add(newEvent(11, false)))
+	require.True(t, c.add(newEvent(12, true)))
+	require.True(t, c.add(newEvent(13, false)))
+
+	// the events are held until they are flushed
+	require.NoError(t, c.sendAndCommit())
+	require.Empty(t, sent)
+
+	// the ddl waits for the events before it to be acknowledged
+	c.advance(13)
+	require.NoError(t, c.sendAndCommit())
+	require.Equal(t, []uint64{11}, sent)
+	require.Equal(t, uint64(10), c.getCommittedTs())
+
+	c.acked()
+	require.NoError(t, c.sendAndCommit())
+	require.Equal(t, []uint64{11, 12, 13}, sent)
+	// the transaction is committed after all events are acknowledged
+	require.Equal(t, uint64(10), c.getCommittedTs())
+
+	c.acked()
+	c.acked()
+	require.NoError(t, c.sendAndCommit())
+	require.Equal(t, uint64(13), c.getCommittedTs())
+	require.Equal(t, []uint64{10, 13}, producer.committed)
+}
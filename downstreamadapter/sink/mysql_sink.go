@@ -24,8 +24,10 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
 	"github.com/pingcap/ticdc/downstreamadapter/worker"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columntransformer"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/ticdc/pkg/sink/util"
@@ -34,6 +36,7 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	utils "github.com/pingcap/tiflow/pkg/util"
 )
 
@@ -53,12 +56,15 @@ type MysqlSink struct {
 	db         *sql.DB
 	errgroup   *errgroup.Group
 	statistics *metrics.Statistics
+	accountant *memquota.Accountant
 
 	errCh    chan error
 	isNormal uint32 // if sink is normal, isNormal is 1, otherwise is 0
+
+	enableTableMonitor bool
 }
 
-func NewMysqlSink(ctx context.Context, changefeedID common.ChangeFeedID, workerCount int, config *config.ChangefeedConfig, sinkURI *url.URL, errCh chan error) (*MysqlSink, error) {
+func NewMysqlSink(ctx context.Context, changefeedID common.ChangeFeedID, workerCount int, config *config.ChangefeedConfig, sinkURI *url.URL, accountant *memquota.Accountant, errCh chan error) (*MysqlSink, error) {
 	errgroup, ctx := errgroup.WithContext(ctx)
 	mysqlSink := MysqlSink{
 		changefeedID: changefeedID,
@@ -66,6 +72,7 @@ func NewMysqlSink(ctx context.Context, changefeedID common.ChangeFeedID, workerC
 		workerCount:  workerCount,
 		errgroup:     errgroup,
 		statistics:   metrics.NewStatistics(changefeedID, "TxnSink"),
+		accountant:   accountant,
 		errCh:        errCh,
 		isNormal:     1,
 	}
@@ -75,12 +82,34 @@ func NewMysqlSink(ctx context.Context, changefeedID common.ChangeFeedID, workerC
 		return nil, err
 	}
 	cfg.SyncPointRetention = utils.GetOrZero(config.SyncPointRetention)
+	if config.TiDBSourceID != 0 {
+		cfg.SourceID = config.TiDBSourceID
+	}
+	cfg.BDRMode = config.BDRMode
+	cfg.EnableTableMonitor = config.EnableTableMonitor
+	if config.SinkConfig != nil {
+		cfg.Integrity = config.SinkConfig.Integrity
+		cfg.TxnAtomicity = utils.GetOrZero(config.SinkConfig.TxnAtomicity)
+		cfg.ColumnTransformers, err = columntransformer.NewColumnTransformers(config.SinkConfig)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	// the data written by the changefeed can not be distinguished by the
+	// other changefeed without the write source, it leads to a replication loop.
+	if cfg.BDRMode && !cfg.IsWriteSourceExisted {
+		db.Close()
+		return nil, cerror.ErrSinkIncompatibleConfig.GenWithStackByArgs(
+			"bdr mode requires the downstream to be TiDB which supports tidb_cdc_write_source")
+	}
 
 	for i := 0; i < workerCount; i++ {
-		mysqlSink.dmlWorker[i] = worker.NewMysqlDMLWorker(ctx, db, cfg, i, mysqlSink.changefeedID, errgroup, mysqlSink.statistics)
+		mysqlSink.dmlWorker[i] = worker.NewMysqlDMLWorker(ctx, db, cfg, i, mysqlSink.changefeedID, errgroup, mysqlSink.statistics, mysqlSink.accountant)
 	}
 	mysqlSink.ddlWorker = worker.NewMysqlDDLWorker(ctx, db, cfg, mysqlSink.changefeedID, errgroup, mysqlSink.statistics)
 	mysqlSink.db = db
+	mysqlSink.enableTableMonitor = cfg.EnableTableMonitor
 
 	go mysqlSink.run()
 
@@ -101,10 +130,11 @@ func NewMysqlSinkWithDBAndConfig(ctx context.Context, changefeedID common.Change
 	}
 
 	for i := 0; i < workerCount; i++ {
-		mysqlSink.dmlWorker[i] = worker.NewMysqlDMLWorker(ctx, db, cfg, i, mysqlSink.changefeedID, errgroup, mysqlSink.statistics)
+		mysqlSink.dmlWorker[i] = worker.NewMysqlDMLWorker(ctx, db, cfg, i, mysqlSink.changefeedID, errgroup, mysqlSink.statistics, mysqlSink.accountant)
 	}
 	mysqlSink.ddlWorker = worker.NewMysqlDDLWorker(ctx, db, cfg, mysqlSink.changefeedID, errgroup, mysqlSink.statistics)
 	mysqlSink.db = db
+	mysqlSink.enableTableMonitor = cfg.EnableTableMonitor
 
 	go mysqlSink.run()
 
@@ -147,6 +177,10 @@ func (s *MysqlSink) AddDMLEvent(event *commonEvent.DMLEvent, tableProgress *type
 	}
 
 	tableProgress.Add(event)
+	// the memory of the event is held until it is flushed to the downstream
+	size := event.GetSize()
+	s.accountant.Acquire(memquota.Sink, size)
+	event.AddPostFlushFunc(func() { s.accountant.Release(memquota.Sink, size) })
 
 	// Considering that the parity of tableID is not necessarily even,
 	// directly dividing by the number of buckets may cause unevenness between buckets.
@@ -172,6 +206,15 @@ func (s *MysqlSink) WriteBlockEvent(event commonEvent.BlockEvent, tableProgress
 
 func (s *MysqlSink) AddCheckpointTs(ts uint64) {}
 
+// WriteTableCheckpoints writes the checkpoint of each table to the downstream,
+// it only works when the table monitor is enabled.
+func (s *MysqlSink) WriteTableCheckpoints(checkpoints []util.TableCheckpoint) error {
+	if !s.enableTableMonitor {
+		return nil
+	}
+	return s.ddlWorker.WriteTableCheckpoints(checkpoints)
+}
+
 func (s *MysqlSink) CheckStartTsList(tableIds []int64, startTsList []int64) ([]int64, error) {
 	startTsList, err := s.ddlWorker.CheckStartTsList(tableIds, startTsList)
 	if err != nil {
@@ -183,6 +226,13 @@ func (s *MysqlSink) CheckStartTsList(tableIds []int64, startTsList []int64) ([]i
 
 func (s *MysqlSink) Close(removeDDLTsItem bool) error {
 	if removeDDLTsItem {
+		if s.enableTableMonitor {
+			// the table monitor items are only used for monitoring, it's ok to leave them
+			if err := s.ddlWorker.RemoveTableCheckpoints(); err != nil {
+				log.Warn("failed to remove table monitor items",
+					zap.Stringer("changefeed", s.changefeedID), zap.Error(err))
+			}
+		}
 		return s.ddlWorker.RemoveDDLTsItem()
 	}
 	for i := 0; i < s.workerCount; i++ {
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
@@ -38,7 +39,27 @@ type Sink interface {
 	IsNormal() bool
 }
 
-func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID common.ChangeFeedID, errCh chan error) (Sink, error) {
+// TableMonitorSink is implemented by the sinks which can record the checkpoint of
+// each table in the downstream, so the freshness of the tables can be measured end to end.
+type TableMonitorSink interface {
+	WriteTableCheckpoints(checkpoints []sinkutil.TableCheckpoint) error
+}
+
+// TransactionalSink is implemented by the sinks which write the events in transactions,
+// such as the kafka sink in the exactly-once mode. The events flushed to the sink are
+// committed at the flushed ts of the changefeed, and the checkpoint ts of the changefeed
+// can't exceed the committed one, so the events are neither lost nor duplicated after restarting.
+type TransactionalSink interface {
+	// CommitTo commits the events not larger than the flushed ts in a transaction,
+	// all events not larger than it must have been flushed to the sink.
+	CommitTo(flushedTs uint64)
+	// GetCommittedTs returns the ts of the last committed transaction,
+	// it returns 0 if the sink doesn't write in transactions.
+	GetCommittedTs() uint64
+}
+
+// NewSink creates the sink of the changefeed, the memory held by the sink is accounted by the accountant.
+func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID common.ChangeFeedID, accountant *memquota.Accountant, errCh chan error) (Sink, error) {
 	sinkURI, err := url.Parse(config.SinkURI)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
@@ -46,9 +67,9 @@ func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID
 	scheme := sink.GetScheme(sinkURI)
 	switch scheme {
 	case sink.MySQLScheme, sink.MySQLSSLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
-		return NewMysqlSink(ctx, changefeedID, 16, config, sinkURI, errCh)
+		return NewMysqlSink(ctx, changefeedID, 16, config, sinkURI, accountant, errCh)
 	case sink.KafkaScheme, sink.KafkaSSLScheme:
-		return NewKafkaSink(ctx, changefeedID, sinkURI, config.SinkConfig, errCh)
+		return NewKafkaSink(ctx, changefeedID, sinkURI, config.SinkConfig, accountant, errCh)
 	}
 	return nil, nil
 }
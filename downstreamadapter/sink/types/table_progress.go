@@ -101,6 +101,14 @@ func (p *TableProgress) Pass(event commonEvent.BlockEvent) {
 	p.maxCommitTs = event.GetCommitTs()
 }
 
+// Reset forgets the events passed, it's called when the table replays events from an earlier ts.
+// All the events added must have been flushed.
+func (p *TableProgress) Reset() {
+	p.rwMutex.Lock()
+	defer p.rwMutex.Unlock()
+	p.maxCommitTs = 0
+}
+
 // GetCheckpointTs returns the current checkpoint timestamp for the table span.
 // It returns:
 // 1. The commitTs of the earliest unflushed event minus 1, if there are unflushed events.
@@ -10,6 +10,7 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	"github.com/pingcap/ticdc/pkg/common/columntransformer"
 	ticonfig "github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/sink/codec"
 	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
@@ -23,13 +24,16 @@ import (
 )
 
 type KafkaComponent struct {
-	EncoderGroup   codec.EncoderGroup
-	Encoder        encoder.EventEncoder
-	ColumnSelector *columnselector.ColumnSelectors
-	EventRouter    *eventrouter.EventRouter
-	TopicManager   topicmanager.TopicManager
-	AdminClient    tikafka.ClusterAdminClient
-	Factory        kafka.Factory
+	EncoderGroup       codec.EncoderGroup
+	Encoder            encoder.EventEncoder
+	ColumnSelector     *columnselector.ColumnSelectors
+	ColumnTransformers *columntransformer.ColumnTransformers
+	EventRouter        *eventrouter.EventRouter
+	TopicManager       topicmanager.TopicManager
+	AdminClient        tikafka.ClusterAdminClient
+	Factory            kafka.Factory
+	// ExactlyOnce is true if the events are written in kafka transactions
+	ExactlyOnce bool
 }
 
 func getKafkaSinkComponentWithFactory(ctx context.Context,
@@ -53,6 +57,7 @@ func getKafkaSinkComponentWithFactory(ctx context.Context,
 		return kafkaComponent, protocol, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
 	}
 
+	kafkaComponent.ExactlyOnce = options.ExactlyOnce
 	kafkaComponent.Factory, err = factoryCreator(options, changefeedID)
 	if err != nil {
 		return kafkaComponent, protocol, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
@@ -94,6 +99,11 @@ func getKafkaSinkComponentWithFactory(ctx context.Context,
 		return kafkaComponent, protocol, errors.Trace(err)
 	}
 
+	kafkaComponent.ColumnTransformers, err = columntransformer.NewColumnTransformers(sinkConfig)
+	if err != nil {
+		return kafkaComponent, protocol, errors.Trace(err)
+	}
+
 	encoderConfig, err := util.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, options.MaxMessageBytes)
 	if err != nil {
 		return kafkaComponent, protocol, errors.Trace(err)
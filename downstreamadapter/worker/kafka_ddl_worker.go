@@ -8,6 +8,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -16,6 +17,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
 	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -42,9 +44,24 @@ type KafkaDDLWorker struct {
 
 	statistics    *metrics.Statistics
 	partitionRule DDLDispatchRule
-	ctx           context.Context
-	cancel        context.CancelFunc
-	errGroup      *errgroup.Group
+
+	// watermarkInterval is the interval to send the watermark to all partitions of all active topics,
+	// the checkpoint is sent only when it advances if it is zero.
+	watermarkInterval time.Duration
+	// watermarkEpoch identifies the sequence of the watermarks sent by the worker. The sequence
+	// number restarts from 1 when the worker is recreated, on the changefeed restarting or the
+	// table trigger event dispatcher moving, so a new epoch is generated along with it.
+	watermarkEpoch uint64
+	// watermarkSeq is the sequence number of the last watermark sent to each topic in the epoch.
+	watermarkSeq map[string]uint64
+
+	// schemaChangeMessage indicates whether to send the structured schema change message
+	// along with or instead of the DDL message, see config.SchemaChangeMessageNone and others.
+	schemaChangeMessage string
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	errGroup *errgroup.Group
 }
 
 // DDLDispatchRule is the dispatch rule for DDL event.
@@ -77,22 +94,28 @@ func NewKafkaDDLWorker(
 	eventRouter *eventrouter.EventRouter,
 	topicManager topicmanager.TopicManager,
 	statistics *metrics.Statistics,
+	watermarkInterval time.Duration,
+	schemaChangeMessage string,
 	errGroup *errgroup.Group,
 ) *KafkaDDLWorker {
 	ctx, cancel := context.WithCancel(ctx)
 	return &KafkaDDLWorker{
-		ctx:              ctx,
-		changeFeedID:     id,
-		protocol:         protocol,
-		encoder:          encoder,
-		producer:         producer,
-		eventRouter:      eventRouter,
-		topicManager:     topicManager,
-		statistics:       statistics,
-		partitionRule:    getDDLDispatchRule(protocol),
-		checkpointTsChan: make(chan uint64, 16),
-		cancel:           cancel,
-		errGroup:         errGroup,
+		ctx:                 ctx,
+		changeFeedID:        id,
+		protocol:            protocol,
+		encoder:             encoder,
+		producer:            producer,
+		eventRouter:         eventRouter,
+		topicManager:        topicManager,
+		statistics:          statistics,
+		partitionRule:       getDDLDispatchRule(protocol),
+		watermarkInterval:   watermarkInterval,
+		watermarkEpoch:      uint64(time.Now().UnixNano()),
+		watermarkSeq:        make(map[string]uint64),
+		schemaChangeMessage: schemaChangeMessage,
+		checkpointTsChan:    make(chan uint64, 16),
+		cancel:              cancel,
+		errGroup:            errGroup,
 	}
 }
 
@@ -111,32 +134,10 @@ func (w *KafkaDDLWorker) SetTableSchemaStore(tableSchemaStore *util.TableSchemaS
 }
 
 func (w *KafkaDDLWorker) WriteBlockEvent(event *event.DDLEvent) error {
-	messages := make([]*ticommon.Message, 0)
-	topics := make([]string, 0)
-
-	// Some ddl event may be multi-events, we need to split it into multiple messages.
-	// Such as rename table test.table1 to test.table10, test.table2 to test.table20
-	if event.IsMultiEvents() {
-		subEvents := event.GetSubEvents()
-		for _, subEvent := range subEvents {
-			message, err := w.encoder.EncodeDDLEvent(&subEvent)
-			if err != nil {
-				return errors.Trace(err)
-			}
-			topic := w.eventRouter.GetTopicForDDL(&subEvent)
-			messages = append(messages, message)
-			topics = append(topics, topic)
-		}
-	} else {
-		message, err := w.encoder.EncodeDDLEvent(event)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		topic := w.eventRouter.GetTopicForDDL(event)
-		messages = append(messages, message)
-		topics = append(topics, topic)
+	messages, topics, err := w.encodeBlockEvent(event)
+	if err != nil {
+		return errors.Trace(err)
 	}
-
 	for i, message := range messages {
 		topic := topics[i]
 		partitionNum, err := w.topicManager.GetPartitionNum(w.ctx, topic)
@@ -162,6 +163,110 @@ func (w *KafkaDDLWorker) WriteBlockEvent(event *event.DDLEvent) error {
 	return nil
 }
 
+// AsyncWriteBlockEvent sends the DDL event by the DML producer without waiting for the
+// acknowledgement, so it's written in the same kafka transaction with the DML events.
+// The callback is called after all messages of the DDL event are acknowledged.
+func (w *KafkaDDLWorker) AsyncWriteBlockEvent(
+	event *event.DDLEvent, dmlProducer producer.DMLProducer, callback func(),
+) error {
+	messages, topics, err := w.encodeBlockEvent(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	partitionNums := make([]int32, len(messages))
+	total := int64(0)
+	for i, topic := range topics {
+		partitionNums[i] = 1
+		if w.partitionRule == PartitionAll {
+			partitionNums[i], err = w.topicManager.GetPartitionNum(w.ctx, topic)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		total += int64(partitionNums[i])
+	}
+	if total == 0 {
+		callback()
+		return nil
+	}
+	acked := atomic.NewInt64(0)
+	messageCallback := func() {
+		if acked.Inc() == total {
+			callback()
+		}
+	}
+	for i, message := range messages {
+		message.Callback = messageCallback
+		for partition := int32(0); partition < partitionNums[i]; partition++ {
+			err = dmlProducer.AsyncSendMessage(w.ctx, topics[i], partition, message)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// encodeBlockEvent encodes the DDL event into the messages, and returns the topic of each message.
+func (w *KafkaDDLWorker) encodeBlockEvent(event *event.DDLEvent) ([]*ticommon.Message, []string, error) {
+	messages := make([]*ticommon.Message, 0)
+	topics := make([]string, 0)
+
+	// Some ddl event may be multi-events, we need to split it into multiple messages.
+	// Such as rename table test.table1 to test.table10, test.table2 to test.table20
+	if event.IsMultiEvents() {
+		subEvents := event.GetSubEvents()
+		for _, subEvent := range subEvents {
+			encoded, err := w.encodeDDLEvent(&subEvent)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			topic := w.eventRouter.GetTopicForDDL(&subEvent)
+			for _, message := range encoded {
+				messages = append(messages, message)
+				topics = append(topics, topic)
+			}
+		}
+	} else {
+		encoded, err := w.encodeDDLEvent(event)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		topic := w.eventRouter.GetTopicForDDL(event)
+		for _, message := range encoded {
+			messages = append(messages, message)
+			topics = append(topics, topic)
+		}
+	}
+	return messages, topics, nil
+}
+
+// encodeDDLEvent encodes the DDL event into the messages sent to the same topic in order.
+// The schema change messages of the tables changed by the DDL are sent after the DDL message,
+// or instead of it in the `only` mode. It falls back to the DDL message if the encoder does not
+// support the schema change message, or the DDL does not change any table, such as create database.
+func (w *KafkaDDLWorker) encodeDDLEvent(e *event.DDLEvent) ([]*ticommon.Message, error) {
+	var schemaChanges []*ticommon.Message
+	if w.schemaChangeMessage != "" && w.schemaChangeMessage != config.SchemaChangeMessageNone {
+		if enc, ok := w.encoder.(encoder.SchemaChangeEventEncoder); ok {
+			var err error
+			schemaChanges, err = enc.EncodeSchemaChangeEvent(e)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
+	if len(schemaChanges) > 0 && w.schemaChangeMessage == config.SchemaChangeMessageOnly {
+		return schemaChanges, nil
+	}
+
+	message, err := w.encoder.EncodeDDLEvent(e)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append([]*ticommon.Message{message}, schemaChanges...), nil
+}
+
 func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents() error {
 	checkpointTsMessageDuration := metrics.CheckpointTsMessageDuration.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
 	checkpointTsMessageCount := metrics.CheckpointTsMessageCount.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
@@ -171,6 +276,22 @@ func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents() error {
 		metrics.CheckpointTsMessageCount.DeleteLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
 	}()
 
+	if _, ok := w.encoder.(encoder.WatermarkEventEncoder); w.watermarkInterval > 0 && !ok {
+		log.Warn("the protocol does not support the watermark sequence, "+
+			"the watermark is sent at the interval without the sequence number",
+			zap.Stringer("changefeedID", w.changeFeedID), zap.String("protocol", w.protocol.String()))
+	}
+	// In the watermark mode, the latest checkpoint is sent at the interval even if it does not advance.
+	var (
+		tickCh       <-chan time.Time
+		checkpointTs uint64
+	)
+	if w.watermarkInterval > 0 {
+		ticker := time.NewTicker(w.watermarkInterval)
+		defer ticker.Stop()
+		tickCh = ticker.C
+	}
+
 	for {
 		select {
 		case <-w.ctx.Done():
@@ -182,48 +303,81 @@ func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents() error {
 					zap.String("changefeed", w.changeFeedID.Name()))
 				return nil
 			}
+			if w.watermarkInterval > 0 {
+				if ts > checkpointTs {
+					checkpointTs = ts
+				}
+				continue
+			}
 			start := time.Now()
-
-			msg, err := w.encoder.EncodeCheckpointEvent(ts)
-			if err != nil {
+			if err := w.sendCheckpointEvent(ts); err != nil {
 				return errors.Trace(err)
 			}
-			tableNames := w.tableSchemaStore.GetAllTableNames(ts)
-			// NOTICE: When there are no tables to replicate,
-			// we need to send checkpoint ts to the default topic.
-			// This will be compatible with the old behavior.
-			if len(tableNames) == 0 {
-				topic := w.eventRouter.GetDefaultTopic()
-				partitionNum, err := w.topicManager.GetPartitionNum(w.ctx, topic)
-				if err != nil {
-					return errors.Trace(err)
-				}
-				log.Debug("Emit checkpointTs to default topic",
-					zap.String("topic", topic), zap.Uint64("checkpointTs", ts), zap.Any("partitionNum", partitionNum))
-				err = w.producer.SyncBroadcastMessage(w.ctx, topic, partitionNum, msg)
-				if err != nil {
-					return errors.Trace(err)
-				}
-			} else {
-				topics := w.eventRouter.GetActiveTopics(tableNames)
-				for _, topic := range topics {
-					partitionNum, err := w.topicManager.GetPartitionNum(w.ctx, topic)
-					if err != nil {
-						return errors.Trace(err)
-					}
-					err = w.producer.SyncBroadcastMessage(w.ctx, topic, partitionNum, msg)
-					if err != nil {
-						return errors.Trace(err)
-					}
-				}
+			checkpointTsMessageCount.Inc()
+			checkpointTsMessageDuration.Observe(time.Since(start).Seconds())
+		case <-tickCh:
+			if checkpointTs == 0 {
+				continue
+			}
+			start := time.Now()
+			if err := w.sendCheckpointEvent(checkpointTs); err != nil {
+				return errors.Trace(err)
 			}
-
 			checkpointTsMessageCount.Inc()
 			checkpointTsMessageDuration.Observe(time.Since(start).Seconds())
 		}
 	}
 }
 
+// sendCheckpointEvent broadcasts the checkpoint ts to all partitions of all active topics.
+func (w *KafkaDDLWorker) sendCheckpointEvent(ts uint64) error {
+	var topics []string
+	tableNames := w.tableSchemaStore.GetAllTableNames(ts)
+	// NOTICE: When there are no tables to replicate,
+	// we need to send checkpoint ts to the default topic.
+	// This will be compatible with the old behavior.
+	if len(tableNames) == 0 {
+		topics = []string{w.eventRouter.GetDefaultTopic()}
+	} else {
+		topics = w.eventRouter.GetActiveTopics(tableNames)
+	}
+
+	var msg *ticommon.Message
+	for _, topic := range topics {
+		partitionNum, err := w.topicManager.GetPartitionNum(w.ctx, topic)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// the message without the sequence number is shared by all topics
+		if msg == nil || w.watermarkInterval > 0 {
+			msg, err = w.encodeCheckpointEvent(topic, ts)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		log.Debug("Emit checkpointTs to topic",
+			zap.String("topic", topic), zap.Uint64("checkpointTs", ts), zap.Int32("partitionNum", partitionNum))
+		err = w.producer.SyncBroadcastMessage(w.ctx, topic, partitionNum, msg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// encodeCheckpointEvent encodes the checkpoint event sent to the topic. In the watermark mode,
+// the epoch and the sequence number of the topic are carried if the encoder supports it. Each watermark is
+// broadcast to all partitions of the topic, so the sequence number is contiguous in each partition.
+func (w *KafkaDDLWorker) encodeCheckpointEvent(topic string, ts uint64) (*ticommon.Message, error) {
+	if w.watermarkInterval > 0 {
+		if e, ok := w.encoder.(encoder.WatermarkEventEncoder); ok {
+			w.watermarkSeq[topic]++
+			return e.EncodeWatermarkEvent(ts, w.watermarkEpoch, w.watermarkSeq[topic])
+		}
+	}
+	return w.encoder.EncodeCheckpointEvent(ts)
+}
+
 func (w *KafkaDDLWorker) Close() error {
 	w.cancel()
 	w.producer.Close()
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"testing"
@@ -13,7 +14,10 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/util"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
@@ -42,7 +46,7 @@ func kafkaDDLWorkerForTest(t *testing.T) *KafkaDDLWorker {
 
 	ddlWorker := NewKafkaDDLWorker(ctx, changefeedID, protocol, ddlMockProducer,
 		kafkaComponent.Encoder, kafkaComponent.EventRouter, kafkaComponent.TopicManager,
-		statistics, errGroup)
+		statistics, 0, "", errGroup)
 	return ddlWorker
 }
 
@@ -114,3 +118,154 @@ func TestWriteCheckpointTs(t *testing.T) {
 	require.Len(t, ddlWorker.producer.(*producer.MockProducer).GetAllEvents(), 2)
 
 }
+
+func TestWriteWatermarkWithSequence(t *testing.T) {
+	ddlWorker := kafkaDDLWorkerForTest(t)
+	ddlWorker.watermarkInterval = 100 * time.Millisecond
+	ddlWorker.Run()
+
+	tableSchemaStore := util.NewTableSchemaStore([]*heartbeatpb.SchemaInfo{}, common.KafkaSinkType)
+	ddlWorker.SetTableSchemaStore(tableSchemaStore)
+
+	ddlWorker.GetCheckpointTsChan() <- 2
+	ddlWorker.GetCheckpointTsChan() <- 1
+
+	// the watermark is sent at the interval even if the checkpoint does not advance
+	mockProducer := ddlWorker.producer.(*producer.MockProducer)
+	require.Eventually(t, func() bool {
+		return len(mockProducer.GetEvents(kafka.DefaultMockTopicName, 0)) >= 3
+	}, 5*time.Second, 50*time.Millisecond)
+
+	for i, msg := range mockProducer.GetEvents(kafka.DefaultMockTopicName, 0) {
+		// skip the version and the length of the key
+		var key struct {
+			Ts    uint64 `json:"ts"`
+			Epoch uint64 `json:"epoch"`
+			Seq   uint64 `json:"seq"`
+		}
+		require.NoError(t, json.Unmarshal(msg.Key[16:], &key))
+		require.Equal(t, uint64(2), key.Ts)
+		require.Equal(t, ddlWorker.watermarkEpoch, key.Epoch)
+		require.Equal(t, uint64(i+1), key.Seq)
+	}
+}
+
+func TestWriteSchemaChangeMessage(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createJob := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	alterJob := helper.DDL2Job("alter table t add column age int not null default 1;")
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Type:         byte(alterJob.Type),
+		Query:        alterJob.Query,
+		SchemaName:   alterJob.SchemaName,
+		TableName:    alterJob.TableName,
+		FinishedTs:   alterJob.BinlogInfo.FinishedTS,
+		TableInfo:    common.WrapTableInfo(alterJob.SchemaID, alterJob.SchemaName, alterJob.BinlogInfo.TableInfo),
+		PreTableInfo: common.WrapTableInfo(createJob.SchemaID, createJob.SchemaName, createJob.BinlogInfo.TableInfo),
+	}
+
+	// the schema change message is sent after the DDL message in the alongside mode
+	ddlWorker := kafkaDDLWorkerForTest(t)
+	ddlWorker.schemaChangeMessage = config.SchemaChangeMessageAlongside
+	require.NoError(t, ddlWorker.WriteBlockEvent(ddlEvent))
+	events := ddlWorker.producer.(*producer.MockProducer).GetEvents(kafka.DefaultMockTopicName, 0)
+	require.Len(t, events, 2)
+
+	var key struct {
+		Type int `json:"t"`
+	}
+	require.NoError(t, json.Unmarshal(events[0].Key[16:], &key))
+	require.Equal(t, int(model.MessageTypeDDL), key.Type)
+	require.NoError(t, json.Unmarshal(events[1].Key[16:], &key))
+	require.Equal(t, 4, key.Type)
+
+	// only the schema change message is sent in the only mode
+	ddlWorker = kafkaDDLWorkerForTest(t)
+	ddlWorker.schemaChangeMessage = config.SchemaChangeMessageOnly
+	require.NoError(t, ddlWorker.WriteBlockEvent(ddlEvent))
+	events = ddlWorker.producer.(*producer.MockProducer).GetEvents(kafka.DefaultMockTopicName, 0)
+	require.Len(t, events, 1)
+	require.NoError(t, json.Unmarshal(events[0].Key[16:], &key))
+	require.Equal(t, 4, key.Type)
+}
+
+func TestWriteSchemaChangeMessagePerTable(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job1 := helper.DDL2Job("create table t1 (id int primary key)")
+	job2 := helper.DDL2Job("create table t2 (id int primary key, name varchar(32))")
+
+	// one schema change message is sent for each table created by the create tables DDL
+	ddlEvent := &commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionCreateTables),
+		Query:      job1.Query + ";" + job2.Query,
+		SchemaName: job1.SchemaName,
+		FinishedTs: job2.BinlogInfo.FinishedTS,
+		MultipleTableInfos: []*common.TableInfo{
+			common.WrapTableInfo(job1.SchemaID, job1.SchemaName, job1.BinlogInfo.TableInfo),
+			common.WrapTableInfo(job2.SchemaID, job2.SchemaName, job2.BinlogInfo.TableInfo),
+		},
+		TableNameChange: &commonEvent.TableNameChange{
+			AddName: []commonEvent.SchemaTableName{
+				{SchemaName: job1.SchemaName, TableName: "t1"},
+				{SchemaName: job2.SchemaName, TableName: "t2"},
+			},
+		},
+	}
+	ddlWorker := kafkaDDLWorkerForTest(t)
+	ddlWorker.schemaChangeMessage = config.SchemaChangeMessageOnly
+	require.NoError(t, ddlWorker.WriteBlockEvent(ddlEvent))
+	events := ddlWorker.producer.(*producer.MockProducer).GetEvents(kafka.DefaultMockTopicName, 0)
+	require.Len(t, events, 2)
+
+	var key struct {
+		Table string `json:"tbl"`
+		Type  int    `json:"t"`
+	}
+	for i, table := range []string{"t1", "t2"} {
+		require.NoError(t, json.Unmarshal(events[i].Key[16:], &key))
+		require.Equal(t, 4, key.Type)
+		require.Equal(t, table, key.Table)
+	}
+	changes := newcommon.NewSchemaChanges(ddlEvent)
+	require.Len(t, changes, 2)
+	require.Equal(t, job2.Query, changes[1].Query)
+	require.Nil(t, changes[1].Before)
+	require.Len(t, changes[1].After.Columns, 2)
+
+	// the dropped table is described before the DDL
+	dropJob := helper.DDL2Job("drop table t1")
+	ddlEvent = &commonEvent.DDLEvent{
+		Type:       byte(dropJob.Type),
+		Query:      dropJob.Query,
+		SchemaName: dropJob.SchemaName,
+		TableName:  dropJob.TableName,
+		FinishedTs: dropJob.BinlogInfo.FinishedTS,
+		TableInfo:  common.WrapTableInfo(job1.SchemaID, job1.SchemaName, job1.BinlogInfo.TableInfo),
+	}
+	changes = newcommon.NewSchemaChanges(ddlEvent)
+	require.Len(t, changes, 1)
+	require.Nil(t, changes[0].After)
+	require.Equal(t, "t1", changes[0].Before.Table)
+	require.Equal(t, "t1", changes[0].GetTable().Table)
+
+	// no schema change message is sent if the DDL changes no table
+	ddlWorker = kafkaDDLWorkerForTest(t)
+	ddlWorker.schemaChangeMessage = config.SchemaChangeMessageOnly
+	require.NoError(t, ddlWorker.WriteBlockEvent(&commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionCreateSchema),
+		Query:      "create database test1",
+		SchemaName: "test1",
+		FinishedTs: dropJob.BinlogInfo.FinishedTS + 1,
+	}))
+	events = ddlWorker.producer.(*producer.MockProducer).GetEvents(kafka.DefaultMockTopicName, 0)
+	require.Len(t, events, 1)
+	require.NoError(t, json.Unmarshal(events[0].Key[16:], &key))
+	require.Equal(t, int(model.MessageTypeDDL), key.Type)
+}
@@ -28,10 +28,14 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	"github.com/pingcap/ticdc/pkg/common/columntransformer"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/codec"
+	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tiflow/cdc/model"
+	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"go.uber.org/zap"
 )
 
@@ -54,6 +58,8 @@ type KafkaDMLWorker struct {
 	ticker *time.Ticker
 
 	columnSelector *columnselector.ColumnSelectors
+	// columnTransformers transform the column values before the rows are encoded.
+	columnTransformers *columntransformer.ColumnTransformers
 	// eventRouter used to route events to the right topic and partition.
 	eventRouter *eventrouter.EventRouter
 	// topicManager used to manage topics.
@@ -66,6 +72,10 @@ type KafkaDMLWorker struct {
 
 	// statistics is used to record DML metrics.
 	statistics *metrics.Statistics
+	// integrityConfig is used to verify the row checksum before encoding.
+	integrityConfig *config.Config
+	// accountant accounts the memory of the encoded messages until they are acked.
+	accountant *memquota.Accountant
 
 	ctx      context.Context
 	cancel   context.CancelFunc
@@ -80,27 +90,33 @@ func NewKafkaDMLWorker(
 	producer producer.DMLProducer,
 	encoderGroup codec.EncoderGroup,
 	columnSelector *columnselector.ColumnSelectors,
+	columnTransformers *columntransformer.ColumnTransformers,
 	eventRouter *eventrouter.EventRouter,
 	topicManager topicmanager.TopicManager,
 	statistics *metrics.Statistics,
+	integrityConfig *config.Config,
+	accountant *memquota.Accountant,
 	errGroup *errgroup.Group,
 ) *KafkaDMLWorker {
 	ctx, cancel := context.WithCancel(ctx)
 	return &KafkaDMLWorker{
-		ctx:            ctx,
-		changeFeedID:   id,
-		protocol:       protocol,
-		eventChan:      make(chan *commonEvent.DMLEvent, 32),
-		rowChan:        make(chan *commonEvent.MQRowEvent, 32),
-		ticker:         time.NewTicker(batchInterval),
-		encoderGroup:   encoderGroup,
-		columnSelector: columnSelector,
-		eventRouter:    eventRouter,
-		topicManager:   topicManager,
-		producer:       producer,
-		statistics:     statistics,
-		cancel:         cancel,
-		errGroup:       errGroup,
+		ctx:                ctx,
+		changeFeedID:       id,
+		protocol:           protocol,
+		eventChan:          make(chan *commonEvent.DMLEvent, 32),
+		rowChan:            make(chan *commonEvent.MQRowEvent, 32),
+		ticker:             time.NewTicker(batchInterval),
+		encoderGroup:       encoderGroup,
+		columnSelector:     columnSelector,
+		columnTransformers: columnTransformers,
+		eventRouter:        eventRouter,
+		topicManager:       topicManager,
+		producer:           producer,
+		statistics:         statistics,
+		integrityConfig:    integrityConfig,
+		accountant:         accountant,
+		cancel:             cancel,
+		errGroup:           errGroup,
 	}
 }
 
@@ -135,6 +151,12 @@ func (w *KafkaDMLWorker) calculateKeyPartitions() error {
 		case <-w.ctx.Done():
 			return errors.Trace(w.ctx.Err())
 		case event := <-w.eventChan:
+			if err := util.VerifyRowChecksum(w.changeFeedID, event, w.integrityConfig, w.statistics); err != nil {
+				return errors.Trace(err)
+			}
+			if err := w.columnTransformers.Apply(event); err != nil {
+				return errors.Trace(err)
+			}
 			topic := w.eventRouter.GetTopicForRowChange(event.TableInfo)
 			partitionNum, err := w.topicManager.GetPartitionNum(w.ctx, topic)
 			if err != nil {
@@ -157,6 +179,7 @@ func (w *KafkaDMLWorker) calculateKeyPartitions() error {
 			rowsCount := uint64(event.Len())
 			rowCallback := toRowCallback(event.PostTxnFlushed, rowsCount)
 
+			event.Rewind()
 			for {
 				row, ok := event.GetNextRow()
 				if !ok {
@@ -340,6 +363,7 @@ func (w *KafkaDMLWorker) sendMessages() error {
 				return errors.Trace(err)
 			}
 			for _, message := range future.Messages {
+				w.holdMessageMemory(message)
 				start := time.Now()
 				if err = w.statistics.RecordBatchExecution(func() (int, int64, error) {
 					message.SetPartitionKey(future.Key.PartitionKey)
@@ -360,6 +384,19 @@ func (w *KafkaDMLWorker) sendMessages() error {
 	}
 }
 
+// holdMessageMemory accounts the memory of the encoded message until it is acked by kafka.
+func (w *KafkaDMLWorker) holdMessageMemory(message *ticommon.Message) {
+	size := int64(message.Length())
+	w.accountant.Acquire(memquota.Encoder, size)
+	callback := message.Callback
+	message.Callback = func() {
+		w.accountant.Release(memquota.Encoder, size)
+		if callback != nil {
+			callback()
+		}
+	}
+}
+
 func (w *KafkaDMLWorker) Close() error {
 	w.ticker.Stop()
 	w.cancel()
@@ -39,9 +39,9 @@ func kafkaDMLWorkerForTest(t *testing.T) *KafkaDMLWorker {
 	dmlMockProducer := producer.NewMockDMLProducer()
 
 	dmlWorker := NewKafkaDMLWorker(ctx, changefeedID, protocol, dmlMockProducer,
-		kafkaComponent.EncoderGroup, kafkaComponent.ColumnSelector,
+		kafkaComponent.EncoderGroup, kafkaComponent.ColumnSelector, kafkaComponent.ColumnTransformers,
 		kafkaComponent.EventRouter, kafkaComponent.TopicManager,
-		statistics, errGroup)
+		statistics, nil, nil, errGroup)
 	return dmlWorker
 }
 
@@ -21,8 +21,10 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/ticdc/pkg/sink/util"
@@ -50,10 +52,11 @@ func NewMysqlDMLWorker(
 	id int,
 	changefeedID common.ChangeFeedID,
 	errGroup *errgroup.Group,
-	statistics *metrics.Statistics) *MysqlDMLWorker {
+	statistics *metrics.Statistics,
+	accountant *memquota.Accountant) *MysqlDMLWorker {
 	return &MysqlDMLWorker{
 		ctx:          ctx,
-		mysqlWriter:  mysql.NewMysqlWriter(ctx, db, config, changefeedID, statistics),
+		mysqlWriter:  mysql.NewMysqlWriter(ctx, db, config, changefeedID, statistics, accountant),
 		id:           id,
 		maxRows:      config.MaxTxnRow,
 		eventChan:    make(chan *commonEvent.DMLEvent, 16),
@@ -122,7 +125,7 @@ func (w *MysqlDMLWorker) Run() {
 				start := time.Now()
 				err := w.mysqlWriter.Flush(events, w.id)
 				if err != nil {
-					return errors.Trace(err)
+					return errors.Trace(wrapTableError(err, events))
 				}
 				workerFlushDuration.Observe(time.Since(start).Seconds())
 				// we record total time to calcuate the worker busy ratio.
@@ -137,6 +140,21 @@ func (w *MysqlDMLWorker) Run() {
 	})
 }
 
+// wrapTableError attaches the table to the error if all the events belong to the same table,
+// so that the error policy can skip the table.
+func wrapTableError(err error, events []*commonEvent.DMLEvent) error {
+	if len(events) == 0 {
+		return err
+	}
+	for _, event := range events[1:] {
+		if event.PhysicalTableID != events[0].PhysicalTableID {
+			return err
+		}
+	}
+	tableInfo := events[0].TableInfo
+	return apperror.WrapTableError(err, tableInfo.GetSchemaName(), tableInfo.GetTableName())
+}
+
 func (w *MysqlDMLWorker) Close() {
 	w.mysqlWriter.Close()
 }
@@ -159,7 +177,7 @@ func NewMysqlDDLWorker(
 	return &MysqlDDLWorker{
 		ctx:          ctx,
 		changefeedID: changefeedID,
-		mysqlWriter:  mysql.NewMysqlWriter(ctx, db, config, changefeedID, statistics),
+		mysqlWriter:  mysql.NewMysqlWriter(ctx, db, config, changefeedID, statistics, nil),
 		errgroup:     errGroup,
 	}
 }
@@ -184,8 +202,12 @@ func (w *MysqlDDLWorker) CheckStartTsList(tableIds []int64, startTsList []int64)
 func (w *MysqlDDLWorker) WriteBlockEvent(event commonEvent.BlockEvent) error {
 	switch event.GetType() {
 	case commonEvent.TypeDDLEvent:
-		err := w.mysqlWriter.FlushDDLEvent(event.(*commonEvent.DDLEvent))
+		ddl := event.(*commonEvent.DDLEvent)
+		err := w.mysqlWriter.FlushDDLEvent(ddl)
 		if err != nil {
+			if !ddl.IsMultiEvents() && ddl.TableName != "" {
+				err = apperror.WrapTableError(err, ddl.SchemaName, ddl.TableName)
+			}
 			return errors.Trace(err)
 		}
 	case commonEvent.TypeSyncPointEvent:
@@ -202,10 +224,18 @@ func (w *MysqlDDLWorker) WriteBlockEvent(event commonEvent.BlockEvent) error {
 	return nil
 }
 
+func (w *MysqlDDLWorker) WriteTableCheckpoints(checkpoints []util.TableCheckpoint) error {
+	return w.mysqlWriter.FlushTableCheckpoints(checkpoints)
+}
+
 func (w *MysqlDDLWorker) RemoveDDLTsItem() error {
 	return w.mysqlWriter.RemoveDDLTsItem()
 }
 
+func (w *MysqlDDLWorker) RemoveTableCheckpoints() error {
+	return w.mysqlWriter.RemoveTableCheckpoints()
+}
+
 func (w *MysqlDDLWorker) Close() {
 	w.mysqlWriter.Close()
 }
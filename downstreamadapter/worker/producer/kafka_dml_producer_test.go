@@ -215,3 +215,66 @@ func TestProducerDoubleClose(t *testing.T) {
 	producer.Close()
 	producer.Close()
 }
+
+func TestProducerExactlyOnce(t *testing.T) {
+	options := getOptions()
+	options.Version = "2.4.0"
+	options.ExactlyOnce = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = context.WithValue(ctx, "testing.T", t)
+	changefeed := common.ChangefeedID4Test("test", "test")
+	factory, err := kafka.NewMockFactory(options, changefeed)
+	require.NoError(t, err)
+
+	adminClient, err := factory.AdminClient(ctx)
+	require.NoError(t, err)
+	metricsCollector := factory.MetricsCollector(util.RoleTester, adminClient)
+
+	failpointCh := make(chan error, 1)
+	asyncProducer, err := factory.AsyncProducer(ctx, failpointCh)
+	require.NoError(t, err)
+	mockProducer := asyncProducer.(*kafka.MockSaramaAsyncProducer).AsyncProducer
+	require.True(t, mockProducer.IsTransactional())
+
+	producer := NewKafkaDMLProducer(ctx, changefeed, asyncProducer, metricsCollector)
+	defer producer.Close()
+	go producer.Run()
+
+	messageCount := 20
+	for i := 0; i < messageCount; i++ {
+		mockProducer.ExpectInputAndSucceed()
+	}
+
+	count := atomic.NewInt64(0)
+	callback := func() {
+		count.Add(1)
+	}
+	for i := 0; i < messageCount; i++ {
+		err = producer.AsyncSendMessage(ctx, tikafka.DefaultMockTopicName, int32(i%2), &ticommon.Message{
+			Key:      []byte("test-key-1"),
+			Value:    []byte("test-value"),
+			Callback: callback,
+		})
+		require.NoError(t, err)
+	}
+	require.Eventuallyf(t, func() bool {
+		return count.Load() == int64(messageCount)
+	}, time.Second*5, time.Millisecond*10, "All msgs should be acked")
+	// the messages are in the transaction until the checkpoint is committed
+	require.NotZero(t, mockProducer.TxnStatus()&sarama.ProducerTxnFlagInTransaction)
+
+	txnProducer := asyncProducer.(kafka.TransactionalProducer)
+	checkpointTs, err := txnProducer.GetCommittedCheckpoint(tikafka.DefaultMockTopicName)
+	require.NoError(t, err)
+	require.Zero(t, checkpointTs)
+
+	err = txnProducer.CommitCheckpoint(tikafka.DefaultMockTopicName, 100)
+	require.NoError(t, err)
+	require.Equal(t, sarama.ProducerTxnFlagReady, mockProducer.TxnStatus())
+	checkpointTs, err = txnProducer.GetCommittedCheckpoint(tikafka.DefaultMockTopicName)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), checkpointTs)
+}
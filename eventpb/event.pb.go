@@ -528,6 +528,16 @@ type RegisterDispatcherRequest struct {
 	SyncPointTs       uint64                    `protobuf:"varint,9,opt,name=sync_point_ts,json=syncPointTs,proto3" json:"sync_point_ts,omitempty"`
 	SyncPointInterval uint64                    `protobuf:"varint,10,opt,name=sync_point_interval,json=syncPointInterval,proto3" json:"sync_point_interval,omitempty"`
 	OnlyReuse         bool                      `protobuf:"varint,11,opt,name=only_reuse,json=onlyReuse,proto3" json:"only_reuse,omitempty"`
+	BdrMode           bool                      `protobuf:"varint,12,opt,name=bdr_mode,json=bdrMode,proto3" json:"bdr_mode,omitempty"`
+	// replay is true if the reset request rewinds the dispatcher to the start_ts,
+	// the dispatcher is registered again since the events may be garbage collected.
+	Replay bool `protobuf:"varint,13,opt,name=replay,proto3" json:"replay,omitempty"`
+	// need_pre_table_info is true if the ddl events should carry the table info before the ddl,
+	// it's only needed by the sink sending the schema change message.
+	NeedPreTableInfo bool `protobuf:"varint,14,opt,name=need_pre_table_info,json=needPreTableInfo,proto3" json:"need_pre_table_info,omitempty"`
+	// integrity_check is true if the changefeed enables the integrity check,
+	// the event service verifies the row checksums encoded by the upstream TiDB.
+	IntegrityCheck bool `protobuf:"varint,15,opt,name=integrity_check,json=integrityCheck,proto3" json:"integrity_check,omitempty"`
 }
 
 func (m *RegisterDispatcherRequest) Reset()         { *m = RegisterDispatcherRequest{} }
@@ -640,6 +650,34 @@ func (m *RegisterDispatcherRequest) GetOnlyReuse() bool {
 	return false
 }
 
+func (m *RegisterDispatcherRequest) GetBdrMode() bool {
+	if m != nil {
+		return m.BdrMode
+	}
+	return false
+}
+
+func (m *RegisterDispatcherRequest) GetReplay() bool {
+	if m != nil {
+		return m.Replay
+	}
+	return false
+}
+
+func (m *RegisterDispatcherRequest) GetNeedPreTableInfo() bool {
+	if m != nil {
+		return m.NeedPreTableInfo
+	}
+	return false
+}
+
+func (m *RegisterDispatcherRequest) GetIntegrityCheck() bool {
+	if m != nil {
+		return m.IntegrityCheck
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterEnum("eventpb.OpType", OpType_name, OpType_value)
 	proto.RegisterEnum("eventpb.ActionType", ActionType_name, ActionType_value)
@@ -656,66 +694,70 @@ func init() {
 func init() { proto.RegisterFile("eventpb/event.proto", fileDescriptor_d7fb2554dfcf7f7d) }
 
 var fileDescriptor_d7fb2554dfcf7f7d = []byte{
-	// 935 bytes of a gzipped FileDescriptorProto
+	// 1006 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x41, 0x6f, 0xe3, 0x44,
-	0x14, 0xae, 0x93, 0x34, 0x8d, 0x9f, 0xd3, 0x5d, 0x77, 0xba, 0x5d, 0xdc, 0x2d, 0x84, 0x92, 0x03,
-	0x0a, 0x95, 0x48, 0x21, 0x80, 0x90, 0x56, 0x68, 0xa5, 0xd2, 0x7a, 0x91, 0x0f, 0xdb, 0x56, 0x13,
-	0x77, 0x25, 0xb8, 0x58, 0x8e, 0xfd, 0x92, 0x1a, 0xdc, 0xb1, 0xeb, 0x99, 0x64, 0x93, 0x7f, 0x01,
-	0x67, 0x4e, 0xfc, 0x1b, 0x8e, 0x7b, 0xe4, 0x06, 0x6a, 0x0f, 0xfc, 0x0d, 0xe4, 0x19, 0xc7, 0x71,
-	0xb6, 0x08, 0x89, 0x53, 0x66, 0xde, 0xf7, 0xbd, 0x99, 0xf7, 0x7d, 0x6f, 0x9e, 0x03, 0xbb, 0x38,
-	0x43, 0x26, 0xd2, 0xd1, 0xb1, 0xfc, 0xed, 0xa7, 0x59, 0x22, 0x12, 0xb2, 0x55, 0x04, 0x9f, 0x1d,
-	0x5c, 0xa3, 0x9f, 0x89, 0x11, 0xfa, 0x39, 0xa3, 0x5c, 0x2b, 0x56, 0xf7, 0xcf, 0x1a, 0x3c, 0xb6,
-	0x73, 0xe2, 0xcb, 0x28, 0x16, 0x98, 0xd1, 0x69, 0x8c, 0xc4, 0x82, 0xad, 0x1b, 0x5f, 0x04, 0xd7,
-	0x98, 0x59, 0xda, 0x61, 0xbd, 0xa7, 0xd3, 0xe5, 0x96, 0x7c, 0x04, 0xed, 0x68, 0xc2, 0x92, 0x0c,
-	0x3d, 0x79, 0xb8, 0x55, 0x93, 0xb0, 0xa1, 0x62, 0xf2, 0x18, 0xf2, 0x01, 0x40, 0x41, 0xe1, 0xb7,
-	0xb1, 0x55, 0x97, 0x04, 0x5d, 0x45, 0x86, 0xb7, 0x31, 0xf9, 0x1a, 0xac, 0x02, 0x8e, 0x18, 0xc7,
-	0x4c, 0x78, 0x33, 0x3f, 0x9e, 0xa2, 0x87, 0xf3, 0x34, 0xb3, 0x1a, 0x87, 0x5a, 0x4f, 0xa7, 0x7b,
-	0x0a, 0x77, 0x24, 0xfc, 0x3a, 0x47, 0xed, 0x79, 0x9a, 0x91, 0x17, 0xf0, 0x7e, 0x91, 0x38, 0x4d,
-	0x43, 0x5f, 0xa0, 0xc7, 0xf0, 0x4d, 0x35, 0x79, 0x53, 0x26, 0x17, 0x87, 0x5f, 0x49, 0xca, 0x39,
-	0xbe, 0xf9, 0x8f, 0xfc, 0x24, 0x0e, 0xab, 0xf9, 0xcd, 0x87, 0xf9, 0x17, 0x71, 0xb8, 0xca, 0x5f,
-	0x15, 0x1e, 0x62, 0x8c, 0x02, 0xab, 0xb9, 0x5b, 0xd5, 0xc2, 0xcf, 0x24, 0x5c, 0x26, 0x76, 0x7f,
-	0xd1, 0xa0, 0xad, 0xcc, 0x3d, 0x4d, 0xd8, 0x38, 0x9a, 0x90, 0x27, 0xb0, 0x99, 0x4d, 0x63, 0xe4,
-	0x85, 0xb9, 0x6a, 0x43, 0x3e, 0x85, 0xdd, 0xe2, 0x7c, 0x31, 0x67, 0x1e, 0x17, 0x7e, 0x26, 0x3c,
-	0xc1, 0xa5, 0xc3, 0x0d, 0x6a, 0x2a, 0xc8, 0x9d, 0xb3, 0x61, 0x0e, 0xb8, 0x9c, 0x7c, 0x03, 0xed,
-	0x4a, 0xdb, 0xb8, 0x34, 0xda, 0x18, 0x58, 0xfd, 0xa2, 0xe9, 0xfd, 0x77, 0x7a, 0x4a, 0xd7, 0xd8,
-	0xdd, 0x36, 0x00, 0x45, 0x9e, 0xc4, 0x33, 0x0c, 0x5d, 0xde, 0x9d, 0xc2, 0xa6, 0xea, 0x9d, 0x09,
-	0xf5, 0x9f, 0x70, 0x61, 0x69, 0x87, 0x5a, 0xaf, 0x4d, 0xf3, 0x65, 0x5e, 0xab, 0xd4, 0x69, 0xd5,
-	0x64, 0x4c, 0x6d, 0xc8, 0x33, 0x68, 0x2d, 0xbd, 0xb1, 0xea, 0x12, 0x28, 0xf7, 0xa4, 0x07, 0x5b,
-	0x49, 0xea, 0x89, 0x45, 0x8a, 0xb2, 0x9f, 0x8f, 0x06, 0x8f, 0xcb, 0x9a, 0x2e, 0x52, 0x77, 0x91,
-	0x22, 0x6d, 0x26, 0xf2, 0xb7, 0xfb, 0x23, 0xb4, 0xdc, 0x39, 0x53, 0x37, 0x7f, 0x0c, 0x4d, 0xc9,
-	0x52, 0xa6, 0x18, 0x83, 0x47, 0xeb, 0x42, 0x68, 0x81, 0x92, 0x03, 0xd0, 0x83, 0xe4, 0xe6, 0x26,
-	0x2a, 0xbc, 0xd1, 0x7a, 0x0d, 0xda, 0x52, 0x01, 0x97, 0x93, 0x7d, 0x68, 0x95, 0xbe, 0xd5, 0x25,
-	0xb6, 0xc5, 0x95, 0x5d, 0x5d, 0x03, 0x74, 0xd7, 0x1f, 0xc5, 0xe8, 0xb0, 0x71, 0xd2, 0xfd, 0x5b,
-	0x03, 0x5d, 0xd9, 0x81, 0x18, 0x92, 0xcf, 0x00, 0x72, 0xc7, 0xd7, 0xae, 0xdf, 0x29, 0xaf, 0x5f,
-	0x56, 0x48, 0x75, 0x51, 0xac, 0x38, 0xf9, 0x10, 0x8c, 0xac, 0x70, 0x6f, 0x55, 0x06, 0x64, 0xa5,
-	0xa1, 0xe4, 0x05, 0x6c, 0x87, 0x11, 0x4f, 0xd5, 0xd0, 0x78, 0x51, 0x28, 0xab, 0x31, 0x06, 0xfb,
-	0xfd, 0xca, 0x24, 0xf6, 0xcf, 0x4a, 0x86, 0x73, 0x46, 0xdb, 0x2b, 0xbe, 0x13, 0xca, 0x17, 0xe2,
-	0x8b, 0x28, 0x91, 0x0e, 0xd6, 0xa8, 0xda, 0x90, 0xcf, 0x01, 0x44, 0xae, 0xc1, 0x8b, 0xd8, 0x38,
-	0x91, 0xef, 0xdd, 0x18, 0x90, 0x55, 0xa1, 0x4b, 0x79, 0x54, 0x17, 0xa5, 0xd2, 0x5f, 0x1b, 0xb0,
-	0x4f, 0x71, 0x12, 0x71, 0x81, 0xd9, 0xea, 0x3e, 0x8a, 0xb7, 0x53, 0xe4, 0x22, 0x2f, 0x33, 0xb8,
-	0xf6, 0xd9, 0x04, 0xc7, 0x88, 0x61, 0x5e, 0xa6, 0xf6, 0x2f, 0x65, 0x9e, 0x96, 0x8c, 0xbc, 0xcc,
-	0x15, 0xdf, 0x09, 0x1f, 0xca, 0xac, 0xfd, 0x3f, 0x99, 0x5f, 0x2d, 0x05, 0xf1, 0xd4, 0x67, 0x85,
-	0x47, 0x4f, 0xd7, 0x92, 0xa5, 0xa8, 0x61, 0xea, 0xb3, 0x42, 0x54, 0xbe, 0x5c, 0x6b, 0x73, 0x63,
-	0xad, 0xcd, 0xf9, 0xf3, 0xe0, 0x98, 0xcd, 0x54, 0x35, 0xea, 0x8b, 0xd0, 0x52, 0x01, 0x27, 0x24,
-	0x5f, 0x82, 0xe1, 0x07, 0x22, 0x4a, 0x98, 0x7a, 0x9d, 0x4d, 0xf9, 0x3a, 0x77, 0x4b, 0x03, 0x4f,
-	0x24, 0x26, 0x5f, 0x28, 0xf8, 0xe5, 0x9a, 0x3c, 0x87, 0xed, 0xb1, 0x9c, 0x1a, 0x2f, 0x90, 0xe3,
-	0x2b, 0x87, 0xdd, 0x18, 0xec, 0x95, 0x79, 0xd5, 0xd9, 0xa6, 0xed, 0x71, 0x75, 0xd2, 0x8f, 0x60,
-	0x07, 0x99, 0x52, 0xb8, 0x60, 0x81, 0x97, 0x26, 0x11, 0x13, 0x56, 0xeb, 0x50, 0xeb, 0xb5, 0xe8,
-	0x63, 0x05, 0x0c, 0x17, 0x2c, 0xb8, 0xcc, 0xc3, 0xa4, 0x0b, 0xdb, 0x2b, 0x52, 0x2e, 0x4d, 0x97,
-	0xd2, 0x0c, 0xbe, 0x64, 0xb8, 0x9c, 0xf4, 0x61, 0xb7, 0xc2, 0x89, 0x98, 0xc0, 0x6c, 0xe6, 0xc7,
-	0x16, 0x48, 0xe6, 0x4e, 0xc9, 0x74, 0x0a, 0x20, 0xff, 0x16, 0x27, 0x2c, 0x5e, 0x78, 0x19, 0x4e,
-	0x39, 0x5a, 0x86, 0xbc, 0x58, 0xcf, 0x23, 0x34, 0x0f, 0x1c, 0x7d, 0x02, 0x4d, 0x35, 0x92, 0x64,
-	0x1b, 0x74, 0xb5, 0xba, 0x9c, 0x0a, 0x73, 0x83, 0x98, 0xd0, 0x56, 0x5b, 0xf5, 0x2d, 0x33, 0xb5,
-	0xa3, 0xdf, 0x34, 0x80, 0x95, 0x41, 0xe4, 0x00, 0xde, 0x3b, 0x39, 0x75, 0x9d, 0x8b, 0x73, 0xcf,
-	0xfd, 0xfe, 0xd2, 0xf6, 0xae, 0xce, 0x87, 0x97, 0xf6, 0xa9, 0xf3, 0xd2, 0xb1, 0xcf, 0xcc, 0x0d,
-	0x62, 0xc1, 0x93, 0x2a, 0x48, 0xed, 0xef, 0x9c, 0xa1, 0x6b, 0x53, 0x53, 0x23, 0x4f, 0x81, 0xac,
-	0x23, 0xaf, 0x2e, 0x5e, 0xdb, 0x66, 0x8d, 0xec, 0xc1, 0x4e, 0x35, 0x7e, 0x79, 0x72, 0x35, 0xb4,
-	0xcd, 0xfa, 0x43, 0xfa, 0xf0, 0xea, 0x95, 0x6d, 0x36, 0xde, 0xa5, 0x53, 0x7b, 0x68, 0xbb, 0xe6,
-	0xe6, 0xb7, 0xcf, 0x7f, 0xbf, 0xeb, 0x68, 0x6f, 0xef, 0x3a, 0xda, 0x5f, 0x77, 0x1d, 0xed, 0xe7,
-	0xfb, 0xce, 0xc6, 0xdb, 0xfb, 0xce, 0xc6, 0x1f, 0xf7, 0x9d, 0x8d, 0x1f, 0x0e, 0x27, 0x91, 0xb8,
-	0x9e, 0x8e, 0xfa, 0x41, 0x72, 0x73, 0x9c, 0x46, 0x6c, 0x12, 0xf8, 0xe9, 0xb1, 0x88, 0x82, 0x30,
-	0x38, 0x2e, 0x9a, 0x38, 0x6a, 0xca, 0x7f, 0xc3, 0x2f, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0x13,
-	0x90, 0xe0, 0x9d, 0x4a, 0x07, 0x00, 0x00,
+	0x14, 0xae, 0x93, 0x36, 0x89, 0x9f, 0xd3, 0xd6, 0x9d, 0xee, 0x2e, 0xde, 0x2d, 0x84, 0x92, 0x03,
+	0x84, 0x4a, 0xa4, 0x10, 0x40, 0x48, 0x2b, 0xb4, 0x52, 0x69, 0xbd, 0xc8, 0x87, 0xb6, 0xd1, 0xc4,
+	0x5d, 0x09, 0x2e, 0x96, 0x63, 0xbf, 0xa4, 0x66, 0xdd, 0xb1, 0x6b, 0x4f, 0xba, 0xcd, 0xbf, 0x80,
+	0x9f, 0xc0, 0x85, 0xdf, 0xc2, 0x71, 0x8f, 0xdc, 0x40, 0xed, 0x81, 0xbf, 0x81, 0x66, 0xc6, 0x71,
+	0x9c, 0x2d, 0x42, 0xe2, 0xe4, 0x79, 0xef, 0xfb, 0xde, 0xcc, 0x7b, 0xdf, 0x7b, 0x33, 0x86, 0x5d,
+	0xbc, 0x41, 0xc6, 0xd3, 0xf1, 0xa1, 0xfc, 0xf6, 0xd3, 0x2c, 0xe1, 0x09, 0x69, 0x16, 0xce, 0x67,
+	0x7b, 0x97, 0xe8, 0x67, 0x7c, 0x8c, 0xbe, 0x60, 0x94, 0x6b, 0xc5, 0xea, 0xfe, 0x59, 0x83, 0x6d,
+	0x5b, 0x10, 0x5f, 0x46, 0x31, 0xc7, 0x8c, 0xce, 0x62, 0x24, 0x16, 0x34, 0xaf, 0x7c, 0x1e, 0x5c,
+	0x62, 0x66, 0x69, 0xfb, 0xf5, 0x9e, 0x4e, 0x17, 0x26, 0xf9, 0x08, 0xda, 0xd1, 0x94, 0x25, 0x19,
+	0x7a, 0x72, 0x73, 0xab, 0x26, 0x61, 0x43, 0xf9, 0xe4, 0x36, 0xe4, 0x03, 0x80, 0x82, 0x92, 0x5f,
+	0xc7, 0x56, 0x5d, 0x12, 0x74, 0xe5, 0x19, 0x5d, 0xc7, 0xe4, 0x1b, 0xb0, 0x0a, 0x38, 0x62, 0x39,
+	0x66, 0xdc, 0xbb, 0xf1, 0xe3, 0x19, 0x7a, 0x78, 0x9b, 0x66, 0xd6, 0xfa, 0xbe, 0xd6, 0xd3, 0xe9,
+	0x63, 0x85, 0x3b, 0x12, 0x7e, 0x25, 0x50, 0xfb, 0x36, 0xcd, 0xc8, 0x0b, 0x78, 0xbf, 0x08, 0x9c,
+	0xa5, 0xa1, 0xcf, 0xd1, 0x63, 0xf8, 0xa6, 0x1a, 0xbc, 0x21, 0x83, 0x8b, 0xcd, 0x2f, 0x24, 0xe5,
+	0x0c, 0xdf, 0xfc, 0x47, 0x7c, 0x12, 0x87, 0xd5, 0xf8, 0xc6, 0xc3, 0xf8, 0xf3, 0x38, 0x5c, 0xc6,
+	0x2f, 0x13, 0x0f, 0x31, 0x46, 0x8e, 0xd5, 0xd8, 0x66, 0x35, 0xf1, 0x13, 0x09, 0x97, 0x81, 0xdd,
+	0x5f, 0x34, 0x68, 0x2b, 0x71, 0x8f, 0x13, 0x36, 0x89, 0xa6, 0xe4, 0x11, 0x6c, 0x64, 0xb3, 0x18,
+	0xf3, 0x42, 0x5c, 0x65, 0x90, 0xcf, 0x60, 0xb7, 0xd8, 0x9f, 0xdf, 0x32, 0x2f, 0xe7, 0x7e, 0xc6,
+	0x3d, 0x9e, 0x4b, 0x85, 0xd7, 0xa9, 0xa9, 0x20, 0xf7, 0x96, 0x8d, 0x04, 0xe0, 0xe6, 0xe4, 0x5b,
+	0x68, 0x57, 0xda, 0x96, 0x4b, 0xa1, 0x8d, 0x81, 0xd5, 0x2f, 0x9a, 0xde, 0x7f, 0xa7, 0xa7, 0x74,
+	0x85, 0xdd, 0x6d, 0x03, 0x50, 0xcc, 0x93, 0xf8, 0x06, 0x43, 0x37, 0xef, 0xce, 0x60, 0x43, 0xf5,
+	0xce, 0x84, 0xfa, 0x6b, 0x9c, 0x5b, 0xda, 0xbe, 0xd6, 0x6b, 0x53, 0xb1, 0x14, 0xb9, 0xca, 0x3a,
+	0xad, 0x9a, 0xf4, 0x29, 0x83, 0x3c, 0x83, 0xd6, 0x42, 0x1b, 0xab, 0x2e, 0x81, 0xd2, 0x26, 0x3d,
+	0x68, 0x26, 0xa9, 0xc7, 0xe7, 0x29, 0xca, 0x7e, 0x6e, 0x0d, 0xb6, 0xcb, 0x9c, 0xce, 0x53, 0x77,
+	0x9e, 0x22, 0x6d, 0x24, 0xf2, 0xdb, 0xfd, 0x09, 0x5a, 0xee, 0x2d, 0x53, 0x27, 0x7f, 0x0c, 0x0d,
+	0xc9, 0x52, 0xa2, 0x18, 0x83, 0xad, 0xd5, 0x42, 0x68, 0x81, 0x92, 0x3d, 0xd0, 0x83, 0xe4, 0xea,
+	0x2a, 0x2a, 0xb4, 0xd1, 0x7a, 0xeb, 0xb4, 0xa5, 0x1c, 0x6e, 0x4e, 0x9e, 0x42, 0xab, 0xd4, 0xad,
+	0x2e, 0xb1, 0x66, 0xae, 0xe4, 0xea, 0x1a, 0xa0, 0xbb, 0xfe, 0x38, 0x46, 0x87, 0x4d, 0x92, 0xee,
+	0xdf, 0x1a, 0xe8, 0x4a, 0x0e, 0xc4, 0x90, 0x7c, 0x0e, 0x20, 0x14, 0x5f, 0x39, 0x7e, 0xa7, 0x3c,
+	0x7e, 0x91, 0x21, 0xd5, 0x79, 0xb1, 0xca, 0xc9, 0x87, 0x60, 0x64, 0x85, 0x7a, 0xcb, 0x34, 0x20,
+	0x2b, 0x05, 0x25, 0x2f, 0x60, 0x33, 0x8c, 0xf2, 0x54, 0x5d, 0x1a, 0x2f, 0x0a, 0x65, 0x36, 0xc6,
+	0xe0, 0x69, 0xbf, 0x72, 0x13, 0xfb, 0x27, 0x25, 0xc3, 0x39, 0xa1, 0xed, 0x25, 0xdf, 0x09, 0xe5,
+	0x84, 0xf8, 0x3c, 0x4a, 0xa4, 0x82, 0x35, 0xaa, 0x0c, 0xf2, 0x05, 0x00, 0x17, 0x35, 0x78, 0x11,
+	0x9b, 0x24, 0x72, 0xde, 0x8d, 0x01, 0x59, 0x26, 0xba, 0x28, 0x8f, 0xea, 0xbc, 0xac, 0xf4, 0xb7,
+	0x0d, 0x78, 0x4a, 0x71, 0x1a, 0xe5, 0x1c, 0xb3, 0xe5, 0x79, 0x14, 0xaf, 0x67, 0x98, 0x73, 0x91,
+	0x66, 0x70, 0xe9, 0xb3, 0x29, 0x4e, 0x10, 0x43, 0x91, 0xa6, 0xf6, 0x2f, 0x69, 0x1e, 0x97, 0x0c,
+	0x91, 0xe6, 0x92, 0xef, 0x84, 0x0f, 0xcb, 0xac, 0xfd, 0xbf, 0x32, 0xbf, 0x5e, 0x14, 0x94, 0xa7,
+	0x3e, 0x2b, 0x34, 0x7a, 0xb2, 0x12, 0x2c, 0x8b, 0x1a, 0xa5, 0x3e, 0x2b, 0x8a, 0x12, 0xcb, 0x95,
+	0x36, 0xaf, 0xaf, 0xb4, 0x59, 0x8c, 0x47, 0x8e, 0xd9, 0x8d, 0xca, 0x46, 0xbd, 0x08, 0x2d, 0xe5,
+	0x70, 0x42, 0xf2, 0x15, 0x18, 0x7e, 0xc0, 0xa3, 0x84, 0xa9, 0xe9, 0x6c, 0xc8, 0xe9, 0xdc, 0x2d,
+	0x05, 0x3c, 0x92, 0x98, 0x9c, 0x50, 0xf0, 0xcb, 0x35, 0x79, 0x0e, 0x9b, 0x13, 0x79, 0x6b, 0xbc,
+	0x40, 0x5e, 0x5f, 0x79, 0xd9, 0x8d, 0xc1, 0xe3, 0x32, 0xae, 0x7a, 0xb7, 0x69, 0x7b, 0x52, 0xb1,
+	0xc8, 0x01, 0xec, 0x20, 0x53, 0x15, 0xce, 0x59, 0xe0, 0xa5, 0x49, 0xc4, 0xb8, 0xd5, 0xda, 0xd7,
+	0x7a, 0x2d, 0xba, 0xad, 0x80, 0xd1, 0x9c, 0x05, 0x43, 0xe1, 0x26, 0x5d, 0xd8, 0x5c, 0x92, 0x44,
+	0x69, 0xba, 0x2c, 0xcd, 0xc8, 0x17, 0x0c, 0x37, 0x27, 0x7d, 0xd8, 0xad, 0x70, 0x22, 0xc6, 0x31,
+	0xbb, 0xf1, 0x63, 0x0b, 0x24, 0x73, 0xa7, 0x64, 0x3a, 0x05, 0x20, 0xde, 0xe2, 0x84, 0xc5, 0x73,
+	0x2f, 0xc3, 0x59, 0x8e, 0x96, 0x21, 0x0f, 0xd6, 0x85, 0x87, 0x0a, 0x87, 0x10, 0x72, 0x1c, 0x66,
+	0xde, 0x55, 0x12, 0xa2, 0xd5, 0x96, 0x60, 0x73, 0x1c, 0x66, 0xa7, 0x49, 0x88, 0xe4, 0x09, 0x34,
+	0x32, 0x4c, 0x63, 0x7f, 0x6e, 0x6d, 0x4a, 0xa0, 0xb0, 0xc4, 0x2b, 0xc5, 0xc4, 0xb0, 0xa4, 0xe2,
+	0x9d, 0x5a, 0x0e, 0xe3, 0x96, 0x24, 0x99, 0x02, 0x1a, 0x66, 0x58, 0x8e, 0x22, 0xf9, 0x04, 0xb6,
+	0x45, 0x96, 0xd3, 0x2c, 0xe2, 0x73, 0x2f, 0xb8, 0xc4, 0xe0, 0xb5, 0xb5, 0x2d, 0xa9, 0x5b, 0xa5,
+	0xfb, 0x58, 0x78, 0x0f, 0x3e, 0x85, 0x86, 0x7a, 0x1d, 0xc8, 0x26, 0xe8, 0x6a, 0x35, 0x9c, 0x71,
+	0x73, 0x8d, 0x98, 0xd0, 0x56, 0xa6, 0x7a, 0x56, 0x4d, 0xed, 0xe0, 0x57, 0x0d, 0x60, 0xd9, 0x2b,
+	0xb2, 0x07, 0xef, 0x1d, 0x1d, 0xbb, 0xce, 0xf9, 0x99, 0xe7, 0xfe, 0x30, 0xb4, 0xbd, 0x8b, 0xb3,
+	0xd1, 0xd0, 0x3e, 0x76, 0x5e, 0x3a, 0xf6, 0x89, 0xb9, 0x46, 0x2c, 0x78, 0x54, 0x05, 0xa9, 0xfd,
+	0xbd, 0x33, 0x72, 0x6d, 0x6a, 0x6a, 0xe4, 0x09, 0x90, 0x55, 0xe4, 0xf4, 0xfc, 0x95, 0x6d, 0xd6,
+	0xc8, 0x63, 0xd8, 0xa9, 0xfa, 0x87, 0x47, 0x17, 0x23, 0xdb, 0xac, 0x3f, 0xa4, 0x8f, 0x2e, 0x4e,
+	0x6d, 0x73, 0xfd, 0x5d, 0x3a, 0xb5, 0x47, 0xb6, 0x6b, 0x6e, 0x7c, 0xf7, 0xfc, 0xf7, 0xbb, 0x8e,
+	0xf6, 0xf6, 0xae, 0xa3, 0xfd, 0x75, 0xd7, 0xd1, 0x7e, 0xbe, 0xef, 0xac, 0xbd, 0xbd, 0xef, 0xac,
+	0xfd, 0x71, 0xdf, 0x59, 0xfb, 0x71, 0x7f, 0x1a, 0xf1, 0xcb, 0xd9, 0xb8, 0x1f, 0x24, 0x57, 0x87,
+	0x69, 0xc4, 0xa6, 0x81, 0x9f, 0x1e, 0xf2, 0x28, 0x08, 0x83, 0xc3, 0x62, 0x9e, 0xc6, 0x0d, 0xf9,
+	0x63, 0xfe, 0xf2, 0x9f, 0x01, 0x00, 0x74, 0x95, 0xf2, 0x72, 0xd5, 0x07, 0x00, 0x00,
 }
 
 func (m *EventFilterRule) Marshal() (dAtA []byte, err error) {
@@ -1094,6 +1136,46 @@ func (m *RegisterDispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if m.IntegrityCheck {
+		i--
+		if m.IntegrityCheck {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.NeedPreTableInfo {
+		i--
+		if m.NeedPreTableInfo {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.Replay {
+		i--
+		if m.Replay {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.BdrMode {
+		i--
+		if m.BdrMode {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x60
+	}
 	if m.OnlyReuse {
 		i--
 		if m.OnlyReuse {
@@ -1410,6 +1492,18 @@ func (m *RegisterDispatcherRequest) Size() (n int) {
 	if m.OnlyReuse {
 		n += 2
 	}
+	if m.BdrMode {
+		n += 2
+	}
+	if m.Replay {
+		n += 2
+	}
+	if m.NeedPreTableInfo {
+		n += 2
+	}
+	if m.IntegrityCheck {
+		n += 2
+	}
 	return n
 }
 
@@ -2785,6 +2879,86 @@ func (m *RegisterDispatcherRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.OnlyReuse = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BdrMode", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BdrMode = bool(v != 0)
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replay", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Replay = bool(v != 0)
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NeedPreTableInfo", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NeedPreTableInfo = bool(v != 0)
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntegrityCheck", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IntegrityCheck = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
@@ -53,16 +53,20 @@ type ScheduleAction int32
 const (
 	ScheduleAction_Create ScheduleAction = 0
 	ScheduleAction_Remove ScheduleAction = 1
+	// Replay makes the dispatcher replay the events from the startTs of the config
+	ScheduleAction_Replay ScheduleAction = 2
 )
 
 var ScheduleAction_name = map[int32]string{
 	0: "Create",
 	1: "Remove",
+	2: "Replay",
 }
 
 var ScheduleAction_value = map[string]int32{
 	"Create": 0,
 	"Remove": 1,
+	"Replay": 2,
 }
 
 func (x ScheduleAction) String() string {
@@ -226,6 +230,11 @@ type HeartBeatRequest struct {
 	Statuses        []*TableSpanStatus `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`
 	CompeleteStatus bool               `protobuf:"varint,4,opt,name=compeleteStatus,proto3" json:"compeleteStatus,omitempty"`
 	Err             *RunningError      `protobuf:"bytes,5,opt,name=err,proto3" json:"err,omitempty"`
+	MemoryUsage     *MemoryUsage       `protobuf:"bytes,6,opt,name=memoryUsage,proto3" json:"memoryUsage,omitempty"`
+	// the warning which doesn't stop the changefeed, such as the eviction of a dispatcher
+	Warning *RunningError `protobuf:"bytes,7,opt,name=warning,proto3" json:"warning,omitempty"`
+	// the checkpoint ts committed by the transactional sink, it's 0 if the sink is not transactional
+	CommittedTs uint64 `protobuf:"varint,8,opt,name=committedTs,proto3" json:"committedTs,omitempty"`
 }
 
 func (m *HeartBeatRequest) Reset()         { *m = HeartBeatRequest{} }
@@ -296,6 +305,27 @@ func (m *HeartBeatRequest) GetErr() *RunningError {
 	return nil
 }
 
+func (m *HeartBeatRequest) GetMemoryUsage() *MemoryUsage {
+	if m != nil {
+		return m.MemoryUsage
+	}
+	return nil
+}
+
+func (m *HeartBeatRequest) GetWarning() *RunningError {
+	if m != nil {
+		return m.Warning
+	}
+	return nil
+}
+
+func (m *HeartBeatRequest) GetCommittedTs() uint64 {
+	if m != nil {
+		return m.CommittedTs
+	}
+	return 0
+}
+
 type Watermark struct {
 	CheckpointTs uint64 `protobuf:"varint,1,opt,name=checkpointTs,proto3" json:"checkpointTs,omitempty"`
 	ResolvedTs   uint64 `protobuf:"varint,2,opt,name=resolvedTs,proto3" json:"resolvedTs,omitempty"`
@@ -654,6 +684,9 @@ func (m *HeartBeatResponse) GetDispatcherStatuses() []*DispatcherStatus {
 type CheckpointTsMessage struct {
 	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	CheckpointTs uint64        `protobuf:"varint,2,opt,name=checkpointTs,proto3" json:"checkpointTs,omitempty"`
+	// the ts all events not larger than it are flushed to the sink, the transactional sink commits at it.
+	// The message carries either the checkpointTs or the flushedTs.
+	FlushedTs uint64 `protobuf:"varint,3,opt,name=flushedTs,proto3" json:"flushedTs,omitempty"`
 }
 
 func (m *CheckpointTsMessage) Reset()         { *m = CheckpointTsMessage{} }
@@ -703,6 +736,13 @@ func (m *CheckpointTsMessage) GetCheckpointTs() uint64 {
 	return 0
 }
 
+func (m *CheckpointTsMessage) GetFlushedTs() uint64 {
+	if m != nil {
+		return m.FlushedTs
+	}
+	return 0
+}
+
 type DispatcherConfig struct {
 	Span         *TableSpan    `protobuf:"bytes,1,opt,name=span,proto3" json:"span,omitempty"`
 	StartTs      uint64        `protobuf:"varint,2,opt,name=startTs,proto3" json:"startTs,omitempty"`
@@ -842,6 +882,7 @@ func (m *ScheduleDispatcherRequest) GetScheduleAction() ScheduleAction {
 
 type MaintainerHeartbeat struct {
 	Statuses []*MaintainerStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	NodeLoad *NodeLoad           `protobuf:"bytes,2,opt,name=node_load,json=nodeLoad,proto3" json:"node_load,omitempty"`
 }
 
 func (m *MaintainerHeartbeat) Reset()         { *m = MaintainerHeartbeat{} }
@@ -884,12 +925,31 @@ func (m *MaintainerHeartbeat) GetStatuses() []*MaintainerStatus {
 	return nil
 }
 
+func (m *MaintainerHeartbeat) GetNodeLoad() *NodeLoad {
+	if m != nil {
+		return m.NodeLoad
+	}
+	return nil
+}
+
 type MaintainerStatus struct {
 	ChangefeedID *ChangefeedID   `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	FeedState    string          `protobuf:"bytes,2,opt,name=feed_state,json=feedState,proto3" json:"feed_state,omitempty"`
 	State        ComponentState  `protobuf:"varint,3,opt,name=state,proto3,enum=heartbeatpb.ComponentState" json:"state,omitempty"`
 	CheckpointTs uint64          `protobuf:"varint,4,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
 	Err          []*RunningError `protobuf:"bytes,5,rep,name=err,proto3" json:"err,omitempty"`
+	// the sum of the event size per second of all table spans in the changefeed
+	EventSizePerSecond float32 `protobuf:"fixed32,6,opt,name=event_size_per_second,json=eventSizePerSecond,proto3" json:"event_size_per_second,omitempty"`
+	// the number of the dispatchers running on the nodes not allowed by the placement rules
+	MisplacedDispatchers int32 `protobuf:"varint,7,opt,name=misplaced_dispatchers,json=misplacedDispatchers,proto3" json:"misplaced_dispatchers,omitempty"`
+	// the memory usage of the changefeed summed over all the nodes
+	MemoryUsage *MemoryUsage `protobuf:"bytes,8,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
+	// the warnings reported by the dispatchers, they don't stop the changefeed
+	Warning []*RunningError `protobuf:"bytes,9,rep,name=warning,proto3" json:"warning,omitempty"`
+	// the id of the table replay handled by the maintainer, it's 0 if there is no table replay
+	TableReplayId uint64 `protobuf:"varint,10,opt,name=table_replay_id,json=tableReplayId,proto3" json:"table_replay_id,omitempty"`
+	// whether all the dispatchers of the table replay have caught up the target ts
+	TableReplayFinished bool `protobuf:"varint,11,opt,name=table_replay_finished,json=tableReplayFinished,proto3" json:"table_replay_finished,omitempty"`
 }
 
 func (m *MaintainerStatus) Reset()         { *m = MaintainerStatus{} }
@@ -960,6 +1020,104 @@ func (m *MaintainerStatus) GetErr() []*RunningError {
 	return nil
 }
 
+func (m *MaintainerStatus) GetEventSizePerSecond() float32 {
+	if m != nil {
+		return m.EventSizePerSecond
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetMisplacedDispatchers() int32 {
+	if m != nil {
+		return m.MisplacedDispatchers
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetMemoryUsage() *MemoryUsage {
+	if m != nil {
+		return m.MemoryUsage
+	}
+	return nil
+}
+
+func (m *MaintainerStatus) GetWarning() []*RunningError {
+	if m != nil {
+		return m.Warning
+	}
+	return nil
+}
+
+func (m *MaintainerStatus) GetTableReplayId() uint64 {
+	if m != nil {
+		return m.TableReplayId
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetTableReplayFinished() bool {
+	if m != nil {
+		return m.TableReplayFinished
+	}
+	return false
+}
+
+// NodeLoad is the resource usage of a node, it is used by the load-weighted balance.
+type NodeLoad struct {
+	// the cpu usage ratio of the process, in [0, 1]
+	CpuUsage float32 `protobuf:"fixed32,1,opt,name=cpu_usage,json=cpuUsage,proto3" json:"cpu_usage,omitempty"`
+	// the memory usage ratio of the process to the memory limit, in [0, 1],
+	// it is 0 if the memory limit is not set
+	MemoryUsage float32 `protobuf:"fixed32,2,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
+}
+
+func (m *NodeLoad) Reset()         { *m = NodeLoad{} }
+func (m *NodeLoad) String() string { return proto.CompactTextString(m) }
+func (*NodeLoad) ProtoMessage()    {}
+func (*NodeLoad) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{13}
+}
+func (m *NodeLoad) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NodeLoad) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NodeLoad.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NodeLoad) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeLoad.Merge(m, src)
+}
+func (m *NodeLoad) XXX_Size() int {
+	return m.Size()
+}
+func (m *NodeLoad) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeLoad.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeLoad proto.InternalMessageInfo
+
+func (m *NodeLoad) GetCpuUsage() float32 {
+	if m != nil {
+		return m.CpuUsage
+	}
+	return 0
+}
+
+func (m *NodeLoad) GetMemoryUsage() float32 {
+	if m != nil {
+		return m.MemoryUsage
+	}
+	return 0
+}
+
 type CoordinatorBootstrapRequest struct {
 	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
@@ -968,7 +1126,7 @@ func (m *CoordinatorBootstrapRequest) Reset()         { *m = CoordinatorBootstra
 func (m *CoordinatorBootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*CoordinatorBootstrapRequest) ProtoMessage()    {}
 func (*CoordinatorBootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{13}
+	return fileDescriptor_6d584080fdadb670, []int{14}
 }
 func (m *CoordinatorBootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1012,7 +1170,7 @@ func (m *CoordinatorBootstrapResponse) Reset()         { *m = CoordinatorBootstr
 func (m *CoordinatorBootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*CoordinatorBootstrapResponse) ProtoMessage()    {}
 func (*CoordinatorBootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{14}
+	return fileDescriptor_6d584080fdadb670, []int{15}
 }
 func (m *CoordinatorBootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1058,7 +1216,7 @@ func (m *AddMaintainerRequest) Reset()         { *m = AddMaintainerRequest{} }
 func (m *AddMaintainerRequest) String() string { return proto.CompactTextString(m) }
 func (*AddMaintainerRequest) ProtoMessage()    {}
 func (*AddMaintainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{15}
+	return fileDescriptor_6d584080fdadb670, []int{16}
 }
 func (m *AddMaintainerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1118,7 +1276,7 @@ func (m *RemoveMaintainerRequest) Reset()         { *m = RemoveMaintainerRequest
 func (m *RemoveMaintainerRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveMaintainerRequest) ProtoMessage()    {}
 func (*RemoveMaintainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{16}
+	return fileDescriptor_6d584080fdadb670, []int{17}
 }
 func (m *RemoveMaintainerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1168,6 +1326,86 @@ func (m *RemoveMaintainerRequest) GetRemoved() bool {
 	return false
 }
 
+// ResetDispatchersRequest is sent by coordinator to the maintainer,
+// the dispatchers of the spans will be recreated and replay events from the reset_ts.
+type ResetDispatchersRequest struct {
+	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	Spans        []*TableSpan  `protobuf:"bytes,2,rep,name=spans,proto3" json:"spans,omitempty"`
+	ResetTs      uint64        `protobuf:"varint,3,opt,name=reset_ts,json=resetTs,proto3" json:"reset_ts,omitempty"`
+	// the id of the table replay persisted in the changefeed info
+	ReplayId uint64 `protobuf:"varint,4,opt,name=replay_id,json=replayId,proto3" json:"replay_id,omitempty"`
+	// the checkpoint ts of the changefeed when the replay is requested
+	TargetTs uint64 `protobuf:"varint,5,opt,name=target_ts,json=targetTs,proto3" json:"target_ts,omitempty"`
+}
+
+func (m *ResetDispatchersRequest) Reset()         { *m = ResetDispatchersRequest{} }
+func (m *ResetDispatchersRequest) String() string { return proto.CompactTextString(m) }
+func (*ResetDispatchersRequest) ProtoMessage()    {}
+func (*ResetDispatchersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{18}
+}
+func (m *ResetDispatchersRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResetDispatchersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResetDispatchersRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResetDispatchersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetDispatchersRequest.Merge(m, src)
+}
+func (m *ResetDispatchersRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResetDispatchersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetDispatchersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetDispatchersRequest proto.InternalMessageInfo
+
+func (m *ResetDispatchersRequest) GetChangefeedID() *ChangefeedID {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return nil
+}
+
+func (m *ResetDispatchersRequest) GetSpans() []*TableSpan {
+	if m != nil {
+		return m.Spans
+	}
+	return nil
+}
+
+func (m *ResetDispatchersRequest) GetResetTs() uint64 {
+	if m != nil {
+		return m.ResetTs
+	}
+	return 0
+}
+
+func (m *ResetDispatchersRequest) GetReplayId() uint64 {
+	if m != nil {
+		return m.ReplayId
+	}
+	return 0
+}
+
+func (m *ResetDispatchersRequest) GetTargetTs() uint64 {
+	if m != nil {
+		return m.TargetTs
+	}
+	return 0
+}
+
 type MaintainerBootstrapRequest struct {
 	ChangefeedID                  *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	Config                        []byte        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
@@ -1179,7 +1417,7 @@ func (m *MaintainerBootstrapRequest) Reset()         { *m = MaintainerBootstrapR
 func (m *MaintainerBootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerBootstrapRequest) ProtoMessage()    {}
 func (*MaintainerBootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{17}
+	return fileDescriptor_6d584080fdadb670, []int{19}
 }
 func (m *MaintainerBootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1254,7 +1492,7 @@ func (m *MaintainerBootstrapResponse) Reset()         { *m = MaintainerBootstrap
 func (m *MaintainerBootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerBootstrapResponse) ProtoMessage()    {}
 func (*MaintainerBootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{18}
+	return fileDescriptor_6d584080fdadb670, []int{20}
 }
 func (m *MaintainerBootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1321,7 +1559,7 @@ func (m *MaintainerPostBootstrapRequest) Reset()         { *m = MaintainerPostBo
 func (m *MaintainerPostBootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerPostBootstrapRequest) ProtoMessage()    {}
 func (*MaintainerPostBootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{19}
+	return fileDescriptor_6d584080fdadb670, []int{21}
 }
 func (m *MaintainerPostBootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1381,7 +1619,7 @@ func (m *MaintainerPostBootstrapResponse) Reset()         { *m = MaintainerPostB
 func (m *MaintainerPostBootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerPostBootstrapResponse) ProtoMessage()    {}
 func (*MaintainerPostBootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{20}
+	return fileDescriptor_6d584080fdadb670, []int{22}
 }
 func (m *MaintainerPostBootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1441,7 +1679,7 @@ func (m *SchemaInfo) Reset()         { *m = SchemaInfo{} }
 func (m *SchemaInfo) String() string { return proto.CompactTextString(m) }
 func (*SchemaInfo) ProtoMessage()    {}
 func (*SchemaInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{21}
+	return fileDescriptor_6d584080fdadb670, []int{23}
 }
 func (m *SchemaInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1500,7 +1738,7 @@ func (m *TableInfo) Reset()         { *m = TableInfo{} }
 func (m *TableInfo) String() string { return proto.CompactTextString(m) }
 func (*TableInfo) ProtoMessage()    {}
 func (*TableInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{22}
+	return fileDescriptor_6d584080fdadb670, []int{24}
 }
 func (m *TableInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1556,7 +1794,7 @@ func (m *BootstrapTableSpan) Reset()         { *m = BootstrapTableSpan{} }
 func (m *BootstrapTableSpan) String() string { return proto.CompactTextString(m) }
 func (*BootstrapTableSpan) ProtoMessage()    {}
 func (*BootstrapTableSpan) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{23}
+	return fileDescriptor_6d584080fdadb670, []int{25}
 }
 func (m *BootstrapTableSpan) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1637,7 +1875,7 @@ func (m *MaintainerCloseRequest) Reset()         { *m = MaintainerCloseRequest{}
 func (m *MaintainerCloseRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerCloseRequest) ProtoMessage()    {}
 func (*MaintainerCloseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{24}
+	return fileDescriptor_6d584080fdadb670, []int{26}
 }
 func (m *MaintainerCloseRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1689,7 +1927,7 @@ func (m *MaintainerCloseResponse) Reset()         { *m = MaintainerCloseResponse
 func (m *MaintainerCloseResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerCloseResponse) ProtoMessage()    {}
 func (*MaintainerCloseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{25}
+	return fileDescriptor_6d584080fdadb670, []int{27}
 }
 func (m *MaintainerCloseResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1744,7 +1982,7 @@ func (m *InfluencedTables) Reset()         { *m = InfluencedTables{} }
 func (m *InfluencedTables) String() string { return proto.CompactTextString(m) }
 func (*InfluencedTables) ProtoMessage()    {}
 func (*InfluencedTables) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{26}
+	return fileDescriptor_6d584080fdadb670, []int{28}
 }
 func (m *InfluencedTables) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1803,7 +2041,7 @@ func (m *Table) Reset()         { *m = Table{} }
 func (m *Table) String() string { return proto.CompactTextString(m) }
 func (*Table) ProtoMessage()    {}
 func (*Table) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{27}
+	return fileDescriptor_6d584080fdadb670, []int{29}
 }
 func (m *Table) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1856,7 +2094,7 @@ func (m *SchemaIDChange) Reset()         { *m = SchemaIDChange{} }
 func (m *SchemaIDChange) String() string { return proto.CompactTextString(m) }
 func (*SchemaIDChange) ProtoMessage()    {}
 func (*SchemaIDChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{28}
+	return fileDescriptor_6d584080fdadb670, []int{30}
 }
 func (m *SchemaIDChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1921,7 +2159,7 @@ func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{29}
+	return fileDescriptor_6d584080fdadb670, []int{31}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2015,7 +2253,7 @@ func (m *TableSpanBlockStatus) Reset()         { *m = TableSpanBlockStatus{} }
 func (m *TableSpanBlockStatus) String() string { return proto.CompactTextString(m) }
 func (*TableSpanBlockStatus) ProtoMessage()    {}
 func (*TableSpanBlockStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{30}
+	return fileDescriptor_6d584080fdadb670, []int{32}
 }
 func (m *TableSpanBlockStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2063,13 +2301,15 @@ type TableSpanStatus struct {
 	ComponentStatus    ComponentState `protobuf:"varint,2,opt,name=component_status,json=componentStatus,proto3,enum=heartbeatpb.ComponentState" json:"component_status,omitempty"`
 	CheckpointTs       uint64         `protobuf:"varint,3,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
 	EventSizePerSecond float32        `protobuf:"fixed32,4,opt,name=event_size_per_second,json=eventSizePerSecond,proto3" json:"event_size_per_second,omitempty"`
+	// the ts the dispatcher replays events from, it's 0 if the dispatcher never replays
+	ReplayedTs uint64 `protobuf:"varint,5,opt,name=replayed_ts,json=replayedTs,proto3" json:"replayed_ts,omitempty"`
 }
 
 func (m *TableSpanStatus) Reset()         { *m = TableSpanStatus{} }
 func (m *TableSpanStatus) String() string { return proto.CompactTextString(m) }
 func (*TableSpanStatus) ProtoMessage()    {}
 func (*TableSpanStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{31}
+	return fileDescriptor_6d584080fdadb670, []int{33}
 }
 func (m *TableSpanStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2126,6 +2366,13 @@ func (m *TableSpanStatus) GetEventSizePerSecond() float32 {
 	return 0
 }
 
+func (m *TableSpanStatus) GetReplayedTs() uint64 {
+	if m != nil {
+		return m.ReplayedTs
+	}
+	return 0
+}
+
 type BlockStatusRequest struct {
 	ChangefeedID  *ChangefeedID           `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	BlockStatuses []*TableSpanBlockStatus `protobuf:"bytes,2,rep,name=blockStatuses,proto3" json:"blockStatuses,omitempty"`
@@ -2135,7 +2382,7 @@ func (m *BlockStatusRequest) Reset()         { *m = BlockStatusRequest{} }
 func (m *BlockStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*BlockStatusRequest) ProtoMessage()    {}
 func (*BlockStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{32}
+	return fileDescriptor_6d584080fdadb670, []int{34}
 }
 func (m *BlockStatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2183,13 +2430,16 @@ type RunningError struct {
 	Node    string `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
 	Code    string `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
 	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// the table the error comes from in the form of `schema`.`table`,
+	// it's empty if the error can't be attributed to a single table
+	Table string `protobuf:"bytes,5,opt,name=table,proto3" json:"table,omitempty"`
 }
 
 func (m *RunningError) Reset()         { *m = RunningError{} }
 func (m *RunningError) String() string { return proto.CompactTextString(m) }
 func (*RunningError) ProtoMessage()    {}
 func (*RunningError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{33}
+	return fileDescriptor_6d584080fdadb670, []int{35}
 }
 func (m *RunningError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2246,6 +2496,13 @@ func (m *RunningError) GetMessage() string {
 	return ""
 }
 
+func (m *RunningError) GetTable() string {
+	if m != nil {
+		return m.Table
+	}
+	return ""
+}
+
 type DispatcherID struct {
 	High uint64 `protobuf:"varint,1,opt,name=high,proto3" json:"high,omitempty"`
 	Low  uint64 `protobuf:"varint,2,opt,name=low,proto3" json:"low,omitempty"`
@@ -2255,7 +2512,7 @@ func (m *DispatcherID) Reset()         { *m = DispatcherID{} }
 func (m *DispatcherID) String() string { return proto.CompactTextString(m) }
 func (*DispatcherID) ProtoMessage()    {}
 func (*DispatcherID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{34}
+	return fileDescriptor_6d584080fdadb670, []int{36}
 }
 func (m *DispatcherID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2309,7 +2566,7 @@ func (m *ChangefeedID) Reset()         { *m = ChangefeedID{} }
 func (m *ChangefeedID) String() string { return proto.CompactTextString(m) }
 func (*ChangefeedID) ProtoMessage()    {}
 func (*ChangefeedID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{35}
+	return fileDescriptor_6d584080fdadb670, []int{37}
 }
 func (m *ChangefeedID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2366,6 +2623,83 @@ func (m *ChangefeedID) GetNamespace() string {
 	return ""
 }
 
+// MemoryUsage is the memory held by the components of a changefeed against its memory quota, in bytes.
+type MemoryUsage struct {
+	Quota             uint64 `protobuf:"varint,1,opt,name=quota,proto3" json:"quota,omitempty"`
+	EventCollector    uint64 `protobuf:"varint,2,opt,name=event_collector,json=eventCollector,proto3" json:"event_collector,omitempty"`
+	Sink              uint64 `protobuf:"varint,3,opt,name=sink,proto3" json:"sink,omitempty"`
+	Encoder           uint64 `protobuf:"varint,4,opt,name=encoder,proto3" json:"encoder,omitempty"`
+	MysqlPreparedDmls uint64 `protobuf:"varint,5,opt,name=mysql_prepared_dmls,json=mysqlPreparedDmls,proto3" json:"mysql_prepared_dmls,omitempty"`
+}
+
+func (m *MemoryUsage) Reset()         { *m = MemoryUsage{} }
+func (m *MemoryUsage) String() string { return proto.CompactTextString(m) }
+func (*MemoryUsage) ProtoMessage()    {}
+func (*MemoryUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{38}
+}
+func (m *MemoryUsage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MemoryUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MemoryUsage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MemoryUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemoryUsage.Merge(m, src)
+}
+func (m *MemoryUsage) XXX_Size() int {
+	return m.Size()
+}
+func (m *MemoryUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemoryUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemoryUsage proto.InternalMessageInfo
+
+func (m *MemoryUsage) GetQuota() uint64 {
+	if m != nil {
+		return m.Quota
+	}
+	return 0
+}
+
+func (m *MemoryUsage) GetEventCollector() uint64 {
+	if m != nil {
+		return m.EventCollector
+	}
+	return 0
+}
+
+func (m *MemoryUsage) GetSink() uint64 {
+	if m != nil {
+		return m.Sink
+	}
+	return 0
+}
+
+func (m *MemoryUsage) GetEncoder() uint64 {
+	if m != nil {
+		return m.Encoder
+	}
+	return 0
+}
+
+func (m *MemoryUsage) GetMysqlPreparedDmls() uint64 {
+	if m != nil {
+		return m.MysqlPreparedDmls
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("heartbeatpb.Action", Action_name, Action_value)
 	proto.RegisterEnum("heartbeatpb.ScheduleAction", ScheduleAction_name, ScheduleAction_value)
@@ -2385,10 +2719,12 @@ func init() {
 	proto.RegisterType((*ScheduleDispatcherRequest)(nil), "heartbeatpb.ScheduleDispatcherRequest")
 	proto.RegisterType((*MaintainerHeartbeat)(nil), "heartbeatpb.MaintainerHeartbeat")
 	proto.RegisterType((*MaintainerStatus)(nil), "heartbeatpb.MaintainerStatus")
+	proto.RegisterType((*NodeLoad)(nil), "heartbeatpb.NodeLoad")
 	proto.RegisterType((*CoordinatorBootstrapRequest)(nil), "heartbeatpb.CoordinatorBootstrapRequest")
 	proto.RegisterType((*CoordinatorBootstrapResponse)(nil), "heartbeatpb.CoordinatorBootstrapResponse")
 	proto.RegisterType((*AddMaintainerRequest)(nil), "heartbeatpb.AddMaintainerRequest")
 	proto.RegisterType((*RemoveMaintainerRequest)(nil), "heartbeatpb.RemoveMaintainerRequest")
+	proto.RegisterType((*ResetDispatchersRequest)(nil), "heartbeatpb.ResetDispatchersRequest")
 	proto.RegisterType((*MaintainerBootstrapRequest)(nil), "heartbeatpb.MaintainerBootstrapRequest")
 	proto.RegisterType((*MaintainerBootstrapResponse)(nil), "heartbeatpb.MaintainerBootstrapResponse")
 	proto.RegisterType((*MaintainerPostBootstrapRequest)(nil), "heartbeatpb.MaintainerPostBootstrapRequest")
@@ -2408,124 +2744,149 @@ func init() {
 	proto.RegisterType((*RunningError)(nil), "heartbeatpb.RunningError")
 	proto.RegisterType((*DispatcherID)(nil), "heartbeatpb.DispatcherID")
 	proto.RegisterType((*ChangefeedID)(nil), "heartbeatpb.ChangefeedID")
+	proto.RegisterType((*MemoryUsage)(nil), "heartbeatpb.MemoryUsage")
 }
 
 func init() { proto.RegisterFile("heartbeatpb/heartbeat.proto", fileDescriptor_6d584080fdadb670) }
 
 var fileDescriptor_6d584080fdadb670 = []byte{
-	// 1790 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x18, 0x4d, 0x73, 0xdb, 0x4c,
-	0x39, 0x92, 0x1c, 0xc7, 0x7e, 0x9c, 0x0f, 0xbd, 0x9b, 0xb7, 0xa9, 0x9b, 0x0f, 0xbf, 0xe9, 0xc2,
-	0x21, 0xa4, 0x90, 0x4c, 0xd3, 0x76, 0x0a, 0x0c, 0xa5, 0x24, 0x4e, 0x68, 0x3d, 0x99, 0xa6, 0x99,
-	0x75, 0x98, 0x52, 0x2e, 0x1e, 0x45, 0xda, 0x38, 0x9a, 0xd8, 0x92, 0xaa, 0x95, 0xd3, 0xb4, 0x33,
-	0x70, 0xe1, 0xca, 0x81, 0x23, 0x07, 0x2e, 0x3d, 0xf2, 0x4b, 0xe0, 0xd8, 0x13, 0x70, 0xe0, 0xc0,
-	0xb4, 0xc3, 0x1f, 0x80, 0x61, 0xb8, 0x32, 0xbb, 0xd2, 0xea, 0xcb, 0x72, 0x92, 0x4e, 0x3c, 0xef,
-	0x49, 0xfb, 0xec, 0x3e, 0x5f, 0xfb, 0x7c, 0xaf, 0x60, 0xe9, 0x94, 0x1a, 0x7e, 0x70, 0x4c, 0x8d,
-	0xc0, 0x3b, 0xde, 0x8c, 0xd7, 0x1b, 0x9e, 0xef, 0x06, 0x2e, 0xaa, 0xa5, 0x0e, 0xf1, 0x6b, 0xa8,
-	0x1e, 0x19, 0xc7, 0x3d, 0xda, 0xf6, 0x0c, 0x07, 0xd5, 0x61, 0x4a, 0x00, 0xad, 0xdd, 0xba, 0xb2,
-	0xaa, 0xac, 0x69, 0x44, 0x82, 0x68, 0x11, 0x2a, 0xed, 0xc0, 0xf0, 0x83, 0x7d, 0xfa, 0xae, 0xae,
-	0xae, 0x2a, 0x6b, 0xd3, 0x24, 0x86, 0xd1, 0x02, 0x94, 0xf7, 0x1c, 0x8b, 0x9f, 0x68, 0xe2, 0x24,
-	0x82, 0xf0, 0x1f, 0x54, 0xd0, 0x9f, 0x73, 0x51, 0x3b, 0xd4, 0x08, 0x08, 0x7d, 0x33, 0xa0, 0x2c,
-	0x40, 0x4f, 0x60, 0xda, 0x3c, 0x35, 0x9c, 0x2e, 0x3d, 0xa1, 0xd4, 0x8a, 0xe4, 0xd4, 0xb6, 0xee,
-	0x6c, 0xa4, 0x74, 0xda, 0x68, 0xa6, 0x10, 0x48, 0x06, 0x1d, 0x3d, 0x84, 0xea, 0x5b, 0x23, 0xa0,
-	0x7e, 0xdf, 0xf0, 0xcf, 0x84, 0x22, 0xb5, 0xad, 0x85, 0x0c, 0xed, 0x2b, 0x79, 0x4a, 0x12, 0x44,
-	0xf4, 0x43, 0xa8, 0xb0, 0xc0, 0x08, 0x06, 0x8c, 0xb2, 0xba, 0xb6, 0xaa, 0xad, 0xd5, 0xb6, 0x96,
-	0x33, 0x44, 0xb1, 0x05, 0xda, 0x02, 0x8b, 0xc4, 0xd8, 0x68, 0x0d, 0xe6, 0x4c, 0xb7, 0xef, 0xd1,
-	0x1e, 0x0d, 0x68, 0x78, 0x58, 0x2f, 0xad, 0x2a, 0x6b, 0x15, 0x92, 0xdf, 0x46, 0xf7, 0x40, 0xa3,
-	0xbe, 0x5f, 0x9f, 0x2c, 0xb8, 0x0f, 0x19, 0x38, 0x8e, 0xed, 0x74, 0xf7, 0x7c, 0xdf, 0xf5, 0x09,
-	0xc7, 0xc2, 0x06, 0x54, 0x63, 0x45, 0x11, 0xe6, 0x26, 0xa1, 0xe6, 0x99, 0xe7, 0xda, 0x4e, 0x70,
-	0xc4, 0x84, 0x49, 0x4a, 0x24, 0xb3, 0x87, 0x1a, 0x00, 0x3e, 0x65, 0x6e, 0xef, 0x9c, 0x5a, 0x47,
-	0x4c, 0x5c, 0xbc, 0x44, 0x52, 0x3b, 0x48, 0x07, 0x8d, 0xd1, 0x37, 0xc2, 0x01, 0x25, 0xc2, 0x97,
-	0xf8, 0xd7, 0xa0, 0xef, 0xda, 0xcc, 0x33, 0x02, 0xf3, 0x94, 0xfa, 0xdb, 0x66, 0x60, 0xbb, 0x0e,
-	0xba, 0x07, 0x65, 0x43, 0xac, 0x84, 0x8c, 0xd9, 0xad, 0xf9, 0x8c, 0x9a, 0x21, 0x12, 0x89, 0x50,
-	0xb8, 0xcb, 0x9b, 0x6e, 0xbf, 0x6f, 0x07, 0xb1, 0xc0, 0x18, 0x46, 0xab, 0x50, 0x6b, 0xb1, 0xf6,
-	0x3b, 0xc7, 0x3c, 0xe4, 0xfa, 0x09, 0xb1, 0x15, 0x92, 0xde, 0xc2, 0x4d, 0xd0, 0xb6, 0x9b, 0xfb,
-	0x19, 0x26, 0xca, 0xe5, 0x4c, 0xd4, 0x61, 0x26, 0xbf, 0x55, 0xe1, 0x56, 0xcb, 0x39, 0xe9, 0x0d,
-	0xa8, 0x63, 0x52, 0x2b, 0xb9, 0x0e, 0x43, 0x3f, 0x83, 0x99, 0xf8, 0xe0, 0xe8, 0x9d, 0x47, 0xa3,
-	0x0b, 0x2d, 0x66, 0x2e, 0x94, 0xc1, 0x20, 0x59, 0x02, 0xf4, 0x14, 0x66, 0x12, 0x86, 0xad, 0x5d,
-	0x7e, 0x47, 0x6d, 0xc8, 0x73, 0x69, 0x0c, 0x92, 0xc5, 0x17, 0x29, 0x61, 0x9e, 0xd2, 0xbe, 0xd1,
-	0xda, 0x15, 0x06, 0xd0, 0x48, 0x0c, 0xa3, 0x7d, 0x98, 0xa7, 0x17, 0x66, 0x6f, 0x60, 0xd1, 0x14,
-	0x8d, 0x25, 0x42, 0xe7, 0x52, 0x11, 0x45, 0x54, 0xf8, 0xcf, 0x4a, 0xda, 0x95, 0x51, 0xb8, 0xfd,
-	0x12, 0x6e, 0xd9, 0x45, 0x96, 0x89, 0x12, 0x0a, 0x17, 0x1b, 0x22, 0x8d, 0x49, 0x8a, 0x19, 0xa0,
-	0x47, 0x71, 0x90, 0x84, 0xf9, 0xb5, 0x32, 0x42, 0xdd, 0x5c, 0xb8, 0x60, 0xd0, 0x0c, 0xf3, 0x4c,
-	0x58, 0xa2, 0xb6, 0xa5, 0x67, 0x03, 0xab, 0xb9, 0x4f, 0xf8, 0x21, 0xfe, 0xa0, 0xc0, 0x57, 0xa9,
-	0x8a, 0xc0, 0x3c, 0xd7, 0x61, 0xf4, 0xa6, 0x25, 0xe1, 0x05, 0x20, 0x2b, 0x67, 0x1d, 0x2a, 0xbd,
-	0x39, 0x4a, 0xf7, 0x28, 0xcf, 0x0b, 0x08, 0xf1, 0x05, 0xcc, 0x37, 0x53, 0x99, 0xf7, 0x82, 0x32,
-	0x66, 0x74, 0x6f, 0xac, 0x64, 0x3e, 0xc7, 0xd5, 0xe1, 0x1c, 0xc7, 0x7f, 0xcb, 0xf8, 0xb9, 0xe9,
-	0x3a, 0x27, 0x76, 0x17, 0xad, 0x43, 0x89, 0x79, 0x86, 0x13, 0xc9, 0x5b, 0x28, 0x2e, 0x5b, 0x44,
-	0xe0, 0xf0, 0xf2, 0xcd, 0x78, 0x51, 0x8e, 0xf9, 0x4b, 0x90, 0x6b, 0x6f, 0xa5, 0xe2, 0x2c, 0xf2,
-	0xd2, 0x25, 0x81, 0x98, 0x41, 0xe7, 0xa1, 0xce, 0x64, 0xa8, 0x97, 0xc2, 0x50, 0x97, 0x30, 0xc2,
-	0x30, 0x63, 0x0e, 0x7c, 0x9f, 0x3a, 0x41, 0xc7, 0xb3, 0x3a, 0x01, 0x13, 0x15, 0xb0, 0x44, 0x6a,
-	0xd1, 0xe6, 0xa1, 0x75, 0xc4, 0xf0, 0x5f, 0x15, 0xb8, 0xc3, 0x73, 0xc3, 0x1a, 0xf4, 0x52, 0xa1,
-	0x3d, 0xa6, 0x96, 0xf0, 0x08, 0xca, 0xa6, 0xb0, 0xd5, 0x15, 0xf1, 0x1a, 0x1a, 0x94, 0x44, 0xc8,
-	0xa8, 0x09, 0xb3, 0x2c, 0x52, 0x29, 0x8c, 0x64, 0x61, 0x94, 0xd9, 0xad, 0xa5, 0x0c, 0x79, 0x3b,
-	0x83, 0x42, 0x72, 0x24, 0xf8, 0x10, 0xe6, 0x5f, 0x18, 0xb6, 0x13, 0x18, 0xb6, 0x43, 0xfd, 0xe7,
-	0x92, 0x0e, 0xfd, 0x28, 0xd5, 0x6f, 0x94, 0x82, 0x40, 0x4c, 0x68, 0xf2, 0x0d, 0x07, 0xff, 0x57,
-	0x01, 0x3d, 0x7f, 0x7c, 0x53, 0x0b, 0xad, 0x00, 0xf0, 0x55, 0x87, 0x0b, 0xa1, 0xc2, 0x4a, 0x55,
-	0x52, 0xe5, 0x3b, 0x9c, 0x3d, 0x45, 0xf7, 0x61, 0x32, 0x3c, 0x29, 0x32, 0x40, 0xd3, 0xed, 0x7b,
-	0xae, 0x43, 0x9d, 0x40, 0xe0, 0x92, 0x10, 0x13, 0x7d, 0x07, 0x66, 0x92, 0xd0, 0xe5, 0x4e, 0x2f,
-	0x15, 0xf4, 0xac, 0xb8, 0x23, 0x6a, 0xd7, 0xe8, 0x88, 0x8f, 0x61, 0xa9, 0xe9, 0xba, 0xbe, 0x65,
-	0x3b, 0x46, 0xe0, 0xfa, 0x3b, 0xae, 0x1b, 0xb0, 0xc0, 0x37, 0x3c, 0x19, 0x23, 0x75, 0x98, 0x3a,
-	0xa7, 0x3e, 0x93, 0xad, 0x4b, 0x23, 0x12, 0xc4, 0xaf, 0x61, 0xb9, 0x98, 0x30, 0xaa, 0x2e, 0x37,
-	0xf0, 0xc5, 0x6f, 0xe0, 0xeb, 0x6d, 0xcb, 0x4a, 0x10, 0xa4, 0x32, 0xdf, 0x03, 0xd5, 0xb6, 0xae,
-	0x76, 0x82, 0x6a, 0x5b, 0x7c, 0x36, 0x4a, 0x05, 0xe7, 0x74, 0x1c, 0x7d, 0x43, 0x06, 0xd4, 0x0a,
-	0x0a, 0xc2, 0x05, 0xdc, 0x26, 0xb4, 0xef, 0x9e, 0xd3, 0x1b, 0xa9, 0x50, 0x87, 0x29, 0xd3, 0x60,
-	0xa6, 0x61, 0xd1, 0xa8, 0xc5, 0x4a, 0x90, 0x9f, 0xf8, 0x82, 0xbf, 0x15, 0x75, 0x70, 0x09, 0xe2,
-	0xff, 0x28, 0xb0, 0x98, 0x08, 0x1d, 0xf2, 0xc6, 0x0d, 0xe3, 0x71, 0x94, 0x51, 0xee, 0x08, 0x57,
-	0xf9, 0x29, 0x7b, 0xc4, 0x05, 0xcc, 0x84, 0xbb, 0x01, 0xaf, 0x76, 0x9d, 0xc0, 0xb7, 0xbb, 0x5d,
-	0xea, 0x77, 0xe8, 0x39, 0xaf, 0x38, 0x49, 0x95, 0xea, 0xd8, 0xd7, 0x68, 0xaf, 0x2b, 0x82, 0xc7,
-	0x51, 0xc8, 0x62, 0x8f, 0x73, 0xc8, 0x34, 0xda, 0x7f, 0x29, 0xb0, 0x54, 0x78, 0xeb, 0xf1, 0x34,
-	0xaa, 0x47, 0x30, 0xc9, 0xcb, 0xb4, 0xec, 0x4d, 0xdf, 0x64, 0xe8, 0x62, 0x69, 0x49, 0x51, 0x0f,
-	0xb1, 0x65, 0x1a, 0x69, 0xd7, 0x19, 0x2c, 0xaf, 0x95, 0x98, 0xf8, 0x7f, 0x0a, 0x34, 0x92, 0x7b,
-	0x1e, 0xba, 0x2c, 0x18, 0xb7, 0x87, 0xaf, 0xe5, 0x2e, 0xf5, 0x66, 0xee, 0x42, 0xf7, 0x61, 0x2a,
-	0xec, 0x42, 0x72, 0xa8, 0xbf, 0x3d, 0x54, 0xba, 0xfb, 0x46, 0xcb, 0x39, 0x71, 0x89, 0xc4, 0xc3,
-	0xff, 0x56, 0xe0, 0x9b, 0x91, 0x37, 0x1f, 0x8f, 0x97, 0xbf, 0x95, 0xab, 0x7f, 0x49, 0x4c, 0xe0,
-	0x0b, 0x80, 0xc4, 0x16, 0x99, 0xb1, 0x55, 0xc9, 0x8d, 0xad, 0x0d, 0x89, 0x79, 0x60, 0xf4, 0x65,
-	0xa3, 0x48, 0xed, 0xa0, 0x0d, 0x28, 0x8b, 0xf0, 0x94, 0x06, 0x2f, 0x18, 0x47, 0x84, 0xbd, 0x23,
-	0x2c, 0xdc, 0x8c, 0x1e, 0x97, 0x42, 0xf0, 0xe8, 0xc7, 0xe5, 0x72, 0x84, 0x96, 0x92, 0x9a, 0x6c,
-	0xe0, 0x3f, 0xa9, 0x80, 0x86, 0xb3, 0x83, 0x57, 0xc0, 0x11, 0xce, 0xc9, 0x18, 0x52, 0x8d, 0x1e,
-	0xaf, 0xf2, 0xca, 0x6a, 0xee, 0xca, 0x72, 0xbe, 0xd2, 0xae, 0x31, 0x5f, 0xfd, 0x1c, 0x74, 0x53,
-	0xb6, 0xc3, 0x0e, 0x4b, 0x5e, 0x83, 0x57, 0xf4, 0xcc, 0x39, 0x33, 0x0d, 0x0f, 0xd8, 0x70, 0x92,
-	0x4e, 0x16, 0x74, 0xcf, 0x07, 0x50, 0x3b, 0xee, 0xb9, 0xe6, 0x59, 0xd4, 0xb5, 0xcb, 0x42, 0x3f,
-	0x94, 0x8d, 0x70, 0xc1, 0x1e, 0x04, 0x9a, 0x58, 0xe3, 0x37, 0xb0, 0x90, 0x84, 0x77, 0xb3, 0xe7,
-	0x32, 0x3a, 0xa6, 0x84, 0x4e, 0xb5, 0x0a, 0x35, 0xdb, 0x2a, 0x7c, 0xb8, 0x3d, 0x24, 0x72, 0x3c,
-	0x99, 0xc4, 0xc7, 0xd9, 0x81, 0x69, 0x52, 0xc6, 0xa4, 0xcc, 0x08, 0xc4, 0xbf, 0x53, 0x40, 0x4f,
-	0xde, 0x34, 0x61, 0xb0, 0x8d, 0xe1, 0x49, 0xb8, 0x08, 0x95, 0x28, 0x24, 0xc3, 0x1a, 0xad, 0x91,
-	0x18, 0xbe, 0xec, 0xb5, 0x87, 0x9f, 0xc0, 0xa4, 0xc0, 0xbb, 0xe2, 0xff, 0xc9, 0x88, 0x10, 0xc4,
-	0x0e, 0xcc, 0xca, 0x75, 0x68, 0x8d, 0x4b, 0xf8, 0xac, 0x42, 0xed, 0x65, 0xcf, 0xca, 0xb1, 0x4a,
-	0x6f, 0x71, 0x8c, 0x03, 0xfa, 0x36, 0xa7, 0x6b, 0x7a, 0x0b, 0x7f, 0xd0, 0x60, 0x32, 0x9c, 0xfc,
-	0x96, 0xa1, 0xda, 0x62, 0x3b, 0x3c, 0x7c, 0x68, 0x38, 0x4c, 0x54, 0x48, 0xb2, 0xc1, 0xb5, 0x10,
-	0xcb, 0xe4, 0x39, 0x11, 0x81, 0xe8, 0x29, 0xd4, 0xc2, 0xa5, 0x2c, 0x06, 0xc3, 0x73, 0x77, 0xde,
-	0x3d, 0x24, 0x4d, 0x81, 0xf6, 0xe1, 0xab, 0x03, 0x4a, 0xad, 0x5d, 0xdf, 0xf5, 0x3c, 0x89, 0x11,
-	0xb5, 0xef, 0x2b, 0xd8, 0x0c, 0xd3, 0xa1, 0x9f, 0xc0, 0x1c, 0xdf, 0xdc, 0xb6, 0xac, 0x98, 0x55,
-	0x38, 0x73, 0xa2, 0xe1, 0x6c, 0x26, 0x79, 0x54, 0xfe, 0x0e, 0xf8, 0x85, 0x67, 0x19, 0x01, 0x8d,
-	0x4c, 0xc8, 0xea, 0x65, 0x41, 0xbc, 0x54, 0xd4, 0x4c, 0x22, 0x07, 0x91, 0x1c, 0x49, 0xfe, 0x57,
-	0xc6, 0xd4, 0xd0, 0xaf, 0x0c, 0xf4, 0x03, 0x31, 0x64, 0x77, 0x69, 0xbd, 0x22, 0xa2, 0x32, 0xdb,
-	0xaa, 0x76, 0xa2, 0x0c, 0xee, 0x86, 0x03, 0x76, 0x97, 0xe2, 0x33, 0xf8, 0x3a, 0xae, 0x3e, 0xf2,
-	0x94, 0x97, 0x8e, 0x2f, 0xa8, 0x7a, 0x6b, 0x72, 0xac, 0x57, 0x47, 0x96, 0x8e, 0x10, 0x01, 0xff,
-	0x43, 0x81, 0xb9, 0xdc, 0x2f, 0xb0, 0x2f, 0x11, 0x54, 0x54, 0x16, 0xd5, 0x71, 0x94, 0xc5, 0x82,
-	0x99, 0x18, 0xdd, 0x87, 0x5b, 0x61, 0x43, 0x65, 0xf6, 0x7b, 0xda, 0xf1, 0xa8, 0xdf, 0x61, 0xd4,
-	0x74, 0x9d, 0x70, 0xf8, 0x53, 0x09, 0x12, 0x87, 0x6d, 0xfb, 0x3d, 0x3d, 0xa4, 0x7e, 0x5b, 0x9c,
-	0xe0, 0x3f, 0x2a, 0x80, 0x52, 0x36, 0x1c, 0x53, 0x45, 0x7c, 0x06, 0x33, 0xc7, 0x09, 0xd3, 0xf8,
-	0x8f, 0xc3, 0xdd, 0xe2, 0x0e, 0x92, 0x96, 0x9f, 0xa5, 0xc3, 0x16, 0x4c, 0xa7, 0x7b, 0x36, 0x42,
-	0x50, 0x0a, 0xec, 0x7e, 0x58, 0xbe, 0xaa, 0x44, 0xac, 0xf9, 0x9e, 0xe3, 0x5a, 0xb2, 0x39, 0x8a,
-	0x35, 0xdf, 0x33, 0xf9, 0x9e, 0x16, 0xee, 0xf1, 0x35, 0x4f, 0xd9, 0x7e, 0xf8, 0xc3, 0x42, 0xd8,
-	0xa3, 0x4a, 0x24, 0x88, 0x1f, 0xc2, 0x74, 0xda, 0x71, 0x9c, 0xfa, 0xd4, 0xee, 0x9e, 0x46, 0x3f,
-	0xe5, 0xc4, 0x1a, 0xe9, 0xa0, 0xf5, 0xdc, 0xb7, 0x51, 0xb2, 0xf3, 0x25, 0x3e, 0x81, 0xe9, 0xb4,
-	0x09, 0xae, 0x47, 0x25, 0xb4, 0xe5, 0xad, 0x3c, 0xd2, 0x8c, 0xaf, 0x79, 0xa9, 0xe1, 0x5f, 0xe6,
-	0x19, 0xa6, 0xd4, 0x2d, 0xd9, 0x58, 0x5f, 0x81, 0x72, 0xf4, 0x8b, 0xb2, 0x0a, 0x93, 0xaf, 0x7c,
-	0x3b, 0xa0, 0xfa, 0x04, 0xaa, 0x40, 0xe9, 0xd0, 0x60, 0x4c, 0x57, 0xd6, 0xd7, 0xc2, 0x0a, 0x99,
-	0x3c, 0xbc, 0x11, 0x40, 0xb9, 0xe9, 0x53, 0x43, 0xe0, 0x01, 0x94, 0xc3, 0x67, 0x92, 0xae, 0xac,
-	0xff, 0x18, 0x20, 0x49, 0x26, 0xce, 0xe1, 0xe0, 0xe5, 0xc1, 0x9e, 0x3e, 0x81, 0x6a, 0x30, 0xf5,
-	0x6a, 0xbb, 0x75, 0xd4, 0x3a, 0x78, 0xa6, 0x2b, 0x02, 0x20, 0x21, 0xa0, 0x72, 0x9c, 0x5d, 0x8e,
-	0xa3, 0xad, 0x7f, 0x3f, 0xd7, 0x40, 0xd0, 0x14, 0x68, 0xdb, 0xbd, 0x9e, 0x3e, 0x81, 0xca, 0xa0,
-	0xee, 0xee, 0xe8, 0x0a, 0x97, 0x74, 0xe0, 0xfa, 0x7d, 0xa3, 0xa7, 0xab, 0xeb, 0x8f, 0x61, 0x36,
-	0x1b, 0xd0, 0x82, 0xad, 0xeb, 0x9f, 0xd9, 0x4e, 0x37, 0x14, 0xd8, 0x0e, 0x44, 0x95, 0x0a, 0x05,
-	0x86, 0x1a, 0x5a, 0xba, 0xba, 0xf3, 0xd3, 0xbf, 0x7c, 0x6a, 0x28, 0x1f, 0x3f, 0x35, 0x94, 0x7f,
-	0x7e, 0x6a, 0x28, 0xbf, 0xff, 0xdc, 0x98, 0xf8, 0xf8, 0xb9, 0x31, 0xf1, 0xf7, 0xcf, 0x8d, 0x89,
-	0x5f, 0x7d, 0xb7, 0x6b, 0x07, 0xa7, 0x83, 0xe3, 0x0d, 0xd3, 0xed, 0x6f, 0x7a, 0xb6, 0xd3, 0x35,
-	0x0d, 0x6f, 0x33, 0xb0, 0x4d, 0xcb, 0xdc, 0x4c, 0xc5, 0xd4, 0x71, 0x59, 0xfc, 0xc5, 0x7f, 0xf0,
-	0xff, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc2, 0x4a, 0x16, 0x30, 0xe4, 0x17, 0x00, 0x00,
+	// 2174 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x19, 0x4d, 0x6f, 0x1c, 0x49,
+	0xd5, 0xdd, 0x3d, 0x1e, 0xcf, 0xbc, 0xf1, 0xc7, 0xa4, 0x1c, 0x27, 0x93, 0x38, 0x71, 0x9c, 0x06,
+	0x81, 0xf1, 0x2e, 0x8e, 0xe2, 0x24, 0x5a, 0x58, 0x58, 0x16, 0x7b, 0x9c, 0xdd, 0x35, 0x21, 0x5e,
+	0xab, 0xec, 0x55, 0x58, 0x2e, 0xa3, 0x72, 0x77, 0x79, 0xdc, 0x72, 0x7f, 0xb9, 0xaa, 0x27, 0x89,
+	0x23, 0xc1, 0x05, 0x8e, 0x1c, 0x38, 0x71, 0xe2, 0xb2, 0x47, 0xd8, 0x1f, 0x02, 0xc7, 0x3d, 0x01,
+	0x12, 0x17, 0x94, 0x88, 0x3f, 0x00, 0x07, 0x4e, 0x48, 0xa8, 0xaa, 0xba, 0xfa, 0x63, 0xa6, 0xfd,
+	0x91, 0xb5, 0xc5, 0x69, 0xea, 0xbd, 0x7a, 0xef, 0xd5, 0x7b, 0xaf, 0xdf, 0x57, 0xd5, 0xc0, 0xfc,
+	0x01, 0x25, 0x2c, 0xd9, 0xa3, 0x24, 0x89, 0xf7, 0xee, 0x65, 0xeb, 0x95, 0x98, 0x45, 0x49, 0x84,
+	0x5a, 0x85, 0x4d, 0xfb, 0x73, 0x68, 0xee, 0x92, 0x3d, 0x9f, 0xee, 0xc4, 0x24, 0x44, 0x1d, 0x98,
+	0x90, 0xc0, 0xe6, 0x46, 0xc7, 0x58, 0x34, 0x96, 0x2c, 0xac, 0x41, 0x74, 0x13, 0x1a, 0x3b, 0x09,
+	0x61, 0xc9, 0x13, 0x7a, 0xdc, 0x31, 0x17, 0x8d, 0xa5, 0x49, 0x9c, 0xc1, 0xe8, 0x1a, 0xd4, 0x1f,
+	0x87, 0xae, 0xd8, 0xb1, 0xe4, 0x4e, 0x0a, 0xd9, 0x5f, 0x5a, 0xd0, 0xfe, 0x44, 0x1c, 0xb5, 0x4e,
+	0x49, 0x82, 0xe9, 0xd1, 0x80, 0xf2, 0x04, 0x7d, 0x00, 0x93, 0xce, 0x01, 0x09, 0xfb, 0x74, 0x9f,
+	0x52, 0x37, 0x3d, 0xa7, 0xb5, 0x7a, 0x63, 0xa5, 0xa0, 0xd3, 0x4a, 0xb7, 0x40, 0x80, 0x4b, 0xe4,
+	0xe8, 0x21, 0x34, 0x5f, 0x90, 0x84, 0xb2, 0x80, 0xb0, 0x43, 0xa9, 0x48, 0x6b, 0xf5, 0x5a, 0x89,
+	0xf7, 0x99, 0xde, 0xc5, 0x39, 0x21, 0xfa, 0x1e, 0x34, 0x78, 0x42, 0x92, 0x01, 0xa7, 0xbc, 0x63,
+	0x2d, 0x5a, 0x4b, 0xad, 0xd5, 0x5b, 0x25, 0xa6, 0xcc, 0x03, 0x3b, 0x92, 0x0a, 0x67, 0xd4, 0x68,
+	0x09, 0x66, 0x9c, 0x28, 0x88, 0xa9, 0x4f, 0x13, 0xaa, 0x36, 0x3b, 0xb5, 0x45, 0x63, 0xa9, 0x81,
+	0x87, 0xd1, 0xe8, 0x1d, 0xb0, 0x28, 0x63, 0x9d, 0xf1, 0x0a, 0x7b, 0xf0, 0x20, 0x0c, 0xbd, 0xb0,
+	0xff, 0x98, 0xb1, 0x88, 0x61, 0x41, 0x85, 0xde, 0x87, 0x56, 0x40, 0x83, 0x88, 0x1d, 0x7f, 0xc6,
+	0x49, 0x9f, 0x76, 0xea, 0x92, 0xa9, 0x53, 0x62, 0x7a, 0x9a, 0xef, 0xe3, 0x22, 0x31, 0x7a, 0x00,
+	0x13, 0x2f, 0x08, 0x13, 0x02, 0x3b, 0x13, 0x67, 0x1d, 0xa6, 0x29, 0xd1, 0x22, 0xb4, 0x9c, 0x28,
+	0x08, 0xbc, 0x24, 0xa1, 0xee, 0x2e, 0xef, 0x34, 0x16, 0x8d, 0xa5, 0x1a, 0x2e, 0xa2, 0x6c, 0x02,
+	0xcd, 0xcc, 0x77, 0xc8, 0x16, 0x5f, 0x89, 0x3a, 0x87, 0x71, 0xe4, 0x85, 0xc9, 0x2e, 0x97, 0x5f,
+	0xa9, 0x86, 0x4b, 0x38, 0xb4, 0x00, 0xc0, 0x28, 0x8f, 0xfc, 0xe7, 0x52, 0xa2, 0x29, 0x29, 0x0a,
+	0x18, 0xd4, 0x06, 0x8b, 0xd3, 0x23, 0x19, 0x13, 0x35, 0x2c, 0x96, 0xf6, 0x2f, 0xa0, 0xbd, 0xe1,
+	0xf1, 0x98, 0x24, 0xce, 0x01, 0x65, 0x6b, 0x4e, 0xe2, 0x45, 0x21, 0x7a, 0x07, 0xea, 0x44, 0xae,
+	0xe4, 0x19, 0xd3, 0xab, 0xb3, 0x25, 0x63, 0x14, 0x11, 0x4e, 0x49, 0x44, 0x14, 0x76, 0xa5, 0xca,
+	0xd9, 0x81, 0x19, 0x2c, 0x2c, 0xdc, 0xe4, 0x3b, 0xc7, 0xa1, 0xb3, 0x2d, 0xf4, 0x93, 0xc7, 0x36,
+	0x70, 0x11, 0x65, 0x77, 0xc1, 0x5a, 0xeb, 0x3e, 0x29, 0x09, 0x31, 0x4e, 0x17, 0x62, 0x8e, 0x0a,
+	0xf9, 0x95, 0x09, 0x73, 0x9b, 0xe1, 0xbe, 0x3f, 0xa0, 0xa1, 0x43, 0xdd, 0xdc, 0x1c, 0x8e, 0x7e,
+	0x0c, 0x53, 0xd9, 0xc6, 0xee, 0x71, 0x4c, 0x53, 0x83, 0x6e, 0x96, 0x0c, 0x2a, 0x51, 0xe0, 0x32,
+	0x03, 0xfa, 0x10, 0xa6, 0x72, 0x81, 0x9b, 0x1b, 0xc2, 0x46, 0x6b, 0xe4, 0xfb, 0x16, 0x29, 0x70,
+	0x99, 0x5e, 0x66, 0xa9, 0x73, 0x40, 0x03, 0xb2, 0xb9, 0x21, 0x1d, 0x60, 0xe1, 0x0c, 0x46, 0x4f,
+	0x60, 0x96, 0xbe, 0x74, 0xfc, 0x81, 0x4b, 0x0b, 0x3c, 0xae, 0x8c, 0xe6, 0x53, 0x8f, 0xa8, 0xe2,
+	0xb2, 0xff, 0x64, 0x14, 0x3f, 0x65, 0x9a, 0x01, 0x3f, 0x83, 0x39, 0xaf, 0xca, 0x33, 0x69, 0x8e,
+	0xdb, 0xd5, 0x8e, 0x28, 0x52, 0xe2, 0x6a, 0x01, 0xe8, 0x51, 0x16, 0x24, 0x2a, 0xe5, 0x6f, 0x9f,
+	0xa0, 0xee, 0x50, 0xb8, 0xd8, 0x60, 0x11, 0xe7, 0x50, 0x7a, 0xa2, 0xb5, 0xda, 0x2e, 0x07, 0x56,
+	0xf7, 0x09, 0x16, 0x9b, 0xf6, 0x17, 0x06, 0x5c, 0x29, 0x14, 0x29, 0x1e, 0x47, 0x21, 0xa7, 0x17,
+	0xad, 0x52, 0x4f, 0x01, 0xb9, 0x43, 0xde, 0xa1, 0xfa, 0x6b, 0x9e, 0xa4, 0xbb, 0x22, 0xc3, 0x15,
+	0x8c, 0xf6, 0xef, 0x0c, 0x98, 0xed, 0x16, 0x52, 0xef, 0x29, 0xe5, 0xb2, 0x12, 0x5c, 0x50, 0xcb,
+	0xe1, 0x24, 0x37, 0x2b, 0x92, 0xfc, 0x16, 0x34, 0xf7, 0xfd, 0x01, 0x3f, 0x90, 0x39, 0xae, 0x52,
+	0x39, 0x47, 0xd8, 0x7f, 0x2d, 0x85, 0x41, 0x37, 0x0a, 0xf7, 0xbd, 0x3e, 0x5a, 0x86, 0x1a, 0x8f,
+	0x49, 0xd8, 0x31, 0x2a, 0xaa, 0x73, 0x56, 0x68, 0x71, 0x8d, 0xa7, 0x0d, 0x87, 0x8b, 0x36, 0x92,
+	0x9d, 0xae, 0x41, 0x61, 0x9b, 0x5b, 0x08, 0xc3, 0x8e, 0x55, 0x61, 0x5b, 0x29, 0x4e, 0x4b, 0xe4,
+	0x22, 0x13, 0xb8, 0xce, 0x84, 0x9a, 0xca, 0x04, 0x0d, 0x23, 0x1b, 0xa6, 0x9c, 0x01, 0x63, 0x34,
+	0x4c, 0x7a, 0xb1, 0xdb, 0x4b, 0x78, 0x67, 0x3c, 0xad, 0x86, 0x0a, 0xb9, 0x2d, 0x2c, 0xfb, 0x8b,
+	0x01, 0x37, 0x44, 0xea, 0xb8, 0x03, 0xbf, 0x10, 0xf9, 0x97, 0xd4, 0xc4, 0x1e, 0x41, 0xdd, 0x91,
+	0xbe, 0x3a, 0x23, 0x9c, 0x95, 0x43, 0x71, 0x4a, 0x8c, 0xba, 0x30, 0xcd, 0x53, 0x95, 0x54, 0xa0,
+	0x4b, 0xa7, 0x4c, 0xaf, 0xce, 0x97, 0xd8, 0x77, 0x4a, 0x24, 0x78, 0x88, 0xc5, 0xfe, 0xb5, 0x01,
+	0xb3, 0x4f, 0x89, 0x17, 0x26, 0xc4, 0x0b, 0x29, 0xfb, 0x44, 0x33, 0xa2, 0xef, 0x17, 0x5a, 0xa4,
+	0x51, 0x11, 0xa8, 0x39, 0xcf, 0x48, 0x8f, 0x5c, 0x85, 0x66, 0x18, 0xb9, 0xb4, 0xe7, 0x47, 0xc4,
+	0x4d, 0x2d, 0x9a, 0x2b, 0xf1, 0x6e, 0x45, 0x2e, 0xfd, 0x69, 0x44, 0x5c, 0xdc, 0x08, 0xd3, 0x95,
+	0xfd, 0x65, 0x0d, 0xda, 0xc3, 0x22, 0x2f, 0xea, 0xd6, 0xdb, 0x00, 0x62, 0xd5, 0x13, 0x8a, 0x51,
+	0xa9, 0x48, 0x13, 0x37, 0x05, 0x46, 0x88, 0xa7, 0xe8, 0x3e, 0x8c, 0xab, 0x9d, 0x2a, 0xaf, 0x75,
+	0xa3, 0x20, 0x8e, 0x42, 0x1a, 0x26, 0x92, 0x16, 0x2b, 0x4a, 0xf4, 0x0d, 0x98, 0xca, 0xb3, 0x41,
+	0x44, 0x4a, 0xad, 0x22, 0x45, 0xb2, 0xc6, 0x6f, 0x9d, 0xa3, 0xf1, 0xdf, 0x87, 0x39, 0xfa, 0x5c,
+	0x44, 0x1e, 0xf7, 0x5e, 0xd1, 0x5e, 0x4c, 0x59, 0x8f, 0x53, 0x27, 0x0a, 0x5d, 0x39, 0x02, 0x98,
+	0x18, 0xc9, 0xcd, 0x1d, 0xef, 0x15, 0xdd, 0xa6, 0x6c, 0x47, 0xee, 0xa0, 0x07, 0x30, 0x17, 0x78,
+	0x3c, 0xf6, 0x89, 0x43, 0xdd, 0x9e, 0x5b, 0x28, 0xab, 0xa2, 0xfb, 0x8f, 0xe3, 0xab, 0xd9, 0x66,
+	0xb1, 0x62, 0xfe, 0x00, 0x26, 0xd5, 0xcc, 0xd0, 0x1b, 0xc8, 0x09, 0xa3, 0xf1, 0x35, 0x27, 0x8c,
+	0xe6, 0x59, 0x56, 0x69, 0x4a, 0xf4, 0x2d, 0x98, 0x49, 0x44, 0x76, 0xf7, 0x18, 0x8d, 0x7d, 0x72,
+	0xdc, 0xf3, 0xdc, 0x0e, 0x48, 0x6f, 0x4d, 0x49, 0x34, 0x96, 0xd8, 0x4d, 0x17, 0xad, 0xc2, 0x5c,
+	0x89, 0x6e, 0xdf, 0x0b, 0x3d, 0x51, 0x4e, 0x3a, 0x2d, 0xd9, 0x6c, 0x67, 0x0b, 0xd4, 0x1f, 0xa5,
+	0x5b, 0xf6, 0x4f, 0xa0, 0xa1, 0x63, 0x08, 0xcd, 0x43, 0xd3, 0x89, 0x07, 0xa9, 0x59, 0x86, 0xf4,
+	0x5a, 0xc3, 0x89, 0x07, 0x4a, 0xf3, 0xbb, 0x43, 0x66, 0x9b, 0x72, 0xbf, 0x68, 0x9c, 0xfd, 0x1e,
+	0xcc, 0x77, 0xa3, 0x88, 0xb9, 0x5e, 0x48, 0x92, 0x88, 0xad, 0x47, 0x51, 0xc2, 0x13, 0x46, 0x62,
+	0x9d, 0xda, 0x1d, 0x98, 0x78, 0x4e, 0x19, 0xd7, 0x03, 0x89, 0x85, 0x35, 0x68, 0x7f, 0x0e, 0xb7,
+	0xaa, 0x19, 0xd3, 0x9e, 0xf1, 0xf5, 0x33, 0xc8, 0xfe, 0x25, 0x5c, 0x5d, 0x73, 0xdd, 0x9c, 0x40,
+	0x2b, 0xf3, 0x1d, 0x30, 0x3d, 0xf7, 0xec, 0x34, 0x30, 0x3d, 0x57, 0x0c, 0xe1, 0x85, 0x9a, 0x32,
+	0x99, 0x15, 0x8d, 0x91, 0x10, 0xb6, 0x46, 0x43, 0xd8, 0x7e, 0x09, 0xd7, 0x31, 0x0d, 0xa2, 0xe7,
+	0xf4, 0x42, 0x2a, 0x74, 0x60, 0xc2, 0x21, 0xdc, 0x21, 0x2e, 0x4d, 0x07, 0x27, 0x0d, 0x8a, 0x1d,
+	0x26, 0xe5, 0xbb, 0xe9, 0x5c, 0xa6, 0x41, 0xfb, 0xef, 0x86, 0x38, 0x9a, 0xd3, 0xa4, 0x38, 0x05,
+	0x5c, 0x4e, 0x95, 0x7d, 0x17, 0xc6, 0x45, 0x8f, 0xd1, 0x7d, 0xf7, 0xa4, 0x46, 0xa4, 0x88, 0xd0,
+	0x0d, 0x68, 0x30, 0xa1, 0x47, 0xee, 0xa2, 0x09, 0x09, 0xef, 0x72, 0x11, 0x71, 0x79, 0x4c, 0xab,
+	0x0a, 0xd0, 0x60, 0x3a, 0x9c, 0xe7, 0xa1, 0x99, 0x10, 0xd6, 0xa7, 0x49, 0xde, 0x48, 0x1a, 0x0a,
+	0xb1, 0xcb, 0xed, 0x7f, 0x1b, 0x70, 0x33, 0x77, 0xe9, 0x48, 0xac, 0x5d, 0xd0, 0xc0, 0x93, 0x3e,
+	0xf9, 0x0d, 0x19, 0x88, 0xac, 0x68, 0x8a, 0xee, 0xaa, 0x0e, 0xdc, 0x55, 0xc9, 0x97, 0x30, 0xaf,
+	0xdf, 0xa7, 0xac, 0xa7, 0x8a, 0x51, 0x5e, 0x55, 0xb4, 0x89, 0xa7, 0xb6, 0xda, 0xdb, 0x52, 0xc6,
+	0xae, 0x12, 0xf1, 0x58, 0x48, 0x28, 0x0d, 0x87, 0xff, 0x34, 0x60, 0xbe, 0xd2, 0xea, 0xcb, 0x19,
+	0xae, 0x1e, 0x95, 0xbf, 0xeb, 0x9d, 0x12, 0x5f, 0x76, 0xda, 0xc8, 0x07, 0x4e, 0xcb, 0xb4, 0x75,
+	0xae, 0xfb, 0xd9, 0x79, 0x0a, 0xbf, 0xfd, 0x1f, 0x03, 0x16, 0x72, 0x3b, 0xb7, 0x23, 0x9e, 0x5c,
+	0xf6, 0x17, 0x3e, 0xd7, 0xe7, 0x32, 0x2f, 0xf6, 0xb9, 0xd0, 0x7d, 0x98, 0x50, 0xa3, 0x91, 0xbe,
+	0x1b, 0x5f, 0x1f, 0x99, 0x27, 0x02, 0xb2, 0x19, 0xee, 0x47, 0x58, 0xd3, 0xd9, 0xff, 0x32, 0xe0,
+	0xce, 0x89, 0x96, 0x5f, 0xce, 0x57, 0xfe, 0xbf, 0x98, 0xfe, 0x36, 0x31, 0x61, 0xbf, 0x04, 0xc8,
+	0x7d, 0x51, 0xba, 0x6a, 0x19, 0x43, 0x57, 0xad, 0x05, 0x4d, 0xb9, 0x45, 0x02, 0x3d, 0x88, 0x14,
+	0x30, 0x68, 0x05, 0xea, 0x32, 0x3c, 0xb5, 0xc3, 0x2b, 0x4a, 0x93, 0xf4, 0x77, 0x4a, 0x65, 0x77,
+	0xa1, 0x99, 0x21, 0x4f, 0x79, 0xa3, 0xb9, 0x95, 0x92, 0x15, 0x4e, 0xcd, 0x11, 0xf6, 0x1f, 0x4c,
+	0x40, 0xa3, 0xd9, 0x21, 0xea, 0xfb, 0x09, 0x1f, 0xa7, 0xe4, 0x48, 0x33, 0x7d, 0x03, 0xd2, 0x26,
+	0x9b, 0x43, 0x26, 0xeb, 0xa1, 0xdf, 0x3a, 0xc7, 0xd0, 0xff, 0x11, 0xb4, 0x1d, 0x3d, 0x6e, 0xf5,
+	0x78, 0xfe, 0xa8, 0x72, 0xc6, 0x4c, 0x36, 0xe3, 0x14, 0xe1, 0x01, 0x1f, 0x4d, 0xd2, 0xf1, 0x8a,
+	0xe9, 0xec, 0x01, 0xb4, 0xf6, 0xfc, 0xc8, 0x39, 0x4c, 0xa7, 0x42, 0xf5, 0xd2, 0x82, 0xca, 0x11,
+	0x2e, 0xc5, 0x83, 0x24, 0x93, 0x6b, 0xfb, 0x08, 0xae, 0xe5, 0xe1, 0xdd, 0xf5, 0x23, 0x4e, 0x2f,
+	0x29, 0xa1, 0x0b, 0x8d, 0xd0, 0x2c, 0x37, 0x42, 0x06, 0xd7, 0x47, 0x8e, 0xbc, 0x9c, 0x4c, 0x12,
+	0x77, 0xac, 0x81, 0xe3, 0x50, 0xce, 0xf5, 0x99, 0x29, 0x68, 0xff, 0xc6, 0x80, 0x76, 0x7e, 0x0f,
+	0x57, 0xc1, 0x76, 0x09, 0xcf, 0x18, 0x37, 0xa1, 0x91, 0x86, 0xa4, 0xaa, 0xd1, 0x16, 0xce, 0xe0,
+	0xd3, 0x5e, 0x28, 0xec, 0x0f, 0x60, 0x5c, 0xd2, 0x9d, 0xf1, 0x0c, 0x79, 0x42, 0x08, 0xda, 0x21,
+	0x4c, 0xeb, 0xb5, 0xf2, 0xc6, 0x29, 0x72, 0x16, 0xa1, 0xf5, 0xa9, 0xef, 0x0e, 0x89, 0x2a, 0xa2,
+	0x04, 0xc5, 0x16, 0x7d, 0x31, 0xa4, 0x6b, 0x11, 0x65, 0x7f, 0x61, 0xc1, 0xb8, 0xba, 0x59, 0xdc,
+	0x82, 0xe6, 0x26, 0x5f, 0x17, 0xe1, 0x43, 0xd5, 0xa8, 0xd4, 0xc0, 0x39, 0x42, 0x68, 0x21, 0x97,
+	0xf9, 0x1d, 0x37, 0x05, 0xd1, 0x87, 0xd0, 0x52, 0x4b, 0x5d, 0x0c, 0x46, 0x2f, 0x83, 0xc3, 0x9f,
+	0x07, 0x17, 0x39, 0xd0, 0x13, 0xb8, 0xb2, 0x45, 0xa9, 0xbb, 0xc1, 0xa2, 0x38, 0xd6, 0x14, 0x9d,
+	0xda, 0x79, 0xc4, 0x8c, 0xf2, 0xa1, 0x1f, 0xc2, 0x8c, 0x40, 0xae, 0xb9, 0x6e, 0x26, 0x4a, 0xdd,
+	0x69, 0xd0, 0x68, 0x36, 0xe3, 0x61, 0x52, 0x71, 0x39, 0xfd, 0x2c, 0x76, 0x49, 0x42, 0x53, 0x17,
+	0xf2, 0x4e, 0x5d, 0x32, 0xcf, 0x57, 0x35, 0x93, 0xf4, 0x03, 0xe1, 0x21, 0x96, 0xe1, 0xe7, 0xb7,
+	0x89, 0x91, 0xe7, 0x37, 0xf4, 0x5d, 0x79, 0x89, 0x4b, 0x2f, 0x34, 0xd3, 0x43, 0xad, 0x6a, 0x3d,
+	0xcd, 0xe0, 0xbe, 0xba, 0xc0, 0xf5, 0xa9, 0x7d, 0x08, 0x57, 0xb3, 0xea, 0xa3, 0x77, 0x45, 0xe9,
+	0x78, 0x8b, 0xaa, 0xb7, 0xa4, 0xaf, 0x8d, 0xe6, 0x89, 0xa5, 0x43, 0x11, 0xd8, 0xff, 0x35, 0x60,
+	0x66, 0xe8, 0x25, 0xf9, 0x6d, 0x0e, 0xaa, 0x2a, 0x8b, 0xe6, 0x65, 0x94, 0xc5, 0x8a, 0x89, 0xff,
+	0xe4, 0x7b, 0x68, 0xed, 0xc4, 0x7b, 0xe8, 0x1d, 0x68, 0xa9, 0xa9, 0x97, 0x16, 0x1e, 0x4d, 0x40,
+	0xa3, 0x76, 0xb9, 0xfd, 0x7b, 0x03, 0x50, 0xc1, 0xc9, 0x97, 0x54, 0x32, 0x3f, 0x86, 0xa9, 0xbd,
+	0x5c, 0x68, 0xf6, 0x8c, 0x76, 0xb7, 0xba, 0xc5, 0x14, 0xcf, 0x2f, 0xf3, 0xd9, 0xaf, 0x60, 0xb2,
+	0xd8, 0xd4, 0x11, 0x82, 0x5a, 0xe2, 0x05, 0xaa, 0xbe, 0x35, 0xb1, 0x5c, 0x0b, 0x9c, 0x78, 0xa2,
+	0x48, 0xbb, 0xa7, 0x5c, 0x0b, 0x9c, 0x23, 0x70, 0x96, 0xc2, 0x89, 0xb5, 0xc8, 0xe9, 0x40, 0x3d,
+	0xc2, 0x49, 0x87, 0x35, 0xb1, 0x06, 0xd1, 0x55, 0x18, 0x97, 0x33, 0x87, 0xf4, 0x4f, 0x13, 0x2b,
+	0xc0, 0x7e, 0x08, 0x93, 0xc5, 0xef, 0x2d, 0x64, 0x1e, 0x78, 0xfd, 0x83, 0xf4, 0xfd, 0x59, 0xae,
+	0xc5, 0x7b, 0xb9, 0x1f, 0xbd, 0x48, 0x6b, 0x84, 0x58, 0xda, 0xfb, 0x30, 0x59, 0x74, 0xcc, 0xf9,
+	0xb8, 0xa4, 0x0d, 0x24, 0xc8, 0xf4, 0x15, 0x6b, 0x51, 0xa1, 0xc4, 0x2f, 0x8f, 0x89, 0xa3, 0x35,
+	0xce, 0x11, 0xf6, 0x1f, 0x0d, 0x68, 0x15, 0x1e, 0x03, 0x84, 0x0d, 0x47, 0x83, 0x28, 0x21, 0xe9,
+	0x41, 0x0a, 0x40, 0xdf, 0x86, 0x19, 0x15, 0x32, 0x4e, 0xe4, 0xfb, 0xd4, 0x49, 0x22, 0x96, 0x9e,
+	0x3a, 0x2d, 0xd1, 0x5d, 0x8d, 0x15, 0x0a, 0x70, 0x2f, 0x3c, 0x4c, 0xe3, 0x4e, 0xae, 0x85, 0xc3,
+	0x68, 0x28, 0x5c, 0xc7, 0xd2, 0x51, 0x5a, 0x83, 0x68, 0x05, 0x66, 0x83, 0x63, 0x7e, 0xe4, 0xf7,
+	0x62, 0x46, 0x63, 0xc2, 0xc4, 0x1b, 0x47, 0xe0, 0xeb, 0xf0, 0xba, 0x22, 0xb7, 0xb6, 0xd3, 0x9d,
+	0x8d, 0xc0, 0xe7, 0xcb, 0xb7, 0xa1, 0x9e, 0xfe, 0x75, 0xd0, 0x84, 0xf1, 0x67, 0xcc, 0x4b, 0x68,
+	0x7b, 0x0c, 0x35, 0xa0, 0xb6, 0x4d, 0x38, 0x6f, 0x1b, 0xcb, 0x0f, 0x55, 0x17, 0xc8, 0x5f, 0xbc,
+	0x10, 0x40, 0xbd, 0xcb, 0x28, 0x91, 0x74, 0x00, 0x75, 0x75, 0xd1, 0x6d, 0x1b, 0x6a, 0x2d, 0x82,
+	0xb7, 0x6d, 0x2e, 0xbf, 0x0f, 0x90, 0x17, 0x0f, 0x21, 0x6d, 0xeb, 0xd3, 0xad, 0xc7, 0xed, 0x31,
+	0xd4, 0x82, 0x89, 0x67, 0x6b, 0x9b, 0xbb, 0x9b, 0x5b, 0x1f, 0xb7, 0x0d, 0x09, 0x60, 0x05, 0x98,
+	0x82, 0x66, 0x43, 0xd0, 0x58, 0xcb, 0xef, 0x0e, 0x35, 0x4c, 0x34, 0x01, 0xd6, 0x9a, 0xef, 0xb7,
+	0xc7, 0x50, 0x1d, 0xcc, 0x8d, 0x75, 0x75, 0xd2, 0x56, 0xc4, 0x02, 0xe2, 0xb7, 0xcd, 0xe5, 0xf7,
+	0x60, 0xba, 0x9c, 0xc0, 0x52, 0x6c, 0xc4, 0x0e, 0xbd, 0xb0, 0xaf, 0x0e, 0xdc, 0x49, 0x64, 0x55,
+	0x56, 0x07, 0x2a, 0x6d, 0xdd, 0xb6, 0xb9, 0xfe, 0xa3, 0x3f, 0xbf, 0x5e, 0x30, 0xbe, 0x7a, 0xbd,
+	0x60, 0xfc, 0xe3, 0xf5, 0x82, 0xf1, 0xdb, 0x37, 0x0b, 0x63, 0x5f, 0xbd, 0x59, 0x18, 0xfb, 0xdb,
+	0x9b, 0x85, 0xb1, 0x9f, 0x7f, 0xb3, 0xef, 0x25, 0x07, 0x83, 0xbd, 0x15, 0x27, 0x0a, 0xee, 0xc5,
+	0x5e, 0xd8, 0x77, 0x48, 0x7c, 0x2f, 0xf1, 0x1c, 0xd7, 0xb9, 0x57, 0x48, 0x91, 0xbd, 0xba, 0xfc,
+	0xf3, 0xef, 0xc1, 0xff, 0x06, 0x00, 0xd1, 0x5c, 0xbe, 0xeb, 0x1b, 0x1c, 0x00, 0x00,
 }
 
 func (m *TableSpan) Marshal() (dAtA []byte, err error) {
@@ -2590,9 +2951,14 @@ func (m *HeartBeatRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if m.Err != nil {
+	if m.CommittedTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CommittedTs))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Warning != nil {
 		{
-			size, err := m.Err.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Warning.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -2600,17 +2966,41 @@ func (m *HeartBeatRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x2a
+		dAtA[i] = 0x3a
 	}
-	if m.CompeleteStatus {
-		i--
-		if m.CompeleteStatus {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	if m.MemoryUsage != nil {
+		{
+			size, err := m.MemoryUsage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x20
+		dAtA[i] = 0x32
+	}
+	if m.Err != nil {
+		{
+			size, err := m.Err.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.CompeleteStatus {
+		i--
+		if m.CompeleteStatus {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
 	}
 	if len(m.Statuses) > 0 {
 		for iNdEx := len(m.Statuses) - 1; iNdEx >= 0; iNdEx-- {
@@ -2959,6 +3349,11 @@ func (m *CheckpointTsMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.FlushedTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.FlushedTs))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.CheckpointTs != 0 {
 		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CheckpointTs))
 		i--
@@ -3113,6 +3508,18 @@ func (m *MaintainerHeartbeat) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.NodeLoad != nil {
+		{
+			size, err := m.NodeLoad.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Statuses) > 0 {
 		for iNdEx := len(m.Statuses) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3150,6 +3557,58 @@ func (m *MaintainerStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.TableReplayFinished {
+		i--
+		if m.TableReplayFinished {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.TableReplayId != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.TableReplayId))
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.Warning) > 0 {
+		for iNdEx := len(m.Warning) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Warning[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if m.MemoryUsage != nil {
+		{
+			size, err := m.MemoryUsage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.MisplacedDispatchers != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MisplacedDispatchers))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.EventSizePerSecond != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.EventSizePerSecond))))
+		i--
+		dAtA[i] = 0x35
+	}
 	if len(m.Err) > 0 {
 		for iNdEx := len(m.Err) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3196,6 +3655,41 @@ func (m *MaintainerStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *NodeLoad) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeLoad) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodeLoad) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MemoryUsage != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.MemoryUsage))))
+		i--
+		dAtA[i] = 0x15
+	}
+	if m.CpuUsage != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.CpuUsage))))
+		i--
+		dAtA[i] = 0xd
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *CoordinatorBootstrapRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3363,6 +3857,70 @@ func (m *RemoveMaintainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *ResetDispatchersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResetDispatchersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResetDispatchersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TargetTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.TargetTs))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ReplayId != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ReplayId))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ResetTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ResetTs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Spans) > 0 {
+		for iNdEx := len(m.Spans) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Spans[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *MaintainerBootstrapRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3882,21 +4440,21 @@ func (m *InfluencedTables) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x18
 	}
 	if len(m.TableIDs) > 0 {
-		dAtA32 := make([]byte, len(m.TableIDs)*10)
-		var j31 int
+		dAtA34 := make([]byte, len(m.TableIDs)*10)
+		var j33 int
 		for _, num1 := range m.TableIDs {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA32[j31] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA34[j33] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j31++
+				j33++
 			}
-			dAtA32[j31] = uint8(num)
-			j31++
+			dAtA34[j33] = uint8(num)
+			j33++
 		}
-		i -= j31
-		copy(dAtA[i:], dAtA32[:j31])
-		i = encodeVarintHeartbeat(dAtA, i, uint64(j31))
+		i -= j33
+		copy(dAtA[i:], dAtA34[:j33])
+		i = encodeVarintHeartbeat(dAtA, i, uint64(j33))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -4151,6 +4709,11 @@ func (m *TableSpanStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ReplayedTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ReplayedTs))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.EventSizePerSecond != 0 {
 		i -= 4
 		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.EventSizePerSecond))))
@@ -4251,6 +4814,13 @@ func (m *RunningError) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Table) > 0 {
+		i -= len(m.Table)
+		copy(dAtA[i:], m.Table)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Table)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Message) > 0 {
 		i -= len(m.Message)
 		copy(dAtA[i:], m.Message)
@@ -4362,6 +4932,54 @@ func (m *ChangefeedID) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MemoryUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemoryUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemoryUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MysqlPreparedDmls != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MysqlPreparedDmls))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Encoder != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.Encoder))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Sink != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.Sink))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.EventCollector != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.EventCollector))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Quota != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.Quota))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintHeartbeat(dAtA []byte, offset int, v uint64) int {
 	offset -= sovHeartbeat(v)
 	base := offset
@@ -4420,6 +5038,17 @@ func (m *HeartBeatRequest) Size() (n int) {
 		l = m.Err.Size()
 		n += 1 + l + sovHeartbeat(uint64(l))
 	}
+	if m.MemoryUsage != nil {
+		l = m.MemoryUsage.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.Warning != nil {
+		l = m.Warning.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.CommittedTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.CommittedTs))
+	}
 	return n
 }
 
@@ -4552,6 +5181,9 @@ func (m *CheckpointTsMessage) Size() (n int) {
 	if m.CheckpointTs != 0 {
 		n += 1 + sovHeartbeat(uint64(m.CheckpointTs))
 	}
+	if m.FlushedTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.FlushedTs))
+	}
 	return n
 }
 
@@ -4613,6 +5245,10 @@ func (m *MaintainerHeartbeat) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
+	if m.NodeLoad != nil {
+		l = m.NodeLoad.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
 	return n
 }
 
@@ -4642,6 +5278,43 @@ func (m *MaintainerStatus) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
+	if m.EventSizePerSecond != 0 {
+		n += 5
+	}
+	if m.MisplacedDispatchers != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MisplacedDispatchers))
+	}
+	if m.MemoryUsage != nil {
+		l = m.MemoryUsage.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if len(m.Warning) > 0 {
+		for _, e := range m.Warning {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if m.TableReplayId != 0 {
+		n += 1 + sovHeartbeat(uint64(m.TableReplayId))
+	}
+	if m.TableReplayFinished {
+		n += 2
+	}
+	return n
+}
+
+func (m *NodeLoad) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CpuUsage != 0 {
+		n += 5
+	}
+	if m.MemoryUsage != 0 {
+		n += 5
+	}
 	return n
 }
 
@@ -4711,6 +5384,34 @@ func (m *RemoveMaintainerRequest) Size() (n int) {
 	return n
 }
 
+func (m *ResetDispatchersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangefeedID != nil {
+		l = m.ChangefeedID.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if len(m.Spans) > 0 {
+		for _, e := range m.Spans {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if m.ResetTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.ResetTs))
+	}
+	if m.ReplayId != 0 {
+		n += 1 + sovHeartbeat(uint64(m.ReplayId))
+	}
+	if m.TargetTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.TargetTs))
+	}
+	return n
+}
+
 func (m *MaintainerBootstrapRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5037,6 +5738,9 @@ func (m *TableSpanStatus) Size() (n int) {
 	if m.EventSizePerSecond != 0 {
 		n += 5
 	}
+	if m.ReplayedTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.ReplayedTs))
+	}
 	return n
 }
 
@@ -5081,6 +5785,10 @@ func (m *RunningError) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovHeartbeat(uint64(l))
 	}
+	l = len(m.Table)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
 	return n
 }
 
@@ -5122,11 +5830,35 @@ func (m *ChangefeedID) Size() (n int) {
 	return n
 }
 
-func sovHeartbeat(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozHeartbeat(x uint64) (n int) {
-	return sovHeartbeat(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *MemoryUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Quota != 0 {
+		n += 1 + sovHeartbeat(uint64(m.Quota))
+	}
+	if m.EventCollector != 0 {
+		n += 1 + sovHeartbeat(uint64(m.EventCollector))
+	}
+	if m.Sink != 0 {
+		n += 1 + sovHeartbeat(uint64(m.Sink))
+	}
+	if m.Encoder != 0 {
+		n += 1 + sovHeartbeat(uint64(m.Encoder))
+	}
+	if m.MysqlPreparedDmls != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MysqlPreparedDmls))
+	}
+	return n
+}
+
+func sovHeartbeat(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozHeartbeat(x uint64) (n int) {
+	return sovHeartbeat(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
 func (m *TableSpan) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
@@ -5456,6 +6188,97 @@ func (m *HeartBeatRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryUsage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MemoryUsage == nil {
+				m.MemoryUsage = &MemoryUsage{}
+			}
+			if err := m.MemoryUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Warning", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Warning == nil {
+				m.Warning = &RunningError{}
+			}
+			if err := m.Warning.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommittedTs", wireType)
+			}
+			m.CommittedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommittedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6301,6 +7124,25 @@ func (m *CheckpointTsMessage) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FlushedTs", wireType)
+			}
+			m.FlushedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FlushedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6705,6 +7547,42 @@ func (m *MaintainerHeartbeat) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeLoad", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NodeLoad == nil {
+				m.NodeLoad = &NodeLoad{}
+			}
+			if err := m.NodeLoad.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6895,6 +7773,217 @@ func (m *MaintainerStatus) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventSizePerSecond", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.EventSizePerSecond = float32(math.Float32frombits(v))
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MisplacedDispatchers", wireType)
+			}
+			m.MisplacedDispatchers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MisplacedDispatchers |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryUsage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MemoryUsage == nil {
+				m.MemoryUsage = &MemoryUsage{}
+			}
+			if err := m.MemoryUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Warning", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Warning = append(m.Warning, &RunningError{})
+			if err := m.Warning[len(m.Warning)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableReplayId", wireType)
+			}
+			m.TableReplayId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableReplayId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableReplayFinished", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TableReplayFinished = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NodeLoad) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NodeLoad: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NodeLoad: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuUsage", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.CpuUsage = float32(math.Float32frombits(v))
+		case 2:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryUsage", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.MemoryUsage = float32(math.Float32frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7167,12 +8256,137 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 			if m.Config == nil {
 				m.Config = []byte{}
 			}
-			iNdEx = postIndex
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+			}
+			m.CheckpointTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CheckpointTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveMaintainerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ChangefeedID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cascade", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Cascade = bool(v != 0)
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
 			}
-			m.CheckpointTs = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7182,11 +8396,12 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CheckpointTs |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Removed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7208,7 +8423,7 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
+func (m *ResetDispatchersRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7231,15 +8446,15 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveMaintainerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResetDispatchersRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResetDispatchersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7266,18 +8481,18 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ChangefeedID{}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cascade", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7287,17 +8502,31 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Cascade = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Spans = append(m.Spans, &TableSpan{})
+			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResetTs", wireType)
 			}
-			var v int
+			m.ResetTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7307,12 +8536,49 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.ResetTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplayId", wireType)
+			}
+			m.ReplayId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplayId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetTs", wireType)
+			}
+			m.TargetTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Removed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -9524,6 +10790,25 @@ func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
 			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
 			iNdEx += 4
 			m.EventSizePerSecond = float32(math.Float32frombits(v))
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplayedTs", wireType)
+			}
+			m.ReplayedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplayedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -9822,6 +11107,38 @@ func (m *RunningError) Unmarshal(dAtA []byte) error {
 			}
 			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Table", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Table = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -10083,6 +11400,151 @@ func (m *ChangefeedID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MemoryUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemoryUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemoryUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quota", wireType)
+			}
+			m.Quota = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Quota |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventCollector", wireType)
+			}
+			m.EventCollector = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventCollector |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sink", wireType)
+			}
+			m.Sink = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sink |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encoder", wireType)
+			}
+			m.Encoder = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Encoder |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MysqlPreparedDmls", wireType)
+			}
+			m.MysqlPreparedDmls = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MysqlPreparedDmls |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipHeartbeat(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
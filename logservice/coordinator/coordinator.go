@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/ticdc/logservice/logservicepb"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tiflow/pkg/chann"
@@ -42,6 +43,8 @@ type subscriptionState struct {
 	span         *heartbeatpb.TableSpan
 	checkpointTs uint64
 	resolvedTs   uint64
+	// the number of dispatchers served by the subscription
+	dispatcherCount uint64
 }
 
 type subscriptionStates []*subscriptionState // sorted by subID for easy update
@@ -50,6 +53,23 @@ type eventStoreState struct {
 	subscriptionStates map[int64]subscriptionStates
 }
 
+// subscriptionSharing is the cluster-level view of how the subscriptions are shared by dispatchers.
+type subscriptionSharing struct {
+	subscriptionCount int
+	dispatcherCount   int
+	// the number of subscriptions whose span is also subscribed by another subscription,
+	// they multiply the load of TiKV and can be shared in theory.
+	duplicateCount int
+}
+
+// ratio returns the average number of dispatchers served by a subscription.
+func (s subscriptionSharing) ratio() float64 {
+	if s.subscriptionCount == 0 {
+		return 0
+	}
+	return float64(s.dispatcherCount) / float64(s.subscriptionCount)
+}
+
 type requestAndTarget struct {
 	req    *logservicepb.ReusableEventServiceRequest
 	target node.ID
@@ -147,6 +167,7 @@ func (c *logCoordinator) handleNodeChange(allNodes map[node.ID]*node.Info) {
 	for id := range c.nodes.m {
 		if _, ok := allNodes[id]; !ok {
 			delete(c.nodes.m, id)
+			c.removeEventStoreState(id)
 			log.Info("log coordinaotr detect node removed", zap.String("nodeId", id.String()))
 		}
 	}
@@ -168,25 +189,69 @@ func (c *logCoordinator) updateEventStoreState(nodeId node.ID, state *logservice
 		subscriptionStates: make(map[int64]subscriptionStates),
 	}
 	count := 0
+	dispatcherCount := uint64(0)
 	for tableId, subscriptions := range state.GetSubscriptions() {
 		subs := subscriptions.GetSubscriptions()
 		subStates := make(subscriptionStates, 0, len(subs))
 		count += len(subs)
 		for _, subscription := range subs {
 			subscriptionState := &subscriptionState{
-				subID:        subscription.GetSubID(),
-				span:         subscription.GetSpan(),
-				checkpointTs: subscription.GetCheckpointTs(),
-				resolvedTs:   subscription.GetResolvedTs(),
+				subID:           subscription.GetSubID(),
+				span:            subscription.GetSpan(),
+				checkpointTs:    subscription.GetCheckpointTs(),
+				resolvedTs:      subscription.GetResolvedTs(),
+				dispatcherCount: subscription.GetDispatcherCount(),
 			}
+			dispatcherCount += subscriptionState.dispatcherCount
 			subStates = append(subStates, subscriptionState)
 		}
 		eventStoreState.subscriptionStates[tableId] = subStates
 	}
 	c.eventStoreStates.m[nodeId] = eventStoreState
+	metrics.LogCoordinatorSubscriptionCount.WithLabelValues(nodeId.String()).Set(float64(count))
+	metrics.LogCoordinatorDispatcherCount.WithLabelValues(nodeId.String()).Set(float64(dispatcherCount))
+	sharing := c.getSubscriptionSharing()
+	metrics.LogCoordinatorSubscriptionSharingRatio.Set(sharing.ratio())
+	metrics.LogCoordinatorDuplicateSubscriptionCount.Set(float64(sharing.duplicateCount))
 	log.Info("update event store state done",
 		zap.String("nodeId", nodeId.String()),
-		zap.Int("subscriptionCount", count))
+		zap.Int("subscriptionCount", count),
+		zap.Uint64("dispatcherCount", dispatcherCount),
+		zap.Float64("clusterSharingRatio", sharing.ratio()),
+		zap.Int("clusterDuplicateSubscriptionCount", sharing.duplicateCount))
+}
+
+func (c *logCoordinator) removeEventStoreState(nodeId node.ID) {
+	c.eventStoreStates.Lock()
+	defer c.eventStoreStates.Unlock()
+	delete(c.eventStoreStates.m, nodeId)
+	metrics.LogCoordinatorSubscriptionCount.DeleteLabelValues(nodeId.String())
+	metrics.LogCoordinatorDispatcherCount.DeleteLabelValues(nodeId.String())
+	sharing := c.getSubscriptionSharing()
+	metrics.LogCoordinatorSubscriptionSharingRatio.Set(sharing.ratio())
+	metrics.LogCoordinatorDuplicateSubscriptionCount.Set(float64(sharing.duplicateCount))
+}
+
+// getSubscriptionSharing returns how the subscriptions of all nodes are shared by dispatchers,
+// it must be called with eventStoreStates locked.
+func (c *logCoordinator) getSubscriptionSharing() subscriptionSharing {
+	var sharing subscriptionSharing
+	spanCount := make(map[string]int)
+	for _, state := range c.eventStoreStates.m {
+		for _, subStates := range state.subscriptionStates {
+			for _, subState := range subStates {
+				sharing.subscriptionCount++
+				sharing.dispatcherCount += int(subState.dispatcherCount)
+				spanCount[subState.span.String()]++
+			}
+		}
+	}
+	for _, count := range spanCount {
+		if count > 1 {
+			sharing.duplicateCount += count
+		}
+	}
+	return sharing
 }
 
 // getCandidateNode return all nodes(exclude the request node) which may contain data for `span` from `startTs`,
@@ -183,3 +183,50 @@ func TestGetCandidateNodes(t *testing.T) {
 		assert.Equal(t, []node.ID{nodeID2, nodeID1}, nodes)
 	}
 }
+
+func TestSubscriptionSharing(t *testing.T) {
+	coordinator := &logCoordinator{}
+	coordinator.eventStoreStates.m = make(map[node.ID]*eventStoreState)
+
+	nodeID1 := node.ID("node-1")
+	nodeID2 := node.ID("node-2")
+	span1 := &heartbeatpb.TableSpan{TableID: 100}
+	span1.StartKey, span1.EndKey = spanz.GetTableRange(span1.TableID)
+	span2 := &heartbeatpb.TableSpan{TableID: 101}
+	span2.StartKey, span2.EndKey = spanz.GetTableRange(span2.TableID)
+
+	coordinator.updateEventStoreState(nodeID1, &logservicepb.EventStoreState{
+		Subscriptions: map[int64]*logservicepb.SubscriptionStates{
+			span1.TableID: {Subscriptions: []*logservicepb.SubscriptionState{
+				{SubID: 1, Span: span1, DispatcherCount: 5},
+			}},
+			span2.TableID: {Subscriptions: []*logservicepb.SubscriptionState{
+				{SubID: 2, Span: span2, DispatcherCount: 1},
+			}},
+		},
+	})
+	sharing := coordinator.getSubscriptionSharing()
+	assert.Equal(t, 2, sharing.subscriptionCount)
+	assert.Equal(t, 6, sharing.dispatcherCount)
+	assert.Equal(t, 0, sharing.duplicateCount)
+	assert.Equal(t, float64(3), sharing.ratio())
+
+	// span1 is also subscribed by node2
+	coordinator.updateEventStoreState(nodeID2, &logservicepb.EventStoreState{
+		Subscriptions: map[int64]*logservicepb.SubscriptionStates{
+			span1.TableID: {Subscriptions: []*logservicepb.SubscriptionState{
+				{SubID: 1, Span: span1, DispatcherCount: 2},
+			}},
+		},
+	})
+	sharing = coordinator.getSubscriptionSharing()
+	assert.Equal(t, 3, sharing.subscriptionCount)
+	assert.Equal(t, 8, sharing.dispatcherCount)
+	assert.Equal(t, 2, sharing.duplicateCount)
+
+	coordinator.removeEventStoreState(nodeID2)
+	sharing = coordinator.getSubscriptionSharing()
+	assert.Equal(t, 2, sharing.subscriptionCount)
+	assert.Equal(t, 0, sharing.duplicateCount)
+	assert.Equal(t, float64(0), subscriptionSharing{}.ratio())
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// catchUpCheckInterval is the interval to check whether the catch-up subscriptions
+// reach the checkpoint ts of their shared subscriptions.
+const catchUpCheckInterval = 100 * time.Millisecond
+
+// catchUp makes the dispatcher share a subscription whose checkpoint ts is larger than
+// the start ts of the dispatcher. A catch-up subscription pulls the data in (startTs, endTs]
+// for the dispatcher, and the dispatcher moves to the shared subscription after the resolved ts
+// of the catch-up subscription reaches endTs, which is the checkpoint ts of the shared subscription.
+func (e *eventStore) catchUp(
+	stat *dispatcherStat,
+	tableSpan *heartbeatpb.TableSpan,
+	sharedSubID logpuller.SubscriptionID,
+	endTs uint64,
+	notifier ResolvedTsNotifier,
+) {
+	chIndex := common.HashTableSpan(tableSpan, len(e.chs))
+	// Note: don't hold any lock when call Subscribe
+	catchUpSubID := e.puller.Subscribe(*tableSpan, stat.checkpointTs)
+	metrics.EventStoreSubscriptionGauge.Inc()
+
+	e.dispatcherMeta.Lock()
+	defer e.dispatcherMeta.Unlock()
+	e.dispatcherMeta.dispatcherStats[stat.dispatcherID] = stat
+	e.addTableDispatcher(tableSpan.TableID, stat.dispatcherID)
+	if _, ok := e.dispatcherMeta.subscriptionStats[sharedSubID]; !ok {
+		// the shared subscription is removed during subscribing,
+		// so the catch-up subscription serves the dispatcher alone.
+		stat.subID = catchUpSubID
+		e.addSubscription(catchUpSubID, tableSpan, chIndex, stat.checkpointTs, 0, stat.dispatcherID, notifier)
+		return
+	}
+	stat.subID = sharedSubID
+	stat.catchUpSubID = catchUpSubID
+	stat.catchUpEndTs = endTs
+	e.addSubscription(catchUpSubID, tableSpan, chIndex, stat.checkpointTs, endTs, stat.dispatcherID, notifier)
+	e.dispatcherMeta.catchUps[catchUpSubID] = stat.dispatcherID
+	metrics.EventStoreSharedDispatcherCount.WithLabelValues("catch-up").Inc()
+	log.Info("catch up with existing subscription",
+		zap.Any("dispatcherID", stat.dispatcherID),
+		zap.Uint64("subID", uint64(sharedSubID)),
+		zap.Uint64("catchUpSubID", uint64(catchUpSubID)),
+		zap.Uint64("startTs", stat.checkpointTs),
+		zap.Uint64("catchUpEndTs", endTs))
+}
+
+// releaseCatchUp stops the catch-up subscription of the dispatcher if it's still running,
+// and deletes its data. It must be called with dispatcherMeta locked.
+func (e *eventStore) releaseCatchUp(stat *dispatcherStat) {
+	catchUpSubID := stat.catchUpSubID
+	if catchUpSubID == logpuller.InvalidSubscriptionID {
+		return
+	}
+	stat.catchUpSubID, stat.catchUpEndTs = logpuller.InvalidSubscriptionID, 0
+	if _, ok := e.dispatcherMeta.subscriptionStats[catchUpSubID]; ok {
+		e.removeCatchUpSubscription(catchUpSubID)
+	}
+	dbIndex := common.HashTableSpan(stat.tableSpan, len(e.chs))
+	e.gcManager.addGCItem(dbIndex, uint64(catchUpSubID), stat.tableSpan.TableID, 0, math.MaxUint64)
+}
+
+// gcConsumedCatchUp deletes the data of the finished catch-up subscription after the dispatcher
+// consumes all of them, the dispatcher never reads the data not larger than its consumed ts.
+func (e *eventStore) gcConsumedCatchUp(dispatcherID common.DispatcherID) {
+	e.dispatcherMeta.Lock()
+	defer e.dispatcherMeta.Unlock()
+	stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]
+	if !ok || stat.catchUpSubID == logpuller.InvalidSubscriptionID || stat.consumedTs.Load() < stat.catchUpEndTs {
+		return
+	}
+	// the catch-up subscription is still serving the dispatcher until the checker moves it
+	if _, ok := e.dispatcherMeta.catchUps[stat.catchUpSubID]; ok {
+		return
+	}
+	log.Info("dispatcher consumes the catch-up data, delete them",
+		zap.Any("dispatcherID", dispatcherID),
+		zap.Uint64("catchUpSubID", uint64(stat.catchUpSubID)),
+		zap.Uint64("catchUpEndTs", stat.catchUpEndTs))
+	e.releaseCatchUp(stat)
+}
+
+// detachCatchUps makes the catch-up subscriptions serve their dispatchers alone after
+// the shared subscription is removed. It must be called with dispatcherMeta locked.
+func (e *eventStore) detachCatchUps(tableID int64, sharedSubID logpuller.SubscriptionID) {
+	for dispatcherID := range e.dispatcherMeta.tableToDispatchers[tableID] {
+		stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]
+		if !ok || stat.subID != sharedSubID || stat.catchUpSubID == logpuller.InvalidSubscriptionID {
+			continue
+		}
+		catchUpStat, ok := e.dispatcherMeta.subscriptionStats[stat.catchUpSubID]
+		if !ok {
+			log.Panic("should not happen")
+		}
+		delete(e.dispatcherMeta.catchUps, stat.catchUpSubID)
+		catchUpStat.catchUpEndTs.Store(0)
+		stat.subID = stat.catchUpSubID
+		stat.catchUpSubID, stat.catchUpEndTs = logpuller.InvalidSubscriptionID, 0
+		log.Info("shared subscription is removed, detach catch-up subscription",
+			zap.Any("dispatcherID", dispatcherID),
+			zap.Uint64("sharedSubID", uint64(sharedSubID)),
+			zap.Uint64("subID", uint64(stat.subID)))
+	}
+}
+
+// removeCatchUpSubscription must be called with dispatcherMeta locked.
+func (e *eventStore) removeCatchUpSubscription(catchUpSubID logpuller.SubscriptionID) {
+	delete(e.dispatcherMeta.subscriptionStats, catchUpSubID)
+	delete(e.dispatcherMeta.catchUps, catchUpSubID)
+	e.puller.Unsubscribe(catchUpSubID)
+	e.ds.RemovePath(catchUpSubID)
+	metrics.EventStoreSubscriptionGauge.Dec()
+}
+
+func (e *eventStore) runCatchUpChecker(ctx context.Context) error {
+	ticker := time.NewTicker(catchUpCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			e.finishCatchUps()
+		}
+	}
+}
+
+// finishCatchUps moves the dispatchers whose catch-up subscriptions reach the catch-up end ts
+// to their shared subscriptions, and stops the catch-up subscriptions.
+// The data of the catch-up subscriptions are kept until the dispatchers consume them.
+func (e *eventStore) finishCatchUps() {
+	e.dispatcherMeta.RLock()
+	pending := len(e.dispatcherMeta.catchUps)
+	e.dispatcherMeta.RUnlock()
+	if pending == 0 {
+		return
+	}
+
+	e.dispatcherMeta.Lock()
+	defer e.dispatcherMeta.Unlock()
+	for catchUpSubID, dispatcherID := range e.dispatcherMeta.catchUps {
+		stat := e.dispatcherMeta.dispatcherStats[dispatcherID]
+		catchUpStat := e.dispatcherMeta.subscriptionStats[catchUpSubID]
+		if catchUpStat.resolvedTs.Load() < stat.catchUpEndTs {
+			continue
+		}
+		sharedStat := e.dispatcherMeta.subscriptionStats[stat.subID]
+		// remove the notifier from the catch-up subscription first,
+		// so the dispatcher never receives a smaller resolved ts from it.
+		catchUpStat.dispatchers.Lock()
+		notifier := catchUpStat.dispatchers.notifiers[dispatcherID]
+		delete(catchUpStat.dispatchers.notifiers, dispatcherID)
+		catchUpStat.dispatchers.Unlock()
+
+		sharedStat.dispatchers.Lock()
+		sharedStat.dispatchers.notifiers[dispatcherID] = notifier
+		sharedStat.dispatchers.Unlock()
+
+		e.removeCatchUpSubscription(catchUpSubID)
+		log.Info("dispatcher catches up with shared subscription",
+			zap.Any("dispatcherID", dispatcherID),
+			zap.Uint64("subID", uint64(stat.subID)),
+			zap.Uint64("catchUpSubID", uint64(catchUpSubID)),
+			zap.Uint64("catchUpEndTs", stat.catchUpEndTs))
+	}
+}
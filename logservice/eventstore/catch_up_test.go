@@ -0,0 +1,203 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/utils/chann"
+	"github.com/pingcap/ticdc/utils/dynstream"
+	"github.com/stretchr/testify/require"
+)
+
+func newEventStoreForTest(t *testing.T) *eventStore {
+	db, err := pebble.Open(t.TempDir(), &pebble.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	codec, err := newValueCodec(config.EventStoreCompressionNone, 0)
+	require.NoError(t, err)
+	ds := dynstream.NewParallelDynamicStream(1, pathHasher{}, &eventsHandler{}, dynstream.NewOption())
+	ds.Start()
+	t.Cleanup(ds.Close)
+	store := &eventStore{
+		dbs:        []*pebble.DB{db},
+		chs:        []*chann.UnlimitedChannel[kvEvent, uint64]{chann.NewUnlimitedChannel[kvEvent, uint64](kvEventGrouper, kvEventSizer)},
+		ds:         ds,
+		puller:     logpuller.NewLogPuller(nil, nil, nil),
+		gcManager:  newGCManager(),
+		valueCodec: codec,
+	}
+	store.dispatcherMeta.dispatcherStats = make(map[common.DispatcherID]*dispatcherStat)
+	store.dispatcherMeta.subscriptionStats = make(map[logpuller.SubscriptionID]*subscriptionStat)
+	store.dispatcherMeta.tableToDispatchers = make(map[int64]map[common.DispatcherID]bool)
+	store.dispatcherMeta.catchUps = make(map[logpuller.SubscriptionID]common.DispatcherID)
+	return store
+}
+
+func writeEventsForTest(t *testing.T, store *eventStore, subID logpuller.SubscriptionID, tableID int64, commitTs ...uint64) {
+	events := make([]kvEvent, 0, len(commitTs))
+	for _, ts := range commitTs {
+		key := []byte(fmt.Sprintf("t_%d_r_%d", tableID, ts))
+		value := []byte("value")
+		events = append(events, kvEvent{
+			raw: &common.RawKVEntry{
+				OpType:   common.OpTypePut,
+				CRTs:     ts,
+				StartTs:  ts - 1,
+				KeyLen:   uint32(len(key)),
+				ValueLen: uint32(len(value)),
+				Key:      key,
+				Value:    value,
+			},
+			subID:   subID,
+			tableID: tableID,
+		})
+	}
+	require.NoError(t, store.writeEvents(store.dbs[0], events))
+}
+
+func readAllForTest(t *testing.T, store *eventStore, dispatcherID common.DispatcherID, startTs, endTs uint64) []uint64 {
+	iter, err := store.GetIterator(dispatcherID, common.DataRange{StartTs: startTs, EndTs: endTs})
+	require.NoError(t, err)
+	var result []uint64
+	for {
+		raw, _, err := iter.Next()
+		require.NoError(t, err)
+		if raw == nil {
+			break
+		}
+		result = append(result, raw.CRTs)
+	}
+	_, err = iter.Close()
+	require.NoError(t, err)
+	return result
+}
+
+func TestCatchUpWithSharedSubscription(t *testing.T) {
+	store := newEventStoreForTest(t)
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("b")}
+	sharedID, catchUpID := common.NewDispatcherID(), common.NewDispatcherID()
+
+	var notified []uint64
+	notifier := func(watermark uint64, _ uint64) { notified = append(notified, watermark) }
+
+	// the shared subscription 1 starts at 100, the dispatcher catches up from 50 by subscription 2
+	store.dispatcherMeta.dispatcherStats[sharedID] = &dispatcherStat{dispatcherID: sharedID, tableSpan: span, checkpointTs: 100, subID: 1}
+	store.addSubscription(1, span, 0, 100, 0, sharedID, func(uint64, uint64) {})
+	store.addTableDispatcher(span.TableID, sharedID)
+	catchUpStat := &dispatcherStat{
+		dispatcherID: catchUpID,
+		tableSpan:    span,
+		checkpointTs: 50,
+		subID:        1,
+		catchUpSubID: 2,
+		catchUpEndTs: 100,
+	}
+	store.dispatcherMeta.dispatcherStats[catchUpID] = catchUpStat
+	store.addSubscription(2, span, 0, 50, 100, catchUpID, notifier)
+	store.addTableDispatcher(span.TableID, catchUpID)
+	store.dispatcherMeta.catchUps[2] = catchUpID
+
+	writeEventsForTest(t, store, 1, span.TableID, 110, 120)
+	// the catch-up subscription pulls data after the end ts, which must be ignored
+	writeEventsForTest(t, store, 2, span.TableID, 60, 100, 110)
+
+	// the resolved ts notified by the catch-up subscription is capped by the end ts
+	handler := &eventsHandler{}
+	resolved := func(ts uint64) kvEvent {
+		return kvEvent{raw: &common.RawKVEntry{OpType: common.OpTypeResolved, CRTs: ts}}
+	}
+	subStat := store.dispatcherMeta.subscriptionStats[2]
+	handler.Handle(subStat, resolved(80))
+	store.finishCatchUps()
+	require.Equal(t, catchUpID, store.dispatcherMeta.catchUps[2])
+	handler.Handle(subStat, resolved(130))
+	require.Equal(t, []uint64{80, 100}, notified)
+
+	// the data are read from both subscriptions
+	require.Equal(t, []uint64{60, 100, 110, 120}, readAllForTest(t, store, catchUpID, 50, 150))
+	require.Equal(t, []uint64{100}, readAllForTest(t, store, catchUpID, 60, 100))
+	require.Equal(t, []uint64{110}, readAllForTest(t, store, catchUpID, 100, 110))
+	require.Empty(t, readAllForTest(t, store, catchUpID, 100, 100))
+
+	// the dispatcher moves to the shared subscription after catching up
+	store.finishCatchUps()
+	require.Empty(t, store.dispatcherMeta.catchUps)
+	require.NotContains(t, store.dispatcherMeta.subscriptionStats, logpuller.SubscriptionID(2))
+	require.Contains(t, store.dispatcherMeta.subscriptionStats[1].dispatchers.notifiers, catchUpID)
+	require.Equal(t, []uint64{60, 100, 110, 120}, readAllForTest(t, store, catchUpID, 50, 150))
+
+	// the data of the catch-up subscription are kept until the dispatcher consumes them
+	require.NoError(t, store.UpdateDispatcherCheckpointTs(catchUpID, 90))
+	require.Empty(t, store.gcManager.fetchAllGCItems())
+	require.NoError(t, store.UpdateDispatcherCheckpointTs(catchUpID, 100))
+	items := store.gcManager.fetchAllGCItems()
+	require.Len(t, items, 1)
+	require.Equal(t, uint64(2), items[0].uniqueKeyID)
+	require.Equal(t, logpuller.InvalidSubscriptionID, store.dispatcherMeta.dispatcherStats[catchUpID].catchUpSubID)
+	require.Equal(t, []uint64{110, 120}, readAllForTest(t, store, catchUpID, 100, 150))
+
+	// the data are not deleted again after the dispatcher is removed
+	require.NoError(t, store.UnregisterDispatcher(catchUpID))
+	require.Empty(t, store.gcManager.fetchAllGCItems())
+	require.Contains(t, store.dispatcherMeta.subscriptionStats, logpuller.SubscriptionID(1))
+}
+
+func TestDetachCatchUpAfterSharedSubscriptionRemoved(t *testing.T) {
+	store := newEventStoreForTest(t)
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("b")}
+	sharedID, catchUpID := common.NewDispatcherID(), common.NewDispatcherID()
+
+	store.dispatcherMeta.dispatcherStats[sharedID] = &dispatcherStat{dispatcherID: sharedID, tableSpan: span, checkpointTs: 100, subID: 1}
+	store.addSubscription(1, span, 0, 100, 0, sharedID, func(uint64, uint64) {})
+	store.addTableDispatcher(span.TableID, sharedID)
+	store.dispatcherMeta.dispatcherStats[catchUpID] = &dispatcherStat{
+		dispatcherID: catchUpID,
+		tableSpan:    span,
+		checkpointTs: 50,
+		subID:        1,
+		catchUpSubID: 2,
+		catchUpEndTs: 100,
+	}
+	store.addSubscription(2, span, 0, 50, 100, catchUpID, func(uint64, uint64) {})
+	store.addTableDispatcher(span.TableID, catchUpID)
+	store.dispatcherMeta.catchUps[2] = catchUpID
+
+	// the catch-up subscription serves the dispatcher alone after the shared one is removed
+	require.NoError(t, store.UnregisterDispatcher(sharedID))
+	require.NotContains(t, store.dispatcherMeta.subscriptionStats, logpuller.SubscriptionID(1))
+	stat := store.dispatcherMeta.dispatcherStats[catchUpID]
+	require.Equal(t, logpuller.SubscriptionID(2), stat.subID)
+	require.Equal(t, logpuller.InvalidSubscriptionID, stat.catchUpSubID)
+	require.Empty(t, store.dispatcherMeta.catchUps)
+	require.Equal(t, uint64(0), store.dispatcherMeta.subscriptionStats[2].catchUpEndTs.Load())
+
+	writeEventsForTest(t, store, 2, span.TableID, 60, 110)
+	require.Equal(t, []uint64{60, 110}, readAllForTest(t, store, catchUpID, 50, 150))
+}
+
+func TestNotifyAfter(t *testing.T) {
+	var notified []uint64
+	notifier := notifyAfter(100, func(watermark uint64, _ uint64) { notified = append(notified, watermark) })
+	notifier(90, 0)
+	notifier(100, 0)
+	notifier(110, 0)
+	require.Equal(t, []uint64{100, 110}, notified)
+}
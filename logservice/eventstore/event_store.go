@@ -30,7 +30,6 @@ import (
 	"github.com/pingcap/ticdc/utils/dynstream"
 
 	"github.com/cockroachdb/pebble"
-	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/logpuller"
@@ -38,6 +37,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
@@ -60,6 +60,11 @@ var metricEventStoreCloseReadDurationHistogram = metrics.EventStoreReadDurationH
 
 type ResolvedTsNotifier func(watermark uint64, latestCommitTs uint64)
 
+// EvictedNotifier is called when the data needed by a dispatcher is evicted from the store.
+// The dispatcher is unregistered before the call and must be registered again,
+// which makes the store pull the data from upstream again.
+type EvictedNotifier func()
+
 type EventStore interface {
 	Name() string
 
@@ -72,6 +77,7 @@ type EventStore interface {
 		span *heartbeatpb.TableSpan,
 		startTS uint64,
 		notifier ResolvedTsNotifier,
+		evictedNotifier EvictedNotifier,
 		onlyReuse bool,
 	) (bool, error)
 
@@ -83,6 +89,17 @@ type EventStore interface {
 
 	// return an iterator which scan the data in ts range (dataRange.StartTs, dataRange.EndTs]
 	GetIterator(dispatcherID common.DispatcherID, dataRange common.DataRange) (EventIterator, error)
+
+	// GetStalledRegions returns the subscribed regions whose resolved ts is stalled
+	// longer than the threshold.
+	GetStalledRegions(ctx context.Context, threshold time.Duration) []logpuller.StalledRegion
+
+	// GetStoreHealth returns the health of the TiKV stores requested by the log puller.
+	GetStoreHealth() []logpuller.StoreHealth
+
+	// ResolveLocks resolves the locks in the subscribed regions of the table or the region
+	// immediately, the zero tableID or regionID matches all.
+	ResolveLocks(ctx context.Context, tableID int64, regionID uint64) ([]uint64, error)
 }
 
 type DMLEventState struct {
@@ -104,8 +121,19 @@ type dispatcherStat struct {
 	tableSpan *heartbeatpb.TableSpan
 	// the max ts of events which is not needed by this dispatcher
 	checkpointTs uint64
+	// the max ts of events which has been consumed by this dispatcher,
+	// it is reported by the dispatcher periodically.
+	consumedTs atomic.Uint64
 
+	evictedNotifier EvictedNotifier
+
+	// subID is the subscription shared by the dispatchers of the same span.
 	subID logpuller.SubscriptionID
+	// catchUpSubID is the subscription which pulls the data in (startTs, catchUpEndTs]
+	// for the dispatcher whose start ts is older than the checkpoint ts of the shared subscription.
+	// It's InvalidSubscriptionID if all data of the dispatcher are in the shared subscription.
+	catchUpSubID logpuller.SubscriptionID
+	catchUpEndTs uint64
 }
 
 type subscriptionStat struct {
@@ -128,6 +156,9 @@ type subscriptionStat struct {
 	resolvedTs atomic.Uint64
 	// the max commit ts of dml event in the store
 	maxEventCommitTs atomic.Uint64
+	// catchUpEndTs is not zero if the subscription only pulls data for a dispatcher to catch up
+	// with a shared subscription, the ts notified to the dispatcher doesn't exceed it.
+	catchUpEndTs atomic.Uint64
 }
 
 type kvEvent struct {
@@ -152,6 +183,8 @@ type eventStore struct {
 
 	gcManager *gcManager
 
+	evictionPolicy evictionPolicy
+
 	messageCenter messaging.MessageCenter
 
 	coordinatorInfo struct {
@@ -171,10 +204,11 @@ type eventStore struct {
 		// table id -> dispatcher ids
 		// use table id as the key is to share data between spans not completely the same in the future.
 		tableToDispatchers map[int64]map[common.DispatcherID]bool
+		// catch-up subscription id -> the dispatcher catching up
+		catchUps map[logpuller.SubscriptionID]common.DispatcherID
 	}
 
-	encoder *zstd.Encoder
-	decoder *zstd.Decoder
+	valueCodec *valueCodec
 
 	metricEventStoreDSAddPathNum       prometheus.Gauge
 	metricEventStoreDSRemovePathNum    prometheus.Gauge
@@ -212,6 +246,7 @@ func New(
 	regionCache *tikv.RegionCache,
 	pdClock pdutil.Clock,
 	kvStorage kv.Storage,
+	storeConfig *config.EventStoreConfig,
 ) EventStore {
 	clientConfig := &logpuller.SubscriptionClientConfig{
 		RegionRequestWorkerPerStore:   16,
@@ -235,15 +270,12 @@ func New(
 	if err != nil {
 		log.Panic("fail to remove path")
 	}
-	// Create the zstd encoder
-	encoder, err := zstd.NewWriter(nil)
-	if err != nil {
-		log.Panic("Failed to create zstd encoder", zap.Error(err))
+	if storeConfig == nil {
+		storeConfig = config.NewDefaultEventStoreConfig()
 	}
-
-	decoder, err := zstd.NewReader(nil)
+	valueCodec, err := newValueCodec(storeConfig.Compression, storeConfig.ZstdDictSampleCount)
 	if err != nil {
-		log.Panic("Failed to create zstd decoder", zap.Error(err))
+		log.Panic("failed to create value codec", zap.Error(err))
 	}
 
 	option := dynstream.NewOption()
@@ -261,9 +293,9 @@ func New(
 
 		ds: ds,
 
-		gcManager: newGCManager(),
-		encoder:   encoder,
-		decoder:   decoder,
+		gcManager:      newGCManager(),
+		evictionPolicy: newEvictionPolicy(storeConfig),
+		valueCodec:     valueCodec,
 
 		metricEventStoreDSAddPathNum:    metrics.DynamicStreamAddPathNum.WithLabelValues("event-store"),
 		metricEventStoreDSRemovePathNum: metrics.DynamicStreamRemovePathNum.WithLabelValues("event-store"),
@@ -292,6 +324,7 @@ func New(
 	store.dispatcherMeta.dispatcherStats = make(map[common.DispatcherID]*dispatcherStat)
 	store.dispatcherMeta.subscriptionStats = make(map[logpuller.SubscriptionID]*subscriptionStat)
 	store.dispatcherMeta.tableToDispatchers = make(map[int64]map[common.DispatcherID]bool)
+	store.dispatcherMeta.catchUps = make(map[logpuller.SubscriptionID]common.DispatcherID)
 
 	consume := func(ctx context.Context, raw *common.RawKVEntry, subID logpuller.SubscriptionID) error {
 		if raw == nil {
@@ -430,6 +463,14 @@ func (e *eventStore) Run(ctx context.Context) error {
 		return e.gcManager.run(ctx, e.deleteEvents)
 	})
 
+	eg.Go(func() error {
+		return e.runEvictionChecker(ctx)
+	})
+
+	eg.Go(func() error {
+		return e.runCatchUpChecker(ctx)
+	})
+
 	eg.Go(func() error {
 		return e.updateMetrics(ctx)
 	})
@@ -462,6 +503,7 @@ func (e *eventStore) RegisterDispatcher(
 	tableSpan *heartbeatpb.TableSpan,
 	startTs uint64,
 	notifier ResolvedTsNotifier,
+	evictedNotifier EvictedNotifier,
 	onlyReuse bool,
 ) (bool, error) {
 	log.Info("register dispatcher",
@@ -479,12 +521,17 @@ func (e *eventStore) RegisterDispatcher(
 	}()
 
 	stat := &dispatcherStat{
-		dispatcherID: dispatcherID,
-		tableSpan:    tableSpan,
-		checkpointTs: startTs,
+		dispatcherID:    dispatcherID,
+		tableSpan:       tableSpan,
+		checkpointTs:    startTs,
+		evictedNotifier: evictedNotifier,
 	}
+	stat.consumedTs.Store(startTs)
 
 	e.dispatcherMeta.Lock()
+	// the shared subscription whose checkpoint ts is the smallest one larger than startTs,
+	// the dispatcher can catch up with it if no subscription can be reused directly.
+	var catchUpTarget *subscriptionStat
 	if candidateIDs, ok := e.dispatcherMeta.tableToDispatchers[tableSpan.TableID]; ok {
 		for candidateID := range candidateIDs {
 			candidateDispatcher, ok := e.dispatcherMeta.dispatcherStats[candidateID]
@@ -496,25 +543,30 @@ func (e *eventStore) RegisterDispatcher(
 				if !ok {
 					log.Panic("should not happen")
 				}
-				// check whether startTs is in the range [checkpointTs, resolvedTs]
-				// for `[checkpointTs`: because we want data > startTs, so data <= checkpointTs == startTs deleted is ok.
-				// for `resolvedTs]`: startTs == resolvedTs is a special case that no resolved ts has been recieved, so it is ok.
-				if subscriptionStat.checkpointTs.Load() <= startTs && startTs <= subscriptionStat.resolvedTs.Load() {
+				// check whether startTs >= checkpointTs,
+				// because we want data > startTs, so data <= checkpointTs == startTs deleted is ok.
+				// If startTs > resolvedTs, the dispatcher is notified after the resolved ts reaches startTs.
+				if subscriptionStat.checkpointTs.Load() <= startTs {
 					stat.subID = candidateDispatcher.subID
 					e.dispatcherMeta.dispatcherStats[dispatcherID] = stat
 					// add dispatcher to existing subscription and return
 					subscriptionStat.dispatchers.Lock()
-					subscriptionStat.dispatchers.notifiers[dispatcherID] = notifier
+					subscriptionStat.dispatchers.notifiers[dispatcherID] = notifyAfter(startTs, notifier)
 					subscriptionStat.dispatchers.Unlock()
 					candidateIDs[dispatcherID] = true
 					e.dispatcherMeta.Unlock()
+					metrics.EventStoreSharedDispatcherCount.WithLabelValues("reuse").Inc()
 					log.Info("reuse existing subscription",
 						zap.Any("dispatcherID", dispatcherID),
 						zap.Uint64("subID", uint64(stat.subID)),
 						zap.Uint64("checkpointTs", subscriptionStat.checkpointTs.Load()),
+						zap.Uint64("resolvedTs", subscriptionStat.resolvedTs.Load()),
 						zap.Uint64("startTs", startTs))
 					return true, nil
 				}
+				if catchUpTarget == nil || subscriptionStat.checkpointTs.Load() < catchUpTarget.checkpointTs.Load() {
+					catchUpTarget = subscriptionStat
+				}
 			}
 		}
 	}
@@ -524,6 +576,11 @@ func (e *eventStore) RegisterDispatcher(
 		return false, nil
 	}
 
+	if catchUpTarget != nil {
+		e.catchUp(stat, tableSpan, catchUpTarget.subID, catchUpTarget.checkpointTs.Load(), notifier)
+		return true, nil
+	}
+
 	// cannot share data from existing subscription, create a new subscription
 
 	// TODO: hash span is only needed when we need to reuse data after restart
@@ -542,8 +599,24 @@ func (e *eventStore) RegisterDispatcher(
 	e.dispatcherMeta.Lock()
 	defer e.dispatcherMeta.Unlock()
 	e.dispatcherMeta.dispatcherStats[dispatcherID] = stat
+	e.addSubscription(stat.subID, tableSpan, chIndex, startTs, 0, dispatcherID, notifier)
+	e.addTableDispatcher(tableSpan.TableID, dispatcherID)
+	return true, nil
+}
+
+// addSubscription adds the stat of a new subscription which serves the dispatcher,
+// it must be called with dispatcherMeta locked.
+func (e *eventStore) addSubscription(
+	subID logpuller.SubscriptionID,
+	tableSpan *heartbeatpb.TableSpan,
+	chIndex int,
+	startTs uint64,
+	catchUpEndTs uint64,
+	dispatcherID common.DispatcherID,
+	notifier ResolvedTsNotifier,
+) *subscriptionStat {
 	subStat := &subscriptionStat{
-		subID:   stat.subID,
+		subID:   subID,
 		tableID: tableSpan.TableID,
 		dbIndex: chIndex,
 		eventCh: e.chs[chIndex],
@@ -553,16 +626,31 @@ func (e *eventStore) RegisterDispatcher(
 	subStat.checkpointTs.Store(startTs)
 	subStat.resolvedTs.Store(startTs)
 	subStat.maxEventCommitTs.Store(startTs)
-	e.dispatcherMeta.subscriptionStats[stat.subID] = subStat
-	e.ds.AddPath(stat.subID, subStat, dynstream.AreaSettings{})
+	subStat.catchUpEndTs.Store(catchUpEndTs)
+	e.dispatcherMeta.subscriptionStats[subID] = subStat
+	e.ds.AddPath(subID, subStat, dynstream.AreaSettings{})
+	return subStat
+}
 
-	dispatchersForSameTable, ok := e.dispatcherMeta.tableToDispatchers[tableSpan.TableID]
+// addTableDispatcher must be called with dispatcherMeta locked.
+func (e *eventStore) addTableDispatcher(tableID int64, dispatcherID common.DispatcherID) {
+	dispatchersForSameTable, ok := e.dispatcherMeta.tableToDispatchers[tableID]
 	if !ok {
-		e.dispatcherMeta.tableToDispatchers[tableSpan.TableID] = map[common.DispatcherID]bool{dispatcherID: true}
+		e.dispatcherMeta.tableToDispatchers[tableID] = map[common.DispatcherID]bool{dispatcherID: true}
 	} else {
 		dispatchersForSameTable[dispatcherID] = true
 	}
-	return true, nil
+}
+
+// notifyAfter returns a notifier which ignores the resolved ts smaller than startTs,
+// because the dispatcher which reuses a subscription may start after its resolved ts.
+func notifyAfter(startTs uint64, notifier ResolvedTsNotifier) ResolvedTsNotifier {
+	return func(watermark uint64, latestCommitTs uint64) {
+		if watermark < startTs {
+			return
+		}
+		notifier(watermark, latestCommitTs)
+	}
 }
 
 func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) error {
@@ -579,6 +667,7 @@ func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) erro
 	subID := stat.subID
 	tableID := stat.tableSpan.TableID
 	delete(e.dispatcherMeta.dispatcherStats, dispatcherID)
+	e.releaseCatchUp(stat)
 
 	// delete the dispatcher from subscription
 	subscriptionStat, ok := e.dispatcherMeta.subscriptionStats[subID]
@@ -591,6 +680,7 @@ func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) erro
 		// TODO: do we need unlock before puller.Unsubscribe?
 		e.puller.Unsubscribe(subID)
 		metrics.EventStoreSubscriptionGauge.Dec()
+		e.detachCatchUps(tableID, subID)
 	}
 
 	// delete the dispatcher from table subscriptions
@@ -611,48 +701,38 @@ func (e *eventStore) UpdateDispatcherCheckpointTs(
 	dispatcherID common.DispatcherID,
 	checkpointTs uint64,
 ) error {
-	// e.dispatcherMeta.RLock()
-	// defer e.dispatcherMeta.RUnlock()
-	// if stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]; ok {
-	// 	stat.checkpointTs = checkpointTs
-	// 	subscriptionStat := e.dispatcherMeta.subscriptionStats[stat.subID]
-	// 	// calculate the new checkpoint ts of the subscription
-	// 	newCheckpointTs := uint64(0)
-	// 	for dispatcherID := range subscriptionStat.ids {
-	// 		dispatcherStat := e.dispatcherMeta.dispatcherStats[dispatcherID]
-	// 		if newCheckpointTs == 0 || dispatcherStat.checkpointTs < newCheckpointTs {
-	// 			newCheckpointTs = dispatcherStat.checkpointTs
-	// 		}
-	// 	}
-	// 	if newCheckpointTs == 0 {
-	// 		return nil
-	// 	}
-	// 	if newCheckpointTs < subscriptionStat.checkpointTs {
-	// 		log.Panic("should not happen",
-	// 			zap.Uint64("newCheckpointTs", newCheckpointTs),
-	// 			zap.Uint64("oldCheckpointTs", subscriptionStat.checkpointTs))
-	// 	}
-	// 	if subscriptionStat.checkpointTs < newCheckpointTs {
-	// 		e.gcManager.addGCItem(
-	// 			subscriptionStat.chIndex,
-	// 			subscriptionStat.uniqueKeyID,
-	// 			stat.tableSpan.TableID,
-	// 			subscriptionStat.checkpointTs,
-	// 			newCheckpointTs,
-	// 		)
-	// 		if log.GetLevel() <= zap.DebugLevel {
-	// 			log.Debug("update checkpoint ts",
-	// 				zap.Any("dispatcherID", dispatcherID),
-	// 				zap.Uint64("subID", uint64(stat.subID)),
-	// 				zap.Uint64("newCheckpointTs", newCheckpointTs),
-	// 				zap.Uint64("oldCheckpointTs", subscriptionStat.checkpointTs))
-	// 			subscriptionStat.checkpointTs = newCheckpointTs
-	// 		}
-	// 	}
-	// }
+	e.dispatcherMeta.RLock()
+	stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]
+	if !ok {
+		e.dispatcherMeta.RUnlock()
+		return nil
+	}
+	// Only record the progress of the dispatcher to decide which data can be evicted.
+	// TODO: delete data <= checkpointTs after the dispatcher never resets to a smaller ts.
+	if checkpointTs > stat.consumedTs.Load() {
+		stat.consumedTs.Store(checkpointTs)
+	}
+	catchUpConsumed := stat.catchUpSubID != logpuller.InvalidSubscriptionID && checkpointTs >= stat.catchUpEndTs
+	e.dispatcherMeta.RUnlock()
+
+	if catchUpConsumed {
+		e.gcConsumedCatchUp(dispatcherID)
+	}
 	return nil
 }
 
+func (e *eventStore) GetStalledRegions(ctx context.Context, threshold time.Duration) []logpuller.StalledRegion {
+	return e.puller.GetStalledRegions(ctx, threshold)
+}
+
+func (e *eventStore) GetStoreHealth() []logpuller.StoreHealth {
+	return e.puller.GetStoreHealth()
+}
+
+func (e *eventStore) ResolveLocks(ctx context.Context, tableID int64, regionID uint64) ([]uint64, error) {
+	return e.puller.ResolveLocks(ctx, tableID, regionID)
+}
+
 func (e *eventStore) GetDispatcherDMLEventState(dispatcherID common.DispatcherID) (bool, DMLEventState) {
 	e.dispatcherMeta.RLock()
 	defer e.dispatcherMeta.RUnlock()
@@ -665,6 +745,11 @@ func (e *eventStore) GetDispatcherDMLEventState(dispatcherID common.DispatcherID
 		}
 	}
 	subscriptionStat := e.dispatcherMeta.subscriptionStats[stat.subID]
+	if catchUpStat, ok := e.dispatcherMeta.subscriptionStats[stat.catchUpSubID]; ok {
+		return true, DMLEventState{
+			MaxEventCommitTs: min(catchUpStat.maxEventCommitTs.Load(), stat.catchUpEndTs),
+		}
+	}
 	return true, DMLEventState{
 		// ResolvedTs:       subscriptionStat.resolvedTs,
 		MaxEventCommitTs: subscriptionStat.maxEventCommitTs.Load(),
@@ -680,7 +765,9 @@ func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange com
 		return nil, nil
 	}
 	subscriptionStat := e.dispatcherMeta.subscriptionStats[stat.subID]
-	if dataRange.StartTs < subscriptionStat.checkpointTs.Load() {
+	// the data <= catchUpEndTs are read from the catch-up subscription
+	catchUpSubID, catchUpEndTs := stat.catchUpSubID, stat.catchUpEndTs
+	if catchUpSubID == logpuller.InvalidSubscriptionID && dataRange.StartTs < subscriptionStat.checkpointTs.Load() {
 		log.Panic("should not happen",
 			zap.Any("dispatcherID", dispatcherID),
 			zap.Uint64("checkpointTs", subscriptionStat.checkpointTs.Load()),
@@ -689,32 +776,51 @@ func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange com
 	db := e.dbs[subscriptionStat.dbIndex]
 	e.dispatcherMeta.RUnlock()
 
-	// convert range before pass it to pebble: (startTs, endTs] is equal to [startTs + 1, endTs + 1)
-	start := EncodeKeyPrefix(uint64(subscriptionStat.subID), stat.tableSpan.TableID, dataRange.StartTs+1)
-	end := EncodeKeyPrefix(uint64(subscriptionStat.subID), stat.tableSpan.TableID, dataRange.EndTs+1)
-	// TODO: optimize read performance
-	iter, err := db.NewIter(&pebble.IterOptions{
-		LowerBound: start,
-		UpperBound: end,
-	})
-	if err != nil {
-		return nil, err
+	type readRange struct {
+		subID          logpuller.SubscriptionID
+		startTs, endTs uint64
+	}
+	ranges := make([]readRange, 0, 2)
+	if catchUpSubID != logpuller.InvalidSubscriptionID && dataRange.StartTs < catchUpEndTs {
+		ranges = append(ranges, readRange{subID: catchUpSubID, startTs: dataRange.StartTs, endTs: min(dataRange.EndTs, catchUpEndTs)})
+	}
+	if catchUpSubID == logpuller.InvalidSubscriptionID || dataRange.EndTs > catchUpEndTs || dataRange.StartTs >= catchUpEndTs {
+		ranges = append(ranges, readRange{subID: subscriptionStat.subID, startTs: max(dataRange.StartTs, catchUpEndTs), endTs: dataRange.EndTs})
+	}
+	iters := make([]*pebble.Iterator, 0, len(ranges))
+	for _, r := range ranges {
+		// convert range before pass it to pebble: (startTs, endTs] is equal to [startTs + 1, endTs + 1)
+		start := EncodeKeyPrefix(uint64(r.subID), stat.tableSpan.TableID, r.startTs+1)
+		end := EncodeKeyPrefix(uint64(r.subID), stat.tableSpan.TableID, r.endTs+1)
+		// TODO: optimize read performance
+		iter, err := db.NewIter(&pebble.IterOptions{
+			LowerBound: start,
+			UpperBound: end,
+		})
+		if err != nil {
+			for _, iter := range iters {
+				_ = iter.Close()
+			}
+			return nil, err
+		}
+		startTime := time.Now()
+		iter.First()
+		metricEventStoreFirstReadDurationHistogram.Observe(time.Since(startTime).Seconds())
+		iters = append(iters, iter)
 	}
-	startTime := time.Now()
-	iter.First()
-	metricEventStoreFirstReadDurationHistogram.Observe(time.Since(startTime).Seconds())
 	metrics.EventStoreScanRequestsCount.Inc()
 
 	return &eventStoreIter{
 		tableID:      stat.tableSpan.TableID,
-		innerIter:    iter,
+		innerIter:    iters[0],
+		pendingIters: iters[1:],
 		prevStartTs:  0,
 		prevCommitTs: 0,
 		iterMounter:  event.NewMounter(time.Local), // FIXME
 		startTs:      dataRange.StartTs,
 		endTs:        dataRange.EndTs,
 		rowCount:     0,
-		decoder:      e.decoder,
+		valueCodec:   e.valueCodec,
 	}, nil
 }
 
@@ -776,10 +882,10 @@ func (e *eventStore) writeEvents(db *pebble.DB, events []kvEvent) error {
 		item := event.raw
 		key := EncodeKey(uint64(event.subID), event.tableID, item)
 		value := item.Encode()
-		compressedValue := e.encoder.EncodeAll(value, nil)
-		ratio := float64(len(value)) / float64(len(compressedValue))
+		encodedValue := e.valueCodec.encode(nil, value)
+		ratio := float64(len(value)) / float64(len(encodedValue))
 		metrics.EventStoreCompressRatio.Set(ratio)
-		if err := batch.Set(key, compressedValue, pebble.NoSync); err != nil {
+		if err := batch.Set(key, encodedValue, pebble.NoSync); err != nil {
 			log.Panic("failed to update pebble batch", zap.Error(err))
 		}
 	}
@@ -800,17 +906,19 @@ func (e *eventStore) deleteEvents(dbIndex int, uniqueKeyID uint64, tableID int64
 }
 
 type eventStoreIter struct {
-	tableID      common.TableID
-	innerIter    *pebble.Iterator
+	tableID   common.TableID
+	innerIter *pebble.Iterator
+	// pendingIters are read in order after innerIter is exhausted
+	pendingIters []*pebble.Iterator
 	prevStartTs  uint64
 	prevCommitTs uint64
 	iterMounter  event.Mounter
+	valueCodec   *valueCodec
 
 	// for debug
 	startTs  uint64
 	endTs    uint64
 	rowCount int64
-	decoder  *zstd.Decoder
 }
 
 func (iter *eventStoreIter) Next() (*common.RawKVEntry, bool, error) {
@@ -818,14 +926,20 @@ func (iter *eventStoreIter) Next() (*common.RawKVEntry, bool, error) {
 		log.Panic("iter is nil")
 	}
 
-	if !iter.innerIter.Valid() {
-		return nil, false, nil
+	for !iter.innerIter.Valid() {
+		if len(iter.pendingIters) == 0 {
+			return nil, false, nil
+		}
+		if err := iter.innerIter.Close(); err != nil {
+			return nil, false, err
+		}
+		iter.innerIter, iter.pendingIters = iter.pendingIters[0], iter.pendingIters[1:]
 	}
 
 	value := iter.innerIter.Value()
-	decompressedValue, err := iter.decoder.DecodeAll(value, nil)
+	decompressedValue, err := iter.valueCodec.decode(value)
 	if err != nil {
-		log.Panic("failed to decompress value", zap.Error(err))
+		log.Panic("failed to decode value", zap.Error(err))
 	}
 	metrics.EventStoreScanBytes.Add(float64(len(decompressedValue)))
 	rawKV := &common.RawKVEntry{}
@@ -854,7 +968,12 @@ func (iter *eventStoreIter) Close() (int64, error) {
 	}
 	startTime := time.Now()
 	err := iter.innerIter.Close()
-	iter.innerIter = nil
+	for _, pending := range iter.pendingIters {
+		if closeErr := pending.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	iter.innerIter, iter.pendingIters = nil, nil
 	metricEventStoreCloseReadDurationHistogram.Observe(float64(time.Since(startTime).Seconds()))
 	return iter.rowCount, err
 }
@@ -886,21 +1005,24 @@ func (e *eventStore) uploadStatePeriodically(ctx context.Context) error {
 			}
 			for tableID, dispatcherIDs := range e.dispatcherMeta.tableToDispatchers {
 				subStates := make([]*logservicepb.SubscriptionState, 0, len(dispatcherIDs))
-				subIDs := make(map[logpuller.SubscriptionID]bool)
+				subIDs := make(map[logpuller.SubscriptionID]*logservicepb.SubscriptionState)
 				for dispatcherID := range dispatcherIDs {
 					dispatcherStat := e.dispatcherMeta.dispatcherStats[dispatcherID]
 					subID := dispatcherStat.subID
 					subStat := e.dispatcherMeta.subscriptionStats[subID]
-					if _, ok := subIDs[subID]; ok {
+					if subState, ok := subIDs[subID]; ok {
+						subState.DispatcherCount++
 						continue
 					}
-					subStates = append(subStates, &logservicepb.SubscriptionState{
-						SubID:        uint64(subID),
-						Span:         dispatcherStat.tableSpan,
-						CheckpointTs: subStat.checkpointTs.Load(),
-						ResolvedTs:   subStat.resolvedTs.Load(),
-					})
-					subIDs[subID] = true
+					subState := &logservicepb.SubscriptionState{
+						SubID:           uint64(subID),
+						Span:            dispatcherStat.tableSpan,
+						CheckpointTs:    subStat.checkpointTs.Load(),
+						ResolvedTs:      subStat.resolvedTs.Load(),
+						DispatcherCount: 1,
+					}
+					subStates = append(subStates, subState)
+					subIDs[subID] = subState
 				}
 				sort.Slice(subStates, func(i, j int) bool {
 					return subStates[i].SubID < subStates[j].SubID
@@ -0,0 +1,269 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+const (
+	evictReasonDiskQuota     = "disk-quota"
+	evictReasonRetentionTime = "retention-time"
+	evictReasonRetentionSize = "retention-size"
+)
+
+// evictionPolicy decides when the data of a subscription must be evicted from the store.
+// A zero value of each limit means no limit.
+type evictionPolicy struct {
+	diskQuota     uint64
+	retentionTime time.Duration
+	retentionSize uint64
+	checkInterval time.Duration
+}
+
+func newEvictionPolicy(cfg *config.EventStoreConfig) evictionPolicy {
+	if cfg == nil {
+		cfg = config.NewDefaultEventStoreConfig()
+	}
+	return evictionPolicy{
+		diskQuota:     cfg.DiskQuotaInMB << 20,
+		retentionTime: time.Duration(cfg.RetentionTime),
+		retentionSize: cfg.RetentionSizeInMB << 20,
+		checkInterval: time.Duration(cfg.EvictionCheckInterval),
+	}
+}
+
+func (p evictionPolicy) enabled() bool {
+	return p.diskQuota > 0 || p.retentionTime > 0 || p.retentionSize > 0
+}
+
+// subscriptionUsage is a snapshot of the disk usage of a subscription.
+type subscriptionUsage struct {
+	subID logpuller.SubscriptionID
+	// the min consumed ts of all dispatchers of the subscription
+	consumedTs uint64
+	// the resolved ts persisted in the store for the subscription
+	resolvedTs uint64
+	// the approximate size of data which is not consumed by all dispatchers
+	unconsumedBytes uint64
+	// the approximate size of all data of the subscription
+	totalBytes uint64
+}
+
+type evictionCandidate struct {
+	subID  logpuller.SubscriptionID
+	reason string
+	bytes  uint64
+}
+
+// selectEvictionCandidates returns the subscriptions which should be evicted.
+// Subscriptions exceeding the retention policy are always evicted, the retention time
+// is compared with the lag between the resolved ts and the consumed ts of the subscription.
+// After that, if the disk usage is still over the quota, subscriptions with the oldest
+// unconsumed data are evicted until the disk usage is expected to be under the quota.
+func (p evictionPolicy) selectEvictionCandidates(
+	usages []subscriptionUsage,
+	diskUsage uint64,
+) []evictionCandidate {
+	var candidates []evictionCandidate
+	remaining := make([]subscriptionUsage, 0, len(usages))
+	for _, usage := range usages {
+		if usage.unconsumedBytes == 0 {
+			remaining = append(remaining, usage)
+			continue
+		}
+		lag := time.Duration(oracle.ExtractPhysical(usage.resolvedTs)-oracle.ExtractPhysical(usage.consumedTs)) * time.Millisecond
+		switch {
+		case p.retentionTime > 0 && lag > p.retentionTime:
+			candidates = append(candidates, evictionCandidate{subID: usage.subID, reason: evictReasonRetentionTime, bytes: usage.totalBytes})
+		case p.retentionSize > 0 && usage.unconsumedBytes > p.retentionSize:
+			candidates = append(candidates, evictionCandidate{subID: usage.subID, reason: evictReasonRetentionSize, bytes: usage.totalBytes})
+		default:
+			remaining = append(remaining, usage)
+		}
+	}
+
+	if p.diskQuota == 0 {
+		return candidates
+	}
+	expectedUsage := diskUsage
+	for _, c := range candidates {
+		expectedUsage = saturatingSub(expectedUsage, c.bytes)
+	}
+	if expectedUsage <= p.diskQuota {
+		return candidates
+	}
+	// evict the oldest unconsumed data first
+	sort.Slice(remaining, func(i, j int) bool {
+		return remaining[i].consumedTs < remaining[j].consumedTs
+	})
+	for _, usage := range remaining {
+		if expectedUsage <= p.diskQuota {
+			break
+		}
+		if usage.unconsumedBytes == 0 {
+			continue
+		}
+		candidates = append(candidates, evictionCandidate{subID: usage.subID, reason: evictReasonDiskQuota, bytes: usage.totalBytes})
+		expectedUsage = saturatingSub(expectedUsage, usage.totalBytes)
+	}
+	return candidates
+}
+
+func saturatingSub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+func (e *eventStore) runEvictionChecker(ctx context.Context) error {
+	metrics.EventStoreDiskQuotaGauge.Set(float64(e.evictionPolicy.diskQuota))
+	ticker := time.NewTicker(e.evictionPolicy.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			e.checkAndEvict()
+		}
+	}
+}
+
+func (e *eventStore) checkAndEvict() {
+	diskUsage := uint64(0)
+	for _, db := range e.dbs {
+		diskUsage += db.Metrics().DiskSpaceUsage()
+	}
+	metrics.EventStoreOnDiskDataSizeGauge.Set(float64(diskUsage))
+	if !e.evictionPolicy.enabled() {
+		return
+	}
+
+	candidates := e.evictionPolicy.selectEvictionCandidates(e.collectSubscriptionUsages(), diskUsage)
+	for _, candidate := range candidates {
+		e.evictSubscription(candidate)
+	}
+}
+
+func (e *eventStore) collectSubscriptionUsages() []subscriptionUsage {
+	e.dispatcherMeta.RLock()
+	defer e.dispatcherMeta.RUnlock()
+	usages := make([]subscriptionUsage, 0, len(e.dispatcherMeta.subscriptionStats))
+	for subID, subStat := range e.dispatcherMeta.subscriptionStats {
+		consumedTs := uint64(math.MaxUint64)
+		subStat.dispatchers.RLock()
+		for dispatcherID := range subStat.dispatchers.notifiers {
+			if stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]; ok {
+				consumedTs = min(consumedTs, stat.consumedTs.Load())
+			}
+		}
+		subStat.dispatchers.RUnlock()
+		if consumedTs == math.MaxUint64 {
+			continue
+		}
+		db := e.dbs[subStat.dbIndex]
+		unconsumedBytes, err := db.EstimateDiskUsage(
+			EncodeKeyPrefix(uint64(subID), subStat.tableID, consumedTs+1),
+			EncodeKeyPrefix(uint64(subID), subStat.tableID, math.MaxUint64))
+		if err != nil {
+			log.Warn("estimate disk usage failed", zap.Uint64("subID", uint64(subID)), zap.Error(err))
+			continue
+		}
+		totalBytes, err := db.EstimateDiskUsage(
+			EncodeKeyPrefix(uint64(subID), subStat.tableID, 0),
+			EncodeKeyPrefix(uint64(subID), subStat.tableID, math.MaxUint64))
+		if err != nil {
+			log.Warn("estimate disk usage failed", zap.Uint64("subID", uint64(subID)), zap.Error(err))
+			continue
+		}
+		usages = append(usages, subscriptionUsage{
+			subID:           subID,
+			consumedTs:      consumedTs,
+			resolvedTs:      subStat.resolvedTs.Load(),
+			unconsumedBytes: unconsumedBytes,
+			totalBytes:      totalBytes,
+		})
+	}
+	return usages
+}
+
+// evictSubscription removes the subscription and all its dispatchers from the store,
+// deletes all its data and notifies the dispatchers to register again.
+func (e *eventStore) evictSubscription(candidate evictionCandidate) {
+	e.dispatcherMeta.Lock()
+	subStat, ok := e.dispatcherMeta.subscriptionStats[candidate.subID]
+	if !ok {
+		e.dispatcherMeta.Unlock()
+		return
+	}
+	delete(e.dispatcherMeta.subscriptionStats, candidate.subID)
+
+	subStat.dispatchers.Lock()
+	evictedNotifiers := make([]EvictedNotifier, 0, len(subStat.dispatchers.notifiers))
+	for dispatcherID := range subStat.dispatchers.notifiers {
+		stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]
+		if !ok {
+			log.Panic("should not happen")
+		}
+		if stat.evictedNotifier != nil {
+			evictedNotifiers = append(evictedNotifiers, stat.evictedNotifier)
+		}
+		if stat.catchUpSubID != candidate.subID {
+			e.releaseCatchUp(stat)
+		}
+		delete(e.dispatcherMeta.dispatcherStats, dispatcherID)
+		if dispatchersForSameTable, ok := e.dispatcherMeta.tableToDispatchers[subStat.tableID]; ok {
+			delete(dispatchersForSameTable, dispatcherID)
+			if len(dispatchersForSameTable) == 0 {
+				delete(e.dispatcherMeta.tableToDispatchers, subStat.tableID)
+			}
+		}
+	}
+	subStat.dispatchers.notifiers = make(map[common.DispatcherID]ResolvedTsNotifier)
+	subStat.dispatchers.Unlock()
+	delete(e.dispatcherMeta.catchUps, candidate.subID)
+	e.detachCatchUps(subStat.tableID, candidate.subID)
+
+	e.puller.Unsubscribe(candidate.subID)
+	e.ds.RemovePath(candidate.subID)
+	metrics.EventStoreSubscriptionGauge.Dec()
+	e.dispatcherMeta.Unlock()
+
+	e.gcManager.addGCItem(subStat.dbIndex, uint64(candidate.subID), subStat.tableID, 0, math.MaxUint64)
+	metrics.EventStoreEvictedSubscriptionCount.WithLabelValues(candidate.reason).Inc()
+	metrics.EventStoreEvictedBytes.Add(float64(candidate.bytes))
+	log.Warn("evict subscription from event store",
+		zap.Uint64("subID", uint64(candidate.subID)),
+		zap.Int64("tableID", subStat.tableID),
+		zap.String("reason", candidate.reason),
+		zap.Uint64("bytes", candidate.bytes),
+		zap.Int("dispatcherCount", len(evictedNotifiers)))
+
+	for _, notify := range evictedNotifiers {
+		notify()
+	}
+}
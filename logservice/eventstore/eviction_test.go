@@ -0,0 +1,121 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestSelectEvictionCandidatesByRetention(t *testing.T) {
+	now := time.Now()
+	resolvedTs := oracle.GoTimeToTS(now)
+	policy := evictionPolicy{
+		retentionTime: time.Hour,
+		retentionSize: 100,
+	}
+	usages := []subscriptionUsage{
+		// lag too much
+		{subID: 1, consumedTs: oracle.GoTimeToTS(now.Add(-2 * time.Hour)), resolvedTs: resolvedTs, unconsumedBytes: 10, totalBytes: 20},
+		// too much unconsumed data
+		{subID: 2, consumedTs: oracle.GoTimeToTS(now), resolvedTs: resolvedTs, unconsumedBytes: 200, totalBytes: 300},
+		// lag too much, but no unconsumed data
+		{subID: 3, consumedTs: oracle.GoTimeToTS(now.Add(-2 * time.Hour)), resolvedTs: resolvedTs, unconsumedBytes: 0, totalBytes: 20},
+		// normal
+		{subID: 4, consumedTs: oracle.GoTimeToTS(now), resolvedTs: resolvedTs, unconsumedBytes: 10, totalBytes: 20},
+		// the resolved ts is also stuck, the lag is not large
+		{subID: 5, consumedTs: oracle.GoTimeToTS(now.Add(-2 * time.Hour)), resolvedTs: oracle.GoTimeToTS(now.Add(-90 * time.Minute)), unconsumedBytes: 10, totalBytes: 20},
+	}
+	candidates := policy.selectEvictionCandidates(usages, 0)
+	require.Equal(t, []evictionCandidate{
+		{subID: 1, reason: evictReasonRetentionTime, bytes: 20},
+		{subID: 2, reason: evictReasonRetentionSize, bytes: 300},
+	}, candidates)
+}
+
+func TestSelectEvictionCandidatesByDiskQuota(t *testing.T) {
+	now := time.Now()
+	resolvedTs := oracle.GoTimeToTS(now)
+	policy := evictionPolicy{
+		diskQuota: 1000,
+	}
+	usages := []subscriptionUsage{
+		{subID: 1, consumedTs: oracle.GoTimeToTS(now.Add(-time.Minute)), resolvedTs: resolvedTs, unconsumedBytes: 100, totalBytes: 400},
+		{subID: 2, consumedTs: oracle.GoTimeToTS(now.Add(-3 * time.Minute)), resolvedTs: resolvedTs, unconsumedBytes: 100, totalBytes: 400},
+		{subID: 3, consumedTs: oracle.GoTimeToTS(now.Add(-2 * time.Minute)), resolvedTs: resolvedTs, unconsumedBytes: 100, totalBytes: 400},
+		{subID: 4, consumedTs: oracle.GoTimeToTS(now.Add(-4 * time.Minute)), resolvedTs: resolvedTs, unconsumedBytes: 0, totalBytes: 400},
+	}
+
+	// under the quota
+	candidates := policy.selectEvictionCandidates(usages, 1000)
+	require.Empty(t, candidates)
+
+	// the oldest unconsumed data is evicted first
+	candidates = policy.selectEvictionCandidates(usages, 1600)
+	require.Len(t, candidates, 2)
+	require.Equal(t, logpuller.SubscriptionID(2), candidates[0].subID)
+	require.Equal(t, logpuller.SubscriptionID(3), candidates[1].subID)
+	require.Equal(t, evictReasonDiskQuota, candidates[0].reason)
+}
+
+// Test the evicted dispatcher is notified, and it pulls the data from upstream
+// by a new subscription after it registers again.
+func TestEvictedDispatcherRegisterAgain(t *testing.T) {
+	store := newEventStoreForTest(t)
+	client := logpuller.NewSubscriptionClient(1,
+		&logpuller.SubscriptionClientConfig{AdvanceResolvedTsIntervalInMs: 300},
+		nil, nil, nil, nil, nil)
+	store.puller = logpuller.NewLogPuller(client, nil, nil)
+
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("b")}
+	dispatcherID := common.NewDispatcherID()
+	evicted := 0
+	ok, err := store.RegisterDispatcher(dispatcherID, span, 100,
+		func(uint64, uint64) {}, func() { evicted++ }, false)
+	require.NoError(t, err)
+	require.True(t, ok)
+	oldSubID := store.dispatcherMeta.dispatcherStats[dispatcherID].subID
+	writeEventsForTest(t, store, oldSubID, span.TableID, 110, 120)
+	store.dispatcherMeta.subscriptionStats[oldSubID].resolvedTs.Store(120)
+	require.Equal(t, []uint64{110, 120}, readAllForTest(t, store, dispatcherID, 100, 120))
+
+	store.evictSubscription(evictionCandidate{subID: oldSubID, reason: evictReasonRetentionTime})
+	require.Equal(t, 1, evicted)
+	require.NotContains(t, store.dispatcherMeta.dispatcherStats, dispatcherID)
+	require.NotContains(t, store.dispatcherMeta.subscriptionStats, oldSubID)
+	require.NotContains(t, store.dispatcherMeta.tableToDispatchers, span.TableID)
+
+	// the dispatcher registers again from its checkpoint ts,
+	// no data is reused and a new subscription is created to scan the data from TiKV
+	ok, err = store.RegisterDispatcher(dispatcherID, span, 100,
+		func(uint64, uint64) {}, func() { evicted++ }, true)
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = store.RegisterDispatcher(dispatcherID, span, 100,
+		func(uint64, uint64) {}, func() { evicted++ }, false)
+	require.NoError(t, err)
+	require.True(t, ok)
+	newSubID := store.dispatcherMeta.dispatcherStats[dispatcherID].subID
+	require.NotEqual(t, oldSubID, newSubID)
+	require.Equal(t, uint64(100), store.dispatcherMeta.subscriptionStats[newSubID].checkpointTs.Load())
+	writeEventsForTest(t, store, newSubID, span.TableID, 110, 130)
+	store.dispatcherMeta.subscriptionStats[newSubID].resolvedTs.Store(130)
+	require.Equal(t, []uint64{110, 130}, readAllForTest(t, store, dispatcherID, 100, 130))
+}
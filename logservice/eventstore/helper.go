@@ -41,10 +41,15 @@ func (h *eventsHandler) Handle(subStat *subscriptionStat, events ...kvEvent) boo
 			log.Panic("should not happen")
 		}
 		subStat.resolvedTs.Store(events[0].raw.CRTs)
+		resolvedTs, maxEventCommitTs := events[0].raw.CRTs, subStat.maxEventCommitTs.Load()
+		// the data after catchUpEndTs are read from the shared subscription
+		if catchUpEndTs := subStat.catchUpEndTs.Load(); catchUpEndTs != 0 {
+			resolvedTs, maxEventCommitTs = min(resolvedTs, catchUpEndTs), min(maxEventCommitTs, catchUpEndTs)
+		}
 		subStat.dispatchers.RLock()
 		defer subStat.dispatchers.RUnlock()
 		for _, notifier := range subStat.dispatchers.notifiers {
-			notifier(events[0].raw.CRTs, subStat.maxEventCommitTs.Load())
+			notifier(resolvedTs, maxEventCommitTs)
 		}
 		return false
 	}
@@ -0,0 +1,202 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	"go.uber.org/zap"
+)
+
+// The on-disk format of a value:
+// | version(1 byte) | compression type(1 byte) | payload |
+// The payload is the encoded RawKVEntry compressed by the compression type.
+const (
+	valueFormatVersion1 = 1
+	valueHeaderSize     = 2
+)
+
+type compressionType byte
+
+const (
+	compressionNone compressionType = iota
+	compressionSnappy
+	compressionZstd
+)
+
+func parseCompressionType(s string) (compressionType, error) {
+	switch s {
+	case config.EventStoreCompressionNone:
+		return compressionNone, nil
+	case config.EventStoreCompressionSnappy:
+		return compressionSnappy, nil
+	case config.EventStoreCompressionZstd:
+		return compressionZstd, nil
+	default:
+		return compressionNone, fmt.Errorf("unknown compression type %s", s)
+	}
+}
+
+const (
+	// the max size of a trained zstd dictionary
+	maxZstdDictSize = 64 << 10
+	// the max size of a value used as a sample to train zstd dictionary
+	maxZstdDictSampleSize = 16 << 10
+)
+
+// valueCodec encodes and decodes the values stored in pebble.
+// It is safe for concurrent use.
+type valueCodec struct {
+	compression compressionType
+
+	// zstd encoder and decoder may be replaced after a dictionary is trained.
+	zstdEncoder atomic.Pointer[zstd.Encoder]
+	zstdDecoder atomic.Pointer[zstd.Decoder]
+
+	dictTraining struct {
+		sync.Mutex
+		sampleCount int
+		samples     [][]byte
+		done        bool
+	}
+}
+
+func newValueCodec(compression string, zstdDictSampleCount int) (*valueCodec, error) {
+	tp, err := parseCompressionType(compression)
+	if err != nil {
+		return nil, err
+	}
+	c := &valueCodec{compression: tp}
+	if tp != compressionZstd {
+		return c, nil
+	}
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	c.zstdEncoder.Store(encoder)
+	c.zstdDecoder.Store(decoder)
+	c.dictTraining.sampleCount = zstdDictSampleCount
+	c.dictTraining.done = zstdDictSampleCount <= 0
+	return c, nil
+}
+
+// encode encodes the value with header and compresses it, the result is appended to dst.
+func (c *valueCodec) encode(dst []byte, value []byte) []byte {
+	dst = append(dst, valueFormatVersion1, byte(c.compression))
+	switch c.compression {
+	case compressionNone:
+		return append(dst, value...)
+	case compressionSnappy:
+		return append(dst, snappy.Encode(nil, value)...)
+	case compressionZstd:
+		c.addDictSample(value)
+		return c.zstdEncoder.Load().EncodeAll(value, dst)
+	default:
+		log.Panic("unknown compression type", zap.Uint8("compression", uint8(c.compression)))
+	}
+	return nil
+}
+
+// decode decodes the data written by encode.
+// The compression type is read from the header, so data written with
+// different compression types can be decoded by the same codec.
+// The returned bytes never alias data, which may be reused by the iterator.
+func (c *valueCodec) decode(data []byte) ([]byte, error) {
+	if len(data) < valueHeaderSize {
+		return nil, fmt.Errorf("invalid value length %d", len(data))
+	}
+	if data[0] != valueFormatVersion1 {
+		return nil, fmt.Errorf("unknown value format version %d", data[0])
+	}
+	payload := data[valueHeaderSize:]
+	switch compressionType(data[1]) {
+	case compressionNone:
+		return append([]byte(nil), payload...), nil
+	case compressionSnappy:
+		return snappy.Decode(nil, payload)
+	case compressionZstd:
+		decoder := c.zstdDecoder.Load()
+		if decoder == nil {
+			return nil, fmt.Errorf("zstd decoder is not initialized")
+		}
+		return decoder.DecodeAll(payload, nil)
+	default:
+		return nil, fmt.Errorf("unknown compression type %d", data[1])
+	}
+}
+
+// addDictSample collects values to train a zstd dictionary.
+// After enough samples are collected, a dictionary is trained in background,
+// and the following values are compressed with the dictionary.
+func (c *valueCodec) addDictSample(value []byte) {
+	c.dictTraining.Lock()
+	if c.dictTraining.done {
+		c.dictTraining.Unlock()
+		return
+	}
+	if len(value) > maxZstdDictSampleSize {
+		value = value[:maxZstdDictSampleSize]
+	}
+	c.dictTraining.samples = append(c.dictTraining.samples, append([]byte(nil), value...))
+	if len(c.dictTraining.samples) < c.dictTraining.sampleCount {
+		c.dictTraining.Unlock()
+		return
+	}
+	samples := c.dictTraining.samples
+	c.dictTraining.samples = nil
+	c.dictTraining.done = true
+	c.dictTraining.Unlock()
+
+	go c.trainDict(samples)
+}
+
+func (c *valueCodec) trainDict(samples [][]byte) {
+	zstdDict, err := dict.BuildZstdDict(samples, dict.Options{
+		MaxDictSize: maxZstdDictSize,
+		HashBytes:   6,
+	})
+	if err != nil {
+		log.Warn("train zstd dictionary failed, continue without dictionary", zap.Error(err))
+		return
+	}
+	// The new decoder can decode values compressed with or without the dictionary,
+	// so it must be ready before any value is compressed with the dictionary.
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderDicts(zstdDict))
+	if err != nil {
+		log.Warn("create zstd decoder with dictionary failed", zap.Error(err))
+		return
+	}
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderDict(zstdDict))
+	if err != nil {
+		log.Warn("create zstd encoder with dictionary failed", zap.Error(err))
+		return
+	}
+	c.zstdDecoder.Store(decoder)
+	c.zstdEncoder.Store(encoder)
+	log.Info("zstd dictionary is trained for event store",
+		zap.Int("sampleCount", len(samples)),
+		zap.Int("dictSize", len(zstdDict)))
+}
@@ -0,0 +1,204 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+// newWideRowEntry returns an entry like a row of a wide table,
+// most columns are similar between rows and some columns are random.
+func newWideRowEntry(rnd *rand.Rand, commitTs uint64) *common.RawKVEntry {
+	value := make([]byte, 0, 4096)
+	for i := 0; i < 64; i++ {
+		value = append(value, fmt.Sprintf("column_%02d_value_%d;", i, rnd.Intn(16))...)
+	}
+	randomBytes := make([]byte, 256)
+	rnd.Read(randomBytes)
+	value = append(value, randomBytes...)
+	return &common.RawKVEntry{
+		OpType:   common.OpTypePut,
+		CRTs:     commitTs,
+		StartTs:  commitTs - 1,
+		RegionID: 1,
+		Key:      []byte(fmt.Sprintf("t_100_r_%d", commitTs)),
+		Value:    value,
+	}
+}
+
+func TestValueCodecRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	compressions := []string{
+		config.EventStoreCompressionNone,
+		config.EventStoreCompressionSnappy,
+		config.EventStoreCompressionZstd,
+	}
+	// data written with any compression can be decoded by any codec.
+	decoder, err := newValueCodec(config.EventStoreCompressionZstd, 0)
+	require.NoError(t, err)
+	for _, compression := range compressions {
+		codec, err := newValueCodec(compression, 0)
+		require.NoError(t, err)
+		entry := newWideRowEntry(rnd, 100)
+		value := entry.Encode()
+		encoded := codec.encode(nil, value)
+		require.Equal(t, byte(valueFormatVersion1), encoded[0])
+
+		decoded, err := codec.decode(encoded)
+		require.NoError(t, err)
+		require.Equal(t, value, decoded)
+		decoded, err = decoder.decode(encoded)
+		require.NoError(t, err)
+		require.Equal(t, value, decoded)
+	}
+
+	_, err = newValueCodec("lz4", 0)
+	require.Error(t, err)
+
+	codec, err := newValueCodec(config.EventStoreCompressionNone, 0)
+	require.NoError(t, err)
+	_, err = codec.decode([]byte{valueFormatVersion1})
+	require.Error(t, err)
+	_, err = codec.decode([]byte{valueFormatVersion1 + 1, byte(compressionNone), 1})
+	require.Error(t, err)
+
+	// the decoded value must not alias the input buffer.
+	encoded := codec.encode(nil, []byte("value"))
+	decoded, err := codec.decode(encoded)
+	require.NoError(t, err)
+	for i := range encoded {
+		encoded[i] = 0
+	}
+	require.Equal(t, []byte("value"), decoded)
+}
+
+func TestValueCodecWithZstdDict(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	codec, err := newValueCodec(config.EventStoreCompressionZstd, 100)
+	require.NoError(t, err)
+
+	var values, encodedValues [][]byte
+	for i := 0; i < 100; i++ {
+		value := newWideRowEntry(rnd, uint64(100+i)).Encode()
+		values = append(values, value)
+		encodedValues = append(encodedValues, codec.encode(nil, value))
+	}
+	// wait the dictionary is trained in background
+	initialEncoder := codec.zstdEncoder.Load()
+	require.Eventually(t, func() bool {
+		return codec.zstdEncoder.Load() != initialEncoder
+	}, 10*time.Second, 10*time.Millisecond)
+
+	for i := 0; i < 100; i++ {
+		value := newWideRowEntry(rnd, uint64(200+i)).Encode()
+		values = append(values, value)
+		encodedValues = append(encodedValues, codec.encode(nil, value))
+	}
+	// values compressed with and without dictionary can both be decoded
+	for i := range values {
+		decoded, err := codec.decode(encodedValues[i])
+		require.NoError(t, err)
+		require.Equal(t, values[i], decoded)
+	}
+}
+
+// BenchmarkValueCodec compares the throughput and compression ratio of different compressions.
+func BenchmarkValueCodec(b *testing.B) {
+	benchmarks := []struct {
+		compression     string
+		dictSampleCount int
+	}{
+		{config.EventStoreCompressionNone, 0},
+		{config.EventStoreCompressionSnappy, 0},
+		{config.EventStoreCompressionZstd, 0},
+		{config.EventStoreCompressionZstd, 1000},
+	}
+	for _, bm := range benchmarks {
+		name := fmt.Sprintf("%s-dict-%d", bm.compression, bm.dictSampleCount)
+		b.Run(name, func(b *testing.B) {
+			rnd := rand.New(rand.NewSource(1))
+			codec, err := newValueCodec(bm.compression, bm.dictSampleCount)
+			require.NoError(b, err)
+			values := make([][]byte, 0, 2000)
+			for i := 0; i < 2000; i++ {
+				values = append(values, newWideRowEntry(rnd, uint64(i+1)).Encode())
+			}
+			// warm up to train the dictionary
+			initialEncoder := codec.zstdEncoder.Load()
+			for _, value := range values {
+				codec.encode(nil, value)
+			}
+			for bm.dictSampleCount > 0 && codec.zstdEncoder.Load() == initialEncoder {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			var rawSize, encodedSize int
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				value := values[i%len(values)]
+				encoded := codec.encode(nil, value)
+				if _, err := codec.decode(encoded); err != nil {
+					b.Fatal(err)
+				}
+				rawSize += len(value)
+				encodedSize += len(encoded)
+			}
+			b.SetBytes(int64(rawSize / b.N))
+			b.ReportMetric(float64(encodedSize)/float64(rawSize), "size-ratio")
+		})
+	}
+}
+
+// BenchmarkWriteEventsDiskUsage compares the write throughput and disk usage of pebble
+// when values are stored with different compressions.
+func BenchmarkWriteEventsDiskUsage(b *testing.B) {
+	for _, compression := range []string{
+		config.EventStoreCompressionNone,
+		config.EventStoreCompressionSnappy,
+		config.EventStoreCompressionZstd,
+	} {
+		b.Run(compression, func(b *testing.B) {
+			rnd := rand.New(rand.NewSource(1))
+			codec, err := newValueCodec(compression, 0)
+			require.NoError(b, err)
+			db, err := pebble.Open(b.TempDir(), &pebble.Options{})
+			require.NoError(b, err)
+			defer db.Close()
+
+			b.ResetTimer()
+			batch := db.NewBatch()
+			for i := 0; i < b.N; i++ {
+				entry := newWideRowEntry(rnd, uint64(i+1))
+				key := EncodeKey(1, 100, entry)
+				require.NoError(b, batch.Set(key, codec.encode(nil, entry.Encode()), pebble.NoSync))
+				if batch.Len() > 1<<20 {
+					require.NoError(b, batch.Commit(pebble.NoSync))
+					batch = db.NewBatch()
+				}
+			}
+			require.NoError(b, batch.Commit(pebble.NoSync))
+			require.NoError(b, db.Flush())
+			b.StopTimer()
+			b.ReportMetric(float64(db.Metrics().DiskSpaceUsage())/float64(b.N), "disk-bytes/op")
+		})
+	}
+}
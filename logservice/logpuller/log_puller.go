@@ -223,6 +223,24 @@ func (p *LogPuller) Unsubscribe(subID SubscriptionID) {
 	p.client.Unsubscribe(progress.subID)
 }
 
+// GetStalledRegions returns the subscribed regions whose resolved ts is stalled
+// longer than the threshold.
+func (p *LogPuller) GetStalledRegions(ctx context.Context, threshold time.Duration) []StalledRegion {
+	return p.client.GetStalledRegions(ctx, threshold)
+}
+
+// GetStoreHealth returns the health of the TiKV stores requested by the log puller.
+func (p *LogPuller) GetStoreHealth() []StoreHealth {
+	return p.client.GetStoreHealth()
+}
+
+// ResolveLocks resolves the locks before the current time in the subscribed regions
+// of the table or the region immediately, it returns the IDs of the resolved regions.
+func (p *LogPuller) ResolveLocks(ctx context.Context, tableID int64, regionID uint64) ([]uint64, error) {
+	targetTs := oracle.GoTimeToTS(p.pdClock.CurrentTime())
+	return p.client.ResolveLocksNow(ctx, tableID, regionID, targetTs)
+}
+
 func (p *LogPuller) getProgress(subID SubscriptionID) *spanProgress {
 	p.subscriptions.RLock()
 	defer p.subscriptions.RUnlock()
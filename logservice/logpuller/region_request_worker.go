@@ -120,7 +120,14 @@ func newRegionRequestWorker(
 			if err := waitForPreFetching(); err != nil {
 				return err
 			}
-			if canceled := worker.run(ctx, credential); canceled {
+			// Don't connect the store until the cooldown passes if its circuit is open.
+			if cooldown := store.health.cooldown(); cooldown > 0 {
+				if err := util.Hang(ctx, cooldown); err != nil {
+					return err
+				}
+			}
+			canceled, backoff := worker.run(ctx, credential)
+			if canceled {
 				return nil
 			}
 			for _, m := range worker.clearRegionStates() {
@@ -139,7 +146,8 @@ func newRegionRequestWorker(
 				}
 				client.onRegionFail(newRegionErrorInfo(region, &sendRequestToStoreErr{}))
 			}
-			if err := util.Hang(ctx, time.Second); err != nil {
+			// The store may be unhealthy, back off before reconnecting it.
+			if err := util.Hang(ctx, backoff); err != nil {
 				return err
 			}
 		}
@@ -148,7 +156,9 @@ func newRegionRequestWorker(
 	return worker
 }
 
-func (s *regionRequestWorker) run(ctx context.Context, credential *security.Credential) (canceled bool) {
+// run creates a grpc stream to the store and handles the regions until the stream fails,
+// it returns the interval to wait before reconnecting the store if it's not canceled.
+func (s *regionRequestWorker) run(ctx context.Context, credential *security.Credential) (canceled bool, backoff time.Duration) {
 	isCanceled := func() bool {
 		select {
 		case <-ctx.Done():
@@ -172,10 +182,12 @@ func (s *regionRequestWorker) run(ctx context.Context, credential *security.Cred
 			zap.Uint64("workerID", s.workerID),
 			zap.Uint64("storeID", s.store.storeID),
 			zap.String("addr", s.store.storeAddr),
-			zap.Bool("canceled", canceled))
+			zap.Bool("canceled", canceled),
+			zap.Duration("backoff", backoff))
 	}()
 
 	g, gctx := errgroup.WithContext(ctx)
+	start := time.Now()
 	cc, err := Connect(gctx, credential, s.store.storeAddr)
 	if err != nil {
 		log.Warn("region request worker create grpc stream failed",
@@ -184,8 +196,12 @@ func (s *regionRequestWorker) run(ctx context.Context, credential *security.Cred
 			zap.Uint64("storeID", s.store.storeID),
 			zap.String("addr", s.store.storeAddr),
 			zap.Error(err))
-		return isCanceled()
+		if isCanceled() {
+			return true, 0
+		}
+		return false, s.store.health.onStreamFailure(s.workerID, err)
 	}
+	s.store.health.onConnect(time.Since(start))
 	defer func() {
 		_ = cc.Conn.Close()
 	}()
@@ -195,8 +211,11 @@ func (s *regionRequestWorker) run(ctx context.Context, credential *security.Cred
 	})
 	g.Go(func() error { return s.processRegionSendTask(gctx, cc) })
 	g.Go(func() error { return s.sendBatchedResolvedTs(gctx) })
-	_ = g.Wait()
-	return isCanceled()
+	err = g.Wait()
+	if isCanceled() {
+		return true, 0
+	}
+	return false, s.store.health.onStreamFailure(s.workerID, err)
 }
 
 // receiveAndDispatchChangeEventsToProcessor receives events from the grpc stream and dispatches them to the processor.
@@ -219,6 +238,7 @@ func (s *regionRequestWorker) receiveAndDispatchChangeEventsToProcessor(
 			}
 			return errors.Trace(err)
 		}
+		s.store.health.onReceive(time.Now())
 		if len(changeEvent.Events) > 0 {
 			if err := s.dispatchRegionChangeEvents(ctx, changeEvent.Events); err != nil {
 				return err
@@ -238,6 +258,13 @@ func (s *regionRequestWorker) processRegionSendTask(
 	conn *ConnAndClient,
 ) error {
 	doSend := func(req *cdcpb.ChangeDataRequest, subscriptionID SubscriptionID) error {
+		// Hold back the request until the cooldown passes if the circuit of the store is open,
+		// it may be opened by the failures of other workers.
+		if cooldown := s.store.health.cooldown(); cooldown > 0 {
+			if err := util.Hang(ctx, cooldown); err != nil {
+				return err
+			}
+		}
 		if err := conn.Client.Send(req); err != nil {
 			log.Warn("region request worker send request to grpc stream failed",
 				zap.Int("subscriptionClientID", int(s.client.id)),
@@ -249,7 +276,7 @@ func (s *regionRequestWorker) processRegionSendTask(
 				zap.Error(err))
 			return errors.Trace(err)
 		}
-		// TODO: add a metric?
+		s.store.health.onRequest()
 		return nil
 	}
 
@@ -381,6 +408,16 @@ func (s *regionRequestWorker) clearRegionStates() map[SubscriptionID]regionFeedS
 	return subscriptions
 }
 
+func (s *regionRequestWorker) regionCount() int {
+	s.requestedRegions.RLock()
+	defer s.requestedRegions.RUnlock()
+	count := 0
+	for _, states := range s.requestedRegions.subscriptions {
+		count += len(states)
+	}
+	return count
+}
+
 func (s *regionRequestWorker) clearPendingRegions() []regionInfo {
 	regions := make([]regionInfo, 0, len(s.requestsCh))
 	if s.preFetchForConnecting != nil {
@@ -403,6 +440,7 @@ func (s *regionRequestWorker) dispatchRegionChangeEvents(ctx context.Context, ev
 		state := s.getRegionState(subscriptionID, regionID)
 		switch x := event.Event.(type) {
 		case *cdcpb.Event_Error:
+			s.store.health.onRegionError()
 			log.Debug("region request worker receives a region error",
 				zap.Int("subscriptionClientID", int(s.client.id)),
 				zap.Uint64("workerID", s.workerID),
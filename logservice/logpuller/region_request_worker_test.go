@@ -14,9 +14,21 @@
 package logpuller
 
 import (
+	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/cdcpb"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller/regionlock"
+	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikv"
+	"golang.org/x/sync/errgroup"
 )
 
 func TestRegionStatesOperation(t *testing.T) {
@@ -38,3 +50,135 @@ func TestRegionStatesOperation(t *testing.T) {
 	require.Nil(t, worker.getRegionState(1, 2))
 	require.Equal(t, 0, len(worker.requestedRegions.subscriptions))
 }
+
+// flappingChangeDataServer fails the streams immediately when fail is set,
+// it counts the streams and the requests reaching it.
+type flappingChangeDataServer struct {
+	*mockChangeDataServer
+	fail     atomic.Bool
+	streams  atomic.Int64
+	requests atomic.Int64
+}
+
+type countingEventFeedServer struct {
+	cdcpb.ChangeData_EventFeedV2Server
+	requests *atomic.Int64
+}
+
+func (s *countingEventFeedServer) Recv() (*cdcpb.ChangeDataRequest, error) {
+	req, err := s.ChangeData_EventFeedV2Server.Recv()
+	if err == nil {
+		s.requests.Add(1)
+	}
+	return req, err
+}
+
+func (m *flappingChangeDataServer) EventFeedV2(s cdcpb.ChangeData_EventFeedV2Server) error {
+	m.streams.Add(1)
+	if m.fail.Load() {
+		return errors.New("mock store failure")
+	}
+	return m.EventFeed(&countingEventFeedServer{ChangeData_EventFeedV2Server: s, requests: &m.requests})
+}
+
+func TestRegionRequestWorkerStoreHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+
+	eventsCh := make(chan *cdcpb.ChangeDataEvent, 10)
+	srv := &flappingChangeDataServer{mockChangeDataServer: newMockChangeDataServer(eventsCh)}
+	srv.fail.Store(true)
+	server, addr := newMockService(ctx, t, srv, wg)
+
+	clientConfig := &SubscriptionClientConfig{
+		RegionRequestWorkerPerStore:   1,
+		ChangeEventProcessorNum:       1,
+		AdvanceResolvedTsIntervalInMs: 300,
+	}
+	client := NewSubscriptionClient(ClientIDTest, clientConfig, nil, nil, nil, nil, &security.Credential{})
+	processor := newChangeEventProcessor(0, client)
+	client.changeEventProcessors = []*changeEventProcessor{processor}
+
+	g, gctx := errgroup.WithContext(ctx)
+	store := &requestedStore{storeID: 1, storeAddr: addr, health: newStoreHealth(client.id, 1, addr)}
+	worker := newRegionRequestWorker(gctx, client, &security.Credential{}, g, store)
+	store.requestWorkers = append(store.requestWorkers, worker)
+	client.stores.storeMap[1] = store
+
+	span := heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("b")}
+	subscribedSpan := client.newSubscribedSpan(1, span, 1)
+	g.Go(func() error {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-gctx.Done():
+				return nil
+			case <-processor.inputCh:
+			case <-ticker.C:
+				region := newRegionInfo(tikv.NewRegionVerID(11, 1, 1), span,
+					&tikv.RPCContext{Meta: &metapb.Region{RegionEpoch: &metapb.RegionEpoch{}}}, subscribedSpan)
+				region.lockedRangeState = &regionlock.LockedRangeState{}
+				select {
+				case worker.requestsCh <- region:
+				default:
+				}
+			}
+		}
+	})
+
+	// the circuit opens after consecutive stream failures
+	require.Eventually(t, func() bool {
+		return client.isStoreUnhealthy(1)
+	}, 10*time.Second, 50*time.Millisecond)
+	health := client.GetStoreHealth()
+	require.Len(t, health, 1)
+	require.True(t, health[0].Unhealthy)
+	require.GreaterOrEqual(t, health[0].StreamRestarts, uint64(storeBreakThreshold))
+	require.Greater(t, health[0].Backoff, storeMinBackoff)
+	require.Contains(t, health[0].LastError, "mock store failure")
+	// no stream reaches the store in the cooldown
+	streams := srv.streams.Load()
+	require.Never(t, func() bool {
+		return srv.streams.Load() != streams
+	}, store.health.cooldown()-200*time.Millisecond, 50*time.Millisecond)
+
+	// the circuit closes after events are received from the store
+	srv.fail.Store(false)
+	require.Eventually(t, func() bool {
+		select {
+		case eventsCh <- mockTsEvent(11, 100, 1):
+		default:
+		}
+		return !client.isStoreUnhealthy(1)
+	}, 10*time.Second, 50*time.Millisecond)
+	health = client.GetStoreHealth()
+	require.Equal(t, 0, health[0].ConsecutiveFailures)
+	require.False(t, health[0].LastReceive.IsZero())
+	require.Greater(t, health[0].RTT, time.Duration(0))
+
+	// the circuit is opened by the failures of another worker of the store,
+	// the requests are held back in the cooldown even if the stream is connected
+	require.Eventually(t, func() bool {
+		return srv.requests.Load() > 0
+	}, 10*time.Second, 50*time.Millisecond)
+	for i := 0; i < storeBreakThreshold; i++ {
+		store.health.onStreamFailure(worker.workerID+1, errors.New("mock worker failure"))
+	}
+	require.True(t, client.isStoreUnhealthy(1))
+	// the request being sent when the circuit opens may reach the store
+	time.Sleep(100 * time.Millisecond)
+	requests := srv.requests.Load()
+	require.Never(t, func() bool {
+		return srv.requests.Load() != requests
+	}, store.health.cooldown()-200*time.Millisecond, 50*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return srv.requests.Load() > requests
+	}, 10*time.Second, 50*time.Millisecond)
+
+	cancel()
+	_ = g.Wait()
+	srv.wg.Wait()
+	server.Stop()
+	wg.Wait()
+}
@@ -0,0 +1,228 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller/regionlock"
+	kvclientv2 "github.com/tikv/client-go/v2/kv"
+	"github.com/tikv/client-go/v2/oracle"
+	"github.com/tikv/client-go/v2/tikv"
+	"github.com/tikv/client-go/v2/txnkv"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// maxStalledRegions is the max number of the stalled regions diagnosed in a request,
+	// the regions lagging behind most are kept.
+	maxStalledRegions = 1024
+	// diagnoseRegionConcurrency is the max number of the regions diagnosed at the same time.
+	diagnoseRegionConcurrency = 16
+	// diagnoseRegionTimeout is the timeout of locating and scanning the locks of a region.
+	diagnoseRegionTimeout = 10 * time.Second
+)
+
+// StalledRegion is a subscribed region whose resolved ts doesn't advance
+// for longer than a threshold.
+type StalledRegion struct {
+	SubscriptionID SubscriptionID
+	// Span is the span of the subscription the region belongs to.
+	Span      heartbeatpb.TableSpan
+	RegionID  uint64
+	StoreID   uint64
+	StoreAddr string
+	// StoreUnhealthy is true if the circuit of the store is open.
+	StoreUnhealthy bool
+	Initialized    bool
+	ResolvedTs     uint64
+	// Locks are the locks in the region which may block the resolved ts,
+	// at most 1024 locks are scanned.
+	Locks []*txnkv.Lock
+	// Error is the error met when diagnosing the region.
+	Error string
+}
+
+type subscribedRegion struct {
+	subID       SubscriptionID
+	span        heartbeatpb.TableSpan
+	regionID    uint64
+	resolvedTs  uint64
+	initialized bool
+}
+
+// collectRegions returns the subscribed regions which satisfy the filter.
+func (s *SubscriptionClient) collectRegions(
+	filter func(rt *subscribedSpan, regionID uint64, state *regionlock.LockedRangeState) bool,
+) []subscribedRegion {
+	var regions []subscribedRegion
+	s.totalSpans.RLock()
+	defer s.totalSpans.RUnlock()
+	for subID, rt := range s.totalSpans.spanMap {
+		if rt.stopped.Load() {
+			continue
+		}
+		rt.rangeLock.IterAll(func(regionID uint64, state *regionlock.LockedRangeState) {
+			if !filter(rt, regionID, state) {
+				return
+			}
+			regions = append(regions, subscribedRegion{
+				subID:       subID,
+				span:        rt.span,
+				regionID:    regionID,
+				resolvedTs:  state.ResolvedTs.Load(),
+				initialized: state.Initialized.Load(),
+			})
+		})
+	}
+	sort.Slice(regions, func(i, j int) bool {
+		if regions[i].subID != regions[j].subID {
+			return regions[i].subID < regions[j].subID
+		}
+		return regions[i].regionID < regions[j].regionID
+	})
+	return regions
+}
+
+// GetStalledRegions returns the subscribed regions whose resolved ts lags behind
+// the current time more than the threshold, the leader store of the regions
+// and the locks before the current time in the regions are also returned.
+// At most maxStalledRegions regions lagging behind most are returned.
+func (s *SubscriptionClient) GetStalledRegions(ctx context.Context, threshold time.Duration) []StalledRegion {
+	return s.getStalledRegions(ctx, threshold, maxStalledRegions)
+}
+
+func (s *SubscriptionClient) getStalledRegions(ctx context.Context, threshold time.Duration, limit int) []StalledRegion {
+	currentTime := s.pdClock.CurrentTime()
+	regions := s.collectRegions(func(_ *subscribedSpan, _ uint64, state *regionlock.LockedRangeState) bool {
+		resolvedTime := oracle.GetTimeFromTS(state.ResolvedTs.Load())
+		return currentTime.Sub(resolvedTime) > threshold
+	})
+	if len(regions) > limit {
+		log.Warn("subscription client finds too many stalled regions, only diagnose part of them",
+			zap.Int("subscriptionClientID", int(s.id)),
+			zap.Duration("threshold", threshold),
+			zap.Int("stalledRegions", len(regions)),
+			zap.Int("limit", limit))
+		// keep the regions lagging behind most, and restore the order of the subscriptions.
+		sort.SliceStable(regions, func(i, j int) bool {
+			return regions[i].resolvedTs < regions[j].resolvedTs
+		})
+		regions = regions[:limit]
+		sort.Slice(regions, func(i, j int) bool {
+			if regions[i].subID != regions[j].subID {
+				return regions[i].subID < regions[j].subID
+			}
+			return regions[i].regionID < regions[j].regionID
+		})
+	}
+
+	maxVersion := oracle.GoTimeToTS(currentTime)
+	stalled := make([]StalledRegion, len(regions))
+	g := new(errgroup.Group)
+	g.SetLimit(diagnoseRegionConcurrency)
+	for i, region := range regions {
+		stalled[i] = StalledRegion{
+			SubscriptionID: region.subID,
+			Span:           region.span,
+			RegionID:       region.regionID,
+			Initialized:    region.initialized,
+			ResolvedTs:     region.resolvedTs,
+		}
+		stalledRegion := &stalled[i]
+		g.Go(func() error {
+			if err := s.diagnoseRegion(ctx, stalledRegion, maxVersion); err != nil {
+				stalledRegion.Error = err.Error()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return stalled
+}
+
+// diagnoseRegion fills the leader store and the locks of the stalled region.
+func (s *SubscriptionClient) diagnoseRegion(ctx context.Context, region *StalledRegion, maxVersion uint64) error {
+	ctx, cancel := context.WithTimeout(ctx, diagnoseRegionTimeout)
+	defer cancel()
+	if s.regionCache != nil {
+		bo := tikv.NewBackoffer(ctx, tikvRequestMaxBackoff)
+		loc, err := s.regionCache.LocateRegionByID(bo, region.RegionID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rpcCtx, err := s.regionCache.GetTiKVRPCContext(bo, loc.Region, kvclientv2.ReplicaReadLeader, 0)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if rpcCtx != nil {
+			region.StoreID = rpcCtx.Peer.GetStoreId()
+			region.StoreAddr = rpcCtx.Addr
+			region.StoreUnhealthy = s.isStoreUnhealthy(region.StoreID)
+		}
+	}
+	if s.lockResolver != nil {
+		locks, err := s.lockResolver.ScanLocks(ctx, region.RegionID, maxVersion)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		region.Locks = locks
+	}
+	return nil
+}
+
+// ResolveLocksNow resolves the locks before targetTs in the subscribed regions immediately,
+// regions are filtered by the tableID and the regionID if they are not zero.
+// It returns the IDs of the regions whose locks are resolved.
+func (s *SubscriptionClient) ResolveLocksNow(
+	ctx context.Context, tableID int64, regionID uint64, targetTs uint64,
+) ([]uint64, error) {
+	if s.lockResolver == nil {
+		return nil, errors.New("lock resolver is not set")
+	}
+	regions := s.collectRegions(func(rt *subscribedSpan, id uint64, _ *regionlock.LockedRangeState) bool {
+		return (tableID == 0 || rt.span.TableID == tableID) && (regionID == 0 || id == regionID)
+	})
+
+	resolved := make([]uint64, 0, len(regions))
+	seen := make(map[uint64]struct{}, len(regions))
+	for _, region := range regions {
+		if _, ok := seen[region.regionID]; ok {
+			continue
+		}
+		seen[region.regionID] = struct{}{}
+		if err := s.lockResolver.Resolve(ctx, region.regionID, targetTs); err != nil {
+			log.Warn("subscription client resolve lock manually fail",
+				zap.Int("subscriptionClientID", int(s.id)),
+				zap.Uint64("subscriptionID", uint64(region.subID)),
+				zap.Uint64("regionID", region.regionID),
+				zap.Error(err))
+			return resolved, errors.Trace(err)
+		}
+		resolved = append(resolved, region.regionID)
+	}
+	log.Info("subscription client resolves locks manually",
+		zap.Int("subscriptionClientID", int(s.id)),
+		zap.Int64("tableID", tableID),
+		zap.Uint64("regionID", regionID),
+		zap.Uint64("targetTs", targetTs),
+		zap.Uint64s("resolvedRegions", resolved))
+	return resolved, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+	"github.com/tikv/client-go/v2/txnkv"
+)
+
+type mockLockResolver struct {
+	locks    map[uint64][]*txnkv.Lock
+	resolved []uint64
+}
+
+func (r *mockLockResolver) Resolve(_ context.Context, regionID uint64, _ uint64) error {
+	r.resolved = append(r.resolved, regionID)
+	return nil
+}
+
+func (r *mockLockResolver) ScanLocks(_ context.Context, regionID uint64, _ uint64) ([]*txnkv.Lock, error) {
+	return r.locks[regionID], nil
+}
+
+func TestStalledRegions(t *testing.T) {
+	lockResolver := &mockLockResolver{
+		locks: map[uint64][]*txnkv.Lock{
+			1: {txnkv.NewLock(&kvrpcpb.LockInfo{LockVersion: 100, PrimaryLock: []byte("a"), Key: []byte("a")})},
+		},
+	}
+	clientConfig := &SubscriptionClientConfig{
+		RegionRequestWorkerPerStore:   1,
+		ChangeEventProcessorNum:       1,
+		AdvanceResolvedTsIntervalInMs: 300,
+	}
+	client := NewSubscriptionClient(ClientIDTest, clientConfig, nil, nil,
+		pdutil.NewClock4Test(), lockResolver, &security.Credential{})
+
+	staleTs := oracle.GoTimeToTS(time.Now().Add(-time.Hour))
+	subscribe := func(subID SubscriptionID, span heartbeatpb.TableSpan) *subscribedSpan {
+		rt := client.newSubscribedSpan(subID, span, staleTs)
+		client.totalSpans.spanMap[subID] = rt
+		return rt
+	}
+	lockRegion := func(rt *subscribedSpan, regionID uint64, start, end string, resolvedTs uint64) {
+		res := rt.rangeLock.LockRange(context.Background(), []byte(start), []byte(end), regionID, 1)
+		res.LockedRangeState.Initialized.Store(true)
+		res.LockedRangeState.ResolvedTs.Store(resolvedTs)
+	}
+	rt1 := subscribe(1, heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("c")})
+	rt2 := subscribe(2, heartbeatpb.TableSpan{TableID: 2, StartKey: []byte("c"), EndKey: []byte("d")})
+	lockRegion(rt1, 1, "a", "b", staleTs)
+	lockRegion(rt1, 2, "b", "c", oracle.GoTimeToTS(time.Now()))
+	lockRegion(rt2, 3, "c", "d", staleTs)
+
+	stalled := client.GetStalledRegions(context.Background(), time.Minute)
+	require.Len(t, stalled, 2)
+	require.Equal(t, SubscriptionID(1), stalled[0].SubscriptionID)
+	require.Equal(t, uint64(1), stalled[0].RegionID)
+	require.Equal(t, staleTs, stalled[0].ResolvedTs)
+	require.True(t, stalled[0].Initialized)
+	require.Len(t, stalled[0].Locks, 1)
+	require.Equal(t, uint64(100), stalled[0].Locks[0].TxnID)
+	require.Equal(t, uint64(3), stalled[1].RegionID)
+	require.Equal(t, int64(2), stalled[1].Span.TableID)
+	require.Empty(t, stalled[1].Locks)
+
+	// resolve locks of a table
+	resolved, err := client.ResolveLocksNow(context.Background(), 1, 0, oracle.GoTimeToTS(time.Now()))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2}, resolved)
+	// resolve locks of a region
+	resolved, err = client.ResolveLocksNow(context.Background(), 0, 3, oracle.GoTimeToTS(time.Now()))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3}, resolved)
+	// the region doesn't belong to the table
+	resolved, err = client.ResolveLocksNow(context.Background(), 1, 3, oracle.GoTimeToTS(time.Now()))
+	require.NoError(t, err)
+	require.Empty(t, resolved)
+	require.Equal(t, []uint64{1, 2, 3}, lockResolver.resolved)
+
+	// all regions are stalled with the zero threshold, the most lagging ones are kept
+	rt3 := subscribe(3, heartbeatpb.TableSpan{TableID: 3, StartKey: []byte("d"), EndKey: []byte("e")})
+	lockRegion(rt3, 4, "d", "e", staleTs-1)
+	stalled = client.getStalledRegions(context.Background(), 0, 2)
+	require.Len(t, stalled, 2)
+	require.Equal(t, uint64(1), stalled[0].RegionID)
+	require.Equal(t, uint64(4), stalled[1].RegionID)
+
+	// the locks can't be resolved without the lock resolver
+	client.lockResolver = nil
+	_, err = client.ResolveLocksNow(context.Background(), 1, 0, oracle.GoTimeToTS(time.Now()))
+	require.Error(t, err)
+}
@@ -0,0 +1,330 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// storeHealthWindow is the window to calculate the region error rate of a store.
+	storeHealthWindow = time.Minute
+	// storeBreakThreshold is the number of consecutive stream failures of a worker to open the circuit of a store.
+	storeBreakThreshold = 3
+	// storeMinBackoff is the interval to reconnect a store when the circuit is closed.
+	storeMinBackoff = time.Second
+	// storeMaxBackoff is the max cooldown of a store when the circuit is open.
+	storeMaxBackoff = 30 * time.Second
+)
+
+// StoreHealth is the health of a TiKV store observed by the subscription client.
+type StoreHealth struct {
+	StoreID   uint64
+	StoreAddr string
+	// Unhealthy is true if the circuit of the store is open.
+	Unhealthy   bool
+	RegionCount int
+	// ErrorRate is the ratio of region errors to region requests in the recent window.
+	ErrorRate      float64
+	StreamRestarts uint64
+	// ConsecutiveFailures is the max consecutive stream failures of the workers.
+	ConsecutiveFailures int
+	// RTT is the smoothed duration of establishing a stream to the store.
+	RTT         time.Duration
+	LastReceive time.Time
+	// Backoff is the interval before reconnecting the store,
+	// it's the cooldown if the circuit is open.
+	Backoff   time.Duration
+	LastError string
+}
+
+// storeHealth tracks the health of a TiKV store, it's shared by all region request workers
+// of the store. The circuit of the store opens after a worker fails storeBreakThreshold
+// times consecutively, then no stream is established and no request is sent to the store
+// by any worker until the cooldown passes. The cooldown doubles every time a stream fails
+// after it passes, until a stream receives events from the store again.
+type storeHealth struct {
+	storeID   uint64
+	storeAddr string
+
+	// lastReceive is the unix nano time of the last event received from the store.
+	lastReceive atomic.Int64
+	// failing is true if there are stream failures not recovered yet,
+	// it's used to avoid locking in the event receiving path.
+	failing atomic.Bool
+	// openUntil is the unix nano time when the cooldown of the open circuit passes,
+	// it's 0 if the circuit is closed.
+	openUntil atomic.Int64
+
+	mu sync.Mutex
+	// requests and errors are counted in the current window,
+	// lastRequests and lastErrors are counted in the previous window.
+	windowStart    time.Time
+	requests       uint64
+	errors         uint64
+	lastRequests   uint64
+	lastErrors     uint64
+	streamRestarts uint64
+	// failures is the number of consecutive stream failures of each worker.
+	failures  map[uint64]int
+	open      bool
+	backoff   time.Duration
+	rtt       time.Duration
+	lastError string
+
+	metricUnhealthy      prometheus.Gauge
+	metricRTT            prometheus.Gauge
+	metricStreamRestarts prometheus.Counter
+	metricRegionErrors   prometheus.Counter
+}
+
+func newStoreHealth(clientID SubscriptionClientID, storeID uint64, storeAddr string) *storeHealth {
+	client, store := clientID.String(), strconv.FormatUint(storeID, 10)
+	h := &storeHealth{
+		storeID:              storeID,
+		storeAddr:            storeAddr,
+		windowStart:          time.Now(),
+		failures:             make(map[uint64]int),
+		backoff:              storeMinBackoff,
+		metricUnhealthy:      metrics.LogPullerStoreUnhealthy.WithLabelValues(client, store),
+		metricRTT:            metrics.LogPullerStoreRTT.WithLabelValues(client, store),
+		metricStreamRestarts: metrics.LogPullerStoreStreamRestartCounter.WithLabelValues(client, store),
+		metricRegionErrors:   metrics.LogPullerStoreRegionErrorCounter.WithLabelValues(client, store),
+	}
+	h.metricUnhealthy.Set(0)
+	return h
+}
+
+// rollWindow moves to a new window if the current one is expired, it must be called with the lock held.
+func (h *storeHealth) rollWindow(now time.Time) {
+	if now.Sub(h.windowStart) < storeHealthWindow {
+		return
+	}
+	h.lastRequests, h.lastErrors = h.requests, h.errors
+	// the previous window is too old to be taken into account
+	if now.Sub(h.windowStart) >= 2*storeHealthWindow {
+		h.lastRequests, h.lastErrors = 0, 0
+	}
+	h.requests, h.errors = 0, 0
+	h.windowStart = now
+}
+
+// consecutiveFailures returns the max consecutive stream failures of the workers,
+// it must be called with the lock held.
+func (h *storeHealth) consecutiveFailures() int {
+	count := 0
+	for _, failures := range h.failures {
+		count = max(count, failures)
+	}
+	return count
+}
+
+// onConnect is called when a stream to the store is established in rtt.
+func (h *storeHealth) onConnect(rtt time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.rtt == 0 {
+		h.rtt = rtt
+	} else {
+		h.rtt = (h.rtt*3 + rtt) / 4
+	}
+	h.metricRTT.Set(h.rtt.Seconds())
+}
+
+// onRequest is called when a region request is sent to the store.
+func (h *storeHealth) onRequest() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rollWindow(time.Now())
+	h.requests++
+}
+
+// onRegionError is called when a region error is received from the store.
+func (h *storeHealth) onRegionError() {
+	h.metricRegionErrors.Inc()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rollWindow(time.Now())
+	h.errors++
+}
+
+// onReceive is called when events are received from the store by any worker,
+// it resets the failures of all workers and closes the circuit of the store.
+func (h *storeHealth) onReceive(now time.Time) {
+	h.lastReceive.Store(now.UnixNano())
+	if !h.failing.Load() {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.open {
+		log.Info("store recovers, close the circuit",
+			zap.Uint64("storeID", h.storeID),
+			zap.String("addr", h.storeAddr),
+			zap.Int("consecutiveFailures", h.consecutiveFailures()))
+	}
+	clear(h.failures)
+	h.open = false
+	h.backoff = storeMinBackoff
+	h.openUntil.Store(0)
+	h.failing.Store(false)
+	h.metricUnhealthy.Set(0)
+}
+
+// onStreamFailure is called when a stream of the worker to the store fails or can't be established,
+// it returns the interval to wait before reconnecting the store.
+func (h *storeHealth) onStreamFailure(workerID uint64, err error) time.Duration {
+	h.metricStreamRestarts.Inc()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.streamRestarts++
+	h.failures[workerID]++
+	h.failing.Store(true)
+	if err != nil {
+		h.lastError = err.Error()
+	}
+	now := time.Now()
+	if h.open {
+		// the failure happens in the cooldown, e.g. another worker fails with the same cause,
+		// so the cooldown is not extended.
+		if cooldown := time.Until(time.Unix(0, h.openUntil.Load())); cooldown > 0 {
+			return max(cooldown, storeMinBackoff)
+		}
+	} else if h.failures[workerID] < storeBreakThreshold {
+		return storeMinBackoff
+	}
+	h.backoff = min(h.backoff*2, storeMaxBackoff)
+	h.openUntil.Store(now.Add(h.backoff).UnixNano())
+	if !h.open {
+		h.open = true
+		log.Warn("store fails consecutively, open the circuit",
+			zap.Uint64("storeID", h.storeID),
+			zap.String("addr", h.storeAddr),
+			zap.Uint64("workerID", workerID),
+			zap.Int("consecutiveFailures", h.failures[workerID]),
+			zap.Duration("backoff", h.backoff),
+			zap.String("lastError", h.lastError))
+	}
+	h.metricUnhealthy.Set(1)
+	return h.backoff
+}
+
+// cooldown returns the remaining interval in which no stream should be established
+// and no request should be sent to the store, it's 0 if the circuit is closed.
+func (h *storeHealth) cooldown() time.Duration {
+	openUntil := h.openUntil.Load()
+	if openUntil == 0 {
+		return 0
+	}
+	return max(time.Until(time.Unix(0, openUntil)), 0)
+}
+
+// isUnhealthy returns true if the circuit of the store is open.
+func (h *storeHealth) isUnhealthy() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.open
+}
+
+func (h *storeHealth) getStatus() StoreHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rollWindow(time.Now())
+	status := StoreHealth{
+		StoreID:             h.storeID,
+		StoreAddr:           h.storeAddr,
+		Unhealthy:           h.open,
+		StreamRestarts:      h.streamRestarts,
+		ConsecutiveFailures: h.consecutiveFailures(),
+		RTT:                 h.rtt,
+		Backoff:             storeMinBackoff,
+		LastError:           h.lastError,
+	}
+	if status.Unhealthy {
+		status.Backoff = h.backoff
+	}
+	if requests := h.requests + h.lastRequests; requests > 0 {
+		status.ErrorRate = float64(h.errors+h.lastErrors) / float64(requests)
+	}
+	if lastReceive := h.lastReceive.Load(); lastReceive > 0 {
+		status.LastReceive = time.Unix(0, lastReceive)
+	}
+	return status
+}
+
+func (rs *requestedStore) regionCount() int {
+	count := 0
+	for _, worker := range rs.requestWorkers {
+		count += worker.regionCount()
+	}
+	return count
+}
+
+func (s *SubscriptionClient) getStores() []*requestedStore {
+	s.stores.RLock()
+	defer s.stores.RUnlock()
+	stores := make([]*requestedStore, 0, len(s.stores.storeMap))
+	for _, rs := range s.stores.storeMap {
+		stores = append(stores, rs)
+	}
+	sort.Slice(stores, func(i, j int) bool { return stores[i].storeID < stores[j].storeID })
+	return stores
+}
+
+// GetStoreHealth returns the health of the TiKV stores requested by the client.
+func (s *SubscriptionClient) GetStoreHealth() []StoreHealth {
+	stores := s.getStores()
+	result := make([]StoreHealth, 0, len(stores))
+	for _, rs := range stores {
+		status := rs.health.getStatus()
+		status.RegionCount = rs.regionCount()
+		result = append(result, status)
+	}
+	return result
+}
+
+// isStoreUnhealthy returns true if the circuit of the store is open.
+func (s *SubscriptionClient) isStoreUnhealthy(storeID uint64) bool {
+	s.stores.RLock()
+	rs := s.stores.storeMap[storeID]
+	s.stores.RUnlock()
+	return rs != nil && rs.health.isUnhealthy()
+}
+
+func (s *SubscriptionClient) updateStoreMetrics(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	client := s.id.String()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		for _, rs := range s.getStores() {
+			store := strconv.FormatUint(rs.storeID, 10)
+			metrics.LogPullerStoreRegionCount.WithLabelValues(client, store).Set(float64(rs.regionCount()))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreHealth(t *testing.T) {
+	h := newStoreHealth(ClientIDTest, 1, "127.0.0.1:20160")
+
+	// region error rate
+	for i := 0; i < 4; i++ {
+		h.onRequest()
+	}
+	h.onRegionError()
+	status := h.getStatus()
+	require.Equal(t, 0.25, status.ErrorRate)
+	require.False(t, status.Unhealthy)
+
+	// the window rolls
+	h.mu.Lock()
+	h.windowStart = h.windowStart.Add(-storeHealthWindow)
+	h.mu.Unlock()
+	h.onRequest()
+	require.Equal(t, 0.2, h.getStatus().ErrorRate)
+	h.mu.Lock()
+	h.windowStart = h.windowStart.Add(-2 * storeHealthWindow)
+	h.mu.Unlock()
+	require.Equal(t, float64(0), h.getStatus().ErrorRate)
+
+	// the failures are counted by each worker
+	for i := 1; i < storeBreakThreshold; i++ {
+		require.Equal(t, storeMinBackoff, h.onStreamFailure(1, errors.New("failed")))
+		require.Equal(t, storeMinBackoff, h.onStreamFailure(2, errors.New("failed")))
+		require.False(t, h.isUnhealthy())
+	}
+	require.Zero(t, h.cooldown())
+
+	// the circuit opens after consecutive failures of a worker
+	require.Equal(t, 2*storeMinBackoff, h.onStreamFailure(1, errors.New("failed")))
+	require.True(t, h.isUnhealthy())
+	require.Greater(t, h.cooldown(), storeMinBackoff)
+	// the failures in the cooldown don't extend it
+	backoff := h.onStreamFailure(2, errors.New("failed"))
+	require.LessOrEqual(t, backoff, 2*storeMinBackoff)
+	require.Greater(t, backoff, storeMinBackoff)
+	require.Equal(t, 2*storeMinBackoff, h.getStatus().Backoff)
+
+	// the cooldown backs off exponentially if the store fails after it passes
+	expire := func() { h.openUntil.Store(time.Now().Add(-time.Millisecond).UnixNano()) }
+	expire()
+	require.Zero(t, h.cooldown())
+	require.True(t, h.isUnhealthy())
+	require.Equal(t, 4*storeMinBackoff, h.onStreamFailure(2, errors.New("failed")))
+	for i := 0; i < 10; i++ {
+		expire()
+		h.onStreamFailure(1, errors.New("failed"))
+	}
+	status = h.getStatus()
+	require.True(t, status.Unhealthy)
+	require.Equal(t, storeMaxBackoff, status.Backoff)
+	require.Equal(t, storeBreakThreshold+10, status.ConsecutiveFailures)
+	require.Equal(t, uint64(2*storeBreakThreshold+11), status.StreamRestarts)
+	require.Equal(t, "failed", status.LastError)
+
+	// the circuit closes after receiving events from any worker
+	h.onReceive(time.Now())
+	status = h.getStatus()
+	require.False(t, status.Unhealthy)
+	require.Equal(t, 0, status.ConsecutiveFailures)
+	require.Equal(t, storeMinBackoff, status.Backoff)
+	require.Zero(t, h.cooldown())
+	require.Equal(t, storeMinBackoff, h.onStreamFailure(1, errors.New("failed")))
+
+	// smoothed rtt
+	h.onConnect(4 * time.Millisecond)
+	h.onConnect(8 * time.Millisecond)
+	require.Equal(t, 5*time.Millisecond, h.getStatus().RTT)
+}
@@ -178,6 +178,12 @@ type SubscriptionClient struct {
 	// the credential to connect tikv
 	credential *security.Credential
 
+	// stores are the TiKV stores requested by the client.
+	stores struct {
+		sync.RWMutex
+		storeMap map[uint64]*requestedStore
+	}
+
 	totalSpans struct {
 		sync.RWMutex
 		spanMap map[SubscriptionID]*subscribedSpan
@@ -251,6 +257,7 @@ func NewSubscriptionClient(
 		errCache:          newErrCache(),
 	}
 	s.totalSpans.spanMap = make(map[SubscriptionID]*subscribedSpan)
+	s.stores.storeMap = make(map[uint64]*requestedStore)
 	s.initMetrics()
 	return s
 }
@@ -346,6 +353,7 @@ func (s *SubscriptionClient) Run(ctx context.Context, consume func(ctx context.C
 	g.Go(func() error { return s.handleErrors(ctx) })
 	g.Go(func() error { return s.handleResolveLockTasks(ctx) })
 	g.Go(func() error { return s.logSlowRegions(ctx) })
+	g.Go(func() error { return s.updateStoreMetrics(ctx) })
 	g.Go(func() error { return s.errCache.dispatch(ctx) })
 
 	log.Info("subscription client starts", zap.Int("subscriptionClientID", int(s.id)))
@@ -394,6 +402,7 @@ func (s *SubscriptionClient) onRegionFail(errInfo regionErrorInfo) {
 type requestedStore struct {
 	storeID   uint64
 	storeAddr string
+	health    *storeHealth
 	// Use to select a worker to send request.
 	nextWorker     atomic.Uint32
 	requestWorkers []*regionRequestWorker
@@ -413,12 +422,19 @@ func (s *SubscriptionClient) handleRegions(ctx context.Context, eg *errgroup.Gro
 		if rs = stores[storeID]; rs != nil {
 			return rs
 		}
-		rs = &requestedStore{storeID: storeID, storeAddr: storeAddr}
+		rs = &requestedStore{
+			storeID:   storeID,
+			storeAddr: storeAddr,
+			health:    newStoreHealth(s.id, storeID, storeAddr),
+		}
 		stores[storeID] = rs
 		for i := uint(0); i < s.config.RegionRequestWorkerPerStore; i++ {
 			requestWorker := newRegionRequestWorker(ctx, s, s.credential, eg, rs)
 			rs.requestWorkers = append(rs.requestWorkers, requestWorker)
 		}
+		s.stores.Lock()
+		s.stores.storeMap[storeID] = rs
+		s.stores.Unlock()
 
 		return rs
 	}
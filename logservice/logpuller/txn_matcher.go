@@ -87,6 +87,9 @@ func (m *matcher) matchRow(row *cdcpb.Event_Row, initialized bool) bool {
 		}
 		row.Value = value.GetValue()
 		row.OldValue = value.GetOldValue()
+		if row.TxnSource == 0 {
+			row.TxnSource = value.GetTxnSource()
+		}
 		delete(m.unmatchedValue, newMatchKey(row))
 		prewriteCacheRowNum.Dec()
 		return true
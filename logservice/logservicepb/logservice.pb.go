@@ -24,10 +24,11 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type SubscriptionState struct {
-	SubID        uint64                 `protobuf:"varint,1,opt,name=SubID,proto3" json:"SubID,omitempty"`
-	Span         *heartbeatpb.TableSpan `protobuf:"bytes,2,opt,name=Span,proto3" json:"Span,omitempty"`
-	CheckpointTs uint64                 `protobuf:"varint,3,opt,name=CheckpointTs,proto3" json:"CheckpointTs,omitempty"`
-	ResolvedTs   uint64                 `protobuf:"varint,4,opt,name=ResolvedTs,proto3" json:"ResolvedTs,omitempty"`
+	SubID           uint64                 `protobuf:"varint,1,opt,name=SubID,proto3" json:"SubID,omitempty"`
+	Span            *heartbeatpb.TableSpan `protobuf:"bytes,2,opt,name=Span,proto3" json:"Span,omitempty"`
+	CheckpointTs    uint64                 `protobuf:"varint,3,opt,name=CheckpointTs,proto3" json:"CheckpointTs,omitempty"`
+	ResolvedTs      uint64                 `protobuf:"varint,4,opt,name=ResolvedTs,proto3" json:"ResolvedTs,omitempty"`
+	DispatcherCount uint64                 `protobuf:"varint,5,opt,name=DispatcherCount,proto3" json:"DispatcherCount,omitempty"`
 }
 
 func (m *SubscriptionState) Reset()         { *m = SubscriptionState{} }
@@ -91,6 +92,13 @@ func (m *SubscriptionState) GetResolvedTs() uint64 {
 	return 0
 }
 
+func (m *SubscriptionState) GetDispatcherCount() uint64 {
+	if m != nil {
+		return m.DispatcherCount
+	}
+	return 0
+}
+
 type SubscriptionStates struct {
 	Subscriptions []*SubscriptionState `protobuf:"bytes,1,rep,name=Subscriptions,proto3" json:"Subscriptions,omitempty"`
 }
@@ -305,34 +313,35 @@ func init() {
 }
 
 var fileDescriptor_a1db670929506a40 = []byte{
-	// 432 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x41, 0x8b, 0x13, 0x31,
-	0x14, 0x6e, 0x66, 0x5a, 0xc5, 0xd7, 0x15, 0x35, 0x2c, 0x32, 0xee, 0xca, 0x38, 0x0c, 0x08, 0xa3,
-	0x87, 0xa9, 0x54, 0x10, 0xf1, 0x22, 0x68, 0x7b, 0xd8, 0x8b, 0x87, 0x4c, 0xf1, 0xa0, 0x07, 0x49,
-	0xa6, 0x8f, 0x36, 0x6c, 0x9d, 0xc4, 0x24, 0x53, 0xd8, 0x3f, 0x21, 0x5e, 0xfd, 0x47, 0x5e, 0x84,
-	0x3d, 0x7a, 0x94, 0xf6, 0x8f, 0x48, 0x27, 0xab, 0x3b, 0xb3, 0xbb, 0x20, 0x7b, 0x7b, 0xef, 0xe5,
-	0x7b, 0x5f, 0xbe, 0x2f, 0x1f, 0x81, 0x6c, 0xa5, 0x16, 0x16, 0xcd, 0x5a, 0x96, 0x38, 0x3a, 0x2f,
-	0xb5, 0x68, 0x35, 0xb9, 0x36, 0xca, 0x29, 0xba, 0xd7, 0x3e, 0x3e, 0x38, 0x5c, 0x22, 0x37, 0x4e,
-	0x20, 0x77, 0x5a, 0x8c, 0xfe, 0xd5, 0x1e, 0x9a, 0x7e, 0x27, 0x70, 0xaf, 0xa8, 0x85, 0x2d, 0x8d,
-	0xd4, 0x4e, 0xaa, 0xaa, 0x70, 0xdc, 0x21, 0xdd, 0x87, 0x41, 0x51, 0x8b, 0xa3, 0x49, 0x44, 0x12,
-	0x92, 0xf5, 0x99, 0x6f, 0xe8, 0x53, 0xe8, 0x17, 0x9a, 0x57, 0x51, 0x90, 0x90, 0x6c, 0x38, 0xbe,
-	0x9f, 0xb7, 0x78, 0xf3, 0x19, 0x17, 0x2b, 0xdc, 0x9d, 0xb2, 0x06, 0x43, 0x53, 0xd8, 0x7b, 0xbb,
-	0xc4, 0xf2, 0x58, 0x2b, 0x59, 0xb9, 0x99, 0x8d, 0xc2, 0x86, 0xa8, 0x33, 0xa3, 0x31, 0x00, 0x43,
-	0xab, 0x56, 0x6b, 0x9c, 0xcf, 0x6c, 0xd4, 0x6f, 0x10, 0xad, 0x49, 0xfa, 0x11, 0xe8, 0x25, 0x69,
-	0x96, 0x4e, 0xe1, 0x76, 0x7b, 0x6a, 0x23, 0x92, 0x84, 0xd9, 0x70, 0xfc, 0x28, 0x6f, 0x9b, 0xce,
-	0x2f, 0x2d, 0xb2, 0xee, 0x56, 0xfa, 0x93, 0xc0, 0x9d, 0xe9, 0x1a, 0x2b, 0x57, 0x38, 0x65, 0xd0,
-	0xdb, 0x7e, 0x7f, 0x35, 0xf5, 0xb3, 0x2e, 0xf5, 0x85, 0xad, 0xce, 0x55, 0x76, 0x5a, 0x39, 0x73,
-	0x72, 0xe1, 0xae, 0x03, 0xd1, 0x35, 0xe2, 0x41, 0xf4, 0x2e, 0x84, 0xc7, 0x78, 0xd2, 0x3c, 0x71,
-	0xc8, 0x76, 0x25, 0x7d, 0x01, 0x83, 0x35, 0x5f, 0xd5, 0x78, 0xf6, 0xc2, 0xc9, 0x7f, 0x2c, 0x59,
-	0xe6, 0xe1, 0xaf, 0x82, 0x97, 0x24, 0xfd, 0x4a, 0xe0, 0x90, 0x61, 0x6d, 0x77, 0x39, 0x78, 0x85,
-	0x7e, 0x91, 0xe1, 0x97, 0x1a, 0xad, 0xa3, 0x4f, 0x20, 0x38, 0xcb, 0x73, 0x38, 0x7e, 0xd0, 0x89,
-	0x6e, 0x22, 0xad, 0xe6, 0xae, 0x5c, 0xa2, 0x39, 0x9a, 0xb0, 0xe0, 0x9a, 0x39, 0x47, 0x70, 0xb3,
-	0x70, 0xdc, 0x9c, 0x47, 0xfc, 0xb7, 0x4d, 0x3f, 0xc1, 0xc3, 0xab, 0xf5, 0x58, 0xad, 0x2a, 0x8b,
-	0xd7, 0x11, 0xb4, 0x0f, 0x83, 0x77, 0x6a, 0x8e, 0x36, 0x0a, 0x92, 0x30, 0xbb, 0xc5, 0x7c, 0xf3,
-	0xe6, 0xf5, 0x8f, 0x4d, 0x4c, 0x4e, 0x37, 0x31, 0xf9, 0xbd, 0x89, 0xc9, 0xb7, 0x6d, 0xdc, 0x3b,
-	0xdd, 0xc6, 0xbd, 0x5f, 0xdb, 0xb8, 0xf7, 0xe1, 0xf1, 0x42, 0xba, 0x65, 0x2d, 0xf2, 0x52, 0x7d,
-	0x1e, 0x69, 0x59, 0x2d, 0x4a, 0xae, 0x47, 0x4e, 0x96, 0xf3, 0xb2, 0xf3, 0x6f, 0xc4, 0x8d, 0xe6,
-	0x0b, 0x3c, 0xff, 0x13, 0x00, 0x00, 0xff, 0xff, 0xf6, 0x53, 0xc3, 0x3e, 0x59, 0x03, 0x00, 0x00,
+	// 444 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x41, 0x8b, 0xd3, 0x40,
+	0x14, 0xee, 0x24, 0xad, 0xe2, 0xeb, 0xca, 0xea, 0xb0, 0x48, 0xdc, 0x95, 0x18, 0x02, 0x42, 0xf4,
+	0x90, 0x4a, 0x05, 0x11, 0x2f, 0x82, 0xdb, 0x1e, 0xf6, 0xe2, 0x61, 0x52, 0x3c, 0xe8, 0x41, 0x66,
+	0xd2, 0x47, 0x1b, 0xb6, 0x66, 0xc6, 0xcc, 0x4b, 0x61, 0xff, 0x84, 0xf8, 0x9f, 0xbc, 0x78, 0x11,
+	0xf6, 0xe8, 0x51, 0xda, 0x3f, 0x22, 0x4d, 0x56, 0x9b, 0xb4, 0x0b, 0xb2, 0xb7, 0xf7, 0xde, 0x7c,
+	0xdf, 0x37, 0x6f, 0xde, 0x37, 0x0f, 0xa2, 0x85, 0x9e, 0x59, 0x2c, 0x96, 0x59, 0x8a, 0x83, 0x6d,
+	0x68, 0x54, 0x23, 0x89, 0x4d, 0xa1, 0x49, 0xf3, 0x83, 0xe6, 0xf1, 0xf1, 0xc9, 0x1c, 0x65, 0x41,
+	0x0a, 0x25, 0x19, 0x35, 0xf8, 0x17, 0xd7, 0xd0, 0xf0, 0x3b, 0x83, 0xfb, 0x49, 0xa9, 0x6c, 0x5a,
+	0x64, 0x86, 0x32, 0x9d, 0x27, 0x24, 0x09, 0xf9, 0x11, 0xf4, 0x92, 0x52, 0x9d, 0x8d, 0x3c, 0x16,
+	0xb0, 0xa8, 0x2b, 0xea, 0x84, 0x3f, 0x83, 0x6e, 0x62, 0x64, 0xee, 0x39, 0x01, 0x8b, 0xfa, 0xc3,
+	0x07, 0x71, 0x43, 0x37, 0x9e, 0x48, 0xb5, 0xc0, 0xcd, 0xa9, 0xa8, 0x30, 0x3c, 0x84, 0x83, 0xd3,
+	0x39, 0xa6, 0xe7, 0x46, 0x67, 0x39, 0x4d, 0xac, 0xe7, 0x56, 0x42, 0xad, 0x1a, 0xf7, 0x01, 0x04,
+	0x5a, 0xbd, 0x58, 0xe2, 0x74, 0x62, 0xbd, 0x6e, 0x85, 0x68, 0x54, 0x78, 0x04, 0x87, 0xa3, 0xcc,
+	0x1a, 0x49, 0xe9, 0x1c, 0x8b, 0x53, 0x5d, 0xe6, 0xe4, 0xf5, 0x2a, 0xd0, 0x6e, 0x39, 0xfc, 0x08,
+	0x7c, 0xef, 0x11, 0x96, 0x8f, 0xe1, 0x6e, 0xb3, 0x6a, 0x3d, 0x16, 0xb8, 0x51, 0x7f, 0xf8, 0x38,
+	0x6e, 0x8e, 0x27, 0xde, 0x23, 0x8a, 0x36, 0x2b, 0xfc, 0xc9, 0xe0, 0x70, 0xbc, 0xc4, 0x9c, 0x12,
+	0xd2, 0x05, 0xd6, 0x03, 0x7a, 0x7f, 0xbd, 0xf4, 0xf3, 0xb6, 0xf4, 0x0e, 0xab, 0x75, 0x95, 0x1d,
+	0xe7, 0x54, 0x5c, 0xec, 0xdc, 0x75, 0xac, 0x80, 0xef, 0x83, 0xf8, 0x3d, 0x70, 0xcf, 0xf1, 0xa2,
+	0x32, 0xc3, 0x15, 0x9b, 0x90, 0xbf, 0x84, 0xde, 0x52, 0x2e, 0x4a, 0xbc, 0xf2, 0x22, 0xf8, 0xcf,
+	0x93, 0xac, 0xa8, 0xe1, 0xaf, 0x9d, 0x57, 0x2c, 0xfc, 0xca, 0xe0, 0x44, 0x60, 0x69, 0x37, 0x8e,
+	0xd5, 0x1d, 0xd6, 0x44, 0x81, 0x5f, 0x4a, 0xb4, 0xc4, 0x9f, 0x82, 0x73, 0xe5, 0x7c, 0x7f, 0xf8,
+	0xb0, 0x65, 0xf2, 0x76, 0xec, 0x67, 0x23, 0xe1, 0xdc, 0xf0, 0x47, 0x78, 0x70, 0x3b, 0x21, 0x59,
+	0x6c, 0x3f, 0xc3, 0xdf, 0x34, 0xfc, 0x04, 0x8f, 0xae, 0xef, 0xc7, 0x1a, 0x9d, 0x5b, 0xbc, 0x49,
+	0x43, 0x47, 0xd0, 0x7b, 0xa7, 0xa7, 0x68, 0x3d, 0x27, 0x70, 0xa3, 0x3b, 0xa2, 0x4e, 0xde, 0xbe,
+	0xf9, 0xb1, 0xf2, 0xd9, 0xe5, 0xca, 0x67, 0xbf, 0x57, 0x3e, 0xfb, 0xb6, 0xf6, 0x3b, 0x97, 0x6b,
+	0xbf, 0xf3, 0x6b, 0xed, 0x77, 0x3e, 0x3c, 0x99, 0x65, 0x34, 0x2f, 0x55, 0x9c, 0xea, 0xcf, 0x03,
+	0x93, 0xe5, 0xb3, 0x54, 0x9a, 0x01, 0x65, 0xe9, 0x34, 0x6d, 0x6d, 0x98, 0xba, 0x55, 0x2d, 0xcb,
+	0x8b, 0x3f, 0x03, 0x00, 0x79, 0xb4, 0xb3, 0x02, 0x83, 0x03, 0x00, 0x00,
 }
 
 func (m *SubscriptionState) Marshal() (dAtA []byte, err error) {
@@ -355,6 +364,11 @@ func (m *SubscriptionState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.DispatcherCount != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.DispatcherCount))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.ResolvedTs != 0 {
 		i = encodeVarintLogservice(dAtA, i, uint64(m.ResolvedTs))
 		i--
@@ -595,6 +609,9 @@ func (m *SubscriptionState) Size() (n int) {
 	if m.ResolvedTs != 0 {
 		n += 1 + sovLogservice(uint64(m.ResolvedTs))
 	}
+	if m.DispatcherCount != 0 {
+		n += 1 + sovLogservice(uint64(m.DispatcherCount))
+	}
 	return n
 }
 
@@ -802,6 +819,25 @@ func (m *SubscriptionState) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DispatcherCount", wireType)
+			}
+			m.DispatcherCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DispatcherCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLogservice(dAtA[iNdEx:])
@@ -1453,6 +1453,7 @@ func buildDDLEvent(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) commo
 		TableInfo:  wrapTableInfo,
 		FinishedTs: rawEvent.FinishedTs,
 		TiDBOnly:   false,
+		BDRRole:    rawEvent.BDRRole,
 	}
 
 	switch model.ActionType(rawEvent.Type) {
@@ -1887,6 +1888,8 @@ func buildDDLEvent(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) commo
 				SchemaName: rawEvent.CurrentSchemaName,
 				TableName:  info.Name.O,
 			})
+			ddlEvent.MultipleTableInfos = append(ddlEvent.MultipleTableInfos,
+				common.WrapTableInfo(rawEvent.CurrentSchemaID, rawEvent.CurrentSchemaName, info))
 			resultQuerys = append(resultQuerys, querys[i])
 		}
 		ddlEvent.TableNameChange = &commonEvent.TableNameChange{
@@ -31,6 +31,9 @@ import (
 // LockResolver resolves lock in the given region.
 type LockResolver interface {
 	Resolve(ctx context.Context, regionID uint64, maxVersion uint64) error
+	// ScanLocks returns the locks before maxVersion in the given region,
+	// at most scanLockLimit locks are returned.
+	ScanLocks(ctx context.Context, regionID uint64, maxVersion uint64) ([]*txnkv.Lock, error)
 }
 
 type resolver struct {
@@ -68,79 +71,96 @@ func (r *resolver) Resolve(ctx context.Context, regionID uint64, maxVersion uint
 	}()
 
 	// TODO test whether this function will kill active transaction
-	req := tikvrpc.NewRequest(tikvrpc.CmdScanLock, &kvrpcpb.ScanLockRequest{
-		MaxVersion: maxVersion,
-		Limit:      scanLockLimit,
-	})
-
 	bo := tikv.NewGcResolveLockMaxBackoffer(ctx)
-	var loc *tikv.KeyLocation
 	var key []byte
-	flushRegion := func() error {
-		var err error
-		loc, err = r.kvStorage.GetRegionCache().LocateRegionByID(bo, regionID)
-		if err != nil {
-			return err
-		}
-		key = loc.StartKey
-		return nil
-	}
-	if err := flushRegion(); err != nil {
-		return errors.Trace(err)
-	}
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 		}
+		loc, locks, err := r.scanLocks(bo, regionID, key, maxVersion)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		totalLocks = append(totalLocks, locks...)
+
+		_, err1 := r.kvStorage.GetLockResolver().ResolveLocks(bo, 0, locks)
+		if err1 != nil {
+			return errors.Trace(err1)
+		}
+		if len(locks) < scanLockLimit {
+			key = loc.EndKey
+		} else {
+			key = locks[len(locks)-1].Key
+		}
+
+		if len(key) == 0 || (len(loc.EndKey) != 0 && bytes.Compare(key, loc.EndKey) >= 0) {
+			break
+		}
+		bo = tikv.NewGcResolveLockMaxBackoffer(ctx)
+	}
+	return nil
+}
+
+func (r *resolver) ScanLocks(ctx context.Context, regionID uint64, maxVersion uint64) ([]*txnkv.Lock, error) {
+	bo := tikv.NewGcResolveLockMaxBackoffer(ctx)
+	_, locks, err := r.scanLocks(bo, regionID, nil, maxVersion)
+	return locks, errors.Trace(err)
+}
+
+// scanLocks scans at most scanLockLimit locks from the key in the region,
+// the scan restarts from the start key of the region if the region is changed.
+// It returns the location of the scanned region and the locks.
+func (r *resolver) scanLocks(
+	bo *tikv.Backoffer, regionID uint64, key []byte, maxVersion uint64,
+) (*tikv.KeyLocation, []*txnkv.Lock, error) {
+	req := tikvrpc.NewRequest(tikvrpc.CmdScanLock, &kvrpcpb.ScanLockRequest{
+		MaxVersion: maxVersion,
+		Limit:      scanLockLimit,
+	})
+
+	loc, err := r.kvStorage.GetRegionCache().LocateRegionByID(bo, regionID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if key == nil {
+		key = loc.StartKey
+	}
+	for {
 		req.ScanLock().StartKey = key
 		resp, err := r.kvStorage.SendReq(bo, req, loc.Region, tikv.ReadTimeoutMedium)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 		regionErr, err := resp.GetRegionError()
 		if err != nil {
-			return errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 		if regionErr != nil {
 			err = bo.Backoff(tikv.BoRegionMiss(), errors.New(regionErr.String()))
 			if err != nil {
-				return errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
-			if err := flushRegion(); err != nil {
-				return errors.Trace(err)
+			loc, err = r.kvStorage.GetRegionCache().LocateRegionByID(bo, regionID)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
 			}
+			key = loc.StartKey
 			continue
 		}
 		if resp.Resp == nil {
-			return errors.Trace(tikverr.ErrBodyMissing)
+			return nil, nil, errors.Trace(tikverr.ErrBodyMissing)
 		}
 		locksResp := resp.Resp.(*kvrpcpb.ScanLockResponse)
 		if locksResp.GetError() != nil {
-			return errors.Errorf("unexpected scanlock error: %s", locksResp)
+			return nil, nil, errors.Errorf("unexpected scanlock error: %s", locksResp)
 		}
 		locksInfo := locksResp.GetLocks()
 		locks := make([]*txnkv.Lock, len(locksInfo))
 		for i := range locksInfo {
 			locks[i] = txnkv.NewLock(locksInfo[i])
 		}
-		totalLocks = append(totalLocks, locks...)
-
-		_, err1 := r.kvStorage.GetLockResolver().ResolveLocks(bo, 0, locks)
-		if err1 != nil {
-			return errors.Trace(err1)
-		}
-		if len(locks) < scanLockLimit {
-			key = loc.EndKey
-		} else {
-			key = locks[len(locks)-1].Key
-		}
-
-		if len(key) == 0 || (len(loc.EndKey) != 0 && bytes.Compare(key, loc.EndKey) >= 0) {
-			break
-		}
-		bo = tikv.NewGcResolveLockMaxBackoffer(ctx)
+		return loc, locks, nil
 	}
-	return nil
 }
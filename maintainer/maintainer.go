@@ -69,6 +69,12 @@ type Maintainer struct {
 	watermark             *heartbeatpb.Watermark
 	checkpointTsByCapture map[node.ID]heartbeatpb.Watermark
 
+	// exactlyOnce is true if the kafka sink writes the events in transactions, all dispatchers
+	// run on the maintainer node, and the checkpoint ts doesn't exceed the committed ts.
+	exactlyOnce bool
+	// committedTs is the checkpoint ts committed by the transactional sink
+	committedTs uint64
+
 	state        heartbeatpb.ComponentState
 	bootstrapper *bootstrap.Bootstrapper[heartbeatpb.MaintainerBootstrapResponse]
 
@@ -105,6 +111,12 @@ type Maintainer struct {
 
 	errLock       sync.Mutex
 	runningErrors map[node.ID]*heartbeatpb.RunningError
+	// the warnings are reported to coordinator once and don't stop the changefeed
+	runningWarnings map[node.ID]*heartbeatpb.RunningError
+
+	// the memory usage reported by the dispatcher manager on each node
+	memoryUsageLock      sync.Mutex
+	memoryUsageByCapture map[node.ID]*heartbeatpb.MemoryUsage
 
 	changefeedCheckpointTsGauge    prometheus.Gauge
 	changefeedCheckpointTsLagGauge prometheus.Gauge
@@ -132,6 +144,12 @@ func NewMaintainer(cfID common.ChangeFeedID,
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
 	nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
 	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	exactlyOnce := config.IsKafkaExactlyOnce(cfg.SinkURI, cfg.Config.Sink)
+	var pinnedNode node.ID
+	if exactlyOnce {
+		// a single producer writes all events of the changefeed in transactions
+		pinnedNode = selfNode.ID
+	}
 	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID, tsoClient,
 		heartbeatpb.DDLSpanSchemaID,
 		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
@@ -146,7 +164,7 @@ func NewMaintainer(cfID common.ChangeFeedID,
 		taskScheduler:     taskScheduler,
 		startCheckpointTs: checkpointTs,
 		controller: NewController(cfID, checkpointTs, pdAPI, tsoClient, regionCache, taskScheduler,
-			cfg.Config, ddlSpan, conf.AddTableBatchSize, time.Duration(conf.CheckBalanceInterval)),
+			cfg.Config, ddlSpan, pinnedNode, conf.AddTableBatchSize, time.Duration(conf.CheckBalanceInterval)),
 		mc:              mc,
 		state:           heartbeatpb.ComponentState_Working,
 		removed:         atomic.NewBool(false),
@@ -163,7 +181,10 @@ func NewMaintainer(cfID common.ChangeFeedID,
 			ResolvedTs:   checkpointTs,
 		},
 		checkpointTsByCapture: make(map[node.ID]heartbeatpb.Watermark),
+		exactlyOnce:           exactlyOnce,
+		memoryUsageByCapture:  make(map[node.ID]*heartbeatpb.MemoryUsage),
 		runningErrors:         map[node.ID]*heartbeatpb.RunningError{},
+		runningWarnings:       map[node.ID]*heartbeatpb.RunningError{},
 
 		changefeedCheckpointTsGauge:    metrics.ChangefeedCheckpointTsGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
 		changefeedCheckpointTsLagGauge: metrics.ChangefeedCheckpointTsLagGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
@@ -266,16 +287,44 @@ func (m *Maintainer) GetMaintainerStatus() *heartbeatpb.MaintainerStatus {
 		}
 		clear(m.runningErrors)
 	}
-	status := &heartbeatpb.MaintainerStatus{
-		ChangefeedID: m.id.ToPB(),
-		FeedState:    string(m.changefeedSate),
-		State:        m.state,
-		CheckpointTs: m.watermark.CheckpointTs,
-		Err:          runningErrors,
+	var runningWarnings []*heartbeatpb.RunningError
+	if len(m.runningWarnings) > 0 {
+		runningWarnings = make([]*heartbeatpb.RunningError, 0, len(m.runningWarnings))
+		for _, w := range m.runningWarnings {
+			runningWarnings = append(runningWarnings, w)
+		}
+		clear(m.runningWarnings)
 	}
+	status := &heartbeatpb.MaintainerStatus{
+		ChangefeedID:         m.id.ToPB(),
+		FeedState:            string(m.changefeedSate),
+		State:                m.state,
+		CheckpointTs:         m.watermark.CheckpointTs,
+		Err:                  runningErrors,
+		EventSizePerSecond:   m.controller.GetEventSizePerSecond(),
+		MisplacedDispatchers: int32(m.controller.GetMisplacedDispatcherSize()),
+		MemoryUsage:          m.getMemoryUsage(),
+		Warning:              runningWarnings,
+	}
+	status.TableReplayId, status.TableReplayFinished = m.controller.GetTableReplayStatus()
 	return status
 }
 
+// getMemoryUsage sums the memory usage reported by all nodes.
+func (m *Maintainer) getMemoryUsage() *heartbeatpb.MemoryUsage {
+	m.memoryUsageLock.Lock()
+	defer m.memoryUsageLock.Unlock()
+	usage := &heartbeatpb.MemoryUsage{}
+	for _, u := range m.memoryUsageByCapture {
+		usage.Quota += u.Quota
+		usage.EventCollector += u.EventCollector
+		usage.Sink += u.Sink
+		usage.Encoder += u.Encoder
+		usage.MysqlPreparedDmls += u.MysqlPreparedDmls
+	}
+	return usage
+}
+
 func (m *Maintainer) initialize() error {
 	start := time.Now()
 	log.Info("start to initialize changefeed maintainer",
@@ -342,6 +391,8 @@ func (m *Maintainer) onMessage(msg *messaging.TargetMessage) {
 		m.onRemoveMaintainer(req.Cascade, req.Removed)
 	case messaging.TypeCheckpointTsMessage:
 		m.onCheckpointTsPersisted(msg.Message[0].(*heartbeatpb.CheckpointTsMessage))
+	case messaging.TypeResetDispatchersRequest:
+		m.onResetDispatchersRequest(msg.Message[0].(*heartbeatpb.ResetDispatchersRequest))
 	default:
 		log.Panic("unexpected message type",
 			zap.String("changefeed", m.id.Name()),
@@ -368,6 +419,18 @@ func (m *Maintainer) onCheckpointTsPersisted(msg *heartbeatpb.CheckpointTsMessag
 	})
 }
 
+// onResetDispatchersRequest makes the dispatchers of the spans replay events from the reset ts,
+// all replayed events are written in safe mode.
+func (m *Maintainer) onResetDispatchersRequest(req *heartbeatpb.ResetDispatchersRequest) {
+	if !m.bootstrapped {
+		// the table replay persisted in the changefeed info is started after bootstrap
+		log.Warn("maintainer is not bootstrapped, ignore reset dispatchers request",
+			zap.String("changefeed", m.id.Name()))
+		return
+	}
+	m.controller.ReplayTables(req.ReplayId, req.Spans, req.ResetTs, req.TargetTs)
+}
+
 func (m *Maintainer) onNodeChanged() {
 	currentNodes := m.bootstrapper.GetAllNodes()
 
@@ -383,6 +446,9 @@ func (m *Maintainer) onNodeChanged() {
 		if _, ok := activeNodes[id]; !ok {
 			removedNodes = append(removedNodes, id)
 			delete(m.checkpointTsByCapture, id)
+			m.memoryUsageLock.Lock()
+			delete(m.memoryUsageByCapture, id)
+			m.memoryUsageLock.Unlock()
 			m.controller.RemoveNode(id)
 		}
 	}
@@ -430,7 +496,21 @@ func (m *Maintainer) calCheckpointTs() {
 		newWatermark.UpdateMin(m.checkpointTsByCapture[id])
 	}
 	if newWatermark.CheckpointTs != math.MaxUint64 {
-		m.watermark.CheckpointTs = newWatermark.CheckpointTs
+		checkpointTs := newWatermark.CheckpointTs
+		if m.exactlyOnce {
+			// the events are flushed to the transactional sink before they are committed,
+			// the sink commits the transaction at the flushed ts, and the checkpoint ts
+			// advances after the transaction is committed.
+			m.sendMessages([]*messaging.TargetMessage{
+				messaging.NewSingleTargetMessage(m.selfNode.ID, messaging.HeartbeatCollectorTopic,
+					&heartbeatpb.CheckpointTsMessage{
+						ChangefeedID: m.id.ToPB(),
+						FlushedTs:    checkpointTs,
+					}),
+			})
+			checkpointTs = min(checkpointTs, max(m.committedTs, m.watermark.CheckpointTs))
+		}
+		m.watermark.CheckpointTs = checkpointTs
 	}
 	if newWatermark.ResolvedTs != math.MaxUint64 {
 		m.watermark.ResolvedTs = newWatermark.ResolvedTs
@@ -476,6 +556,14 @@ func (m *Maintainer) onHeartBeatRequest(msg *messaging.TargetMessage) {
 			m.checkpointTsByCapture[msg.From] = *req.Watermark
 		}
 	}
+	if req.CommittedTs > m.committedTs {
+		m.committedTs = req.CommittedTs
+	}
+	if req.MemoryUsage != nil {
+		m.memoryUsageLock.Lock()
+		m.memoryUsageByCapture[msg.From] = req.MemoryUsage
+		m.memoryUsageLock.Unlock()
+	}
 	m.controller.HandleStatus(msg.From, req.Statuses)
 	if req.Err != nil {
 		log.Warn("dispatcher report an error",
@@ -484,6 +572,13 @@ func (m *Maintainer) onHeartBeatRequest(msg *messaging.TargetMessage) {
 			zap.String("error", req.Err.Message))
 		m.onError(msg.From, req.Err)
 	}
+	if req.Warning != nil {
+		log.Warn("dispatcher report a warning",
+			zap.String("changefeed", m.id.Name()),
+			zap.String("from", msg.From.String()),
+			zap.String("warning", req.Warning.Message))
+		m.onWarning(msg.From, req.Warning)
+	}
 }
 
 func (m *Maintainer) onError(from node.ID, err *heartbeatpb.RunningError) {
@@ -497,6 +592,17 @@ func (m *Maintainer) onError(from node.ID, err *heartbeatpb.RunningError) {
 	m.errLock.Unlock()
 }
 
+func (m *Maintainer) onWarning(from node.ID, warning *heartbeatpb.RunningError) {
+	warning.Node = from.String()
+	if info, ok := m.nodeManager.GetAliveNodes()[from]; ok {
+		warning.Node = info.AdvertiseAddr
+	}
+	m.errLock.Lock()
+	m.statusChanged.Store(true)
+	m.runningWarnings[from] = warning
+	m.errLock.Unlock()
+}
+
 func (m *Maintainer) onBlockStateRequest(msg *messaging.TargetMessage) {
 	// the barrier is not initialized
 	if !m.bootstrapped {
@@ -555,6 +661,10 @@ func (m *Maintainer) onBootstrapDone(cachedResp map[node.ID]*heartbeatpb.Maintai
 	}
 	m.barrier = barrier
 	m.bootstrapped = true
+	if replay := m.config.TableReplay; replay != nil {
+		// the table replay may be interrupted by the restart of the maintainer
+		m.controller.ReplayTables(replay.ID, toTableReplaySpans(replay.Spans), replay.ReplayTs, replay.TargetTs)
+	}
 
 	// Memory Consumption is 64(tableName/schemaName limit) * 4(utf8.UTFMax) * 2(tableName+schemaName) * tableNum
 	// For a extreme case(100w tables, and 64 utf8 characters for each name), the memory consumption is about 488MB.
@@ -654,18 +764,32 @@ func (m *Maintainer) handleError(err error) {
 // a changefeed dispatcher manager.
 func (m *Maintainer) getNewBootstrapFn() bootstrap.NewBootstrapMessageFn {
 	cfg := m.config
+	sinkConfig := cfg.Config.Sink
+	if cfg.Config.Integrity != nil && sinkConfig != nil {
+		// the integrity config is set at the changefeed level, pass it to the sink
+		// by a copy of the sink config to avoid modifying the changefeed info.
+		copied := *sinkConfig
+		copied.Integrity = &config.Config{
+			IntegrityCheckLevel:   cfg.Config.Integrity.IntegrityCheckLevel,
+			CorruptionHandleLevel: cfg.Config.Integrity.CorruptionHandleLevel,
+		}
+		sinkConfig = &copied
+	}
 	changefeedConfig := config.ChangefeedConfig{
 		ChangefeedID:       cfg.ChangefeedID,
 		StartTS:            cfg.StartTs,
 		TargetTS:           cfg.TargetTs,
 		SinkURI:            cfg.SinkURI,
 		ForceReplicate:     cfg.Config.ForceReplicate,
-		SinkConfig:         cfg.Config.Sink,
+		SinkConfig:         sinkConfig,
 		Filter:             cfg.Config.Filter,
 		EnableSyncPoint:    *cfg.Config.EnableSyncPoint,
 		SyncPointInterval:  cfg.Config.SyncPointInterval,
 		SyncPointRetention: cfg.Config.SyncPointRetention,
 		MemoryQuota:        cfg.Config.MemoryQuota,
+		BDRMode:            cfg.Config.BDRMode != nil && *cfg.Config.BDRMode,
+		TiDBSourceID:       cfg.Config.Sink.TiDBSourceID,
+		EnableTableMonitor: cfg.Config.EnableTableMonitor != nil && *cfg.Config.EnableTableMonitor,
 		// other fields are not necessary for maintainer
 	}
 	// cfgBytes only holds necessary fields to initialize a changefeed dispatcher.
@@ -703,6 +827,9 @@ func (m *Maintainer) onPeriodTask() {
 	m.handleResendMessage()
 	m.collectMetrics()
 	m.calCheckpointTs()
+	if m.bootstrapped {
+		m.controller.CheckTableReplay()
+	}
 	SubmitScheduledEvent(m.taskScheduler, m.stream, &Event{
 		changefeedID: m.id,
 		eventType:    EventPeriod,
@@ -37,6 +37,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/spanz"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -59,9 +60,20 @@ type Controller struct {
 
 	cfConfig     *config.ReplicaConfig
 	changefeedID common.ChangeFeedID
+	// placement is the placement rules of the dispatchers, it can be nil.
+	placement *config.PlacementConfig
+	// pinnedNode is the only node the dispatchers run on if it's not empty,
+	// it's the maintainer node when the kafka sink writes in transactions.
+	pinnedNode node.ID
 
 	taskScheduler threadpool.ThreadPool
 	taskHandlers  []*threadpool.TaskHandle
+
+	// tableReplay is the running table replay, it's nil if there is none
+	tableReplay *tableReplay
+	// the id and the state of the last table replay, they are read by the maintainer status
+	tableReplayID       atomic.Uint64
+	tableReplayFinished atomic.Bool
 }
 
 func NewController(changefeedID common.ChangeFeedID,
@@ -72,6 +84,7 @@ func NewController(changefeedID common.ChangeFeedID,
 	taskScheduler threadpool.ThreadPool,
 	cfConfig *config.ReplicaConfig,
 	ddlSpan *replica.SpanReplication,
+	pinnedNode node.ID,
 	batchSize int, balanceInterval time.Duration) *Controller {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
 	replicaSetDB := replica.NewReplicaSetDB(changefeedID, ddlSpan)
@@ -89,12 +102,21 @@ func NewController(changefeedID common.ChangeFeedID,
 		taskScheduler:      taskScheduler,
 		cfConfig:           cfConfig,
 		tsoClient:          tsoClient,
+		pinnedNode:         pinnedNode,
 	}
 	if cfConfig != nil && cfConfig.Scheduler.EnableTableAcrossNodes {
 		s.splitter = split.NewSplitter(changefeedID, pdapi, regionCache, cfConfig.Scheduler)
 		s.spanReplicationEnabled = true
 	}
-	s.schedulerController = scheduler.NewController(changefeedID, batchSize, oc, replicaSetDB, nodeManager, balanceInterval, s.splitter)
+	balanceStrategy := config.BalanceStrategyTaskCount
+	if cfConfig != nil && cfConfig.Scheduler.BalanceStrategy != "" {
+		balanceStrategy = cfConfig.Scheduler.BalanceStrategy
+	}
+	if cfConfig != nil {
+		s.placement = cfConfig.Scheduler.Placement
+	}
+	s.schedulerController = scheduler.NewController(changefeedID, batchSize, oc, replicaSetDB, nodeManager,
+		balanceInterval, balanceStrategy, s.placement, s.pinnedNode, s.splitter)
 	return s
 }
 
@@ -386,6 +408,27 @@ func (c *Controller) GetTaskSizeByNodeID(id node.ID) int {
 	return c.replicationDB.GetTaskSizeByNodeID(id)
 }
 
+// GetEventSizePerSecond returns the sum of the event size per second of all replicating spans.
+func (c *Controller) GetEventSizePerSecond() float32 {
+	return c.replicationDB.GetEventSizePerSecond()
+}
+
+// GetMisplacedDispatcherSize returns the number of the dispatchers running on
+// the nodes not allowed by the placement rules or out of the pinned node.
+func (c *Controller) GetMisplacedDispatcherSize() int {
+	if c.placement.IsEmpty() && c.pinnedNode == "" {
+		return 0
+	}
+	nodes := scheduler.SchedulableNodes(c.nodeManager, c.placement, c.pinnedNode)
+	misplaced := 0
+	for id, size := range c.replicationDB.GetTaskSizePerNode() {
+		if _, ok := nodes[id]; !ok {
+			misplaced += size
+		}
+	}
+	return misplaced
+}
+
 func (c *Controller) addWorkingSpans(tableMap utils.Map[*heartbeatpb.TableSpan, *replica.SpanReplication]) {
 	tableMap.Ascend(func(span *heartbeatpb.TableSpan, stm *replica.SpanReplication) bool {
 		c.replicationDB.AddReplicatingSpan(stm)
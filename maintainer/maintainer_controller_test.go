@@ -54,7 +54,7 @@ func TestSchedule(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 9, time.Minute)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, "", 9, time.Minute)
 	for i := 0; i < 10; i++ {
 		controller.AddNewTable(commonEvent.Table{
 			SchemaID: 1,
@@ -86,7 +86,7 @@ func TestRemoveAbsentTask(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 9, time.Minute)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, "", 9, time.Minute)
 	controller.AddNewTable(commonEvent.Table{
 		SchemaID: 1,
 		TableID:  int64(1),
@@ -110,7 +110,7 @@ func TestBalanceGlobalEven(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, "", 1000, 0)
 	for i := 0; i < 100; i++ {
 		// generate 100 groups
 		totalSpan := spanz.TableIDToComparableSpan(int64(i))
@@ -181,7 +181,7 @@ func TestBalanceGlobalUneven(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, "", 1000, 0)
 	for i := 0; i < 100; i++ {
 		// generate 100 groups
 		totalSpan := spanz.TableIDToComparableSpan(int64(i))
@@ -257,7 +257,7 @@ func TestBalance(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, "", 1000, 0)
 	for i := 0; i < 100; i++ {
 		sz := spanz.TableIDToComparableSpan(int64(i))
 		span := &heartbeatpb.TableSpan{TableID: sz.TableID, StartKey: sz.StartKey, EndKey: sz.EndKey}
@@ -325,7 +325,7 @@ func TestStoppedWhenMoving(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, "", 1000, 0)
 	for i := 0; i < 2; i++ {
 		sz := spanz.TableIDToComparableSpan(int64(i))
 		span := &heartbeatpb.TableSpan{TableID: sz.TableID, StartKey: sz.StartKey, EndKey: sz.EndKey}
@@ -370,7 +370,7 @@ func TestFinishBootstrap(t *testing.T) {
 			CheckpointTs:    1,
 		}, "node1")
 	s := NewController(cfID, 1, nil, tsoClient, nil, &mockThreadPool{},
-		config.GetDefaultReplicaConfig(), ddlSpan, 1000, 0)
+		config.GetDefaultReplicaConfig(), ddlSpan, "", 1000, 0)
 	totalSpan := spanz.TableIDToComparableSpan(1)
 	span := &heartbeatpb.TableSpan{TableID: int64(1), StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey}
 	schemaStore := &mockSchemaStore{
@@ -440,7 +440,7 @@ func TestBalanceUnEvenTask(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, "", 1000, 0)
 
 	for i := 0; i < 4; i++ {
 		sz := spanz.TableIDToComparableSpan(int64(i))
@@ -533,7 +533,7 @@ func TestSplitTableWhenBootstrapFinished(t *testing.T) {
 		WriteKeyThreshold:      1,
 	}
 	s := NewController(cfID, 1,
-		pdAPI, tsoClient, nil, nil, defaultConfig, ddlSpan, 1000, 0)
+		pdAPI, tsoClient, nil, nil, defaultConfig, ddlSpan, "", 1000, 0)
 	s.taskScheduler = &mockThreadPool{}
 	schemaStore := &mockSchemaStore{tables: []commonEvent.Table{
 		{TableID: 1, SchemaID: 1, SchemaTableName: &commonEvent.SchemaTableName{SchemaName: "test", TableName: "t"}},
@@ -614,7 +614,7 @@ func TestDynamicSplitTableBasic(t *testing.T) {
 				EnableTableAcrossNodes: true,
 				RegionThreshold:        0,
 				WriteKeyThreshold:      1,
-			}}, ddlSpan, 1000, 0)
+			}}, ddlSpan, "", 1000, 0)
 	s.taskScheduler = &mockThreadPool{}
 
 	for i := 1; i <= 2; i++ {
@@ -702,3 +702,97 @@ type mockTsoClient struct {
 func (m *mockTsoClient) GetTS(_ context.Context) (int64, int64, error) {
 	return m.phy, m.logic, m.err
 }
+
+func TestReplayTables(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &mockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, "", 1000, 0)
+	var replicating *replica.SpanReplication
+	for i := 1; i <= 2; i++ {
+		sz := spanz.TableIDToComparableSpan(int64(i))
+		span := &heartbeatpb.TableSpan{TableID: sz.TableID, StartKey: sz.StartKey, EndKey: sz.EndKey}
+		spanReplica := replica.NewReplicaSet(cfID, common.NewDispatcherID(), tsoClient, 1, span, 10)
+		spanReplica.SetNodeID("node1")
+		s.replicationDB.AddReplicatingSpan(spanReplica)
+		if i == 1 {
+			replicating = spanReplica
+		}
+	}
+	s.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 3}, 10)
+
+	// table 2 is not replayed, table 4 does not exist
+	spans := make([]*heartbeatpb.TableSpan, 0, 3)
+	for _, id := range []int64{1, 3, 4} {
+		sz := spanz.TableIDToComparableSpan(id)
+		spans = append(spans, &heartbeatpb.TableSpan{TableID: sz.TableID, StartKey: sz.StartKey, EndKey: sz.EndKey})
+	}
+	s.ReplayTables(100, spans, 5, 10)
+	require.Len(t, s.tableReplay.tasks, 2)
+	id, finished := s.GetTableReplayStatus()
+	require.Equal(t, uint64(100), id)
+	require.False(t, finished)
+	// the dispatchers are not rewound, no operator is created
+	require.Equal(t, 0, s.operatorController.OperatorSize())
+	require.Equal(t, 2, s.replicationDB.GetReplicatingSize())
+	// the same replay is started only once
+	replay := s.tableReplay
+	s.ReplayTables(100, spans, 5, 10)
+	require.Same(t, replay, s.tableReplay)
+
+	// the checkpoint ts falls back only when the dispatcher starts the replay
+	s.HandleStatus("node1", []*heartbeatpb.TableSpanStatus{{
+		ID:              replicating.ID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Working,
+		CheckpointTs:    3,
+	}})
+	require.Equal(t, uint64(10), replicating.GetStatus().CheckpointTs)
+	s.HandleStatus("node1", []*heartbeatpb.TableSpanStatus{{
+		ID:              replicating.ID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Working,
+		CheckpointTs:    5,
+		ReplayedTs:      5,
+	}})
+	require.Equal(t, uint64(5), replicating.GetStatus().CheckpointTs)
+	s.CheckTableReplay()
+	require.True(t, s.tableReplay.tasks[replicating.ID].started)
+
+	// table 3 is recreated before it starts the replay, the new task is replayed
+	oldTask := s.replicationDB.GetTasksByTableIDs(3)[0]
+	s.replicationDB.TryRemoveByTableIDs(3)
+	s.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 3}, 10)
+	s.CheckTableReplay()
+	require.Len(t, s.tableReplay.tasks, 2)
+	newTask := s.replicationDB.GetTasksByTableIDs(3)[0]
+	require.NotEqual(t, oldTask.ID, newTask.ID)
+	require.Contains(t, s.tableReplay.tasks, newTask.ID)
+	newTask.UpdateStatus(&heartbeatpb.TableSpanStatus{
+		ID:              newTask.ID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Working,
+		CheckpointTs:    11,
+		ReplayedTs:      5,
+	})
+
+	// the replay finishes after all dispatchers catch up the target ts
+	s.CheckTableReplay()
+	require.Len(t, s.tableReplay.tasks, 1)
+	s.HandleStatus("node1", []*heartbeatpb.TableSpanStatus{{
+		ID:              replicating.ID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Working,
+		CheckpointTs:    10,
+	}})
+	s.CheckTableReplay()
+	require.Nil(t, s.tableReplay)
+	id, finished = s.GetTableReplayStatus()
+	require.Equal(t, uint64(100), id)
+	require.True(t, finished)
+}
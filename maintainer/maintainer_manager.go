@@ -53,11 +53,15 @@ type Manager struct {
 	pdAPI       pdutil.PDAPIClient
 	tsoClient   replica.TSOClient
 	regionCache *tikv.RegionCache
+	// sourceID is the source id of the upstream cluster
+	sourceID uint64
 
 	msgCh chan *messaging.TargetMessage
 
 	stream        dynstream.DynamicStream[int, common.GID, *Event, *Maintainer, *StreamHandler]
 	taskScheduler threadpool.ThreadPool
+
+	nodeLoadCollector *nodeLoadCollector
 }
 
 // NewMaintainerManager create a changefeed maintainer manager instance,
@@ -69,6 +73,7 @@ func NewMaintainerManager(selfNode *node.Info,
 	pdAPI pdutil.PDAPIClient,
 	pdClient replica.TSOClient,
 	regionCache *tikv.RegionCache,
+	sourceID uint64,
 ) *Manager {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
 	m := &Manager{
@@ -81,6 +86,9 @@ func NewMaintainerManager(selfNode *node.Info,
 		pdAPI:         pdAPI,
 		tsoClient:     pdClient,
 		regionCache:   regionCache,
+		sourceID:      sourceID,
+
+		nodeLoadCollector: newNodeLoadCollector(),
 	}
 	m.stream = dynstream.NewDynamicStream(NewStreamHandler())
 	m.stream.Start()
@@ -123,6 +131,10 @@ func (m *Manager) recvMessages(ctx context.Context, msg *messaging.TargetMessage
 	case messaging.TypeCheckpointTsMessage:
 		req := msg.Message[0].(*heartbeatpb.CheckpointTsMessage)
 		return m.dispatcherMaintainerMessage(ctx, common.NewChangefeedIDFromPB(req.ChangefeedID), msg)
+	// receive reset dispatchers request from the coordinator
+	case messaging.TypeResetDispatchersRequest:
+		req := msg.Message[0].(*heartbeatpb.ResetDispatchersRequest)
+		return m.dispatcherMaintainerMessage(ctx, common.NewChangefeedIDFromPB(req.ChangefeedID), msg)
 	default:
 		log.Panic("unknown message type", zap.Any("message", msg.Message))
 	}
@@ -230,6 +242,10 @@ func (m *Manager) onAddMaintainerRequest(req *heartbeatpb.AddMaintainerRequest)
 	if err != nil {
 		log.Panic("decode changefeed fail", zap.Error(err))
 	}
+	// the source id is not persisted, fill it before bootstrapping the dispatchers
+	if cfConfig.Config != nil && cfConfig.Config.Sink != nil {
+		cfConfig.Config.Sink.TiDBSourceID = m.sourceID
+	}
 	cf = NewMaintainer(cfID, m.conf, cfConfig, m.selfNode, m.stream, m.taskScheduler,
 		m.pdAPI, m.tsoClient, m.regionCache,
 		req.CheckpointTs)
@@ -312,6 +328,7 @@ func (m *Manager) sendHeartbeat() {
 			return true
 		})
 		if len(response.Statuses) != 0 {
+			response.NodeLoad = m.nodeLoadCollector.collect()
 			m.sendMessages(response)
 		}
 	}
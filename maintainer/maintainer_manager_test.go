@@ -70,7 +70,7 @@ func TestMaintainerSchedulesNodeChanges(t *testing.T) {
 		CheckBalanceInterval: 0,
 	}
 	tsoClient := &mockTsoClient{}
-	manager := NewMaintainerManager(selfNode, schedulerConf, nil, tsoClient, nil)
+	manager := NewMaintainerManager(selfNode, schedulerConf, nil, tsoClient, nil, config.DefaultTiDBSourceID)
 	msg := messaging.NewSingleTargetMessage(selfNode.ID,
 		messaging.MaintainerManagerTopic,
 		&heartbeatpb.CoordinatorBootstrapRequest{Version: 1})
@@ -222,7 +222,7 @@ func TestMaintainerBootstrapWithTablesReported(t *testing.T) {
 		return nil
 	})
 	tsoClient := &mockTsoClient{}
-	manager := NewMaintainerManager(selfNode, config.GetGlobalServerConfig().Debug.Scheduler, nil, tsoClient, nil)
+	manager := NewMaintainerManager(selfNode, config.GetGlobalServerConfig().Debug.Scheduler, nil, tsoClient, nil, config.DefaultTiDBSourceID)
 	msg := messaging.NewSingleTargetMessage(selfNode.ID,
 		messaging.MaintainerManagerTopic,
 		&heartbeatpb.CoordinatorBootstrapRequest{Version: 1})
@@ -327,7 +327,7 @@ func TestStopNotExistsMaintainer(t *testing.T) {
 	})
 	schedulerConf := &config.SchedulerConfig{AddTableBatchSize: 1000}
 	tsoClient := &mockTsoClient{}
-	manager := NewMaintainerManager(selfNode, schedulerConf, nil, tsoClient, nil)
+	manager := NewMaintainerManager(selfNode, schedulerConf, nil, tsoClient, nil, config.DefaultTiDBSourceID)
 	msg := messaging.NewSingleTargetMessage(selfNode.ID,
 		messaging.MaintainerManagerTopic,
 		&heartbeatpb.CoordinatorBootstrapRequest{Version: 1})
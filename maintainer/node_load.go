@@ -0,0 +1,84 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"math"
+	"runtime/debug"
+	"runtime/metrics"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+const (
+	cpuTotalMetric  = "/cpu/classes/total:cpu-seconds"
+	cpuIdleMetric   = "/cpu/classes/idle:cpu-seconds"
+	memoryMetric    = "/memory/classes/total:bytes"
+	cpuTotalIndex   = 0
+	cpuIdleIndex    = 1
+	memoryIndex     = 2
+	nodeLoadMetrics = 3
+)
+
+// nodeLoadCollector samples the resource usage of the process, the result is
+// reported to the coordinator for the load-weighted balance.
+// It is not thread safe.
+type nodeLoadCollector struct {
+	samples []metrics.Sample
+
+	lastCPUTotal float64
+	lastCPUIdle  float64
+}
+
+func newNodeLoadCollector() *nodeLoadCollector {
+	c := &nodeLoadCollector{samples: make([]metrics.Sample, nodeLoadMetrics)}
+	c.samples[cpuTotalIndex].Name = cpuTotalMetric
+	c.samples[cpuIdleIndex].Name = cpuIdleMetric
+	c.samples[memoryIndex].Name = memoryMetric
+	return c
+}
+
+// collect returns the resource usage since the last collection.
+func (c *nodeLoadCollector) collect() *heartbeatpb.NodeLoad {
+	metrics.Read(c.samples)
+	load := &heartbeatpb.NodeLoad{}
+
+	cpuTotal := c.samples[cpuTotalIndex].Value.Float64()
+	cpuIdle := c.samples[cpuIdleIndex].Value.Float64()
+	if total := cpuTotal - c.lastCPUTotal; total > 0 {
+		load.CpuUsage = float32(math.Max(0, 1-(cpuIdle-c.lastCPUIdle)/total))
+	}
+	c.lastCPUTotal, c.lastCPUIdle = cpuTotal, cpuIdle
+
+	if limit := memoryLimit(); limit > 0 {
+		load.MemoryUsage = float32(math.Min(1, float64(c.samples[memoryIndex].Value.Uint64())/float64(limit)))
+	}
+	return load
+}
+
+// memoryLimit returns the soft memory limit of the process,
+// or the total memory of the system if the limit is not set.
+func memoryLimit() uint64 {
+	// a negative input does not adjust the limit, but returns the current one
+	if limit := debug.SetMemoryLimit(-1); limit > 0 && limit != math.MaxInt64 {
+		return uint64(limit)
+	}
+	// the total memory respects the cgroup limit
+	total, err := memory.MemTotal()
+	if err != nil {
+		return 0
+	}
+	return total
+}
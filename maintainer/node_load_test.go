@@ -0,0 +1,38 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"math"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeLoadMemoryUsage(t *testing.T) {
+	old := debug.SetMemoryLimit(math.MaxInt64)
+	defer debug.SetMemoryLimit(old)
+
+	// the total memory of the system is used without the memory limit
+	c := newNodeLoadCollector()
+	require.Greater(t, memoryLimit(), uint64(0))
+	usage := c.collect().MemoryUsage
+	require.Greater(t, usage, float32(0))
+
+	// the memory limit is used if it's set
+	debug.SetMemoryLimit(1)
+	require.Equal(t, uint64(1), memoryLimit())
+	require.Equal(t, float32(1), c.collect().MemoryUsage)
+}
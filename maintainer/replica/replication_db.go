@@ -161,6 +161,20 @@ func (db *ReplicationDB) GetReplicatingSize() int {
 	return sum
 }
 
+// GetEventSizePerSecond returns the sum of the event size per second of all replicating spans
+func (db *ReplicationDB) GetEventSizePerSecond() float32 {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	sum := float32(0)
+	for _, g := range db.taskGroups {
+		for _, span := range g.replicating {
+			sum += span.GetEventSizePerSecond()
+		}
+	}
+	return sum
+}
+
 // GetTasksByTableIDs returns the spans by the table ids
 func (db *ReplicationDB) GetTasksByTableIDs(tableIDs ...int64) []*SpanReplication {
 	db.lock.RLock()
@@ -135,12 +135,24 @@ func (r *SpanReplication) initGroupID() {
 func (r *SpanReplication) UpdateStatus(newStatus *heartbeatpb.TableSpanStatus) {
 	if newStatus != nil {
 		oldStatus := r.status.Load()
-		if newStatus.CheckpointTs >= oldStatus.CheckpointTs {
+		// the checkpoint ts falls back only when the dispatcher starts a new replay
+		replayStarted := newStatus.ReplayedTs != 0 && newStatus.ReplayedTs != oldStatus.ReplayedTs
+		if newStatus.CheckpointTs >= oldStatus.CheckpointTs || replayStarted {
 			r.status.Store(newStatus)
 		}
 	}
 }
 
+// GetStatus returns the last status reported by the dispatcher.
+func (r *SpanReplication) GetStatus() *heartbeatpb.TableSpanStatus {
+	return r.status.Load()
+}
+
+// GetEventSizePerSecond returns the event size per second reported by the dispatcher.
+func (r *SpanReplication) GetEventSizePerSecond() float32 {
+	return r.status.Load().EventSizePerSecond
+}
+
 func (r *SpanReplication) IsDropped() bool {
 	return false
 	// state := r.blockState.Load()
@@ -207,6 +219,28 @@ func (r *SpanReplication) NewAddDispatcherMessage(server node.ID) (*messaging.Ta
 		}), nil
 }
 
+// NewReplayDispatcherMessage returns the message to make the dispatcher replay the events from the replay ts,
+// the events before the current pd ts are written in safe mode.
+func (r *SpanReplication) NewReplayDispatcherMessage(server node.ID, replayTs uint64) (*messaging.TargetMessage, error) {
+	ts, err := getTs(r.tsoClient)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return messaging.NewSingleTargetMessage(server,
+		messaging.HeartbeatCollectorTopic,
+		&heartbeatpb.ScheduleDispatcherRequest{
+			ChangefeedID: r.ChangefeedID.ToPB(),
+			Config: &heartbeatpb.DispatcherConfig{
+				DispatcherID: r.ID.ToPB(),
+				SchemaID:     r.schemaID,
+				Span:         r.Span,
+				StartTs:      replayTs,
+				CurrentPdTs:  ts,
+			},
+			ScheduleAction: heartbeatpb.ScheduleAction_Replay,
+		}), nil
+}
+
 func (r *SpanReplication) NewRemoveDispatcherMessage(server node.ID) *messaging.TargetMessage {
 	return NewRemoveDispatcherMessage(server, r.ChangefeedID, r.ID.ToPB())
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/ticdc/maintainer/operator"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler"
 	"github.com/pingcap/ticdc/server/watcher"
@@ -36,10 +37,15 @@ type balanceScheduler struct {
 	operatorController *operator.Controller
 	replicationDB      *replica.ReplicationDB
 	nodeManager        *watcher.NodeManager
+	placement          *config.PlacementConfig
+	// pinnedNode is the only node the spans are scheduled to if it's not empty
+	pinnedNode node.ID
 
 	random               *rand.Rand
 	lastRebalanceTime    time.Time
 	checkBalanceInterval time.Duration
+	// balanceStrategy is the strategy to balance the spans, task-count or load.
+	balanceStrategy string
 	// forceBalance forces the scheduler to produce schedule tasks regardless of
 	// `checkBalanceInterval`.
 	// It is set to true when the last time `Schedule` produces some tasks,
@@ -52,7 +58,7 @@ type balanceScheduler struct {
 func newbalanceScheduler(
 	changefeedID common.ChangeFeedID, batchSize int,
 	oc *operator.Controller, db *replica.ReplicationDB, nodeManager *watcher.NodeManager,
-	balanceInterval time.Duration,
+	balanceInterval time.Duration, balanceStrategy string, placement *config.PlacementConfig, pinnedNode node.ID,
 ) *balanceScheduler {
 	return &balanceScheduler{
 		changefeedID:         changefeedID,
@@ -61,7 +67,10 @@ func newbalanceScheduler(
 		operatorController:   oc,
 		replicationDB:        db,
 		nodeManager:          nodeManager,
+		placement:            placement,
+		pinnedNode:           pinnedNode,
 		checkBalanceInterval: balanceInterval,
+		balanceStrategy:      balanceStrategy,
 		lastRebalanceTime:    time.Now(),
 	}
 }
@@ -77,11 +86,20 @@ func (s *balanceScheduler) Execute() time.Time {
 		return now.Add(s.checkBalanceInterval)
 	}
 
-	nodes := s.nodeManager.GetAliveNodes()
-	moved := s.schedulerGroup(nodes)
-	if moved == 0 {
-		// all groups are balanced, safe to do the global balance
-		moved = s.schedulerGlobal(nodes)
+	nodes := SchedulableNodes(s.nodeManager, s.placement, s.pinnedNode)
+	// move the spans off the nodes not allowed by the placement rules first,
+	// the balance is done after all spans are placed properly.
+	moved, misplaced := s.evictMisplaced(nodes)
+	if !misplaced {
+		if s.balanceStrategy == config.BalanceStrategyLoad {
+			moved = s.schedulerByLoad(nodes)
+		} else {
+			moved = s.schedulerGroup(nodes)
+			if moved == 0 {
+				// all groups are balanced, safe to do the global balance
+				moved = s.schedulerGlobal(nodes)
+			}
+		}
 	}
 
 	s.forceBalance = moved >= s.batchSize
@@ -89,6 +107,36 @@ func (s *balanceScheduler) Execute() time.Time {
 	return now.Add(s.checkBalanceInterval)
 }
 
+// evictMisplaced moves the spans running on the nodes out of the schedulable nodes,
+// returns true if there are misplaced spans.
+func (s *balanceScheduler) evictMisplaced(nodes map[node.ID]*node.Info) (int, bool) {
+	if s.placement.IsEmpty() && s.pinnedNode == "" {
+		return 0, false
+	}
+	var misplaced []*replica.SpanReplication
+	for _, r := range s.replicationDB.GetReplicating() {
+		if _, ok := nodes[r.GetNodeID()]; !ok {
+			misplaced = append(misplaced, r)
+		}
+	}
+	if len(misplaced) == 0 {
+		return 0, false
+	}
+	taskSize := s.replicationDB.GetTaskSizePerNode()
+	nodeSize := make(map[node.ID]int, len(nodes))
+	for id := range nodes {
+		nodeSize[id] = taskSize[id]
+	}
+	moved := scheduler.EvictMisplaced(s.batchSize, misplaced, nodeSize,
+		func(_ *replica.SpanReplication, id node.ID) bool {
+			_, ok := nodes[id]
+			return ok
+		}, s.doMove)
+	log.Info("evict misplaced spans", zap.Stringer("changefeed", s.changefeedID),
+		zap.Int("misplaced", len(misplaced)), zap.Int("moved", moved))
+	return moved, true
+}
+
 func (s *balanceScheduler) schedulerGroup(nodes map[node.ID]*node.Info) int {
 	batch, moved := s.batchSize, 0
 	for _, group := range s.replicationDB.GetGroups() {
@@ -172,6 +220,23 @@ func (s *balanceScheduler) schedulerGlobal(nodes map[node.ID]*node.Info) int {
 	return moved
 }
 
+// schedulerByLoad balances all spans by the observed throughput,
+// the groups are not considered since a hot span can make its group imbalanced by load anyway.
+// The node loads are reported to the coordinator only, so the resource usage of the nodes
+// is not taken into account here.
+func (s *balanceScheduler) schedulerByLoad(nodes map[node.ID]*node.Info) int {
+	model := &scheduler.CostModel[*replica.SpanReplication]{
+		EventSizePerSecond: func(r *replica.SpanReplication) float64 {
+			return float64(r.GetEventSizePerSecond())
+		},
+	}
+	moved := scheduler.BalanceByLoad(s.batchSize, nodes, s.replicationDB.GetReplicating(), model, s.doMove)
+	if moved > 0 {
+		log.Info("finish load balance", zap.Stringer("changefeed", s.changefeedID), zap.Int("moved", moved))
+	}
+	return moved
+}
+
 func (s *balanceScheduler) doMove(replication *replica.SpanReplication, id node.ID) bool {
 	op := operator.NewMoveDispatcherOperator(s.replicationDB, replication, replication.GetNodeID(), id)
 	return s.operatorController.AddOperator(op)
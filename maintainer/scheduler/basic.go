@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/ticdc/maintainer/operator"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler"
 	"github.com/pingcap/ticdc/server/watcher"
@@ -34,6 +35,9 @@ type basicScheduler struct {
 	operatorController *operator.Controller
 	replicationDB      *replica.ReplicationDB
 	nodeManager        *watcher.NodeManager
+	placement          *config.PlacementConfig
+	// pinnedNode is the only node the spans are scheduled to if it's not empty
+	pinnedNode node.ID
 
 	// buffer for the absent spans
 	absent []*replica.SpanReplication
@@ -42,6 +46,7 @@ type basicScheduler struct {
 func newBasicScheduler(
 	changefeedID common.ChangeFeedID, batchSize int,
 	oc *operator.Controller, db *replica.ReplicationDB, nodeManager *watcher.NodeManager,
+	placement *config.PlacementConfig, pinnedNode node.ID,
 ) *basicScheduler {
 	return &basicScheduler{
 		batchSize:          batchSize,
@@ -49,6 +54,8 @@ func newBasicScheduler(
 		operatorController: oc,
 		replicationDB:      db,
 		nodeManager:        nodeManager,
+		placement:          placement,
+		pinnedNode:         pinnedNode,
 		absent:             make([]*replica.SpanReplication, 0, batchSize),
 	}
 }
@@ -76,12 +83,13 @@ func (s *basicScheduler) Execute() time.Time {
 
 func (s *basicScheduler) schedule(id replica.GroupID, availableSize int) (scheduled int) {
 	absent := s.replicationDB.GetAbsentByGroup(id, s.absent, availableSize)
-	nodeSize := s.replicationDB.GetTaskSizePerNodeByGroup(id)
-	// add the absent node to the node size map
-	for id := range s.nodeManager.GetAliveNodes() {
-		if _, ok := nodeSize[id]; !ok {
-			nodeSize[id] = 0
-		}
+	taskSize := s.replicationDB.GetTaskSizePerNodeByGroup(id)
+	// only the nodes allowed by the placement rules are the candidates,
+	// the absent node is added to the node size map with 0 size
+	nodes := SchedulableNodes(s.nodeManager, s.placement, s.pinnedNode)
+	nodeSize := make(map[node.ID]int, len(nodes))
+	for id := range nodes {
+		nodeSize[id] = taskSize[id]
 	}
 	// what happens if the some node removed when scheduling?
 	scheduler.BasicSchedule(availableSize, absent, nodeSize, func(replication *replica.SpanReplication, id node.ID) bool {
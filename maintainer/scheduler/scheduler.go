@@ -20,6 +20,9 @@ import (
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/maintainer/split"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/threadpool"
 )
@@ -49,6 +52,9 @@ func NewController(changefeedID common.ChangeFeedID,
 	db *replica.ReplicationDB,
 	nodeManager *watcher.NodeManager,
 	balanceInterval time.Duration,
+	balanceStrategy string,
+	placement *config.PlacementConfig,
+	pinnedNode node.ID,
 	splitter *split.Splitter,
 ) *Controller {
 	m := &Controller{
@@ -56,14 +62,30 @@ func NewController(changefeedID common.ChangeFeedID,
 		schedulers:   make(map[string]Scheduler),
 	}
 
-	m.schedulers[BasicScheduler] = newBasicScheduler(changefeedID, batchSize, oc, db, nodeManager)
-	m.schedulers[BalanceScheduler] = newbalanceScheduler(changefeedID, batchSize, oc, db, nodeManager, balanceInterval)
+	m.schedulers[BasicScheduler] = newBasicScheduler(changefeedID, batchSize, oc, db, nodeManager, placement, pinnedNode)
+	m.schedulers[BalanceScheduler] = newbalanceScheduler(changefeedID, batchSize, oc, db, nodeManager, balanceInterval, balanceStrategy, placement, pinnedNode)
 	if splitter != nil {
 		m.schedulers[SplitScheduler] = newSplitScheduler(changefeedID, batchSize, splitter, oc, db, nodeManager)
 	}
 	return m
 }
 
+// SchedulableNodes returns the alive nodes allowed by the placement rules,
+// all alive nodes are returned if no node matches, so the changefeed keeps running
+// and the violation is reported instead.
+// Only the pinned node is returned if it's not empty and alive, the placement rules are ignored.
+func SchedulableNodes(nodeManager *watcher.NodeManager, placement *config.PlacementConfig, pinnedNode node.ID) map[node.ID]*node.Info {
+	nodes := nodeManager.GetAliveNodes()
+	if info, ok := nodes[pinnedNode]; ok {
+		return map[node.ID]*node.Info{pinnedNode: info}
+	}
+	matched := scheduler.FilterNodes(nodes, placement)
+	if len(matched) == 0 {
+		return nodes
+	}
+	return matched
+}
+
 func (sm *Controller) GetSchedulers() (s []Scheduler) {
 	for _, scheduler := range sm.schedulers {
 		s = append(s, scheduler)
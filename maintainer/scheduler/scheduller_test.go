@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/ticdc/maintainer/operator"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tiflow/pkg/spanz"
@@ -39,7 +40,7 @@ func TestBasicScheduler(t *testing.T) {
 	operatorController := operator.NewOperatorController(cfID, nil, db, 10)
 	nm := watcher.NewNodeManager(nil, nil)
 	nm.GetAliveNodes()[self.ID] = self
-	s := newBasicScheduler(cfID, 4, operatorController, db, nm)
+	s := newBasicScheduler(cfID, 4, operatorController, db, nm, nil, "")
 	s.batchSize = 4
 	s.Execute()
 	require.Equal(t, 4, operatorController.OperatorSize())
@@ -49,3 +50,39 @@ func TestBasicScheduler(t *testing.T) {
 	s.Execute()
 	require.Equal(t, 4, operatorController.OperatorSize())
 }
+
+func TestBasicSchedulerPlacement(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	db := replica.NewReplicaSetDB(cfID, replica.NewReplicaSet(cfID, common.NewDispatcherID(), nil, heartbeatpb.DDLSpanSchemaID, heartbeatpb.DDLSpan, 1))
+	for i := 0; i < 4; i++ {
+		id := int64(i + 1)
+		totalSpan := spanz.TableIDToComparableSpan(id)
+		absent := replica.NewReplicaSet(cfID, common.NewDispatcherID(), nil, 1, &heartbeatpb.TableSpan{TableID: id, StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey}, 1)
+		db.AddAbsentReplicaSet(absent)
+	}
+	node1 := node.NewInfo("node1", "")
+	node1.Labels = map[string]string{"zone": "a"}
+	node2 := node.NewInfo("node2", "")
+	node2.Labels = map[string]string{"zone": "b"}
+	operatorController := operator.NewOperatorController(cfID, nil, db, 10)
+	nm := watcher.NewNodeManager(nil, nil)
+	nm.GetAliveNodes()[node1.ID] = node1
+	nm.GetAliveNodes()[node2.ID] = node2
+
+	placement := &config.PlacementConfig{NodeAffinity: map[string]string{"zone": "a"}}
+	require.Len(t, SchedulableNodes(nm, placement, ""), 1)
+	s := newBasicScheduler(cfID, 10, operatorController, db, nm, placement, "")
+	s.Execute()
+	require.Equal(t, 4, operatorController.OperatorSize())
+	require.Equal(t, map[node.ID]int{node1.ID: 4}, db.GetTaskSizePerNode())
+
+	// all alive nodes are schedulable if no node matches
+	placement = &config.PlacementConfig{NodeAffinity: map[string]string{"zone": "c"}}
+	require.Len(t, SchedulableNodes(nm, placement, ""), 2)
+
+	// the pinned node overrides the placement rules, and it's ignored if it's not alive
+	pinned := SchedulableNodes(nm, placement, node2.ID)
+	require.Len(t, pinned, 1)
+	require.Contains(t, pinned, node2.ID)
+	require.Len(t, SchedulableNodes(nm, placement, "node3"), 2)
+}
@@ -0,0 +1,194 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"go.uber.org/zap"
+)
+
+// tableReplayResendInterval is the interval to resend the replay request to the dispatchers
+// which have not started the replay.
+const tableReplayResendInterval = 5 * time.Second
+
+// tableReplay is a replay of some tables of the changefeed, the dispatchers of the tasks
+// overlapping with the spans replay the events from the replay ts.
+// It's finished when all the dispatchers have replayed and caught up the target ts.
+type tableReplay struct {
+	id       uint64
+	replayTs uint64
+	targetTs uint64
+	// tasks are the tasks which have not finished the replay
+	tasks map[common.DispatcherID]*tableReplayTask
+	// finished are the tasks which have finished the replay
+	finished     map[common.DispatcherID]struct{}
+	lastSendTime time.Time
+}
+
+type tableReplayTask struct {
+	task *replica.SpanReplication
+	// started is true once the dispatcher reports it's replaying from the replay ts
+	started bool
+}
+
+func (r *tableReplay) addTasks(tasks []*replica.SpanReplication) {
+	for _, task := range tasks {
+		if _, ok := r.finished[task.ID]; ok {
+			continue
+		}
+		if _, ok := r.tasks[task.ID]; !ok {
+			r.tasks[task.ID] = &tableReplayTask{task: task}
+		}
+	}
+}
+
+// ReplayTables makes the dispatchers of the tasks overlapping with the spans replay the events
+// from the replay ts until they catch up the target ts, the replay with the same id is started only once.
+func (c *Controller) ReplayTables(id uint64, spans []*heartbeatpb.TableSpan, replayTs, targetTs uint64) {
+	if c.tableReplayID.Load() == id {
+		return
+	}
+	replay := &tableReplay{
+		id:       id,
+		replayTs: replayTs,
+		targetTs: targetTs,
+		tasks:    make(map[common.DispatcherID]*tableReplayTask),
+		finished: make(map[common.DispatcherID]struct{}),
+	}
+	replay.addTasks(c.getTasksOverlapWithSpans(spans))
+	if c.tableReplay != nil {
+		log.Warn("table replay is replaced by a new one",
+			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Uint64("id", c.tableReplay.id),
+			zap.Uint64("newID", id))
+	}
+	c.tableReplay = replay
+	c.tableReplayID.Store(id)
+	c.tableReplayFinished.Store(false)
+	log.Info("start table replay",
+		zap.String("changefeed", c.changefeedID.Name()),
+		zap.Uint64("id", id),
+		zap.Uint64("replayTs", replayTs),
+		zap.Uint64("targetTs", targetTs),
+		zap.Int("spans", len(spans)),
+		zap.Int("dispatchers", len(replay.tasks)))
+	c.CheckTableReplay()
+}
+
+// CheckTableReplay sends the replay request to the dispatchers which have not started the replay,
+// and finishes the table replay when all the dispatchers have caught up the target ts.
+func (c *Controller) CheckTableReplay() {
+	replay := c.tableReplay
+	if replay == nil {
+		return
+	}
+	resend := time.Since(replay.lastSendTime) >= tableReplayResendInterval
+	for id, t := range replay.tasks {
+		if c.GetTask(id) == nil {
+			// the task is removed by ddl or replaced by split and merge
+			delete(replay.tasks, id)
+			if !t.started {
+				// the new tasks start from the checkpoint ts of the removed task, which is not replayed
+				replay.addTasks(c.getTasksOverlapWithSpans([]*heartbeatpb.TableSpan{t.task.Span}))
+			}
+			continue
+		}
+		status := t.task.GetStatus()
+		if status.ReplayedTs == replay.replayTs {
+			t.started = true
+		}
+		if t.started {
+			if status.CheckpointTs >= replay.targetTs {
+				delete(replay.tasks, id)
+				replay.finished[id] = struct{}{}
+			}
+			continue
+		}
+		nodeID := t.task.GetNodeID()
+		if !resend || nodeID == "" || c.operatorController.GetOperator(id) != nil {
+			// the dispatcher is scheduling, send the request after it's created
+			continue
+		}
+		msg, err := t.task.NewReplayDispatcherMessage(nodeID, replay.replayTs)
+		if err != nil {
+			log.Warn("create replay dispatcher message failed, retry later",
+				zap.String("changefeed", c.changefeedID.Name()),
+				zap.String("dispatcher", id.String()),
+				zap.Error(err))
+			continue
+		}
+		_ = c.messageCenter.SendCommand(msg)
+	}
+	if resend {
+		replay.lastSendTime = time.Now()
+	}
+	if len(replay.tasks) == 0 {
+		log.Info("table replay finished",
+			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Uint64("id", replay.id),
+			zap.Int("dispatchers", len(replay.finished)))
+		c.tableReplay = nil
+		c.tableReplayFinished.Store(true)
+	}
+}
+
+// GetTableReplayStatus returns the id of the last table replay and whether it's finished,
+// the id is 0 if there is no table replay.
+func (c *Controller) GetTableReplayStatus() (uint64, bool) {
+	return c.tableReplayID.Load(), c.tableReplayFinished.Load()
+}
+
+// getTasksOverlapWithSpans returns the tasks overlapping with the given spans,
+// the table trigger event dispatcher is never included.
+func (c *Controller) getTasksOverlapWithSpans(spans []*heartbeatpb.TableSpan) []*replica.SpanReplication {
+	var tasks []*replica.SpanReplication
+	seen := make(map[common.DispatcherID]struct{})
+	for _, span := range spans {
+		for _, task := range c.replicationDB.GetTasksByTableIDs(span.TableID) {
+			if task.ID == c.ddlDispatcherID {
+				continue
+			}
+			if _, ok := seen[task.ID]; ok {
+				continue
+			}
+			if bytes.Compare(task.Span.StartKey, span.EndKey) >= 0 ||
+				bytes.Compare(span.StartKey, task.Span.EndKey) >= 0 {
+				continue
+			}
+			seen[task.ID] = struct{}{}
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// toTableReplaySpans converts the spans of the table replay persisted in the changefeed info.
+func toTableReplaySpans(spans []config.TableReplaySpan) []*heartbeatpb.TableSpan {
+	result := make([]*heartbeatpb.TableSpan, 0, len(spans))
+	for _, span := range spans {
+		result = append(result, &heartbeatpb.TableSpan{
+			TableID:  span.TableID,
+			StartKey: span.StartKey,
+			EndKey:   span.EndKey,
+		})
+	}
+	return result
+}
@@ -32,6 +32,8 @@ type ChangefeedsGetter interface {
 type ChangefeedInterface interface {
 	// Create creates a changefeed
 	Create(ctx context.Context, cfg *v2.ChangefeedConfig) (*v2.ChangeFeedInfo, error)
+	// BatchCreate creates a batch of changefeeds from a template
+	BatchCreate(ctx context.Context, cfg *v2.BatchCreateChangefeedConfig) (*v2.BatchCreateChangefeedResponse, error)
 	// VerifyTable verifies table for a changefeed
 	VerifyTable(ctx context.Context, cfg *v2.VerifyTableConfig) (*v2.Tables, error)
 	// Update updates a changefeed
@@ -43,6 +45,8 @@ type ChangefeedInterface interface {
 	Delete(ctx context.Context, namespace string, name string) error
 	// Pause pauses a changefeed with given name
 	Pause(ctx context.Context, namespace string, name string) error
+	// ResetTables replays the given tables of a changefeed from the reset ts
+	ResetTables(ctx context.Context, cfg *v2.ResetTablesConfig, namespace string, name string) error
 	// Get gets a changefeed detaail info
 	Get(ctx context.Context, namespace string, name string) (*v2.ChangeFeedInfo, error)
 	// List lists all changefeeds
@@ -72,6 +76,17 @@ func (c *changefeeds) Create(ctx context.Context,
 	return result, err
 }
 
+func (c *changefeeds) BatchCreate(ctx context.Context,
+	cfg *v2.BatchCreateChangefeedConfig,
+) (*v2.BatchCreateChangefeedResponse, error) {
+	result := &v2.BatchCreateChangefeedResponse{}
+	err := c.client.Post().
+		WithURI("changefeeds/batch").
+		WithBody(cfg).
+		Do(ctx).Into(result)
+	return result, err
+}
+
 func (c *changefeeds) VerifyTable(ctx context.Context,
 	cfg *v2.VerifyTableConfig,
 ) (*v2.Tables, error) {
@@ -128,6 +143,17 @@ func (c *changefeeds) Pause(ctx context.Context,
 		Do(ctx).Error()
 }
 
+// ResetTables resets the tables of a changefeed
+func (c *changefeeds) ResetTables(ctx context.Context,
+	cfg *v2.ResetTablesConfig, namespace string, name string,
+) error {
+	u := fmt.Sprintf("changefeeds/%s/reset_tables?namespace=%s", name, namespace)
+	return c.client.Post().
+		WithURI(u).
+		WithBody(cfg).
+		Do(ctx).Error()
+}
+
 // Get gets a changefeed detaail info
 func (c *changefeeds) Get(ctx context.Context,
 	namespace string, name string,
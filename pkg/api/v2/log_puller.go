@@ -0,0 +1,79 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	v2 "github.com/pingcap/ticdc/api/v2"
+	"github.com/pingcap/ticdc/pkg/api/internal/rest"
+)
+
+// LogPullerGetter has a method to return a LogPullerInterface.
+type LogPullerGetter interface {
+	LogPuller() LogPullerInterface
+}
+
+// LogPullerInterface has methods to work with the log puller of a server.
+type LogPullerInterface interface {
+	StalledRegions(ctx context.Context, threshold time.Duration) ([]v2.StalledRegion, error)
+	StoreHealth(ctx context.Context) ([]v2.StoreHealth, error)
+	ResolveLocks(ctx context.Context, req *v2.ResolveLocksReq) (*v2.ResolveLocksResp, error)
+}
+
+// logPuller implements LogPullerInterface
+type logPuller struct {
+	client rest.CDCRESTInterface
+}
+
+// newLogPuller returns logPuller
+func newLogPuller(c *APIV2Client) *logPuller {
+	return &logPuller{
+		client: c.RESTClient(),
+	}
+}
+
+// StalledRegions returns the regions whose resolved ts is stalled longer than the threshold
+func (c *logPuller) StalledRegions(ctx context.Context, threshold time.Duration) ([]v2.StalledRegion, error) {
+	var result []v2.StalledRegion
+	err := c.client.Get().
+		WithURI("log_puller/stalled_regions").
+		WithParam("threshold", strconv.FormatInt(int64(threshold/time.Second), 10)).
+		Do(ctx).
+		Into(&result)
+	return result, err
+}
+
+// StoreHealth returns the health of the TiKV stores requested by the log puller
+func (c *logPuller) StoreHealth(ctx context.Context) ([]v2.StoreHealth, error) {
+	var result []v2.StoreHealth
+	err := c.client.Get().
+		WithURI("log_puller/store_health").
+		Do(ctx).
+		Into(&result)
+	return result, err
+}
+
+// ResolveLocks resolves the locks in the subscribed regions of a table or a region
+func (c *logPuller) ResolveLocks(ctx context.Context, req *v2.ResolveLocksReq) (*v2.ResolveLocksResp, error) {
+	result := &v2.ResolveLocksResp{}
+	err := c.client.Post().
+		WithURI("log_puller/resolve_locks").
+		WithBody(req).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
@@ -1,10 +1,15 @@
 package apperror
 
 import (
+	stderrors "errors"
 	"fmt"
 	"strings"
 
+	gmysql "github.com/go-sql-driver/mysql"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	dmretry "github.com/pingcap/tiflow/dm/pkg/retry"
 	tierrors "github.com/pingcap/tiflow/pkg/errors"
 )
 
@@ -17,6 +22,14 @@ var (
 		"failed to init table trigger event dispatcher",
 		errors.RFCCodeText("CDC:ErrChangefeedInitTableTriggerEventDispatcherFailed"),
 	)
+	ErrDispatcherEvicted = errors.Normalize(
+		"the data of dispatcher %s is evicted from the event store, pull it from upstream again at %d",
+		errors.RFCCodeText("CDC:ErrDispatcherEvicted"),
+	)
+	ErrDownstreamDataIncompatible = errors.Normalize(
+		"the data is incompatible with the downstream",
+		errors.RFCCodeText("CDC:ErrDownstreamDataIncompatible"),
+	)
 )
 
 type ErrorType int
@@ -141,23 +154,93 @@ var changefeedUnRetryableErrors = []*errors.Error{
 	tierrors.ErrStartTsBeforeGC,
 }
 
+// mysqlErrorCodes maps the error numbers reported by the downstream database
+// to the error codes, so that the coordinator can tell what happens to the downstream.
+var mysqlErrorCodes = map[uint16]errors.RFCErrorCode{
+	mysql.ErrAccessDenied:      tierrors.ErrMySQLConnectionError.RFCCode(),
+	mysql.ErrDBaccessDenied:    tierrors.ErrMySQLConnectionError.RFCCode(),
+	mysql.ErrConCount:          tierrors.ErrMySQLConnectionError.RFCCode(),
+	errno.ErrPDServerTimeout:   tierrors.ErrMySQLConnectionError.RFCCode(),
+	errno.ErrRegionUnavailable: tierrors.ErrMySQLConnectionError.RFCCode(),
+
+	mysql.ErrDupEntry:                    tierrors.ErrMySQLDuplicateEntry.RFCCode(),
+	mysql.ErrBadField:                    ErrDownstreamDataIncompatible.RFCCode(),
+	mysql.ErrNoSuchTable:                 ErrDownstreamDataIncompatible.RFCCode(),
+	mysql.ErrWarnDataOutOfRange:          ErrDownstreamDataIncompatible.RFCCode(),
+	mysql.ErrTruncatedWrongValueForField: ErrDownstreamDataIncompatible.RFCCode(),
+	mysql.ErrDataTooLong:                 ErrDownstreamDataIncompatible.RFCCode(),
+}
+
 // ErrorCode returns the RFC error code for the given error.
-// If the error is a changefeed unretryable error, returns ErrChangefeedUnretryable.
-// otherwise, return ErrChangefeedRetryable
+// If the error is a changefeed unretryable error, returns the code of the unretryable error,
+// if the error comes from the downstream database, returns the code mapped from the error number,
+// otherwise, returns the code of the error, or ErrChangefeedRetryable if the error has no code.
 func ErrorCode(err error) errors.RFCErrorCode {
 	for _, e := range changefeedUnRetryableErrors {
 		if e.Equal(err) {
-			return tierrors.ErrChangefeedUnretryable.RFCCode()
+			return e.RFCCode()
 		}
 		if code, ok := tierrors.RFCCode(err); ok {
 			if code == e.RFCCode() {
-				return tierrors.ErrChangefeedUnretryable.RFCCode()
+				return code
 			}
 		}
 		if strings.Contains(err.Error(), string(e.RFCCode())) {
-			return tierrors.ErrChangefeedUnretryable.RFCCode()
+			return e.RFCCode()
 		}
 	}
 
+	if mysqlErr, ok := errors.Cause(err).(*gmysql.MySQLError); ok {
+		if code, ok := mysqlErrorCodes[mysqlErr.Number]; ok {
+			return code
+		}
+	}
+	if dmretry.IsConnectionError(err) {
+		return tierrors.ErrMySQLConnectionError.RFCCode()
+	}
+	if code, ok := tierrors.RFCCode(err); ok {
+		return code
+	}
 	return ErrChangefeedRetryable.RFCCode()
 }
+
+// TableError is an error that happens when the data of a single table
+// is written to the downstream.
+type TableError struct {
+	Schema string
+	Table  string
+	err    error
+}
+
+// WrapTableError attaches the table to the error, it returns nil if err is nil.
+func WrapTableError(err error, schema, table string) error {
+	if err == nil {
+		return nil
+	}
+	return &TableError{Schema: schema, Table: table, err: err}
+}
+
+func (e *TableError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the underlying error, it's used by errors.Cause.
+func (e *TableError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the underlying error, it's used by errors.As and errors.Is.
+func (e *TableError) Unwrap() error {
+	return e.err
+}
+
+// ErrorTable returns the table the error comes from in the form of `schema`.`table`,
+// it returns an empty string if the error can't be attributed to a single table.
+func ErrorTable(err error) string {
+	var tableErr *TableError
+	if !stderrors.As(err, &tableErr) {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(tableErr.Schema, "`", "``") + "`.`" +
+		strings.ReplaceAll(tableErr.Table, "`", "``") + "`"
+}
@@ -0,0 +1,397 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columntransformer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// transformFunc returns the transformed value of a column, the row is the original row
+// which contains the column, it is only used by the expression function.
+type transformFunc func(d types.Datum, row chunk.Row) (types.Datum, error)
+
+// sha256HexLength is the length of the hex encoded sha256 digest.
+const sha256HexLength = 64
+
+type columnTransformer struct {
+	tableF filter.Filter
+	config *ticonfig.ColumnTransformer
+}
+
+func newColumnTransformer(
+	rule *ticonfig.ColumnTransformer, caseSensitive bool,
+) (*columnTransformer, error) {
+	tableM, err := filter.Parse(rule.Matcher)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrFilterRuleInvalid, err, rule.Matcher)
+	}
+	if !caseSensitive {
+		tableM = filter.CaseInsensitive(tableM)
+	}
+	return &columnTransformer{
+		tableF: tableM,
+		config: rule,
+	}, nil
+}
+
+func (t *columnTransformer) match(schema, table string) bool {
+	return t.tableF.MatchTable(schema, table)
+}
+
+// tableTransformer is the transform functions of a version of the table,
+// the key of the funcs is the offset of the transformed column.
+type tableTransformer struct {
+	tableInfo *common.TableInfo
+	funcs     map[int]transformFunc
+}
+
+// ColumnTransformers manages an array of transformers, all transformers match the
+// table are applied to its columns. If a column is transformed by several transformers,
+// the first one is used.
+type ColumnTransformers struct {
+	transformers []*columnTransformer
+	sessCtx      sessionctx.Context
+
+	mu sync.Mutex
+	// tables caches the transform functions of each table, it is rebuilt after the table is changed.
+	tables map[string]*tableTransformer
+}
+
+// NewColumnTransformers returns the column transformers of the sink config.
+func NewColumnTransformers(sinkConfig *ticonfig.SinkConfig) (*ColumnTransformers, error) {
+	transformers := make([]*columnTransformer, 0, len(sinkConfig.ColumnTransformers))
+	for _, r := range sinkConfig.ColumnTransformers {
+		transformer, err := newColumnTransformer(r, sinkConfig.CaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		transformers = append(transformers, transformer)
+	}
+	return &ColumnTransformers{
+		transformers: transformers,
+		sessCtx:      newSessionCtx(),
+		tables:       make(map[string]*tableTransformer),
+	}, nil
+}
+
+// Match returns true if any transformer matches the table.
+func (c *ColumnTransformers) Match(schema, table string) bool {
+	for _, t := range c.transformers {
+		if t.match(schema, table) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify checks the transformers against the table schemas, it's called when the changefeed
+// is created, updated or resumed. An error is returned if the value produced by any transformer
+// is not compatible with the column, or a transformer matches some tables but none of them
+// contains the column.
+func (c *ColumnTransformers) Verify(tableInfos []*common.TableInfo) error {
+	matched := make([]bool, len(c.transformers))
+	found := make([]bool, len(c.transformers))
+	for _, tableInfo := range tableInfos {
+		for i, t := range c.transformers {
+			if !t.match(tableInfo.GetSchemaName(), tableInfo.GetTableName()) {
+				continue
+			}
+			matched[i] = true
+			if findColumn(tableInfo, t.config.Column) >= 0 {
+				found[i] = true
+			}
+		}
+		if _, err := c.buildTableTransformer(tableInfo); err != nil {
+			return err
+		}
+	}
+	for i, t := range c.transformers {
+		if matched[i] && !found[i] {
+			return errors.ErrSinkInvalidConfig.GenWithStack(
+				"column %s of the column transformer is not found in any matched table", t.config.Column)
+		}
+	}
+	return nil
+}
+
+// Apply transforms the column values of all rows in the DML event in place.
+func (c *ColumnTransformers) Apply(event *commonEvent.DMLEvent) error {
+	if len(c.transformers) == 0 || event.Rows == nil || event.Rows.NumRows() == 0 {
+		return nil
+	}
+	t, err := c.getTableTransformer(event.TableInfo)
+	if err != nil {
+		return err
+	}
+	if len(t.funcs) == 0 {
+		return nil
+	}
+
+	fields := event.TableInfo.GetFieldSlice()
+	numRows := event.Rows.NumRows()
+	// Build all transformed columns before replacing any of them,
+	// so the expressions are always evaluated against the original row.
+	columns := make(map[int]*chunk.Chunk, len(t.funcs))
+	for offset, fn := range t.funcs {
+		ft := fields[offset]
+		chk := chunk.NewChunkWithCapacity([]*types.FieldType{ft}, numRows)
+		for i := 0; i < numRows; i++ {
+			row := event.Rows.GetRow(i)
+			d, err := fn(row.GetDatum(offset, ft), row)
+			if err != nil {
+				return err
+			}
+			chk.AppendDatum(0, &d)
+		}
+		columns[offset] = chk
+	}
+	for offset, chk := range columns {
+		event.Rows.SetCol(offset, chk.Column(0))
+	}
+	return nil
+}
+
+func (c *ColumnTransformers) getTableTransformer(tableInfo *common.TableInfo) (*tableTransformer, error) {
+	name := tableInfo.TableName.String()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t, ok := c.tables[name]; ok && t.tableInfo.UpdateTS() == tableInfo.UpdateTS() {
+		return t, nil
+	}
+	t, err := c.buildTableTransformer(tableInfo)
+	if err != nil {
+		return nil, err
+	}
+	c.tables[name] = t
+	return t, nil
+}
+
+func (c *ColumnTransformers) buildTableTransformer(tableInfo *common.TableInfo) (*tableTransformer, error) {
+	t := &tableTransformer{
+		tableInfo: tableInfo,
+		funcs:     make(map[int]transformFunc),
+	}
+	for _, transformer := range c.transformers {
+		if !transformer.match(tableInfo.GetSchemaName(), tableInfo.GetTableName()) {
+			continue
+		}
+		offset := findColumn(tableInfo, transformer.config.Column)
+		if offset < 0 {
+			continue
+		}
+		if _, ok := t.funcs[offset]; ok {
+			continue
+		}
+		fn, err := c.newTransformFunc(transformer.config, tableInfo, tableInfo.GetColumns()[offset])
+		if err != nil {
+			log.Error("failed to build the column transformer",
+				zap.String("table", tableInfo.TableName.String()),
+				zap.String("column", transformer.config.Column),
+				zap.Error(err))
+			return nil, err
+		}
+		t.funcs[offset] = fn
+	}
+	return t, nil
+}
+
+func (c *ColumnTransformers) newTransformFunc(
+	cfg *ticonfig.ColumnTransformer, tableInfo *common.TableInfo, col *model.ColumnInfo,
+) (transformFunc, error) {
+	// the rows are identified by the primary key and the unique keys in the downstream,
+	// the transformed values may conflict or can't be matched with the upstream rows.
+	if isKeyColumn(tableInfo, col) {
+		return nil, errors.ErrSinkInvalidConfig.GenWithStack(
+			"column transformer %s can not be applied to the primary key or unique key column %s of table %s",
+			cfg.Function, col.Name.O, tableInfo.TableName.String())
+	}
+	ft := &col.FieldType
+	switch cfg.Function {
+	case ticonfig.ColumnTransformSHA256, ticonfig.ColumnTransformMask, ticonfig.ColumnTransformTruncate:
+		if !types.IsString(ft.GetType()) {
+			return nil, errors.ErrSinkInvalidConfig.GenWithStack(
+				"column transformer %s only supports the string columns, but column %s of table %s is %s",
+				cfg.Function, col.Name.O, tableInfo.TableName.String(), types.TypeToStr(ft.GetType(), ft.GetCharset()))
+		}
+		if cfg.Function == ticonfig.ColumnTransformSHA256 &&
+			ft.GetFlen() != types.UnspecifiedLength && ft.GetFlen() < sha256HexLength {
+			return nil, errors.ErrSinkInvalidConfig.GenWithStack(
+				"column transformer %s produces %d characters, but the length of column %s of table %s is %d",
+				cfg.Function, sha256HexLength, col.Name.O, tableInfo.TableName.String(), ft.GetFlen())
+		}
+		binary := ft.GetCharset() == charset.CharsetBin
+		var transform func(s string) string
+		switch cfg.Function {
+		case ticonfig.ColumnTransformSHA256:
+			transform = func(s string) string {
+				sum := sha256.Sum256([]byte(s))
+				return hex.EncodeToString(sum[:])
+			}
+		case ticonfig.ColumnTransformMask:
+			transform = func(s string) string { return mask(s, cfg.Length, binary) }
+		default:
+			transform = func(s string) string { return truncate(s, cfg.Length, binary) }
+		}
+		return func(d types.Datum, _ chunk.Row) (types.Datum, error) {
+			if d.IsNull() {
+				return d, nil
+			}
+			d.SetString(transform(d.GetString()), d.Collation())
+			return d, nil
+		}, nil
+	case ticonfig.ColumnTransformConstant:
+		if cfg.Value == nil {
+			if mysql.HasNotNullFlag(ft.GetFlag()) {
+				return nil, errors.ErrSinkInvalidConfig.GenWithStack(
+					"column %s of table %s can not be set to NULL by the column transformer",
+					col.Name.O, tableInfo.TableName.String())
+			}
+			return func(types.Datum, chunk.Row) (types.Datum, error) {
+				return types.Datum{}, nil
+			}, nil
+		}
+		value := types.NewStringDatum(*cfg.Value)
+		constant, err := value.ConvertTo(c.sessCtx.GetExprCtx().GetEvalCtx().TypeCtx(), ft)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrSinkInvalidConfig, err)
+		}
+		return func(types.Datum, chunk.Row) (types.Datum, error) {
+			return constant, nil
+		}, nil
+	case ticonfig.ColumnTransformExpression:
+		expr, err := expression.ParseSimpleExprWithTableInfo(
+			c.sessCtx.GetExprCtx(), cfg.Expression, toTiDBTableInfo(tableInfo))
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrSinkInvalidConfig, err)
+		}
+		// The session context is not thread safe, each transformer evaluates the expression
+		// with its own contexts, the pool keeps one for each goroutine applying it at the same time.
+		sessCtxs := &sync.Pool{
+			New: func() any {
+				return newSessionCtx()
+			},
+		}
+		return func(_ types.Datum, row chunk.Row) (types.Datum, error) {
+			sessCtx := sessCtxs.Get().(sessionctx.Context)
+			defer sessCtxs.Put(sessCtx)
+			evalCtx := sessCtx.GetExprCtx().GetEvalCtx()
+			d, err := expr.Eval(evalCtx, row)
+			if err != nil {
+				return types.Datum{}, errors.Trace(err)
+			}
+			d, err = d.ConvertTo(evalCtx.TypeCtx(), ft)
+			if err != nil {
+				return types.Datum{}, errors.Trace(err)
+			}
+			return d, nil
+		}, nil
+	default:
+		return nil, errors.ErrSinkInvalidConfig.GenWithStack(
+			"unknown column transformer function %s", cfg.Function)
+	}
+}
+
+func newSessionCtx() sessionctx.Context {
+	return utils.NewSessionCtx(map[string]string{
+		"time_zone": "",
+	})
+}
+
+// isKeyColumn returns true if the column is in the primary key or any unique key of the table.
+func isKeyColumn(tableInfo *common.TableInfo, col *model.ColumnInfo) bool {
+	if mysql.HasPriKeyFlag(col.GetFlag()) {
+		return true
+	}
+	for _, idx := range tableInfo.GetIndices() {
+		if !idx.Primary && !idx.Unique {
+			continue
+		}
+		for _, idxCol := range idx.Columns {
+			if idxCol.Name.L == col.Name.L {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findColumn returns the offset of the column in the table, or -1 if it is not found.
+// The virtual generated columns are never transformed since they are not replicated.
+func findColumn(tableInfo *common.TableInfo, name string) int {
+	for i, col := range tableInfo.GetColumns() {
+		if col.Name.L == strings.ToLower(name) && common.IsColCDCVisible(col) {
+			return i
+		}
+	}
+	return -1
+}
+
+// toTiDBTableInfo returns the minimal table info to build the expressions on the row of the table.
+func toTiDBTableInfo(tableInfo *common.TableInfo) *model.TableInfo {
+	return &model.TableInfo{
+		Name:    pmodel.NewCIStr(tableInfo.GetTableName()),
+		Columns: tableInfo.GetColumns(),
+		State:   model.StatePublic,
+	}
+}
+
+// mask replaces the characters of s with `*`, except the last keep characters.
+func mask(s string, keep int, binary bool) string {
+	if binary {
+		if len(s) <= keep {
+			return s
+		}
+		return strings.Repeat("*", len(s)-keep) + s[len(s)-keep:]
+	}
+	runes := []rune(s)
+	if len(runes) <= keep {
+		return s
+	}
+	return strings.Repeat("*", len(runes)-keep) + string(runes[len(runes)-keep:])
+}
+
+// truncate keeps the first n characters of s.
+func truncate(s string, n int, binary bool) string {
+	if binary {
+		if len(s) <= n {
+			return s
+		}
+		return s[:n]
+	}
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
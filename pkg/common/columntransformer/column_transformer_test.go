@@ -0,0 +1,119 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columntransformer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnTransformersApply(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, email varchar(128), card varchar(32), " +
+		"phone varchar(32), name varchar(32), score int)")
+	tableInfo := helper.GetTableInfo(job)
+
+	transformers, err := NewColumnTransformers(&ticonfig.SinkConfig{
+		ColumnTransformers: []*ticonfig.ColumnTransformer{
+			{Matcher: []string{"test.t"}, Column: "email", Function: ticonfig.ColumnTransformSHA256},
+			{Matcher: []string{"test.t"}, Column: "card", Function: ticonfig.ColumnTransformMask, Length: 4},
+			{Matcher: []string{"test.t"}, Column: "phone", Function: ticonfig.ColumnTransformConstant},
+			{Matcher: []string{"test.t"}, Column: "name", Function: ticonfig.ColumnTransformTruncate, Length: 2},
+			{Matcher: []string{"test.t"}, Column: "score", Function: ticonfig.ColumnTransformExpression, Expression: "score * 10 + id"},
+			// the column is already transformed by the first transformer
+			{Matcher: []string{"test.*"}, Column: "email", Function: ticonfig.ColumnTransformTruncate, Length: 1},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, transformers.Verify([]*common.TableInfo{tableInfo}))
+
+	dmlEvent := helper.DML2Event("test", "t",
+		"insert into t values (1, 'a@pingcap.com', '1234567812345678', '13800000000', 'alice', 3)")
+	require.NoError(t, transformers.Apply(dmlEvent))
+
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	sum := sha256.Sum256([]byte("a@pingcap.com"))
+	require.Equal(t, hex.EncodeToString(sum[:]), row.Row.GetString(1))
+	require.Equal(t, "************5678", row.Row.GetString(2))
+	require.True(t, row.Row.IsNull(3))
+	require.Equal(t, "al", row.Row.GetString(4))
+	require.Equal(t, int64(31), row.Row.GetInt64(5))
+	// the columns without transformer are not changed
+	require.Equal(t, int64(1), row.Row.GetInt64(0))
+}
+
+func TestColumnTransformersVerify(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (pk varchar(64) primary key, email varchar(128) not null, age int, " +
+		"code varchar(32), account varchar(128), unique key uk(account))")
+	tableInfo := helper.GetTableInfo(job)
+
+	cases := []struct {
+		transformer *ticonfig.ColumnTransformer
+		ok          bool
+	}{
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "email", Function: ticonfig.ColumnTransformSHA256}, true},
+		// sha256 only supports the string columns
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "age", Function: ticonfig.ColumnTransformSHA256}, false},
+		// the sha256 digest is longer than the column
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "code", Function: ticonfig.ColumnTransformSHA256}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "code", Function: ticonfig.ColumnTransformMask, Length: 4}, true},
+		// the primary key and unique key columns can not be transformed
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "account", Function: ticonfig.ColumnTransformMask, Length: 4}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "account", Function: ticonfig.ColumnTransformTruncate, Length: 4}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "account", Function: ticonfig.ColumnTransformSHA256}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "account", Function: ticonfig.ColumnTransformConstant, Value: util.AddressOf("a")}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "account", Function: ticonfig.ColumnTransformExpression, Expression: "concat(account, 'a')"}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "pk", Function: ticonfig.ColumnTransformMask, Length: 4}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "pk", Function: ticonfig.ColumnTransformTruncate, Length: 4}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "pk", Function: ticonfig.ColumnTransformSHA256}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "pk", Function: ticonfig.ColumnTransformConstant, Value: util.AddressOf("a")}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "pk", Function: ticonfig.ColumnTransformExpression, Expression: "concat(pk, 'a')"}, false},
+		// the not null column can not be set to NULL
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "email", Function: ticonfig.ColumnTransformConstant}, false},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "age", Function: ticonfig.ColumnTransformConstant, Value: util.AddressOf("18")}, true},
+		// the column is not found in the matched table
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "phone", Function: ticonfig.ColumnTransformSHA256}, false},
+		// the table is not matched
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t1"}, Column: "phone", Function: ticonfig.ColumnTransformSHA256}, true},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "age", Function: ticonfig.ColumnTransformExpression, Expression: "age + 1"}, true},
+		{&ticonfig.ColumnTransformer{Matcher: []string{"test.t"}, Column: "age", Function: ticonfig.ColumnTransformExpression, Expression: "unknown + 1"}, false},
+	}
+	for _, c := range cases {
+		transformers, err := NewColumnTransformers(&ticonfig.SinkConfig{
+			ColumnTransformers: []*ticonfig.ColumnTransformer{c.transformer},
+		})
+		require.NoError(t, err)
+		err = transformers.Verify([]*common.TableInfo{tableInfo})
+		if c.ok {
+			require.NoError(t, err, c.transformer)
+		} else {
+			require.Error(t, err, c.transformer)
+		}
+	}
+}
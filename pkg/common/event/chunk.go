@@ -1,6 +1,7 @@
 package event
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math"
 	"time"
@@ -22,9 +23,33 @@ import (
 	"go.uber.org/zap"
 )
 
-func (m *mounter) rawKVToChunkV2(value []byte, tableInfo *common.TableInfo, chk *chunk.Chunk, handle kv.Handle) error {
+// rowChecksum is the bytes-level checksum of a row encoded by the upstream TiDB.
+type rowChecksum struct {
+	expected  uint32
+	version   int
+	corrupted bool
+	// columnIDs are the IDs of the columns encoded in the row value, it's nil if
+	// some of them are not in the table info, so the checksum can't be calculated again.
+	columnIDs []int64
+}
+
+// The flags of the row value, rowFlagLarge indicates the column IDs and the offsets are
+// encoded in 4 bytes, rowFlagChecksum indicates the checksum is encoded,
+// see https://github.com/pingcap/tidb/blob/master/pkg/util/rowcodec/row.go for details.
+const (
+	rowFlagLarge    byte = 1 << 0
+	rowFlagChecksum byte = 1 << 1
+)
+
+// checksumVersionRawBytes is the version of the bytes-level checksum, the version 0 is
+// the deprecated columns-level checksum, which can not be verified after schema changes.
+const checksumVersionRawBytes = 1
+
+func (m *mounter) rawKVToChunkV2(
+	value []byte, tableInfo *common.TableInfo, chk *chunk.Chunk, handle kv.Handle, key kv.Key,
+) (*rowChecksum, error) {
 	if len(value) == 0 {
-		return nil
+		return nil, nil
 	}
 	handleColIDs, _, reqCols := tableInfo.GetRowColInfos()
 	// This function is used to set the default value for the column that
@@ -52,13 +77,133 @@ func (m *mounter) rawKVToChunkV2(value []byte, tableInfo *common.TableInfo, chk
 		chk.AppendDatum(i, &colDatum)
 		return nil
 	}
+	hasChecksum := m.verifyChecksum && len(value) > 1 && value[1]&rowFlagChecksum != 0
+	if hasChecksum {
+		// the checksum calculation overwrites the row data in place,
+		// copy it to keep the raw value untouched.
+		value = append([]byte(nil), value...)
+	}
 	decoder := rowcodec.NewChunkDecoder(reqCols, handleColIDs, defVal, m.tz)
 	// cache it for later use
 	err := decoder.DecodeToChunk(value, handle, chk)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
-	return nil
+	if !hasChecksum {
+		return nil, nil
+	}
+	return m.verifyRawBytesChecksum(decoder, reqCols, value, chk.GetRow(chk.NumRows()-1), key, tableInfo)
+}
+
+// verifyRawBytesChecksum calculates the bytes-level checksum by the decoded column values,
+// and compares it with the one encoded by the upstream TiDB.
+func (m *mounter) verifyRawBytesChecksum(
+	decoder *rowcodec.ChunkDecoder, reqCols []rowcodec.ColInfo, value []byte, row chunk.Row, key kv.Key, tableInfo *common.TableInfo,
+) (*rowChecksum, error) {
+	expected, ok := decoder.GetChecksum()
+	if !ok || decoder.ChecksumVersion() != checksumVersionRawBytes {
+		return nil, nil
+	}
+	// decode the column IDs before the checksum calculation, which overwrites the row data.
+	encodedColumnIDs, err := decodeColumnIDs(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var (
+		columnIDs []int64
+		datums    []*types.Datum
+	)
+	for i, col := range reqCols {
+		// TiDB does not encode null value into the bytes, so just ignore it.
+		if col.ID < 0 || col.VirtualGenCol || row.IsNull(i) {
+			continue
+		}
+		datum := row.GetDatum(i, col.Ft)
+		datums = append(datums, &datum)
+		columnIDs = append(columnIDs, col.ID)
+	}
+	obtained, err := decoder.CalculateRawChecksum(m.tz, columnIDs, datums, key, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if obtained != expected {
+		log.Error("raw bytes checksum mismatch",
+			zap.String("table", tableInfo.TableName.String()),
+			zap.Uint32("expected", expected), zap.Uint32("obtained", obtained))
+	}
+	// the columns which are not in the table info can't be encoded again by the decoded row.
+	for _, id := range encodedColumnIDs {
+		if findRowColumn(reqCols, id) < 0 {
+			encodedColumnIDs = nil
+			break
+		}
+	}
+	return &rowChecksum{
+		expected:  expected,
+		version:   decoder.ChecksumVersion(),
+		corrupted: obtained != expected,
+		columnIDs: encodedColumnIDs,
+	}, nil
+}
+
+// decodeColumnIDs decodes the IDs of the not null columns and the null columns
+// encoded in the row value, see rowcodec.Encoder for the format.
+func decodeColumnIDs(value []byte) ([]int64, error) {
+	const headerSize = 6
+	if len(value) < headerSize {
+		return nil, errors.Errorf("row value is truncated, length %d", len(value))
+	}
+	large := value[1]&rowFlagLarge != 0
+	numCols := int(binary.LittleEndian.Uint16(value[2:])) + int(binary.LittleEndian.Uint16(value[4:]))
+	idSize := 1
+	if large {
+		idSize = 4
+	}
+	if len(value) < headerSize+numCols*idSize {
+		return nil, errors.Errorf("row value is truncated, length %d, columns %d", len(value), numCols)
+	}
+	columnIDs := make([]int64, numCols)
+	for i := range columnIDs {
+		offset := headerSize + i*idSize
+		if large {
+			columnIDs[i] = int64(binary.LittleEndian.Uint32(value[offset:]))
+		} else {
+			columnIDs[i] = int64(value[offset])
+		}
+	}
+	return columnIDs, nil
+}
+
+func findRowColumn(reqCols []rowcodec.ColInfo, id int64) int {
+	for i, col := range reqCols {
+		if col.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// calculateRawChecksum calculates the bytes-level checksum of the decoded row again,
+// the columns are encoded in the same way as the upstream TiDB encodes the row value.
+func calculateRawChecksum(
+	tz *time.Location, tableInfo *common.TableInfo, row chunk.Row, columnIDs []int64, key []byte,
+) (uint32, error) {
+	_, _, reqCols := tableInfo.GetRowColInfos()
+	datums := make([]types.Datum, 0, len(columnIDs))
+	for _, id := range columnIDs {
+		i := findRowColumn(reqCols, id)
+		if i < 0 {
+			return 0, errors.Errorf("column %d is not found in table %s", id, tableInfo.TableName.String())
+		}
+		datums = append(datums, row.GetDatum(i, reqCols[i].Ft))
+	}
+	var encoder rowcodec.Encoder
+	value, err := encoder.Encode(tz, columnIDs, datums, rowcodec.RawChecksum{Key: key}, nil)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	// the checksum is appended to the end of the row value.
+	return binary.LittleEndian.Uint32(value[len(value)-4:]), nil
 }
 
 func (m *mounter) rawKVToChunkV1(value []byte, tableInfo *common.TableInfo, chk *chunk.Chunk, handle kv.Handle) error {
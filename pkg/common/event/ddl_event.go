@@ -32,13 +32,17 @@ type DDLEvent struct {
 	PrevTableName  string            `json:"prev_table_name"`
 	Query          string            `json:"query"`
 	TableInfo      *common.TableInfo `json:"-"`
-	FinishedTs     uint64            `json:"finished_ts"`
+	// PreTableInfo is the table info before the DDL is executed. It is set by the event service
+	// for the DDLs sent to a table dispatcher, and is nil if the table does not exist before the DDL.
+	PreTableInfo *common.TableInfo `json:"-"`
+	FinishedTs   uint64            `json:"finished_ts"`
 	// The seq of the event. It is set by event service.
 	Seq uint64 `json:"seq"`
 	// State is the state of sender when sending this event.
 	State EventSenderState `json:"state"`
-	// TODO: just here for compile, may be changed later
-	MultipleTableInfos []*common.TableInfo `json:"multiple_table_infos"`
+	// MultipleTableInfos are the table infos of the tables created by a create tables DDL,
+	// in the same order as the queries.
+	MultipleTableInfos []*common.TableInfo `json:"-"`
 
 	BlockedTables     *InfluencedTables `json:"blocked_tables"`
 	UpdatedSchemas    []SchemaIDChange  `json:"updated_schemas"`
@@ -56,6 +60,11 @@ type DDLEvent struct {
 	TableNameChange *TableNameChange `json:"table_name_change"`
 
 	TiDBOnly bool `json:"tidb_only"`
+	// BDRRole is the bdr role of the upstream cluster when the ddl is executed.
+	BDRRole string `json:"bdr_role"`
+	// NotSync is set by the event service if the ddl should not be written to the downstream,
+	// the dispatcher only deals with the tables added or dropped by the ddl.
+	NotSync bool `json:"not_sync"`
 	// 用于在event flush 后执行，后续兼容不同下游的时候要看是不是要拆下去
 	PostTxnFlushed []func() `json:"-"`
 	// eventSize is the size of the event in bytes. It is set when it's unmarshaled.
@@ -145,14 +154,18 @@ func (d *DDLEvent) GetSubEvents() []DDLEvent {
 			log.Panic("querys length should be equal to addName length", zap.String("query", d.Query), zap.Any("addName", d.TableNameChange.AddName))
 		}
 		for i, schemaAndTable := range d.TableNameChange.AddName {
-			events = append(events, DDLEvent{
+			event := DDLEvent{
 				Version:    d.Version,
 				Type:       d.Type,
 				SchemaName: schemaAndTable.SchemaName,
 				TableName:  schemaAndTable.TableName,
 				Query:      querys[i],
 				FinishedTs: d.FinishedTs,
-			})
+			}
+			if len(d.MultipleTableInfos) == len(d.TableNameChange.AddName) {
+				event.TableInfo = d.MultipleTableInfos[i]
+			}
+			events = append(events, event)
 		}
 		return events
 	default:
@@ -212,7 +225,8 @@ func (e *DDLEvent) GetDDLType() model.ActionType {
 }
 
 func (t DDLEvent) Marshal() ([]byte, error) {
-	// restData | dispatcherIDData | dispatcherIDDataSize | tableInfoData | tableInfoDataSize
+	// restData | dispatcherIDData | dispatcherIDDataSize | tableInfoData | tableInfoDataSize | preTableInfoData | preTableInfoDataSize
+	// | multipleTableInfoData | multipleTableInfoDataSize | ... | multipleTableInfoCount
 	data, err := json.Marshal(t)
 	if err != nil {
 		return nil, err
@@ -223,36 +237,81 @@ func (t DDLEvent) Marshal() ([]byte, error) {
 	data = append(data, dispatcherIDData...)
 	data = append(data, dispatcherIDDataSize...)
 
-	if t.TableInfo != nil {
-		tableInfoData, err := json.Marshal(t.TableInfo)
+	data, err = appendTableInfo(data, t.TableInfo)
+	if err != nil {
+		return nil, err
+	}
+	data, err = appendTableInfo(data, t.PreTableInfo)
+	if err != nil {
+		return nil, err
+	}
+	for _, tableInfo := range t.MultipleTableInfos {
+		data, err = appendTableInfo(data, tableInfo)
 		if err != nil {
 			return nil, err
 		}
-		tableInfoDataSize := make([]byte, 8)
-		binary.BigEndian.PutUint64(tableInfoDataSize, uint64(len(tableInfoData)))
-		data = append(data, tableInfoData...)
-		data = append(data, tableInfoDataSize...)
-	} else {
-		tableInfoDataSize := make([]byte, 8)
+	}
+	return binary.BigEndian.AppendUint64(data, uint64(len(t.MultipleTableInfos))), nil
+}
+
+// appendTableInfo appends the table info and its size to the data, only the size 0 is appended if the table info is nil.
+func appendTableInfo(data []byte, tableInfo *common.TableInfo) ([]byte, error) {
+	tableInfoDataSize := make([]byte, 8)
+	if tableInfo == nil {
 		binary.BigEndian.PutUint64(tableInfoDataSize, 0)
-		data = append(data, tableInfoDataSize...)
+		return append(data, tableInfoDataSize...), nil
+	}
+	// the data is not a valid json, so MarshalJSON is called directly.
+	tableInfoData, err := tableInfo.MarshalJSON()
+	if err != nil {
+		return nil, err
 	}
-	return data, nil
+	binary.BigEndian.PutUint64(tableInfoDataSize, uint64(len(tableInfoData)))
+	data = append(data, tableInfoData...)
+	return append(data, tableInfoDataSize...), nil
+}
+
+// readTableInfo reads the table info appended by appendTableInfo which ends at the end,
+// it returns the start of the table info data.
+func readTableInfo(data []byte, end int) (*common.TableInfo, int, error) {
+	tableInfoDataSize := int(binary.BigEndian.Uint64(data[end-8 : end]))
+	start := end - 8 - tableInfoDataSize
+	if tableInfoDataSize == 0 {
+		return nil, start, nil
+	}
+	tableInfo, err := common.UnmarshalJSONToTableInfo(data[start : end-8])
+	if err != nil {
+		return nil, 0, err
+	}
+	return tableInfo, start, nil
 }
 
 func (t *DDLEvent) Unmarshal(data []byte) error {
-	// restData | dispatcherIDData | dispatcherIDDataSize | tableInfoData | tableInfoDataSize
+	// restData | dispatcherIDData | dispatcherIDDataSize | tableInfoData | tableInfoDataSize | preTableInfoData | preTableInfoDataSize
+	// | multipleTableInfoData | multipleTableInfoDataSize | ... | multipleTableInfoCount
 	t.eventSize = int64(len(data))
-	tableInfoDataSize := binary.BigEndian.Uint64(data[len(data)-8:])
 	var err error
-	end := len(data) - 8 - int(tableInfoDataSize)
-	if tableInfoDataSize > 0 {
-		tableInfoData := data[len(data)-8-int(tableInfoDataSize) : len(data)-8]
-		t.TableInfo, err = common.UnmarshalJSONToTableInfo(tableInfoData)
+	end := len(data)
+	multipleTableInfoCount := int(binary.BigEndian.Uint64(data[end-8 : end]))
+	end -= 8
+	t.MultipleTableInfos = nil
+	if multipleTableInfoCount > 0 {
+		t.MultipleTableInfos = make([]*common.TableInfo, multipleTableInfoCount)
+	}
+	for i := multipleTableInfoCount - 1; i >= 0; i-- {
+		t.MultipleTableInfos[i], end, err = readTableInfo(data, end)
 		if err != nil {
 			return err
 		}
 	}
+	t.PreTableInfo, end, err = readTableInfo(data, end)
+	if err != nil {
+		return err
+	}
+	t.TableInfo, end, err = readTableInfo(data, end)
+	if err != nil {
+		return err
+	}
 	dispatcherIDDatSize := binary.BigEndian.Uint64(data[end-8 : end])
 	dispatcherIDData := data[end-8-int(dispatcherIDDatSize) : end-8]
 	err = t.DispatcherID.Unmarshal(dispatcherIDData)
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,3 +38,81 @@ func TestDDLEvent(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, ddlEvent, reverseEvent)
 }
+
+func TestDDLEventWithPreTableInfo(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	createJob := helper.DDL2Job("create table t1 (id int primary key, name varchar(32))")
+	require.NotNil(t, createJob)
+	alterJob := helper.DDL2Job("alter table t1 add column age int")
+	require.NotNil(t, alterJob)
+
+	ddlEvent := &DDLEvent{
+		Version:      DDLEventVersion,
+		DispatcherID: common.NewDispatcherID(),
+		Type:         byte(alterJob.Type),
+		SchemaID:     alterJob.SchemaID,
+		TableID:      alterJob.TableID,
+		SchemaName:   alterJob.SchemaName,
+		TableName:    alterJob.TableName,
+		Query:        alterJob.Query,
+		TableInfo:    common.WrapTableInfo(alterJob.SchemaID, alterJob.SchemaName, alterJob.BinlogInfo.TableInfo),
+		PreTableInfo: common.WrapTableInfo(createJob.SchemaID, createJob.SchemaName, createJob.BinlogInfo.TableInfo),
+		FinishedTs:   alterJob.BinlogInfo.FinishedTS,
+	}
+
+	data, err := ddlEvent.Marshal()
+	require.Nil(t, err)
+
+	reverseEvent := &DDLEvent{}
+	err = reverseEvent.Unmarshal(data)
+	reverseEvent.eventSize = 0
+	require.Nil(t, err)
+	require.Equal(t, ddlEvent, reverseEvent)
+	require.Len(t, reverseEvent.PreTableInfo.GetColumns(), 2)
+	require.Len(t, reverseEvent.TableInfo.GetColumns(), 3)
+}
+
+func TestCreateTablesDDLEvent(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	job1 := helper.DDL2Job("create table t1 (id int primary key)")
+	job2 := helper.DDL2Job("create table t2 (id int primary key, name varchar(32))")
+
+	ddlEvent := &DDLEvent{
+		Version:      DDLEventVersion,
+		DispatcherID: common.NewDispatcherID(),
+		Type:         byte(model.ActionCreateTables),
+		SchemaName:   job1.SchemaName,
+		Query:        job1.Query + ";" + job2.Query,
+		MultipleTableInfos: []*common.TableInfo{
+			common.WrapTableInfo(job1.SchemaID, job1.SchemaName, job1.BinlogInfo.TableInfo),
+			common.WrapTableInfo(job2.SchemaID, job2.SchemaName, job2.BinlogInfo.TableInfo),
+		},
+		TableNameChange: &TableNameChange{
+			AddName: []SchemaTableName{
+				{SchemaName: job1.SchemaName, TableName: "t1"},
+				{SchemaName: job2.SchemaName, TableName: "t2"},
+			},
+		},
+		FinishedTs: job2.BinlogInfo.FinishedTS,
+	}
+
+	data, err := ddlEvent.Marshal()
+	require.Nil(t, err)
+	reverseEvent := &DDLEvent{}
+	require.Nil(t, reverseEvent.Unmarshal(data))
+	require.Len(t, reverseEvent.MultipleTableInfos, 2)
+	require.Len(t, reverseEvent.MultipleTableInfos[1].GetColumns(), 2)
+
+	// each sub event carries the table info of the created table
+	subEvents := reverseEvent.GetSubEvents()
+	require.Len(t, subEvents, 2)
+	for i, event := range subEvents {
+		require.Equal(t, event.TableName, event.TableInfo.GetTableName(), i)
+	}
+}
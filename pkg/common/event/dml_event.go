@@ -2,10 +2,13 @@ package event
 
 import (
 	"encoding/binary"
+	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/pkg/integrity"
 	"go.uber.org/zap"
 )
 
@@ -43,6 +46,10 @@ type DMLEvent struct {
 	// All the data is stored in RawRows.
 	// The receiver needs to call DecodeRawRows function to decode the RawRows into Rows.
 	RawRows []byte `json:"raw_rows"`
+	// Checksum is the checksum of every row change in the transaction, it is nil
+	// if the upstream TiDB does not enable the row level checksum.
+	// len(Checksum) == Length if it is not nil.
+	Checksum []*RowChecksum `json:"checksum"`
 
 	// TableInfo is the table info of the transaction.
 	// If the DMLEvent is send from a remote eventService, the TableInfo is nil.
@@ -58,6 +65,8 @@ type DMLEvent struct {
 	// offset is the offset of the current row in the transaction.
 	// It is internal field, not exported. So it doesn't need to be marshalled.
 	offset int `json:"-"`
+	// rowIndex is the index of the current row change in the transaction.
+	rowIndex int `json:"-"`
 }
 
 func NewDMLEvent(
@@ -81,22 +90,61 @@ func NewDMLEvent(
 	}
 }
 
-func (t *DMLEvent) AppendRow(raw *common.RawKVEntry,
-	decode func(
-		rawKv *common.RawKVEntry,
-		tableInfo *common.TableInfo, chk *chunk.Chunk) (int, error),
-) error {
-	RowType := RowTypeInsert
+// GetRowType returns the type of the row change in the raw kv entry.
+func GetRowType(raw *common.RawKVEntry) RowType {
 	if raw.OpType == common.OpTypeDelete {
-		RowType = RowTypeDelete
+		return RowTypeDelete
 	}
 	if len(raw.Value) != 0 && len(raw.OldValue) != 0 {
-		RowType = RowTypeUpdate
+		return RowTypeUpdate
 	}
-	count, err := decode(raw, t.TableInfo, t.Rows)
+	return RowTypeInsert
+}
+
+// RowFilter decides whether to skip a row change after it is decoded into the chunk,
+// the preRow is empty for the insert and the row is empty for the delete.
+type RowFilter func(rowType RowType, preRow, row chunk.Row) (bool, error)
+
+// AppendRow decodes the raw kv entry and appends the row change to the event,
+// the row change is dropped if the filter is not nil and it returns true.
+func (t *DMLEvent) AppendRow(raw *common.RawKVEntry,
+	decode func(
+		rawKv *common.RawKVEntry,
+		tableInfo *common.TableInfo, chk *chunk.Chunk) (int, *RowChecksum, error),
+	filter RowFilter,
+) error {
+	RowType := GetRowType(raw)
+	numRows := t.Rows.NumRows()
+	count, checksum, err := decode(raw, t.TableInfo, t.Rows)
 	if err != nil {
 		return err
 	}
+	if filter != nil && count > 0 {
+		var preRow, row chunk.Row
+		switch {
+		case RowType == RowTypeDelete:
+			preRow = t.Rows.GetRow(numRows)
+		case RowType == RowTypeUpdate && count == 2:
+			preRow = t.Rows.GetRow(numRows)
+			row = t.Rows.GetRow(numRows + 1)
+		default:
+			row = t.Rows.GetRow(numRows)
+		}
+		skip, err := filter(RowType, preRow, row)
+		if err != nil {
+			return err
+		}
+		if skip {
+			t.Rows.TruncateTo(numRows)
+			return nil
+		}
+	}
+	if checksum != nil && t.Checksum == nil {
+		t.Checksum = make([]*RowChecksum, t.Length, t.Length+1)
+	}
+	if t.Checksum != nil {
+		t.Checksum = append(t.Checksum, checksum)
+	}
 	if count == 1 {
 		t.RowTypes = append(t.RowTypes, RowType)
 	} else if count == 2 {
@@ -145,33 +193,50 @@ func (t *DMLEvent) AddPostFlushFunc(f func()) {
 	t.PostTxnFlushed = append(t.PostTxnFlushed, f)
 }
 
+// Rewind resets the iterator of GetNextRow, so the row changes can be
+// iterated again from the first one.
+func (t *DMLEvent) Rewind() {
+	t.offset = 0
+	t.rowIndex = 0
+}
+
 func (t *DMLEvent) GetNextRow() (RowChange, bool) {
 	if t.offset >= len(t.RowTypes) {
 		return RowChange{}, false
 	}
 	rowType := t.RowTypes[t.offset]
+	var checksum *integrity.Checksum
+	if t.rowIndex < len(t.Checksum) && t.Checksum[t.rowIndex] != nil {
+		checksum = &t.Checksum[t.rowIndex].Checksum
+	}
 	switch rowType {
 	case RowTypeInsert:
 		row := RowChange{
-			Row:     t.Rows.GetRow(t.offset),
-			RowType: rowType,
+			Row:      t.Rows.GetRow(t.offset),
+			RowType:  rowType,
+			Checksum: checksum,
 		}
 		t.offset++
+		t.rowIndex++
 		return row, true
 	case RowTypeDelete:
 		row := RowChange{
-			PreRow:  t.Rows.GetRow(t.offset),
-			RowType: rowType,
+			PreRow:   t.Rows.GetRow(t.offset),
+			RowType:  rowType,
+			Checksum: checksum,
 		}
 		t.offset++
+		t.rowIndex++
 		return row, true
 	case RowTypeUpdate:
 		row := RowChange{
-			PreRow:  t.Rows.GetRow(t.offset),
-			Row:     t.Rows.GetRow(t.offset + 1),
-			RowType: rowType,
+			PreRow:   t.Rows.GetRow(t.offset),
+			Row:      t.Rows.GetRow(t.offset + 1),
+			RowType:  rowType,
+			Checksum: checksum,
 		}
 		t.offset += 2
+		t.rowIndex++
 		return row, true
 	default:
 		log.Panic("TEvent.GetNextRow: invalid row type")
@@ -271,6 +336,8 @@ func (t *DMLEvent) encodeV0() ([]byte, error) {
 		buf[offset] = byte(rowType)
 		offset++
 	}
+	// Checksum
+	buf = t.encodeChecksum(buf)
 
 	encoder := chunk.NewCodec(t.TableInfo.GetFieldSlice())
 	data := encoder.Encode(t.Rows)
@@ -319,10 +386,223 @@ func (t *DMLEvent) decodeV0(data []byte) error {
 		t.RowTypes[i] = RowType(data[offset])
 		offset++
 	}
+	n, err := t.decodeChecksum(data[offset:])
+	if err != nil {
+		return err
+	}
+	offset += n
 	t.RawRows = data[offset:]
 	return nil
 }
 
+// RowChecksum is the checksum of a row change verified by the mounter. Besides the column values,
+// the bytes-level checksum covers the row key and the columns encoded in the row value, they are
+// kept to calculate the checksum again by the row change before it's written to the downstream.
+type RowChecksum struct {
+	integrity.Checksum
+	// Key is the row key.
+	Key []byte
+	// PreColumnIDs and ColumnIDs are the IDs of the columns encoded in the old value and the value,
+	// they are nil if the checksum of the value is absent or can't be calculated again.
+	PreColumnIDs []int64
+	ColumnIDs    []int64
+}
+
+const (
+	checksumFlagExist byte = 1 << iota
+	checksumFlagCorrupted
+	checksumFlagPreColumns
+	checksumFlagColumns
+)
+
+// checksumSize is the size of the fixed part of an encoded checksum, it contains
+// the flags, the version, the current checksum and the previous checksum.
+const checksumSize = 1 + 1 + 4 + 4
+
+// encodeChecksum appends the checksum of every row change to the buffer,
+// the first byte indicates whether the checksum exists.
+func (t *DMLEvent) encodeChecksum(buf []byte) []byte {
+	if t.Checksum == nil {
+		return append(buf, 0)
+	}
+	buf = append(buf, 1)
+	for _, checksum := range t.Checksum {
+		var (
+			flags   byte
+			version byte
+			current uint32
+			prev    uint32
+		)
+		if checksum != nil {
+			flags |= checksumFlagExist
+			if checksum.Corrupted {
+				flags |= checksumFlagCorrupted
+			}
+			if checksum.PreColumnIDs != nil {
+				flags |= checksumFlagPreColumns
+			}
+			if checksum.ColumnIDs != nil {
+				flags |= checksumFlagColumns
+			}
+			version = byte(checksum.Version)
+			current = checksum.Current
+			prev = checksum.Previous
+		}
+		buf = append(buf, flags, version)
+		buf = binary.LittleEndian.AppendUint32(buf, current)
+		buf = binary.LittleEndian.AppendUint32(buf, prev)
+		if flags&(checksumFlagPreColumns|checksumFlagColumns) == 0 {
+			continue
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(checksum.Key)))
+		buf = append(buf, checksum.Key...)
+		if checksum.PreColumnIDs != nil {
+			buf = appendColumnIDs(buf, checksum.PreColumnIDs)
+		}
+		if checksum.ColumnIDs != nil {
+			buf = appendColumnIDs(buf, checksum.ColumnIDs)
+		}
+	}
+	return buf
+}
+
+func appendColumnIDs(buf []byte, columnIDs []int64) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(columnIDs)))
+	for _, id := range columnIDs {
+		buf = binary.AppendVarint(buf, id)
+	}
+	return buf
+}
+
+// decodeChecksum decodes the checksum of every row change from the data,
+// it returns the number of bytes consumed.
+func (t *DMLEvent) decodeChecksum(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, errors.Errorf("DMLEvent: checksum flag is missing")
+	}
+	if data[0] == 0 {
+		return 1, nil
+	}
+	if t.Length < 0 {
+		return 0, errors.Errorf("DMLEvent: invalid row count %d", t.Length)
+	}
+	offset := 1
+	t.Checksum = make([]*RowChecksum, t.Length)
+	for i := 0; i < int(t.Length); i++ {
+		if len(data)-offset < checksumSize {
+			return 0, errors.Errorf("DMLEvent: checksum of row %d is truncated", i)
+		}
+		flags := data[offset]
+		if flags&checksumFlagExist != 0 {
+			t.Checksum[i] = &RowChecksum{
+				Checksum: integrity.Checksum{
+					Version:   int(data[offset+1]),
+					Current:   binary.LittleEndian.Uint32(data[offset+2:]),
+					Previous:  binary.LittleEndian.Uint32(data[offset+6:]),
+					Corrupted: flags&checksumFlagCorrupted != 0,
+				},
+			}
+		}
+		offset += checksumSize
+		if flags&(checksumFlagPreColumns|checksumFlagColumns) == 0 {
+			continue
+		}
+		if t.Checksum[i] == nil {
+			return 0, errors.Errorf("DMLEvent: columns of the absent checksum of row %d", i)
+		}
+		keyLen, n := binary.Uvarint(data[offset:])
+		if n <= 0 || uint64(len(data)-offset-n) < keyLen {
+			return 0, errors.Errorf("DMLEvent: checksum key of row %d is truncated", i)
+		}
+		offset += n
+		t.Checksum[i].Key = data[offset : offset+int(keyLen)]
+		offset += int(keyLen)
+		if flags&checksumFlagPreColumns != 0 {
+			t.Checksum[i].PreColumnIDs, n = decodeColumnIDList(data[offset:])
+			if n <= 0 {
+				return 0, errors.Errorf("DMLEvent: checksum columns of row %d is truncated", i)
+			}
+			offset += n
+		}
+		if flags&checksumFlagColumns != 0 {
+			t.Checksum[i].ColumnIDs, n = decodeColumnIDList(data[offset:])
+			if n <= 0 {
+				return 0, errors.Errorf("DMLEvent: checksum columns of row %d is truncated", i)
+			}
+			offset += n
+		}
+	}
+	return offset, nil
+}
+
+// decodeColumnIDList decodes the column IDs appended by appendColumnIDs,
+// it returns the number of bytes consumed, which is not positive if the data is truncated.
+func decodeColumnIDList(data []byte) ([]int64, int) {
+	count, offset := binary.Uvarint(data)
+	if offset <= 0 || count > uint64(len(data)) {
+		return nil, 0
+	}
+	columnIDs := make([]int64, count)
+	for i := range columnIDs {
+		id, n := binary.Varint(data[offset:])
+		if n <= 0 {
+			return nil, 0
+		}
+		columnIDs[i] = id
+		offset += n
+	}
+	return columnIDs, offset
+}
+
+// VerifyChecksum calculates the bytes-level checksum of every row change again by the column values,
+// and compares it with the one encoded by the upstream TiDB. It returns the indexes of the row changes
+// which are marked as corrupted by the mounter, or mismatch the calculated checksums, which means the
+// rows are corrupted after they are mounted. The tz must be the time zone used to decode the rows.
+func (t *DMLEvent) VerifyChecksum(tz *time.Location) ([]int, error) {
+	var corrupted []int
+	offset := 0
+	for i := 0; offset < len(t.RowTypes); i++ {
+		// the offsets of the old value and the value in the rows, -1 if it's absent.
+		preOffset, rowOffset := -1, -1
+		switch t.RowTypes[offset] {
+		case RowTypeInsert:
+			rowOffset = offset
+			offset++
+		case RowTypeDelete:
+			preOffset = offset
+			offset++
+		default:
+			preOffset, rowOffset = offset, offset+1
+			offset += 2
+		}
+		if i >= len(t.Checksum) || t.Checksum[i] == nil {
+			continue
+		}
+		checksum := t.Checksum[i]
+		ok := !checksum.Corrupted
+		if ok && preOffset >= 0 && checksum.PreColumnIDs != nil {
+			obtained, err := calculateRawChecksum(
+				tz, t.TableInfo, t.Rows.GetRow(preOffset), checksum.PreColumnIDs, checksum.Key)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ok = obtained == checksum.Previous
+		}
+		if ok && rowOffset >= 0 && checksum.ColumnIDs != nil {
+			obtained, err := calculateRawChecksum(
+				tz, t.TableInfo, t.Rows.GetRow(rowOffset), checksum.ColumnIDs, checksum.Key)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ok = obtained == checksum.Current
+		}
+		if !ok {
+			corrupted = append(corrupted, i)
+		}
+	}
+	return corrupted, nil
+}
+
 // AssembleRows assembles the Rows from the RawRows.
 // It also sets the TableInfo and clears the RawRows.
 func (t *DMLEvent) AssembleRows(tableInfo *common.TableInfo) error {
@@ -353,6 +633,8 @@ type RowChange struct {
 	PreRow  chunk.Row
 	Row     chunk.Row
 	RowType RowType
+	// Checksum is only not nil if the upstream TiDB enables the row level checksum.
+	Checksum *integrity.Checksum
 }
 
 type RowType byte
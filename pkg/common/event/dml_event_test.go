@@ -1,8 +1,13 @@
 package event
 
 import (
+	"bytes"
 	"testing"
+	"time"
 
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/pkg/integrity"
 	"github.com/stretchr/testify/require"
 )
 
@@ -79,3 +84,120 @@ func TestEncodeAndDecodeV0(t *testing.T) {
 	reverseEvent.eventSize = 0
 	require.Equal(t, dmlEvent, reverseEvent)
 }
+
+// TestDMLEventChecksum test the row checksum is extracted and verified by the mounter,
+// and it is kept after Marshal and Unmarshal.
+func TestDMLEventChecksum(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("set global tidb_enable_row_level_checksum = 1")
+	defer helper.tk.MustExec("set global tidb_enable_row_level_checksum = 0")
+	// the checksum is enabled only for the new sessions
+	helper.tk.RefreshSession()
+	helper.tk.MustExec("use test")
+	ddlJob := helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	require.NotNil(t, ddlJob)
+
+	// the checksum is not verified by the mounter without the integrity check
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'aaaa')")
+	require.Len(t, dmlEvent.Checksum, 0)
+
+	tableInfo := helper.tableInfos[toTableInfosKey("test", "t")]
+	mounter := NewChecksumMounter(time.Local)
+	dmlEvent = NewDMLEvent(common.NewDispatcherID(), tableInfo.TableName.TableID, 1, 2, tableInfo)
+	rawKV := helper.DML2RawKv("test", "t", "insert into t values (2, 'bbbb')")[0]
+	require.NoError(t, dmlEvent.AppendRow(rawKV, mounter.DecodeToChunk, nil))
+	require.Len(t, dmlEvent.Checksum, 1)
+	checksum := &dmlEvent.Checksum[0].Checksum
+	require.NotZero(t, checksum.Current)
+	require.Zero(t, checksum.Previous)
+	require.False(t, checksum.Corrupted)
+	require.Equal(t, rawKV.Key, dmlEvent.Checksum[0].Key)
+	require.Nil(t, dmlEvent.Checksum[0].PreColumnIDs)
+	// the primary key is the handle, which is not encoded in the row value
+	require.Equal(t, []int64{tableInfo.GetColumns()[1].ID}, dmlEvent.Checksum[0].ColumnIDs)
+
+	// corrupt the value of the column name
+	rawKV = helper.DML2RawKv("test", "t", "insert into t values (3, 'cccc')")[0]
+	idx := bytes.Index(rawKV.Value, []byte("cccc"))
+	require.Greater(t, idx, 0)
+	rawKV.Value[idx] = 'd'
+	require.NoError(t, dmlEvent.AppendRow(rawKV, mounter.DecodeToChunk, nil))
+	require.Len(t, dmlEvent.Checksum, 2)
+	require.True(t, dmlEvent.Checksum[1].Corrupted)
+
+	data, err := dmlEvent.Marshal()
+	require.NoError(t, err)
+	reverseEvent := &DMLEvent{}
+	require.NoError(t, reverseEvent.Unmarshal(data))
+	require.Equal(t, dmlEvent.Checksum, reverseEvent.Checksum)
+	require.NoError(t, reverseEvent.AssembleRows(dmlEvent.TableInfo))
+	// the checksum is calculated again by the rows after they are transferred
+	corrupted, err := reverseEvent.VerifyChecksum(time.Local)
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, corrupted)
+
+	row, ok := reverseEvent.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, checksum, row.Checksum)
+	row, ok = reverseEvent.GetNextRow()
+	require.True(t, ok)
+	require.True(t, row.Checksum.Corrupted)
+	_, ok = reverseEvent.GetNextRow()
+	require.False(t, ok)
+
+	// the rows and their checksums are iterated again after rewinding
+	reverseEvent.Rewind()
+	row, ok = reverseEvent.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, checksum, row.Checksum)
+
+	// the value of the column name is changed after the row is mounted
+	chk := chunk.NewChunkWithCapacity(tableInfo.GetFieldSlice(), 2)
+	chk.AppendInt64(0, 2)
+	chk.AppendString(1, "bbbc")
+	chk.AppendInt64(0, 3)
+	chk.AppendString(1, "dccc")
+	reverseEvent.Rows.SetCol(1, chk.Column(1))
+	corrupted, err = reverseEvent.VerifyChecksum(time.Local)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1}, corrupted)
+}
+
+func TestDecodeChecksum(t *testing.T) {
+	event := &DMLEvent{Length: 2}
+	_, err := event.decodeChecksum(nil)
+	require.Error(t, err)
+
+	n, err := event.decodeChecksum([]byte{0})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Nil(t, event.Checksum)
+
+	checksums := []*RowChecksum{
+		{
+			Checksum:     integrity.Checksum{Current: 1, Previous: 2},
+			Key:          []byte("key"),
+			PreColumnIDs: []int64{1, 300},
+			ColumnIDs:    []int64{},
+		},
+		nil,
+	}
+	event.Checksum = checksums
+	data := event.encodeChecksum(nil)
+	event.Checksum = nil
+	n, err = event.decodeChecksum(data)
+	require.NoError(t, err)
+	require.Equal(t, len(data), n)
+	require.Equal(t, checksums, event.Checksum)
+
+	for i := 1; i < len(data); i++ {
+		_, err = event.decodeChecksum(data[:i])
+		require.Error(t, err)
+	}
+
+	event.Length = -1
+	_, err = event.decodeChecksum(data)
+	require.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package event
+
+import (
+	"encoding/binary"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"go.uber.org/zap"
+)
+
+const (
+	ErrorEventVersion = 0
+)
+
+// ErrorEvent is sent by the event service when it fails to produce the events of a dispatcher,
+// the dispatcher reports the error to its changefeed.
+type ErrorEvent struct {
+	Version      byte
+	DispatcherID common.DispatcherID
+	Message      string
+}
+
+func NewErrorEvent(dispatcherID common.DispatcherID, err error) ErrorEvent {
+	return ErrorEvent{
+		Version:      ErrorEventVersion,
+		DispatcherID: dispatcherID,
+		Message:      err.Error(),
+	}
+}
+
+// GetType returns the event type
+func (e *ErrorEvent) GetType() int {
+	return TypeErrorEvent
+}
+
+// GeSeq return the sequence number of error event.
+func (e *ErrorEvent) GetSeq() uint64 {
+	// not used
+	return 0
+}
+
+// GetDispatcherID returns the dispatcher ID
+func (e *ErrorEvent) GetDispatcherID() common.DispatcherID {
+	return e.DispatcherID
+}
+
+// GetCommitTs returns the commit timestamp
+func (e *ErrorEvent) GetCommitTs() common.Ts {
+	// not used
+	return 0
+}
+
+// GetStartTs returns the start timestamp
+func (e *ErrorEvent) GetStartTs() common.Ts {
+	// not used
+	return 0
+}
+
+// GetSize returns the approximate size of the event in bytes
+func (e *ErrorEvent) GetSize() int64 {
+	return int64(1 + e.DispatcherID.GetSize() + 4 + len(e.Message))
+}
+
+func (e *ErrorEvent) IsPaused() bool {
+	return false
+}
+
+func (e ErrorEvent) Marshal() ([]byte, error) {
+	return e.encode()
+}
+
+func (e *ErrorEvent) Unmarshal(data []byte) error {
+	return e.decode(data)
+}
+
+func (e ErrorEvent) encode() ([]byte, error) {
+	if e.Version != 0 {
+		log.Panic("ErrorEvent: invalid version, expect 0, got ", zap.Uint8("version", e.Version))
+	}
+	return e.encodeV0()
+}
+
+func (e *ErrorEvent) decode(data []byte) error {
+	version := data[0]
+	if version != 0 {
+		log.Panic("ErrorEvent: invalid version, expect 0, got ", zap.Uint8("version", version))
+	}
+	return e.decodeV0(data)
+}
+
+func (e ErrorEvent) encodeV0() ([]byte, error) {
+	data := make([]byte, e.GetSize())
+	offset := 0
+	data[offset] = e.Version
+	offset += 1
+	copy(data[offset:], e.DispatcherID.Marshal())
+	offset += e.DispatcherID.GetSize()
+	binary.BigEndian.PutUint32(data[offset:], uint32(len(e.Message)))
+	offset += 4
+	copy(data[offset:], e.Message)
+	return data, nil
+}
+
+func (e *ErrorEvent) decodeV0(data []byte) error {
+	offset := 0
+	e.Version = data[offset]
+	offset += 1
+	if err := e.DispatcherID.Unmarshal(data[offset : offset+e.DispatcherID.GetSize()]); err != nil {
+		return err
+	}
+	offset += e.DispatcherID.GetSize()
+	length := binary.BigEndian.Uint32(data[offset:])
+	offset += 4
+	e.Message = string(data[offset : offset+int(length)])
+	return nil
+}
@@ -54,6 +54,8 @@ const (
 	TypeReadyEvent
 	// TypeNotReusableEvent is the event type to indicate the event service has no data for reuse.
 	TypeNotReusableEvent
+	// TypeErrorEvent is the event type to indicate the event service fails to produce the events of a dispatcher.
+	TypeErrorEvent
 )
 
 // fakeDispatcherID is a fake dispatcherID for batch resolvedTs.
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
+	"github.com/pingcap/tiflow/pkg/integrity"
 	"github.com/pingcap/tiflow/pkg/spanz"
 )
 
@@ -49,11 +50,15 @@ type Mounter interface {
 	// If the rawKV is a delete event, it will only decode the old value.
 	// If the rawKV is an insert event, it will only decode the value.
 	// If the rawKV is an update event, it will decode both the value and the old value.
-	DecodeToChunk(rawKV *common.RawKVEntry, tableInfo *common.TableInfo, chk *chunk.Chunk) (int, error)
+	// The returned checksum is not nil only if the mounter verifies the checksum and
+	// the upstream TiDB enables the row level checksum.
+	DecodeToChunk(rawKV *common.RawKVEntry, tableInfo *common.TableInfo, chk *chunk.Chunk) (int, *RowChecksum, error)
 }
 
 type mounter struct {
 	tz *time.Location
+	// verifyChecksum is true if the row checksum encoded by the upstream TiDB is verified
+	verifyChecksum bool
 }
 
 // NewMounter creates a mounter
@@ -63,31 +68,53 @@ func NewMounter(tz *time.Location) Mounter {
 	}
 }
 
+// NewChecksumMounter creates a mounter which verifies the row checksum,
+// it's used by the changefeeds enabling the integrity check.
+func NewChecksumMounter(tz *time.Location) Mounter {
+	return &mounter{
+		tz:             tz,
+		verifyChecksum: true,
+	}
+}
+
 // DecodeToChunk decodes the raw KV entry to a chunk, it returns the number of rows decoded.
-func (m *mounter) DecodeToChunk(raw *common.RawKVEntry, tableInfo *common.TableInfo, chk *chunk.Chunk) (int, error) {
+func (m *mounter) DecodeToChunk(raw *common.RawKVEntry, tableInfo *common.TableInfo, chk *chunk.Chunk) (int, *RowChecksum, error) {
 	recordID, err := tablecodec.DecodeRowKey(raw.Key)
 	if err != nil {
-		return 0, errors.Trace(err)
+		return 0, nil, errors.Trace(err)
 	}
 	if !bytes.HasPrefix(raw.Key, tablePrefix) {
-		return 0, nil
+		return 0, nil, nil
 	}
 
 	// key, physicalTableID, err := decodeTableID(raw.Key)
 	// if err != nil {
 	// 	return nil
 	// }
+	var checksum *RowChecksum
 	count := 0
 	if len(raw.OldValue) != 0 {
 		if !rowcodec.IsNewFormat(raw.OldValue) {
 			err := m.rawKVToChunkV1(raw.OldValue, tableInfo, chk, recordID)
 			if err != nil {
-				return 0, errors.Trace(err)
+				return 0, nil, errors.Trace(err)
 			}
 		} else {
-			err := m.rawKVToChunkV2(raw.OldValue, tableInfo, chk, recordID)
+			rowChecksum, err := m.rawKVToChunkV2(raw.OldValue, tableInfo, chk, recordID, raw.Key)
 			if err != nil {
-				return 0, errors.Trace(err)
+				return 0, nil, errors.Trace(err)
+			}
+			if rowChecksum != nil {
+				checksum = &RowChecksum{
+					Checksum: integrity.Checksum{
+						Previous:  rowChecksum.expected,
+						Corrupted: rowChecksum.corrupted,
+						Version:   rowChecksum.version,
+					},
+					// the key is copied since the raw entry may be reused.
+					Key:          append([]byte(nil), raw.Key...),
+					PreColumnIDs: rowChecksum.columnIDs,
+				}
 			}
 		}
 		count++
@@ -96,17 +123,28 @@ func (m *mounter) DecodeToChunk(raw *common.RawKVEntry, tableInfo *common.TableI
 		if !rowcodec.IsNewFormat(raw.Value) {
 			err := m.rawKVToChunkV1(raw.Value, tableInfo, chk, recordID)
 			if err != nil {
-				return 0, errors.Trace(err)
+				return 0, nil, errors.Trace(err)
 			}
 		} else {
-			err := m.rawKVToChunkV2(raw.Value, tableInfo, chk, recordID)
+			rowChecksum, err := m.rawKVToChunkV2(raw.Value, tableInfo, chk, recordID, raw.Key)
 			if err != nil {
-				return 0, errors.Trace(err)
+				return 0, nil, errors.Trace(err)
+			}
+			if rowChecksum != nil {
+				if checksum == nil {
+					checksum = &RowChecksum{
+						Checksum: integrity.Checksum{Version: rowChecksum.version},
+						Key:      append([]byte(nil), raw.Key...),
+					}
+				}
+				checksum.Current = rowChecksum.expected
+				checksum.Corrupted = checksum.Corrupted || rowChecksum.corrupted
+				checksum.ColumnIDs = rowChecksum.columnIDs
 			}
 		}
 		count++
 	}
-	return count, nil
+	return count, checksum, nil
 }
 
 // IsLegacyFormatJob returns true if the job is from the legacy DDL list key.
@@ -177,7 +177,7 @@ func (s *EventTestHelper) DML2Event(schema, table string, dml ...string) *DMLEve
 	dmlEvent := NewDMLEvent(did, tableInfo.TableName.TableID, ts-1, ts+1, tableInfo)
 	rawKvs := s.DML2RawKv(schema, table, dml...)
 	for _, rawKV := range rawKvs {
-		err := dmlEvent.AppendRow(rawKV, s.mounter.DecodeToChunk)
+		err := dmlEvent.AppendRow(rawKV, s.mounter.DecodeToChunk, nil)
 		require.NoError(s.t, err)
 	}
 	return dmlEvent
@@ -31,6 +31,10 @@ const (
 	OpTypeResolved
 )
 
+// cdcWriteSourceMask is the mask of the cdc write source in the txn source,
+// it's the same as the definition in TiDB.
+const cdcWriteSourceMask = (1 << 8) - 1
+
 type CompressType uint32
 
 const (
@@ -57,6 +61,9 @@ type RawKVEntry struct {
 	Value []byte `msg:"value"`
 	// nil for insert type
 	OldValue []byte `msg:"old_value"`
+	// TxnSource is the source of the transaction,
+	// the lowest 8 bits is the cdc write source set by the mysql sink in bdr mode.
+	TxnSource uint64 `msg:"txn_source"`
 }
 
 func (v *RawKVEntry) IsResolved() bool {
@@ -68,11 +75,17 @@ func (v *RawKVEntry) IsUpdate() bool {
 	return v.OpType == OpTypePut && v.OldValue != nil && v.Value != nil
 }
 
+// IsWrittenByCDC checks if the event is written by a changefeed,
+// which sets tidb_cdc_write_source when writing to the downstream TiDB.
+func (v *RawKVEntry) IsWrittenByCDC() bool {
+	return v.TxnSource&cdcWriteSourceMask != 0
+}
+
 func (v *RawKVEntry) String() string {
 	// TODO: redact values.
 	return fmt.Sprintf(
-		"OpType: %v, Key: %s, Value: %s, OldValue: %s, StartTs: %d, CRTs: %d, RegionID: %d",
-		v.OpType, string(v.Key), string(v.Value), string(v.OldValue), v.StartTs, v.CRTs, v.RegionID)
+		"OpType: %v, Key: %s, Value: %s, OldValue: %s, StartTs: %d, CRTs: %d, RegionID: %d, TxnSource: %d",
+		v.OpType, string(v.Key), string(v.Value), string(v.OldValue), v.StartTs, v.CRTs, v.RegionID, v.TxnSource)
 }
 
 // ApproximateDataSize calculate the approximate size of protobuf binary
@@ -84,7 +97,7 @@ func (v *RawKVEntry) ApproximateDataSize() int64 {
 // Encode serializes the RawKVEntry into a byte slice
 func (v *RawKVEntry) Encode() []byte {
 	// Calculate total size
-	totalSize := 4*5 + 8*3 + len(v.Key) + len(v.Value) + len(v.OldValue)
+	totalSize := 4*5 + 8*4 + len(v.Key) + len(v.Value) + len(v.OldValue)
 	buf := make([]byte, 0, totalSize)
 	// Use binary.LittleEndian.PutUint32/64 to write directly to the buffer
 	buf = binary.LittleEndian.AppendUint32(buf, uint32(v.OpType))
@@ -103,6 +116,8 @@ func (v *RawKVEntry) Encode() []byte {
 	buf = append(buf, v.Key...)
 	buf = append(buf, v.Value...)
 	buf = append(buf, v.OldValue...)
+	// TxnSource is appended at the end to be compatible with the data encoded without it
+	buf = binary.LittleEndian.AppendUint64(buf, v.TxnSource)
 
 	return buf
 }
@@ -142,6 +157,12 @@ func (v *RawKVEntry) Decode(data []byte) error {
 	offset += int(v.ValueLen)
 
 	v.OldValue = data[offset : offset+int(v.OldValueLen)]
+	offset += int(v.OldValueLen)
+
+	v.TxnSource = 0
+	if len(data[offset:]) >= 8 {
+		v.TxnSource = binary.LittleEndian.Uint64(data[offset : offset+8])
+	}
 
 	return nil
 }
@@ -72,6 +72,37 @@ func TestRawKVEntryEncodeDecode_ResolvedOperation(t *testing.T) {
 	require.Equal(t, original, decoded)
 }
 
+func TestRawKVEntryEncodeDecode_TxnSource(t *testing.T) {
+	original := RawKVEntry{
+		OpType:    OpTypePut,
+		CRTs:      5555555555,
+		StartTs:   6666666666,
+		RegionID:  7,
+		Key:       []byte("key"),
+		Value:     []byte("value"),
+		OldValue:  make([]byte, 0),
+		TxnSource: 1,
+	}
+	require.True(t, original.IsWrittenByCDC())
+
+	encoded := original.Encode()
+	var decoded RawKVEntry
+	err := decoded.Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+	require.True(t, decoded.IsWrittenByCDC())
+
+	// the data encoded without txn source can still be decoded
+	err = decoded.Decode(encoded[:len(encoded)-8])
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), decoded.TxnSource)
+	require.False(t, decoded.IsWrittenByCDC())
+
+	// only the lowest 8 bits are the cdc write source
+	decoded.TxnSource = 1 << 8
+	require.False(t, decoded.IsWrittenByCDC())
+}
+
 func TestCompareEncodedSize(t *testing.T) {
 	entry := getRawKVEntry()
 	encoded := entry.Encode()
@@ -302,7 +302,9 @@ func (ti *TableInfo) InitPrivateFields() {
 
 func (ti *TableInfo) MarshalJSON() ([]byte, error) {
 	// otherField | columnSchemaData | columnSchemaDataSize
-	data, err := json.Marshal(ti)
+	// use the alias type to marshal the other fields, otherwise MarshalJSON is called recursively.
+	type tableInfoAlias TableInfo
+	data, err := json.Marshal((*tableInfoAlias)(ti))
 	if err != nil {
 		return nil, err
 	}
@@ -333,6 +335,7 @@ func UnmarshalJSONToTableInfo(data []byte) (*TableInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	ti.TableName.quotedName = QuoteSchema(ti.TableName.Schema, ti.TableName.Table)
 
 	ti.columnSchema, err = unmarshalJsonToColumnSchema(columnSchemaData)
 	if err != nil {
@@ -25,6 +25,21 @@ type Digest struct {
 	d uint64
 }
 
+// MarshalJSON marshals the digest as an array of 4 uint64.
+func (d Digest) MarshalJSON() ([]byte, error) {
+	return json.Marshal([4]uint64{d.a, d.b, d.c, d.d})
+}
+
+// UnmarshalJSON unmarshals the digest from an array of 4 uint64.
+func (d *Digest) UnmarshalJSON(data []byte) error {
+	var v [4]uint64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	d.a, d.b, d.c, d.d = v[0], v[1], v[2], v[3]
+	return nil
+}
+
 func ToDigest(b []byte) Digest {
 	return Digest{
 		a: binary.BigEndian.Uint64(b[0:8]),
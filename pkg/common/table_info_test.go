@@ -0,0 +1,46 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableInfoJSONRoundTrip(t *testing.T) {
+	ft := types.NewFieldType(mysql.TypeLong)
+	ft.AddFlag(mysql.PriKeyFlag)
+	tableInfo := WrapTableInfo(1, "test", &model.TableInfo{
+		ID:         100,
+		Name:       pmodel.NewCIStr("t"),
+		PKIsHandle: true,
+		Columns: []*model.ColumnInfo{
+			{ID: 1, Name: pmodel.NewCIStr("id"), FieldType: *ft, State: model.StatePublic},
+		},
+	})
+
+	data, err := tableInfo.MarshalJSON()
+	require.NoError(t, err)
+	decoded, err := UnmarshalJSONToTableInfo(data)
+	require.NoError(t, err)
+	require.Equal(t, tableInfo.TableName, decoded.TableName)
+	require.Equal(t, "`test`.`t`", decoded.TableName.QuoteString())
+	require.Equal(t, tableInfo.columnSchema.Digest, decoded.columnSchema.Digest)
+	require.Len(t, decoded.GetColumns(), 1)
+}
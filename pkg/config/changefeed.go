@@ -35,6 +35,15 @@ type ChangefeedConfig struct {
 	SyncPointInterval  *time.Duration `json:"sync_point_interval" default:"1m"`
 	SyncPointRetention *time.Duration `json:"sync_point_retention" default:"24h"`
 	SinkConfig         *SinkConfig    `json:"sink_config"`
+	// BDRMode is true if the changefeed is a part of the bidirectional replication,
+	// the rows and ddls written by other changefeeds are not replicated in this mode.
+	BDRMode bool `json:"bdr_mode" default:"false"`
+	// TiDBSourceID is the source id of the upstream cluster, it's set to
+	// tidb_cdc_write_source when writing to the downstream TiDB.
+	TiDBSourceID uint64 `json:"tidb_source_id"`
+	// EnableTableMonitor is true if the checkpoint of each table should be
+	// exported as metrics and written to the downstream periodically.
+	EnableTableMonitor bool `json:"enable_table_monitor" default:"false"`
 }
 
 // ChangeFeedInfo describes the detail of a ChangeFeed
@@ -63,6 +72,27 @@ type ChangeFeedInfo struct {
 	CreatorVersion string `json:"creator-version"`
 	// Epoch is the epoch of a changefeed, changes on every restart.
 	Epoch uint64 `json:"epoch"`
+	// TableReplay is the running replay of some tables of the changefeed, it's nil if there is none.
+	// It's kept until all the replayed tables catch up the target ts of the replay.
+	TableReplay *TableReplay `json:"table-replay,omitempty"`
+}
+
+// TableReplay is a replay of some tables of the changefeed, the dispatchers of the spans
+// replay the events after the ReplayTs in safe mode until they catch up the TargetTs.
+type TableReplay struct {
+	// ID identifies the replay, the replay with the same id is not started twice by a maintainer
+	ID       uint64            `json:"id"`
+	Spans    []TableReplaySpan `json:"spans"`
+	ReplayTs uint64            `json:"replay-ts"`
+	// TargetTs is the checkpoint ts of the changefeed when the replay is requested
+	TargetTs uint64 `json:"target-ts"`
+}
+
+// TableReplaySpan is the key range of a table to replay.
+type TableReplaySpan struct {
+	TableID  int64  `json:"table-id"`
+	StartKey []byte `json:"start-key"`
+	EndKey   []byte `json:"end-key"`
 }
 
 // NeedBlockGC returns true if the changefeed need to block the GC safepoint.
@@ -34,6 +34,8 @@ type DebugConfig struct {
 	SchemaStore *SchemaStoreConfig `toml:"schema-store" json:"schema-store"`
 
 	EventService *EventServiceConfig `toml:"event-service" json:"event-service"`
+
+	EventStore *EventStoreConfig `toml:"event-store" json:"event-store"`
 }
 
 // ValidateAndAdjust validates and adjusts the debug configuration
@@ -47,6 +49,12 @@ func (c *DebugConfig) ValidateAndAdjust() error {
 	if err := c.Scheduler.ValidateAndAdjust(); err != nil {
 		return errors.Trace(err)
 	}
+	if c.EventStore == nil {
+		c.EventStore = NewDefaultEventStoreConfig()
+	}
+	if err := c.EventStore.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
 
 	return nil
 }
@@ -93,3 +101,68 @@ func NewDefaultEventServiceConfig() *EventServiceConfig {
 		ScanTaskQueueSize: 1024 * 8,
 	}
 }
+
+// EventStoreConfig represents config for event store
+type EventStoreConfig struct {
+	// DiskQuotaInMB is the max disk space the event store can use in the sort dir.
+	// When the quota is exceeded, the oldest unconsumed data is evicted.
+	// 0 means no limit.
+	DiskQuotaInMB uint64 `toml:"disk-quota-in-mb" json:"disk-quota-in-mb"`
+	// RetentionTime is the max time lag between the resolved ts of a subscription and
+	// the min checkpoint ts of its dispatchers. Data of a subscription lagging behind it is evicted.
+	// The checkpoint ts of the dispatchers is reported to the event store every 2 minutes,
+	// so the lag can be overestimated by that interval.
+	// 0 means no limit.
+	RetentionTime TomlDuration `toml:"retention-time" json:"retention-time"`
+	// RetentionSizeInMB is the max size of unconsumed data kept for a single subscription.
+	// 0 means no limit.
+	RetentionSizeInMB uint64 `toml:"retention-size-in-mb" json:"retention-size-in-mb"`
+	// EvictionCheckInterval is the interval of checking the quota and retention policy.
+	EvictionCheckInterval TomlDuration `toml:"eviction-check-interval" json:"eviction-check-interval"`
+
+	// Compression is the compression algorithm of the values stored in event store.
+	// It can be "none", "snappy" or "zstd".
+	Compression string `toml:"compression" json:"compression"`
+	// ZstdDictSampleCount is the number of values used to train a zstd dictionary.
+	// It only takes effect when the compression is "zstd", 0 means no dictionary.
+	ZstdDictSampleCount int `toml:"zstd-dict-sample-count" json:"zstd-dict-sample-count"`
+}
+
+const (
+	// EventStoreCompressionNone stores values without compression.
+	EventStoreCompressionNone = "none"
+	// EventStoreCompressionSnappy compresses values with snappy.
+	EventStoreCompressionSnappy = "snappy"
+	// EventStoreCompressionZstd compresses values with zstd.
+	EventStoreCompressionZstd = "zstd"
+)
+
+// NewDefaultEventStoreConfig return the default event store configuration
+func NewDefaultEventStoreConfig() *EventStoreConfig {
+	return &EventStoreConfig{
+		DiskQuotaInMB:         0,
+		RetentionTime:         0,
+		RetentionSizeInMB:     0,
+		EvictionCheckInterval: TomlDuration(10 * time.Second),
+		Compression:           EventStoreCompressionZstd,
+		ZstdDictSampleCount:   0,
+	}
+}
+
+// ValidateAndAdjust validates and adjusts the event store configuration
+func (c *EventStoreConfig) ValidateAndAdjust() error {
+	if c.EvictionCheckInterval <= 0 {
+		return errors.New("eviction-check-interval must be larger than 0")
+	}
+	switch c.Compression {
+	case "":
+		c.Compression = EventStoreCompressionZstd
+	case EventStoreCompressionNone, EventStoreCompressionSnappy, EventStoreCompressionZstd:
+	default:
+		return errors.Errorf("unsupported compression %s, must be one of none, snappy and zstd", c.Compression)
+	}
+	if c.ZstdDictSampleCount < 0 {
+		return errors.New("zstd-dict-sample-count must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// ErrorCategory is the category of the errors reported by a changefeed
+type ErrorCategory string
+
+const (
+	// ErrorCategoryDownstreamUnavailable means the downstream can not be accessed,
+	// such as network errors and authentication failures.
+	ErrorCategoryDownstreamUnavailable ErrorCategory = "downstream-unavailable"
+	// ErrorCategoryDataIncompatible means the data can not be written to the downstream,
+	// such as schema mismatch and message too large.
+	ErrorCategoryDataIncompatible ErrorCategory = "data-incompatible"
+	// ErrorCategoryUpstreamGC means the data needed by the changefeed is garbage collected.
+	ErrorCategoryUpstreamGC ErrorCategory = "upstream-gc"
+	// ErrorCategoryInternal is the category of all other errors.
+	ErrorCategoryInternal ErrorCategory = "internal"
+)
+
+// ErrorAction is the action taken by the coordinator when a changefeed meets an error
+type ErrorAction string
+
+const (
+	// ErrorActionRetry restarts the changefeed with an exponential backoff,
+	// the changefeed fails if the error is not retryable or it stuck too long.
+	ErrorActionRetry ErrorAction = "retry"
+	// ErrorActionPause pauses the changefeed, it should be resumed manually.
+	ErrorActionPause ErrorAction = "pause"
+	// ErrorActionFail sets the changefeed to failed state.
+	ErrorActionFail ErrorAction = "fail"
+	// ErrorActionSkipTable removes the table the error comes from by adding it to the
+	// filter rules and restarts the changefeed, the changefeed is paused if the error
+	// can't be attributed to a single table. It's only valid for data-incompatible errors.
+	ErrorActionSkipTable ErrorAction = "skip-table"
+	// ErrorActionAlertOnly reports the changefeed as warning and restarts it with backoff,
+	// the changefeed never fails no matter how long it has been stuck.
+	ErrorActionAlertOnly ErrorAction = "alert-only"
+)
+
+// ErrorPolicyConfig represents the action of each error category for a changefeed
+type ErrorPolicyConfig struct {
+	DownstreamUnavailable ErrorAction `toml:"downstream-unavailable" json:"downstream-unavailable"`
+	DataIncompatible      ErrorAction `toml:"data-incompatible" json:"data-incompatible"`
+	UpstreamGC            ErrorAction `toml:"upstream-gc" json:"upstream-gc"`
+	Internal              ErrorAction `toml:"internal" json:"internal"`
+}
+
+// ActionOf returns the action of the error category
+func (c *ErrorPolicyConfig) ActionOf(category ErrorCategory) ErrorAction {
+	var action ErrorAction
+	switch category {
+	case ErrorCategoryDownstreamUnavailable:
+		action = c.DownstreamUnavailable
+	case ErrorCategoryDataIncompatible:
+		action = c.DataIncompatible
+	case ErrorCategoryUpstreamGC:
+		if c.UpstreamGC == "" {
+			return ErrorActionFail
+		}
+		action = c.UpstreamGC
+	default:
+		action = c.Internal
+	}
+	if action == "" {
+		return ErrorActionRetry
+	}
+	return action
+}
+
+// ValidateAndAdjust validates the error policy and fills the default actions
+func (c *ErrorPolicyConfig) ValidateAndAdjust() error {
+	if c.UpstreamGC == "" {
+		c.UpstreamGC = ErrorActionFail
+	}
+	for _, action := range []*ErrorAction{
+		&c.DownstreamUnavailable, &c.DataIncompatible, &c.UpstreamGC, &c.Internal,
+	} {
+		switch *action {
+		case "":
+			*action = ErrorActionRetry
+		case ErrorActionRetry, ErrorActionPause, ErrorActionFail, ErrorActionAlertOnly, ErrorActionSkipTable:
+		default:
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				"unknown error action " + string(*action))
+		}
+	}
+	// the data before the gc safepoint is lost, the changefeed can never make progress
+	if c.UpstreamGC == ErrorActionRetry || c.UpstreamGC == ErrorActionAlertOnly {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"the action of upstream-gc errors should be pause or fail")
+	}
+	// only the data of a table can be incompatible with the downstream
+	for _, action := range []ErrorAction{c.DownstreamUnavailable, c.UpstreamGC, c.Internal} {
+		if action == ErrorActionSkipTable {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				"skip-table is only valid for data-incompatible errors")
+		}
+	}
+	return nil
+}
+
+// ErrorDecision records the action taken for an error of a changefeed
+type ErrorDecision struct {
+	Time     time.Time     `json:"time"`
+	Code     string        `json:"code"`
+	Message  string        `json:"message"`
+	Category ErrorCategory `json:"category"`
+	Action   ErrorAction   `json:"action"`
+	// Table is the table the error comes from, it's empty if the error
+	// can't be attributed to a single table
+	Table string `json:"table,omitempty"`
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// GcPolicyActionNone takes no action on the changefeeds holding back the GC safepoint.
+	GcPolicyActionNone = "none"
+	// GcPolicyActionAlert logs and reports the changefeeds holding back the GC safepoint.
+	GcPolicyActionAlert = "alert"
+	// GcPolicyActionFail fails the changefeeds holding back the GC safepoint,
+	// so the GC safepoint is released by them.
+	GcPolicyActionFail = "fail"
+)
+
+// GcPolicyConfig is the policy for the changefeeds which hold back the GC safepoint
+// longer than the threshold, only the changefeeds with check-gc-safe-point enabled
+// are affected.
+type GcPolicyConfig struct {
+	// Action is the action taken on the changefeeds, none, alert or fail.
+	Action string `toml:"action" json:"action"`
+	// Threshold is the max duration a changefeed can hold back the GC safepoint,
+	// it must be less than the gc-ttl.
+	Threshold TomlDuration `toml:"threshold" json:"threshold"`
+}
+
+// NewDefaultGcPolicyConfig returns the default gc policy config.
+func NewDefaultGcPolicyConfig() *GcPolicyConfig {
+	return &GcPolicyConfig{
+		Action:    GcPolicyActionNone,
+		Threshold: TomlDuration(12 * time.Hour),
+	}
+}
+
+// ValidateAndAdjust validates and adjusts the gc policy configuration,
+// gcTTL is the TTL of the service GC safepoint in seconds.
+func (c *GcPolicyConfig) ValidateAndAdjust(gcTTL int64) error {
+	switch c.Action {
+	case "":
+		c.Action = GcPolicyActionNone
+	case GcPolicyActionNone, GcPolicyActionAlert, GcPolicyActionFail:
+	default:
+		return cerror.ErrInvalidServerOption.GenWithStack(
+			"unsupported gc-policy action %s, it should be none, alert or fail", c.Action)
+	}
+	if c.Action == GcPolicyActionNone {
+		return nil
+	}
+	if c.Threshold <= 0 || time.Duration(c.Threshold) >= time.Duration(gcTTL)*time.Second {
+		return cerror.ErrInvalidServerOption.GenWithStack(
+			"gc-policy threshold %s should be positive and less than gc-ttl %ds",
+			time.Duration(c.Threshold), gcTTL)
+	}
+	return nil
+}
@@ -101,6 +101,7 @@ var defaultReplicaConfig = &ReplicaConfig{
 		EnableTableAcrossNodes: false,
 		RegionThreshold:        100_000,
 		WriteKeyThreshold:      0,
+		BalanceStrategy:        BalanceStrategyTaskCount,
 	},
 	Integrity: &integrity.Config{
 		IntegrityCheckLevel:   integrity.CheckLevelNone,
@@ -108,6 +109,12 @@ var defaultReplicaConfig = &ReplicaConfig{
 	},
 	ChangefeedErrorStuckDuration: util.AddressOf(time.Minute * 30),
 	SyncedStatus:                 &SyncedStatusConfig{SyncedCheckInterval: 5 * 60, CheckpointInterval: 15},
+	ErrorPolicy: &ErrorPolicyConfig{
+		DownstreamUnavailable: ErrorActionRetry,
+		DataIncompatible:      ErrorActionRetry,
+		UpstreamGC:            ErrorActionFail,
+		Internal:              ErrorActionRetry,
+	},
 }
 
 // GetDefaultReplicaConfig returns the default replica config.
@@ -157,10 +164,12 @@ type replicaConfig struct {
 	Consistent *ConsistentConfig `toml:"consistent" json:"consistent,omitempty"`
 	// Scheduler is the configuration for scheduler.
 	Scheduler *ChangefeedSchedulerConfig `toml:"scheduler" json:"scheduler,omitempty"`
-	// Integrity is only available when the downstream is MQ.
+	// Integrity is only available when the downstream is MQ or MySQL.
 	Integrity                    *integrity.Config   `toml:"integrity" json:"integrity"`
 	ChangefeedErrorStuckDuration *time.Duration      `toml:"changefeed-error-stuck-duration" json:"changefeed-error-stuck-duration,omitempty"`
 	SyncedStatus                 *SyncedStatusConfig `toml:"synced-status" json:"synced-status,omitempty"`
+	// ErrorPolicy is the action of each error category when the changefeed meets an error.
+	ErrorPolicy *ErrorPolicyConfig `toml:"error-policy" json:"error-policy,omitempty"`
 
 	// Deprecated: we don't use this field since v8.0.0.
 	SQLMode string `toml:"sql-mode" json:"sql-mode"`
@@ -296,10 +305,11 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 
 	if c.Integrity != nil {
 		switch strings.ToLower(sinkURI.Scheme) {
-		case sink.KafkaScheme, sink.KafkaSSLScheme:
+		case sink.KafkaScheme, sink.KafkaSSLScheme,
+			sink.MySQLScheme, sink.MySQLSSLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
 		default:
 			if c.Integrity.Enabled() {
-				log.Warn("integrity checksum only support kafka sink now, disable integrity")
+				log.Warn("integrity checksum only support kafka and mysql sink now, disable integrity")
 				c.Integrity.IntegrityCheckLevel = integrity.CheckLevelNone
 			}
 		}
@@ -314,6 +324,17 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 				"integrity check enabled and column selector set, not allowed")
 
 		}
+		if c.Integrity.Enabled() && len(c.Sink.ColumnTransformers) != 0 {
+			log.Error("it's not allowed to enable the integrity check and column transformer at the same time")
+			return cerror.ErrInvalidReplicaConfig.GenWithStack(
+				"integrity check enabled and column transformer set, not allowed")
+		}
+	}
+
+	if c.ErrorPolicy != nil {
+		if err := c.ErrorPolicy.ValidateAndAdjust(); err != nil {
+			return err
+		}
 	}
 
 	if c.ChangefeedErrorStuckDuration != nil &&
@@ -20,6 +20,15 @@ import (
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 )
 
+const (
+	// BalanceStrategyTaskCount balances the tasks by the task count per node.
+	BalanceStrategyTaskCount = "task-count"
+	// BalanceStrategyLoad balances the tasks by the load per node,
+	// the load is calculated from the observed throughput of the tasks
+	// and the resource usage of the nodes.
+	BalanceStrategyLoad = "load"
+)
+
 // ChangefeedSchedulerConfig is per changefeed scheduler settings.
 type ChangefeedSchedulerConfig struct {
 	// EnableTableAcrossNodes set true to split one table to multiple spans and
@@ -29,10 +38,23 @@ type ChangefeedSchedulerConfig struct {
 	RegionThreshold int `toml:"region-threshold" json:"region-threshold"`
 	// WriteKeyThreshold is the written keys threshold of splitting a table.
 	WriteKeyThreshold int `toml:"write-key-threshold" json:"write-key-threshold"`
+	// BalanceStrategy is the strategy to balance the table spans among nodes,
+	// it can be task-count or load. The resource usage of the nodes is only known
+	// by the coordinator, so the spans are balanced by their throughput only.
+	BalanceStrategy string `toml:"balance-strategy" json:"balance-strategy"`
+	// Placement constrains the nodes which the maintainer and the dispatchers
+	// of the changefeed can be placed on.
+	Placement *PlacementConfig `toml:"placement" json:"placement,omitempty"`
 }
 
 // Validate validates the config.
 func (c *ChangefeedSchedulerConfig) Validate() error {
+	if err := validateBalanceStrategy(c.BalanceStrategy); err != nil {
+		return err
+	}
+	if err := c.Placement.validate(); err != nil {
+		return err
+	}
 	if !c.EnableTableAcrossNodes {
 		return nil
 	}
@@ -45,6 +67,53 @@ func (c *ChangefeedSchedulerConfig) Validate() error {
 	return nil
 }
 
+// PlacementConfig selects the nodes by their labels.
+// A node is allowed only if it has all the labels in NodeAffinity,
+// and has none of the labels in NodeAntiAffinity.
+type PlacementConfig struct {
+	// NodeAffinity is the labels the node must have, e.g. zone=us-east-1a
+	// pins the changefeed to the nodes in the same AZ as the downstream.
+	NodeAffinity map[string]string `toml:"node-affinity" json:"node-affinity,omitempty"`
+	// NodeAntiAffinity is the labels the node must not have, e.g. tenant=noisy
+	// keeps the changefeed away from the nodes of a noisy tenant.
+	NodeAntiAffinity map[string]string `toml:"node-anti-affinity" json:"node-anti-affinity,omitempty"`
+}
+
+// IsEmpty returns true if there is no placement rule.
+func (c *PlacementConfig) IsEmpty() bool {
+	return c == nil || (len(c.NodeAffinity) == 0 && len(c.NodeAntiAffinity) == 0)
+}
+
+// Match returns true if the node with the labels is allowed by the placement rules.
+func (c *PlacementConfig) Match(labels map[string]string) bool {
+	if c.IsEmpty() {
+		return true
+	}
+	for key, value := range c.NodeAffinity {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	for key, value := range c.NodeAntiAffinity {
+		if v, ok := labels[key]; ok && v == value {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *PlacementConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	for key, value := range c.NodeAffinity {
+		if v, ok := c.NodeAntiAffinity[key]; ok && v == value {
+			return errors.New("placement label " + key + "=" + value + " is both in node-affinity and node-anti-affinity")
+		}
+	}
+	return nil
+}
+
 // SchedulerConfig configs TiCDC scheduler.
 type SchedulerConfig struct {
 	// HeartbeatTick is the number of owner tick to initial a heartbeat to captures.
@@ -63,6 +132,9 @@ type SchedulerConfig struct {
 	// When there are only 2 captures, and a large number of tables, this can be helpful to prevent
 	// oom caused by all tables dispatched to only one capture.
 	AddTableBatchSize int `toml:"add-table-batch-size" json:"add-table-batch-size"`
+	// BalanceStrategy is the strategy to balance the changefeed maintainers among nodes,
+	// it can be task-count or load.
+	BalanceStrategy string `toml:"balance-strategy" json:"balance-strategy"`
 
 	// ChangefeedSettings is setting by changefeed.
 	ChangefeedSettings *ChangefeedSchedulerConfig `toml:"-" json:"-"`
@@ -79,6 +151,7 @@ func NewDefaultSchedulerConfig() *SchedulerConfig {
 		// TODO: no need to check balance each minute, relax the interval.
 		CheckBalanceInterval: TomlDuration(time.Minute),
 		AddTableBatchSize:    1000,
+		BalanceStrategy:      BalanceStrategyTaskCount,
 	}
 }
 
@@ -104,5 +177,18 @@ func (c *SchedulerConfig) ValidateAndAdjust() error {
 		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
 			"add-table-batch-size must be large than 0")
 	}
+	if err := validateBalanceStrategy(c.BalanceStrategy); err != nil {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(err.Error())
+	}
 	return nil
 }
+
+func validateBalanceStrategy(strategy string) error {
+	switch strategy {
+	// empty strategy is the same as task-count for the compatibility
+	case "", BalanceStrategyTaskCount, BalanceStrategyLoad:
+		return nil
+	default:
+		return errors.New("balance-strategy must be task-count or load")
+	}
+}
@@ -97,9 +97,10 @@ var defaultServerConfig = &ServerConfig{
 		},
 		InternalErrOutput: "stderr",
 	},
-	DataDir: "",
-	GcTTL:   24 * 60 * 60, // 24H
-	TZ:      "System",
+	DataDir:  "",
+	GcTTL:    24 * 60 * 60, // 24H
+	GcPolicy: NewDefaultGcPolicyConfig(),
+	TZ:       "System",
 	// The default election-timeout in PD is 3s and minimum session TTL is 5s,
 	// which is calculated by `math.Ceil(3 * election-timeout / 2)`, we choose
 	// default capture session ttl to 10s to increase robust to PD jitter,
@@ -121,6 +122,7 @@ var defaultServerConfig = &ServerConfig{
 		Puller:       NewDefaultPullerConfig(),
 		SchemaStore:  NewDefaultSchemaStoreConfig(),
 		EventService: NewDefaultEventServiceConfig(),
+		EventStore:   NewDefaultEventStoreConfig(),
 	},
 	ClusterID:              "default",
 	GcTunerMemoryThreshold: DisableMemoryLimit,
@@ -140,6 +142,8 @@ type ServerConfig struct {
 
 	GcTTL int64  `toml:"gc-ttl" json:"gc-ttl"`
 	TZ    string `toml:"tz" json:"tz"`
+	// GcPolicy is the policy for the changefeeds holding back the GC safepoint too long.
+	GcPolicy *GcPolicyConfig `toml:"gc-policy" json:"gc-policy"`
 
 	CaptureSessionTTL int `toml:"capture-session-ttl" json:"capture-session-ttl"`
 
@@ -153,6 +157,10 @@ type ServerConfig struct {
 	ClusterID              string               `toml:"cluster-id" json:"cluster-id"`
 	GcTunerMemoryThreshold uint64               `toml:"gc-tuner-memory-threshold" json:"gc-tuner-memory-threshold"`
 
+	// Labels are the labels of the node, e.g. zone=us-east-1a,
+	// they are used by the placement rules of the changefeeds.
+	Labels map[string]string `toml:"labels" json:"labels,omitempty"`
+
 	// Deprecated: we don't use this field anymore.
 	PerTableMemoryQuota uint64 `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
 	// Deprecated: we don't use this field anymore.
@@ -226,6 +234,17 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	if c.GcTTL == 0 {
 		return cerror.ErrInvalidServerOption.GenWithStack("empty GC TTL is not allowed")
 	}
+	if c.GcPolicy == nil {
+		c.GcPolicy = NewDefaultGcPolicyConfig()
+	}
+	if err := c.GcPolicy.ValidateAndAdjust(c.GcTTL); err != nil {
+		return err
+	}
+	for key := range c.Labels {
+		if strings.TrimSpace(key) == "" {
+			return cerror.ErrInvalidServerOption.GenWithStack("empty label key is not allowed")
+		}
+	}
 	// 5s is minimum lease ttl in etcd(PD)
 	if c.CaptureSessionTTL < 5 {
 		log.Warn("capture session ttl too small, set to default value 10s")
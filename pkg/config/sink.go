@@ -38,8 +38,10 @@ const (
 
 	// TxnAtomicityKey specifies the key of the transaction-atomicity in the SinkURI.
 	TxnAtomicityKey = "transaction-atomicity"
-	// defaultTxnAtomicity is the default atomicity level.
-	defaultTxnAtomicity = noneTxnAtomicity
+	// defaultTxnAtomicity is the default atomicity level. It keeps the rows of an
+	// upstream transaction in one downstream transaction, which is the behavior of
+	// the changefeeds created before the transaction-atomicity was supported.
+	defaultTxnAtomicity = tableTxnAtomicity
 	// unknownTxnAtomicity is an invalid atomicity level and will be treated as
 	// defaultTxnAtomicity when initializing sink in processor.
 	unknownTxnAtomicity AtomicityLevel = ""
@@ -93,6 +95,25 @@ const (
 	// to send all tables bootstrap message at changefeed start.
 	DefaultSendAllBootstrapAtStart = false
 
+	// SchemaChangeMessageNone means only the DDL message is sent for a DDL.
+	SchemaChangeMessageNone = "none"
+	// SchemaChangeMessageAlongside means the schema change message is sent after the DDL message.
+	SchemaChangeMessageAlongside = "alongside"
+	// SchemaChangeMessageOnly means the schema change message is sent instead of the DDL message.
+	SchemaChangeMessageOnly = "only"
+
+	// ColumnTransformSHA256 replaces the column value with its hex encoded sha256 digest.
+	ColumnTransformSHA256 = "sha256"
+	// ColumnTransformMask replaces the characters of the column value with `*`,
+	// except the last `length` characters.
+	ColumnTransformMask = "mask"
+	// ColumnTransformConstant replaces the column value with a constant value or NULL.
+	ColumnTransformConstant = "constant"
+	// ColumnTransformTruncate keeps the first `length` characters of the column value.
+	ColumnTransformTruncate = "truncate"
+	// ColumnTransformExpression replaces the column value with the result of a SQL expression.
+	ColumnTransformExpression = "expression"
+
 	// DefaultMaxReconnectToPulsarBroker is the default max reconnect times to pulsar broker.
 	// The pulsar client uses an exponential backoff with jitter to reconnect to the broker.
 	// Based on test, when the max reconnect times is 3,
@@ -104,6 +125,7 @@ const (
 type AtomicityLevel string
 
 // ShouldSplitTxn returns whether the sink should split txn.
+// Txns are split only if the atomicity level is set to none explicitly.
 func (l AtomicityLevel) ShouldSplitTxn() bool {
 	if l == unknownTxnAtomicity {
 		l = defaultTxnAtomicity
@@ -139,6 +161,9 @@ type SinkConfig struct {
 	DispatchRules []*DispatchRule `toml:"dispatchers" json:"dispatchers,omitempty"`
 
 	ColumnSelectors []*ColumnSelector `toml:"column-selectors" json:"column-selectors,omitempty"`
+	// ColumnTransformers transform the column values of the row changes before they are
+	// written to the downstream, such as hashing or masking the sensitive data.
+	ColumnTransformers []*ColumnTransformer `toml:"column-transformers" json:"column-transformers,omitempty"`
 	// SchemaRegistry is only available when the downstream is MQ using avro protocol.
 	SchemaRegistry *string `toml:"schema-registry" json:"schema-registry,omitempty"`
 	// EncoderConcurrency is only available when the downstream is MQ.
@@ -194,6 +219,19 @@ type SinkConfig struct {
 	SendBootstrapToAllPartition *bool `toml:"send-bootstrap-to-all-partition" json:"send-bootstrap-to-all-partition,omitempty"`
 	// SendAllBootstrapAtStart determines whether to send all tables bootstrap message at changefeed start.
 	SendAllBootstrapAtStart *bool `toml:"send-all-bootstrap-at-start" json:"send-all-bootstrap-at-start,omitempty"`
+	// SendWatermarkIntervalInMs is the interval in milliseconds to send watermark messages
+	// to all partitions of all active topics, MQ only. Each watermark message carries an
+	// epoch and a sequence number which is contiguous within a topic in the epoch, so the
+	// consumers can detect missing or duplicated watermarks. If it is not positive, the
+	// checkpoint is sent only when it advances and no sequence number is carried.
+	// Only the open and canal-json protocols support it.
+	SendWatermarkIntervalInMs *int64 `toml:"send-watermark-interval-in-ms" json:"send-watermark-interval-in-ms,omitempty"`
+	// SchemaChangeMessage controls whether to send the structured schema change message for the DDLs,
+	// one message for each table changed by the DDL, which carries the column definitions of the table
+	// before and after the DDL, MQ only. It can be none, alongside or only.
+	// Only the open and canal-json protocols support it, the changefeed is rejected if it's set to
+	// alongside or only with the other protocols.
+	SchemaChangeMessage *string `toml:"schema-change-message" json:"schema-change-message,omitempty"`
 	// Debezium only. Whether schema should be excluded in the output.
 	DebeziumDisableSchema *bool `toml:"debezium-disable-schema" json:"debezium-disable-schema,omitempty"`
 
@@ -205,7 +243,7 @@ type SinkConfig struct {
 	Debezium *DebeziumConfig `toml:"debezium" json:"debezium,omitempty"`
 
 	CaseSensitive bool `toml:"case-sensitive" json:"case-sensitive"`
-	// Integrity is only available when the downstream is MQ.
+	// Integrity is only available when the downstream is MQ or MySQL.
 	Integrity      *Config `toml:"integrity" json:"integrity"`
 	ForceReplicate bool    `toml:"force-replicate" json:"force-replicate"`
 }
@@ -405,6 +443,55 @@ type ColumnSelector struct {
 	Columns []string `toml:"columns" json:"columns"`
 }
 
+// ColumnTransformer represents a rule to transform the value of a column of the matched tables.
+// The columns in the primary key or any unique key can not be transformed,
+// and the sha256, mask and truncate functions only apply to the string columns.
+type ColumnTransformer struct {
+	Matcher  []string `toml:"matcher" json:"matcher"`
+	Column   string   `toml:"column" json:"column"`
+	Function string   `toml:"function" json:"function"`
+	// Value is the value of the constant function, the column is set to NULL if it is not set.
+	Value *string `toml:"value" json:"value,omitempty"`
+	// Length is the number of characters kept by the truncate function,
+	// or the number of trailing characters left unmasked by the mask function.
+	Length int `toml:"length" json:"length,omitempty"`
+	// Expression is the SQL expression evaluated by the expression function,
+	// it can reference any column of the row.
+	Expression string `toml:"expression" json:"expression,omitempty"`
+}
+
+// validate checks the rule regardless of the table schemas,
+// which are verified when the changefeed is created.
+func (t *ColumnTransformer) validate() error {
+	if len(t.Matcher) == 0 || t.Column == "" {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"matcher and column of the column transformer should not be empty, rule: %+v", t)
+	}
+	switch t.Function {
+	case ColumnTransformSHA256, ColumnTransformConstant:
+	case ColumnTransformMask:
+		if t.Length < 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"length of the mask column transformer should not be negative, but got %d", t.Length)
+		}
+	case ColumnTransformTruncate:
+		if t.Length <= 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"length of the truncate column transformer should be positive, but got %d", t.Length)
+		}
+	case ColumnTransformExpression:
+		if t.Expression == "" {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"expression of the column transformer should not be empty, column: %s", t.Column)
+		}
+	default:
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"function of the column transformer should be one of sha256, mask, constant, "+
+				"truncate and expression, but got %s", t.Function)
+	}
+	return nil
+}
+
 // CodecConfig represents a MQ codec configuration
 type CodecConfig struct {
 	EnableTiDBExtension            *bool   `toml:"enable-tidb-extension" json:"enable-tidb-extension,omitempty"`
@@ -450,6 +537,7 @@ type KafkaConfig struct {
 	Cert                         *string                   `toml:"cert" json:"cert,omitempty"`
 	Key                          *string                   `toml:"key" json:"key,omitempty"`
 	InsecureSkipVerify           *bool                     `toml:"insecure-skip-verify" json:"insecure-skip-verify,omitempty"`
+	ExactlyOnce                  *bool                     `toml:"exactly-once" json:"exactly-once,omitempty"`
 	CodecConfig                  *CodecConfig              `toml:"codec-config" json:"codec-config,omitempty"`
 	LargeMessageHandle           *LargeMessageHandleConfig `toml:"large-message-handle" json:"large-message-handle,omitempty"`
 	GlueSchemaRegistryConfig     *GlueSchemaRegistryConfig `toml:"glue-schema-registry-config" json:"glue-schema-registry-config"`
@@ -466,6 +554,24 @@ func (k *KafkaConfig) GetOutputRawChangeEvent() bool {
 	return *k.OutputRawChangeEvent
 }
 
+// IsKafkaExactlyOnce returns true if the kafka sink writes the events in transactions,
+// the `exactly-once` parameter of the sink uri takes precedence over the kafka config.
+func IsKafkaExactlyOnce(sinkURIStr string, sinkConfig *SinkConfig) bool {
+	sinkURI, err := url.Parse(sinkURIStr)
+	if err != nil {
+		return false
+	}
+	if scheme := sink.GetScheme(sinkURI); scheme != sink.KafkaScheme && scheme != sink.KafkaSSLScheme {
+		return false
+	}
+	if value := sinkURI.Query().Get("exactly-once"); value != "" {
+		exactlyOnce, err := strconv.ParseBool(value)
+		return err == nil && exactlyOnce
+	}
+	return sinkConfig != nil && sinkConfig.KafkaConfig != nil &&
+		util.GetOrZero(sinkConfig.KafkaConfig.ExactlyOnce)
+}
+
 // MaskSensitiveData masks sensitive data in KafkaConfig
 func (k *KafkaConfig) MaskSensitiveData() {
 	k.SASLPassword = aws.String("******")
@@ -707,11 +813,27 @@ func (c *CloudStorageConfig) GetOutputRawChangeEvent() bool {
 	return *c.OutputRawChangeEvent
 }
 
+// SchemaChangeMessageEnabled returns true if the structured schema change message is sent for the DDLs.
+func (s *SinkConfig) SchemaChangeMessageEnabled() bool {
+	switch util.GetOrZero(s.SchemaChangeMessage) {
+	case "", SchemaChangeMessageNone:
+		return false
+	default:
+	}
+	return true
+}
+
 func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 	if err := s.validateAndAdjustSinkURI(sinkURI); err != nil {
 		return err
 	}
 
+	for _, transformer := range s.ColumnTransformers {
+		if err := transformer.validate(); err != nil {
+			return err
+		}
+	}
+
 	if sink.IsMySQLCompatibleScheme(sinkURI.Scheme) {
 		return nil
 	}
@@ -778,6 +900,25 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 		}
 	}
 
+	switch util.GetOrZero(s.SchemaChangeMessage) {
+	case "", SchemaChangeMessageNone:
+	case SchemaChangeMessageAlongside, SchemaChangeMessageOnly:
+		if protocol != ProtocolOpen && protocol != ProtocolCanalJSON {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"schema-change-message is only supported by the open and canal-json protocols, but got %s", protocol)
+		}
+	default:
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"schema-change-message should be one of none, alongside and only, but got %s",
+			util.GetOrZero(s.SchemaChangeMessage))
+	}
+
+	if util.GetOrZero(s.SendWatermarkIntervalInMs) > 0 &&
+		protocol != ProtocolOpen && protocol != ProtocolCanalJSON {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"send-watermark-interval-in-ms is only supported by the open and canal-json protocols, but got %s", protocol)
+	}
+
 	if util.GetOrZero(s.EncoderConcurrency) < 0 {
 		return cerror.ErrSinkInvalidConfig.GenWithStack(
 			"encoder-concurrency should greater than 0, but got %d", s.EncoderConcurrency)
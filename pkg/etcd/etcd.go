@@ -117,7 +117,7 @@ type CDCEtcdClient interface {
 
 	GetEnsureGCServiceID(tag string) string
 
-	PutCaptureInfo(context.Context, *model.CaptureInfo, clientv3.LeaseID) error
+	PutCaptureInfo(context.Context, CaptureInfo, clientv3.LeaseID) error
 
 	DeleteCaptureInfo(context.Context, model.CaptureID) error
 
@@ -505,17 +505,24 @@ func (c *CDCEtcdClientImpl) SaveChangeFeedInfo(ctx context.Context,
 	return errors.WrapError(errors.ErrPDEtcdAPIError, err)
 }
 
+// CaptureInfo is the information of a capture stored in etcd,
+// the marshaled value must be compatible with model.CaptureInfo.
+type CaptureInfo interface {
+	GetID() model.CaptureID
+	Marshal() ([]byte, error)
+}
+
 // PutCaptureInfo put capture info into etcd,
 // this happens when the capture starts.
 func (c *CDCEtcdClientImpl) PutCaptureInfo(
-	ctx context.Context, info *model.CaptureInfo, leaseID clientv3.LeaseID,
+	ctx context.Context, info CaptureInfo, leaseID clientv3.LeaseID,
 ) error {
 	data, err := info.Marshal()
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	key := GetEtcdKeyCaptureInfo(c.ClusterID, info.ID)
+	key := GetEtcdKeyCaptureInfo(c.ClusterID, info.GetID())
 	_, err = c.Client.Put(ctx, key, string(data), clientv3.WithLease(leaseID))
 	return errors.WrapError(errors.ErrPDEtcdAPIError, err)
 }
@@ -301,7 +301,7 @@ func (mr *MockCDCEtcdClientMockRecorder) GetUpstreamInfo(ctx, upstreamID, namesp
 }
 
 // PutCaptureInfo mocks base method.
-func (m *MockCDCEtcdClient) PutCaptureInfo(arg0 context.Context, arg1 *model.CaptureInfo, arg2 clientv3.LeaseID) error {
+func (m *MockCDCEtcdClient) PutCaptureInfo(arg0 context.Context, arg1 etcd.CaptureInfo, arg2 clientv3.LeaseID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PutCaptureInfo", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
@@ -1,6 +1,7 @@
 package eventservice
 
 import (
+	"strconv"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/atomic"
@@ -59,6 +61,13 @@ type dispatcherStat struct {
 	nextSyncPoint     uint64
 	syncPointInterval time.Duration
 
+	// bdrMode is true if the rows and ddls written by other changefeeds should be dropped
+	bdrMode bool
+	// needPreTableInfo is true if the ddl events should carry the table info before the ddl
+	needPreTableInfo bool
+	// integrityCheck is true if the row checksums should be verified when the rows are decoded
+	integrityCheck bool
+
 	// Scan task related
 	// scanning is used to indicate whether the scan task is running.
 	// If so, we should wait until it is done before we send next resolvedTs event of
@@ -69,6 +78,8 @@ type dispatcherStat struct {
 	metricEventServiceSendKvCount         prometheus.Counter
 	metricEventServiceSendDDLCount        prometheus.Counter
 	metricEventServiceSendResolvedTsCount prometheus.Counter
+	// metricSkippedRowCount is the skipped row counter of each filter rule.
+	metricSkippedRowCount map[string]prometheus.Counter
 }
 
 func newDispatcherStat(
@@ -84,11 +95,25 @@ func newDispatcherStat(
 		info:                                  info,
 		filter:                                filter,
 		startTs:                               startTs,
+		bdrMode:                               info.BDRModeEnabled(),
+		needPreTableInfo:                      info.PreTableInfoEnabled(),
+		integrityCheck:                        info.IntegrityCheckEnabled(),
 		metricSorterOutputEventCountKV:        metrics.SorterOutputEventCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), "kv"),
 		metricEventServiceSendKvCount:         metrics.EventServiceSendEventCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), "kv"),
 		metricEventServiceSendDDLCount:        metrics.EventServiceSendEventCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), "ddl"),
 		metricEventServiceSendResolvedTsCount: metrics.EventServiceSendEventCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), "resolved_ts"),
 	}
+	if filter != nil {
+		rules := []string{"table", "start-ts"}
+		for i := 0; i < filter.EventFilterRuleCount(); i++ {
+			rules = append(rules, strconv.Itoa(i))
+		}
+		dispStat.metricSkippedRowCount = make(map[string]prometheus.Counter, len(rules))
+		for _, rule := range rules {
+			dispStat.metricSkippedRowCount[rule] = metrics.EventServiceSkippedRowCount.WithLabelValues(
+				changefeedID.Namespace(), changefeedID.Name(), rule)
+		}
+	}
 	if info.SyncPointEnabled() {
 		dispStat.enableSyncPoint = true
 		dispStat.nextSyncPoint = info.GetSyncPointTs()
@@ -101,6 +126,64 @@ func newDispatcherStat(
 	return dispStat
 }
 
+// txnSkipRule returns the rule which skips all rows of the txn, or empty if the txn should be scanned,
+// so the rows of the skipped txn are not decoded.
+func (a *dispatcherStat) txnSkipRule(tableInfo *common.TableInfo, startTs uint64) string {
+	if a.filter == nil {
+		return ""
+	}
+	if a.filter.ShouldIgnoreTable(tableInfo.GetSchemaName(), tableInfo.GetTableName()) {
+		return "table"
+	}
+	if a.filter.ShouldIgnoreStartTs(startTs) {
+		return "start-ts"
+	}
+	return ""
+}
+
+// shouldSkipRowType checks whether the row should be skipped by its type before it is decoded.
+func (a *dispatcherStat) shouldSkipRowType(rowType pevent.RowType, tableInfo *common.TableInfo) (bool, error) {
+	if a.filter == nil {
+		return false, nil
+	}
+	ignore, idx, err := a.filter.ShouldIgnoreDMLType(rowType, tableInfo.GetSchemaName(), tableInfo.GetTableName())
+	if err != nil || !ignore {
+		return false, err
+	}
+	a.addSkippedRowCount(strconv.Itoa(idx))
+	return true, nil
+}
+
+// newRowFilter returns the filter to skip the decoded rows by their columns value,
+// it returns nil if the dispatcher has no filter.
+func (a *dispatcherStat) newRowFilter(tableInfo *common.TableInfo) pevent.RowFilter {
+	if a.filter == nil {
+		return nil
+	}
+	return func(rowType pevent.RowType, preRow, row chunk.Row) (bool, error) {
+		ignore, idx, err := a.filter.ShouldIgnoreDML(rowType, preRow, row, tableInfo)
+		if err != nil || !ignore {
+			return false, err
+		}
+		a.addSkippedRowCount(strconv.Itoa(idx))
+		return true, nil
+	}
+}
+
+func (a *dispatcherStat) addSkippedRowCount(rule string) {
+	if counter, ok := a.metricSkippedRowCount[rule]; ok {
+		counter.Inc()
+	}
+}
+
+// deleteSkippedRowCount removes the skipped row counters of the changefeed.
+func (a *dispatcherStat) deleteSkippedRowCount() {
+	changefeedID := a.info.GetChangefeedID()
+	for rule := range a.metricSkippedRowCount {
+		metrics.EventServiceSkippedRowCount.DeleteLabelValues(changefeedID.Namespace(), changefeedID.Name(), rule)
+	}
+}
+
 func (a *dispatcherStat) getEventSenderState() pevent.EventSenderState {
 	if a.isRunning.Load() {
 		return pevent.EventSenderStateNormal
@@ -216,6 +299,14 @@ func newWrapNotReusableEvent(serverID node.ID, e pevent.NotReusableEvent) *wrapE
 	return w
 }
 
+func newWrapErrorEvent(serverID node.ID, e pevent.ErrorEvent) *wrapEvent {
+	w := getWrapEvent()
+	w.serverID = serverID
+	w.e = &e
+	w.msgType = pevent.TypeErrorEvent
+	return w
+}
+
 func newWrapResolvedEvent(serverID node.ID, e pevent.ResolvedEvent, state pevent.EventSenderState) *wrapEvent {
 	e.State = state
 	w := getWrapEvent()
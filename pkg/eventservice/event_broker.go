@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/eventstore"
@@ -18,6 +19,8 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/utils/dynstream"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
 
@@ -51,6 +54,8 @@ type eventBroker struct {
 	schemaStore schemastore.SchemaStore
 	// todo: only one mounter, this may become the bottleneck affect the throughput performance
 	mounter pevent.Mounter
+	// checksumMounter is used by the dispatchers enabling the integrity check
+	checksumMounter pevent.Mounter
 	// msgSender is used to send the events to the dispatchers.
 	msgSender messaging.MessageSender
 
@@ -58,6 +63,10 @@ type eventBroker struct {
 	dispatchers sync.Map
 	// dispatcherID -> dispatcherStat map, track all table trigger dispatchers.
 	tableTriggerDispatchers sync.Map
+	// changefeedDispatcherCount tracks the number of the registered dispatchers of each changefeed,
+	// the metrics of a changefeed are removed after its last dispatcher is removed.
+	changefeedMu              sync.Mutex
+	changefeedDispatcherCount map[common.ChangeFeedID]int
 	// taskPool is used to store the scan tasks and merge the tasks of same dispatcher.
 	// TODO: Make it support merge the tasks of the same table span, even if the tasks are from different dispatchers.
 	taskQueue chan scanTask
@@ -110,18 +119,20 @@ func newEventBroker(
 	conf := config.GetGlobalServerConfig().Debug.EventService
 
 	c := &eventBroker{
-		tidbClusterID:           id,
-		eventStore:              eventStore,
-		mounter:                 pevent.NewMounter(tz),
-		schemaStore:             schemaStore,
-		dispatchers:             sync.Map{},
-		tableTriggerDispatchers: sync.Map{},
-		msgSender:               mc,
-		taskQueue:               make(chan scanTask, conf.ScanTaskQueueSize),
-		scanWorkerCount:         defaultScanWorkerCount,
-		messageCh:               make([]chan *wrapEvent, messageWorkerCount),
-		cancel:                  cancel,
-		wg:                      wg,
+		tidbClusterID:             id,
+		eventStore:                eventStore,
+		mounter:                   pevent.NewMounter(tz),
+		checksumMounter:           pevent.NewChecksumMounter(tz),
+		schemaStore:               schemaStore,
+		dispatchers:               sync.Map{},
+		tableTriggerDispatchers:   sync.Map{},
+		msgSender:                 mc,
+		changefeedDispatcherCount: make(map[common.ChangeFeedID]int),
+		taskQueue:                 make(chan scanTask, conf.ScanTaskQueueSize),
+		scanWorkerCount:           defaultScanWorkerCount,
+		messageCh:                 make([]chan *wrapEvent, messageWorkerCount),
+		cancel:                    cancel,
+		wg:                        wg,
 
 		metricDispatcherCount:                metrics.EventServiceDispatcherGauge.WithLabelValues(strconv.FormatUint(id, 10)),
 		metricEventServiceReceivedResolvedTs: metrics.EventServiceResolvedTsGauge,
@@ -205,6 +216,24 @@ func (c *eventBroker) sendNotReusableEvent(
 	c.getMessageCh(d.workerIndex) <- wrapEvent
 }
 
+// handleScanError stops scanning the dispatcher and reports the error to it,
+// the dispatcher reports the error to its changefeed.
+func (c *eventBroker) handleScanError(d *dispatcherStat, err error) {
+	log.Error("scan events failed, stop scanning the dispatcher",
+		zap.Stringer("dispatcher", d.id),
+		zap.Stringer("changefeedID", d.info.GetChangefeedID()),
+		zap.Error(err))
+	// the watermark is not advanced, the dispatcher is scanned again after it's resumed or reset
+	d.isRunning.Store(false)
+	c.sendErrorEvent(node.ID(d.info.GetServerID()), d, err)
+}
+
+func (c *eventBroker) sendErrorEvent(server node.ID, d *dispatcherStat, err error) {
+	event := pevent.NewErrorEvent(d.id, err)
+	wrapEvent := newWrapErrorEvent(server, event)
+	c.getMessageCh(d.workerIndex) <- wrapEvent
+}
+
 func (c *eventBroker) getMessageCh(workerIndex int) chan *wrapEvent {
 	return c.messageCh[workerIndex]
 }
@@ -219,7 +248,9 @@ func (c *eventBroker) runScanWorker(ctx context.Context) {
 				case <-ctx.Done():
 					return
 				case task := <-c.taskQueue:
-					c.doScan(ctx, task)
+					if err := c.doScan(ctx, task); err != nil {
+						c.handleScanError(task, err)
+					}
 				}
 			}
 		}()
@@ -309,10 +340,19 @@ func (c *eventBroker) logUnresetDispatchers(ctx context.Context) {
 	}()
 }
 
+// isReplicableDDLInBDRMode checks whether the ddl should be written to the downstream in bdr mode,
+// only the ddls executed in the primary cluster are replicated.
+func isReplicableDDLInBDRMode(e *pevent.DDLEvent) bool {
+	return e.BDRRole == string(ast.BDRRolePrimary)
+}
+
 func (c *eventBroker) sendDDL(ctx context.Context, remoteID node.ID, e pevent.DDLEvent, d *dispatcherStat) {
 	c.emitSyncPointEventIfNeeded(e.FinishedTs, d, remoteID)
 	e.DispatcherID = d.id
 	e.Seq = d.seq.Add(1)
+	if d.bdrMode && !isReplicableDDLInBDRMode(&e) {
+		e.NotSync = true
+	}
 	log.Info("send ddl event to dispatcher", zap.Stringer("dispatcher", d.id), zap.String("query", e.Query), zap.Int64("table", e.TableID), zap.Uint64("commitTs", e.FinishedTs), zap.Uint64("seq", e.Seq))
 	ddlEvent := newWrapDDLEvent(remoteID, &e, d.getEventSenderState())
 	select {
@@ -323,6 +363,37 @@ func (c *eventBroker) sendDDL(ctx context.Context, remoteID node.ID, e pevent.DD
 	}
 }
 
+// setPreTableInfo sets the table info before the ddl is executed, so the sink can describe
+// the schema change of the table. The ddl is still sent without it if it can't be got,
+// the sink describes the table after the ddl only.
+func (c *eventBroker) setPreTableInfo(e *pevent.DDLEvent, tableID int64) {
+	// the table trigger event dispatcher doesn't belong to any table,
+	// and the table doesn't exist before it's created.
+	if e.TableInfo == nil || e.FinishedTs == 0 || tableID == heartbeatpb.DDLSpan.TableID ||
+		!tableExistsBeforeDDL(e.GetDDLType()) {
+		return
+	}
+	preTableInfo, err := c.schemaStore.GetTableInfo(tableID, e.FinishedTs-1)
+	if err != nil {
+		log.Warn("get table info before the ddl failed, send the ddl without it",
+			zap.Int64("tableID", tableID), zap.String("query", e.Query),
+			zap.Uint64("finishedTs", e.FinishedTs), zap.Error(err))
+		return
+	}
+	e.PreTableInfo = preTableInfo
+}
+
+// tableExistsBeforeDDL returns false if the ddl creates the table.
+func tableExistsBeforeDDL(ddlType model.ActionType) bool {
+	switch ddlType {
+	case model.ActionCreateTable, model.ActionCreateTables, model.ActionCreateView,
+		model.ActionRecoverTable, model.ActionRecoverSchema:
+		return false
+	default:
+	}
+	return true
+}
+
 // checkNeedScan checks if the dispatcher needs to scan the event store.
 // If the dispatcher needs to scan the event store, it returns true.
 // If the dispatcher does not need to scan the event store, it send the watermark to the dispatcher
@@ -418,8 +489,9 @@ func (c *eventBroker) emitSyncPointEventIfNeeded(ts uint64, d *dispatcherStat, r
 	}
 }
 
-// TODO: handle error properly.
-func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
+// doScan scans the events of the dispatcher and sends them to the dispatcher,
+// nothing after the error is sent and the watermark is not advanced if it returns an error.
+func (c *eventBroker) doScan(ctx context.Context, task scanTask) (err error) {
 	task.handle()
 	start := time.Now()
 	remoteID := node.ID(task.info.GetServerID())
@@ -433,23 +505,51 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 	// To avoid the useless scan task.
 	if !c.msgSender.IsReadyToSend(remoteID) {
 		log.Info("The remote target is not ready, skip scan", zap.Stringer("dispatcher", task.id), zap.Stringer("remote", remoteID))
-		return
+		return nil
 	}
 
 	needScan, dataRange := c.checkNeedScan(task, true)
 	if !needScan {
-		return
+		return nil
+	}
+
+	// Get event iterator from eventStore before fetching ddl events,
+	// the dispatcher may be removed or evicted from the event store,
+	// and we must not send watermark for the data which is not scanned.
+	iter, err := c.eventStore.GetIterator(dispatcherID, dataRange)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// TODO: use error to indicate the dispatcher is removed
+	if iter == nil {
+		return nil
 	}
 
+	defer func() {
+		eventCount, _ := iter.Close()
+		if eventCount != 0 {
+			task.metricSorterOutputEventCountKV.Add(float64(eventCount))
+		}
+		metricEventBrokerScanTaskCount.Inc()
+	}()
+
 	// TODO: distinguish only dml or only ddl scenario
 	ddlEvents, err := c.schemaStore.FetchTableDDLEvents(dataRange.Span.TableID, task.filter, dataRange.StartTs, dataRange.EndTs)
 	if err != nil {
-		log.Panic("get ddl events failed", zap.Error(err))
+		return errors.Trace(err)
+	}
+	if task.needPreTableInfo {
+		for i := range ddlEvents {
+			c.setPreTableInfo(&ddlEvents[i], dataRange.Span.TableID)
+		}
 	}
 
 	// After all the events are sent, we need to
 	// drain the ddlEvents and wake up the dispatcher.
 	defer func() {
+		if err != nil {
+			return
+		}
 		for _, e := range ddlEvents {
 			c.sendDDL(ctx, remoteID, e, task)
 		}
@@ -461,26 +561,9 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 			task.metricEventServiceSendResolvedTsCount)
 	}()
 
-	//2. Get event iterator from eventStore.
-	iter, err := c.eventStore.GetIterator(dispatcherID, dataRange)
-	if err != nil {
-		log.Panic("read events failed", zap.Error(err))
-	}
-	// TODO: use error to indicate the dispatcher is removed
-	if iter == nil {
-		return
-	}
-
-	defer func() {
-		eventCount, _ := iter.Close()
-		if eventCount != 0 {
-			task.metricSorterOutputEventCountKV.Add(float64(eventCount))
-		}
-		metricEventBrokerScanTaskCount.Inc()
-	}()
-
 	sendDML := func(dml *pevent.DMLEvent) {
-		if dml == nil {
+		// all rows of the txn may be dropped in bdr mode
+		if dml == nil || dml.Len() == 0 {
 			return
 		}
 
@@ -494,19 +577,28 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 		task.metricEventServiceSendKvCount.Add(float64(dml.Len()))
 	}
 
-	// 3. Send the events to the dispatcher.
-	var dml *pevent.DMLEvent
+	mounter := c.mounter
+	if task.integrityCheck {
+		mounter = c.checksumMounter
+	}
+	// Send the events to the dispatcher.
+	var (
+		dml       *pevent.DMLEvent
+		rowFilter pevent.RowFilter
+		// skipRule is the rule which skips all rows of the current txn
+		skipRule string
+	)
 	for {
 		//Node: The first event of the txn must return isNewTxn as true.
 		e, isNewTxn, err := iter.Next()
 		if err != nil {
-			log.Panic("read events failed", zap.Error(err))
+			return errors.Trace(err)
 		}
 		if e == nil {
 			// Send the last dml to the dispatcher.
 			sendDML(dml)
 			c.metricScanEventDuration.Observe(time.Since(start).Seconds())
-			return
+			return nil
 		}
 		if e.CRTs < task.watermark.Load() {
 			// If the commitTs of the event is less than the watermark of the dispatcher,
@@ -518,12 +610,31 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 			tableID := task.info.GetTableSpan().TableID
 			tableInfo, err := c.schemaStore.GetTableInfo(tableID, e.CRTs-1)
 			if err != nil {
-				// FIXME handle the error
-				log.Panic("get table info failed", zap.Error(err))
+				return errors.Trace(err)
 			}
 			dml = pevent.NewDMLEvent(dispatcherID, tableID, e.StartTs, e.CRTs, tableInfo)
+			rowFilter = task.newRowFilter(tableInfo)
+			skipRule = task.txnSkipRule(tableInfo, e.StartTs)
+		}
+		if skipRule != "" {
+			task.addSkippedRowCount(skipRule)
+			continue
+		}
+		// the row is written by other changefeeds, drop it to avoid the replication loop
+		if task.bdrMode && e.IsWrittenByCDC() {
+			continue
+		}
+		// filter the row by its type before decoding it
+		skip, err := task.shouldSkipRowType(pevent.GetRowType(e), dml.TableInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if skip {
+			continue
+		}
+		if err := dml.AppendRow(e, mounter.DecodeToChunk, rowFilter); err != nil {
+			return errors.Trace(err)
 		}
-		dml.AppendRow(e, c.mounter.DecodeToChunk)
 	}
 }
 
@@ -745,6 +856,23 @@ func (c *eventBroker) onNotify(d *dispatcherStat, resolvedTs uint64, latestCommi
 	}
 }
 
+// onEvicted is called after the data of the dispatcher is evicted from the event store.
+// The dispatcher is removed and a not reusable event is sent to the collector,
+// so the collector can register it again to pull the data from upstream.
+func (c *eventBroker) onEvicted(d *dispatcherStat) {
+	if _, ok := c.dispatchers.LoadAndDelete(d.id); !ok {
+		return
+	}
+	log.Warn("dispatcher is evicted from event store",
+		zap.Stringer("dispatcher", d.id),
+		zap.Int64("tableID", d.info.GetTableSpan().TableID),
+		zap.Uint64("watermark", d.watermark.Load()))
+	d.isRunning.Store(false)
+	c.schemaStore.UnregisterTable(d.info.GetTableSpan().TableID)
+	c.metricDispatcherCount.Dec()
+	c.sendNotReusableEvent(node.ID(d.info.GetServerID()), d)
+}
+
 func (c *eventBroker) getDispatcher(id common.DispatcherID) (*dispatcherStat, bool) {
 	stat, ok := c.dispatchers.Load(id)
 	if !ok {
@@ -768,6 +896,7 @@ func (c *eventBroker) addDispatcher(info DispatcherInfo) {
 	dispatcher := newDispatcherStat(startTs, info, filter, workerIndex)
 	if span.Equal(heartbeatpb.DDLSpan) {
 		c.tableTriggerDispatchers.Store(id, dispatcher)
+		c.addChangefeedDispatcher(info.GetChangefeedID())
 		log.Info("table trigger dispatcher register dispatcher", zap.Uint64("clusterID", c.tidbClusterID),
 			zap.Any("dispatcherID", id), zap.Int64("tableID", span.TableID),
 			zap.Uint64("startTs", startTs), zap.Duration("brokerRegisterDuration", time.Since(start)))
@@ -782,6 +911,7 @@ func (c *eventBroker) addDispatcher(info DispatcherInfo) {
 		span,
 		info.GetStartTs(),
 		func(resolvedTs uint64, latestCommitTs uint64) { c.onNotify(dispatcher, resolvedTs, latestCommitTs) },
+		func() { c.onEvicted(dispatcher) },
 		info.IsOnlyReuse(),
 	)
 	if err != nil {
@@ -809,6 +939,7 @@ func (c *eventBroker) addDispatcher(info DispatcherInfo) {
 	eventStoreRegisterDuration := time.Since(start)
 
 	c.dispatchers.Store(id, dispatcher)
+	c.addChangefeedDispatcher(info.GetChangefeedID())
 
 	log.Info("register dispatcher", zap.Uint64("clusterID", c.tidbClusterID),
 		zap.Any("dispatcherID", id), zap.Int64("tableID", span.TableID),
@@ -819,16 +950,40 @@ func (c *eventBroker) addDispatcher(info DispatcherInfo) {
 func (c *eventBroker) removeDispatcher(dispatcherInfo DispatcherInfo) {
 	defer c.metricDispatcherCount.Dec()
 	id := dispatcherInfo.GetID()
-	if _, ok := c.dispatchers.Load(id); !ok {
-		c.tableTriggerDispatchers.Delete(id)
+	stat, ok := c.dispatchers.Load(id)
+	if !ok {
+		if stat, ok = c.tableTriggerDispatchers.LoadAndDelete(id); ok {
+			c.removeChangefeedDispatcher(stat.(*dispatcherStat))
+		}
 		return
 	}
 	c.eventStore.UnregisterDispatcher(id)
 	c.schemaStore.UnregisterTable(dispatcherInfo.GetTableSpan().TableID)
 	c.dispatchers.Delete(id)
+	c.removeChangefeedDispatcher(stat.(*dispatcherStat))
 	log.Info("deregister acceptor", zap.Uint64("clusterID", c.tidbClusterID), zap.Any("acceptorID", id))
 }
 
+func (c *eventBroker) addChangefeedDispatcher(changefeedID common.ChangeFeedID) {
+	c.changefeedMu.Lock()
+	defer c.changefeedMu.Unlock()
+	c.changefeedDispatcherCount[changefeedID]++
+}
+
+// removeChangefeedDispatcher removes the metrics of the changefeed
+// if the dispatcher is the last dispatcher of the changefeed.
+func (c *eventBroker) removeChangefeedDispatcher(stat *dispatcherStat) {
+	changefeedID := stat.info.GetChangefeedID()
+	c.changefeedMu.Lock()
+	defer c.changefeedMu.Unlock()
+	c.changefeedDispatcherCount[changefeedID]--
+	if c.changefeedDispatcherCount[changefeedID] > 0 {
+		return
+	}
+	delete(c.changefeedDispatcherCount, changefeedID)
+	stat.deleteSkippedRowCount()
+}
+
 func (c *eventBroker) pauseDispatcher(dispatcherInfo DispatcherInfo) {
 	stat, ok := c.getDispatcher(dispatcherInfo.GetID())
 	if !ok {
@@ -853,8 +1008,28 @@ func (c *eventBroker) resetDispatcher(dispatcherInfo DispatcherInfo) {
 	if !ok {
 		return
 	}
+	if dispatcherInfo.IsReplay() {
+		c.replayDispatcher(dispatcherInfo)
+		return
+	}
 	log.Info("reset dispatcher", zap.Any("dispatcher", stat.id), zap.Uint64("startTs", stat.info.GetStartTs()))
 	stat.resetTs.Store(dispatcherInfo.GetStartTs())
 	stat.isInitialized.Store(false)
 	stat.scanning.Store(false)
 }
+
+// replayDispatcher rewinds the dispatcher to replay the events after the start ts of the request.
+// The events before the watermark may be garbage collected from the event store,
+// so the dispatcher is registered again at the start ts.
+func (c *eventBroker) replayDispatcher(dispatcherInfo DispatcherInfo) {
+	log.Info("replay dispatcher", zap.Stringer("dispatcher", dispatcherInfo.GetID()),
+		zap.Uint64("replayTs", dispatcherInfo.GetStartTs()))
+	c.removeDispatcher(dispatcherInfo)
+	c.addDispatcher(dispatcherInfo)
+	stat, ok := c.getDispatcher(dispatcherInfo.GetID())
+	if !ok {
+		return
+	}
+	// the request is a reset request, so the handshake is sent without waiting for another reset
+	stat.resetTs.Store(dispatcherInfo.GetStartTs())
+}
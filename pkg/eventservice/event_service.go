@@ -34,7 +34,19 @@ type DispatcherInfo interface {
 	GetSyncPointTs() uint64
 	GetSyncPointInterval() time.Duration
 
+	// BDRModeEnabled returns true if the rows and ddls written by
+	// other changefeeds should not be sent to the dispatcher.
+	BDRModeEnabled() bool
+	// PreTableInfoEnabled returns true if the ddl events sent to the dispatcher
+	// should carry the table info before the ddl.
+	PreTableInfoEnabled() bool
+	// IntegrityCheckEnabled returns true if the row checksums encoded by
+	// the upstream TiDB should be verified when the rows are decoded.
+	IntegrityCheckEnabled() bool
+
 	IsOnlyReuse() bool
+	// IsReplay returns true if the reset request rewinds the dispatcher to the start ts.
+	IsReplay() bool
 }
 
 // EventService accepts the requests of pulling events.
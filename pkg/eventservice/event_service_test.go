@@ -397,6 +397,18 @@ func (m *mockDispatcherInfo) GetSyncPointInterval() time.Duration {
 	return 0
 }
 
+func (m *mockDispatcherInfo) BDRModeEnabled() bool {
+	return false
+}
+
+func (m *mockDispatcherInfo) PreTableInfoEnabled() bool {
+	return false
+}
+
+func (m *mockDispatcherInfo) IntegrityCheckEnabled() bool {
+	return false
+}
+
 func genEvents(helper *pevent.EventTestHelper, t *testing.T, ddl string, dmls ...string) (pevent.DDLEvent, []*common.RawKVEntry) {
 	job := helper.DDL2Job(ddl)
 	schema := job.SchemaName
@@ -19,9 +19,13 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/tidb/pkg/expression"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
@@ -46,6 +50,10 @@ type dmlExprFilterRule struct {
 	updateNewExprs map[string]expression.Expression // tableName -> expr
 	deleteExprs    map[string]expression.Expression // tableName -> expr
 
+	// chunkExprs caches the expressions used to filter the chunk rows,
+	// they are rebuilt when the table schema is changed.
+	chunkExprs map[string]*chunkExprs // tableName -> exprs
+
 	tableMatcher tfilter.Filter
 	// All tables in this rule share the same config.
 	config *config.EventFilterRule
@@ -68,6 +76,7 @@ func newExprFilterRule(
 		updateOldExprs: make(map[string]expression.Expression),
 		updateNewExprs: make(map[string]expression.Expression),
 		deleteExprs:    make(map[string]expression.Expression),
+		chunkExprs:     make(map[string]*chunkExprs),
 		config:         cfg,
 		tableMatcher:   tf,
 		sessCtx:        sessCtx,
@@ -316,6 +325,117 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 	}
 }
 
+// chunkExprs is the expressions of a table used to filter the chunk rows.
+type chunkExprs struct {
+	updateTS  uint64
+	insert    expression.Expression
+	updateOld expression.Expression
+	updateNew expression.Expression
+	delete    expression.Expression
+}
+
+// getChunkExprs returns the expressions of the table,
+// the expressions are lazy built and cached until the table schema is changed.
+// The caller must hold r.mu.Lock() before calling this function.
+func (r *dmlExprFilterRule) getChunkExprs(ti *common.TableInfo) (*chunkExprs, error) {
+	tableName := ti.TableName.String()
+	if exprs, ok := r.chunkExprs[tableName]; ok && exprs.updateTS == ti.UpdateTS() {
+		return exprs, nil
+	}
+
+	tidbTableInfo := &timodel.TableInfo{
+		Name:    pmodel.NewCIStr(ti.GetTableName()),
+		Columns: ti.GetColumns(),
+		State:   timodel.StatePublic,
+	}
+	build := func(expr string) (expression.Expression, error) {
+		if expr == "" {
+			return nil, nil
+		}
+		e, err := expression.ParseSimpleExprWithTableInfo(r.sessCtx.GetExprCtx(), expr, tidbTableInfo)
+		if err != nil {
+			if plannererrors.ErrUnknownColumn.Equal(err) {
+				log.Error("meet unknown column when generating expression",
+					zap.String("expression", expr),
+					zap.Error(err))
+				return nil, cerror.ErrExpressionColumnNotFound.
+					FastGenByArgs(getColumnFromError(err), tableName, expr)
+			}
+			log.Error("failed to parse expression", zap.Error(err))
+			return nil, cerror.ErrExpressionParseFailed.FastGenByArgs(err, expr)
+		}
+		return e, nil
+	}
+
+	var (
+		exprs = &chunkExprs{updateTS: ti.UpdateTS()}
+		err   error
+	)
+	if exprs.insert, err = build(r.config.IgnoreInsertValueExpr); err != nil {
+		return nil, err
+	}
+	if exprs.updateOld, err = build(r.config.IgnoreUpdateOldValueExpr); err != nil {
+		return nil, err
+	}
+	if exprs.updateNew, err = build(r.config.IgnoreUpdateNewValueExpr); err != nil {
+		return nil, err
+	}
+	if exprs.delete, err = build(r.config.IgnoreDeleteValueExpr); err != nil {
+		return nil, err
+	}
+	r.chunkExprs[tableName] = exprs
+	return exprs, nil
+}
+
+// shouldSkipChunkRow checks whether the row change decoded in the chunk should be skipped,
+// the preRow is empty for the insert and the row is empty for the delete.
+func (r *dmlExprFilterRule) shouldSkipChunkRow(
+	rowType commonEvent.RowType,
+	preRow, row chunk.Row,
+	ti *common.TableInfo,
+) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	exprs, err := r.getChunkExprs(ti)
+	if err != nil {
+		return false, err
+	}
+	switch rowType {
+	case commonEvent.RowTypeInsert:
+		return r.skipRowByExpression(row, exprs.insert)
+	case commonEvent.RowTypeUpdate:
+		ignoreOld, err := r.skipRowByExpression(preRow, exprs.updateOld)
+		if err != nil {
+			return false, err
+		}
+		if ignoreOld {
+			return true, nil
+		}
+		return r.skipRowByExpression(row, exprs.updateNew)
+	case commonEvent.RowTypeDelete:
+		return r.skipRowByExpression(preRow, exprs.delete)
+	default:
+		log.Warn("unknown row changed event type", zap.Int("rowType", int(rowType)))
+		return false, nil
+	}
+}
+
+func (r *dmlExprFilterRule) skipRowByExpression(
+	row chunk.Row,
+	expr expression.Expression,
+) (bool, error) {
+	if row.IsEmpty() || expr == nil {
+		return false, nil
+	}
+	d, err := expr.Eval(r.sessCtx.GetExprCtx().GetEvalCtx(), row)
+	if err != nil {
+		log.Error("failed to eval expression", zap.Error(err))
+		return false, errors.Trace(err)
+	}
+	return d.GetInt64() == 1, nil
+}
+
 func (r *dmlExprFilterRule) skipDMLByExpression(
 	rowData []types.Datum,
 	expr expression.Expression,
@@ -431,3 +551,32 @@ func (f *dmlExprFilter) shouldSkipDML(
 	}
 	return false, nil
 }
+
+// shouldSkipChunkRow skips the row change decoded in the chunk by sql expression,
+// it returns the index of the event filter rule which skips the row.
+func (f *dmlExprFilter) shouldSkipChunkRow(
+	rowType commonEvent.RowType,
+	preRow, row chunk.Row,
+	ti *common.TableInfo,
+) (bool, int, error) {
+	if len(f.rules) == 0 || ti == nil {
+		return false, -1, nil
+	}
+	schema, table := ti.GetSchemaName(), ti.GetTableName()
+	for idx, rule := range f.rules {
+		if !rule.tableMatcher.MatchTable(schema, table) {
+			continue
+		}
+		ignore, err := rule.shouldSkipChunkRow(rowType, preRow, row, ti)
+		if err != nil {
+			if cerror.ShouldFailChangefeed(err) {
+				return false, -1, err
+			}
+			return false, -1, cerror.WrapError(cerror.ErrFailedToFilterDML, err, ti.TableName.String())
+		}
+		if ignore {
+			return true, idx, nil
+		}
+	}
+	return false, -1, nil
+}
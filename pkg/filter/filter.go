@@ -24,6 +24,7 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
@@ -35,6 +36,9 @@ const (
 	SyncPointTable = "syncpoint_v1"
 	// DDLTsTable is the table name use to write ddl commitTs for each table when downstream is mysql-class
 	DDLTsTable = "ddl_ts_v1"
+	// TableMonitorTable is the table name use to write the checkpoint of each table
+	// when the table monitor is enabled and downstream is mysql-class
+	TableMonitorTable = "table_monitor_v1"
 
 	// TiCDCSystemSchema is the schema only use by TiCDC.
 	TiCDCSystemSchema = "tidb_cdc"
@@ -47,6 +51,18 @@ const (
 type Filter interface {
 	// ShouldIgnoreDMLEvent returns true if the DML event should not be sent to downstream.
 	ShouldIgnoreDMLEvent(dml *model.RowChangedEvent, rawRow model.RowChangedDatums, tableInfo *model.TableInfo) (bool, error)
+	// ShouldIgnoreDMLType returns true if the row change should not be sent to downstream by its type,
+	// it can be called before the row is decoded.
+	// The returned int is the index of the event filter rule which ignores the row.
+	ShouldIgnoreDMLType(rowType commonEvent.RowType, schema, table string) (bool, int, error)
+	// ShouldIgnoreDML returns true if the row change decoded in the chunk should not be sent to downstream
+	// by its columns value. The returned int is the index of the event filter rule which ignores the row.
+	ShouldIgnoreDML(rowType commonEvent.RowType, preRow, row chunk.Row, tableInfo *common.TableInfo) (bool, int, error)
+	// ShouldIgnoreStartTs returns true if the txn with the start ts should not be sent to downstream.
+	ShouldIgnoreStartTs(ts uint64) bool
+	// EventFilterRuleCount returns the number of the event filter rules,
+	// the index returned by ShouldIgnoreDMLType and ShouldIgnoreDML is less than it.
+	EventFilterRuleCount() int
 	// ShouldIgnoreDDLEvent returns true if the DDL event should not be sent to downstream.
 	ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error)
 	// ShouldDiscardDDL returns true if this DDL should be discarded.
@@ -142,7 +158,7 @@ func (f *filter) ShouldIgnoreDMLEvent(
 	rawRow model.RowChangedDatums,
 	ti *model.TableInfo,
 ) (bool, error) {
-	if f.shouldIgnoreStartTs(dml.StartTs) {
+	if f.ShouldIgnoreStartTs(dml.StartTs) {
 		return true, nil
 	}
 
@@ -160,6 +176,21 @@ func (f *filter) ShouldIgnoreDMLEvent(
 	return f.dmlExprFilter.shouldSkipDML(dml, rawRow, ti)
 }
 
+// ShouldIgnoreDMLType checks if a row change should be ignored by its type.
+func (f *filter) ShouldIgnoreDMLType(rowType commonEvent.RowType, schema, table string) (bool, int, error) {
+	return f.sqlEventFilter.shouldSkipRow(rowType, schema, table)
+}
+
+// ShouldIgnoreDML checks if a row change decoded in the chunk should be ignored by its columns value.
+// The start ts, table name and type of the row should be checked before the row is decoded.
+func (f *filter) ShouldIgnoreDML(
+	rowType commonEvent.RowType,
+	preRow, row chunk.Row,
+	ti *common.TableInfo,
+) (bool, int, error) {
+	return f.dmlExprFilter.shouldSkipChunkRow(rowType, preRow, row, ti)
+}
+
 // ShouldDiscardDDL checks if a DDL should be discarded by conditions below:
 // 0. By allow list.
 // 1. By schema name.
@@ -190,7 +221,7 @@ func (f *filter) ShouldDiscardDDL(ddlType timodel.ActionType, schema, table stri
 //  1. `CREATE TABLE test.worker` will be ignored, but the table will be replicated by changefeed-test.
 //  2. `CREATE TABLE other.worker` will be discarded, and the table will not be replicated by changefeed-test.
 func (f *filter) ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error) {
-	if f.shouldIgnoreStartTs(ddl.StartTs) {
+	if f.ShouldIgnoreStartTs(ddl.StartTs) {
 		return true, nil
 	}
 	return f.sqlEventFilter.shouldSkipDDL(ddl)
@@ -214,7 +245,8 @@ func (f *filter) Verify(tableInfos []*model.TableInfo) error {
 	return f.dmlExprFilter.verify(tableInfos)
 }
 
-func (f *filter) shouldIgnoreStartTs(ts uint64) bool {
+// ShouldIgnoreStartTs returns true if the txn with the start ts is in the ignore-txn-start-ts list.
+func (f *filter) ShouldIgnoreStartTs(ts uint64) bool {
 	for _, ignoreTs := range f.ignoreTxnStartTs {
 		if ignoreTs == ts {
 			return true
@@ -223,6 +255,11 @@ func (f *filter) shouldIgnoreStartTs(ts uint64) bool {
 	return false
 }
 
+// EventFilterRuleCount returns the number of the event filter rules.
+func (f *filter) EventFilterRuleCount() int {
+	return len(f.sqlEventFilter.rules)
+}
+
 func isAllowedDDL(actionType timodel.ActionType) bool {
 	_, ok := ddlWhiteListMap[actionType]
 	return ok
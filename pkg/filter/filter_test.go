@@ -0,0 +1,94 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldIgnoreDML(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32), age int)")
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t",
+		"insert into t values (1, 'alice', 10)",
+		"insert into t values (2, 'bob', 20)")
+	row1, row2 := dmlEvent.Rows.GetRow(0), dmlEvent.Rows.GetRow(1)
+
+	f, err := NewFilter(&config.FilterConfig{
+		Rules:            []string{"*.*"},
+		IgnoreTxnStartTs: []uint64{100},
+		EventFilters: []*config.EventFilterRule{
+			{Matcher: []string{"test.t1"}, IgnoreEvent: []bf.EventType{bf.AllDML}},
+			{Matcher: []string{"test.t"}, IgnoreEvent: []bf.EventType{bf.DeleteEvent}},
+			{
+				Matcher:                  []string{"test.*"},
+				IgnoreInsertValueExpr:    "age > 15",
+				IgnoreUpdateOldValueExpr: "name = 'alice'",
+			},
+		},
+	}, "UTC", false)
+	require.NoError(t, err)
+
+	require.True(t, f.ShouldIgnoreStartTs(100))
+	require.False(t, f.ShouldIgnoreStartTs(1))
+	require.Equal(t, 3, f.EventFilterRuleCount())
+
+	typeCases := []struct {
+		rowType commonEvent.RowType
+		table   string
+		ignore  bool
+		rule    int
+	}{
+		{rowType: commonEvent.RowTypeInsert, table: "t", ignore: false, rule: -1},
+		{rowType: commonEvent.RowTypeDelete, table: "t", ignore: true, rule: 1},
+		{rowType: commonEvent.RowTypeUpdate, table: "t1", ignore: true, rule: 0},
+		{rowType: commonEvent.RowTypeUpdate, table: "t2", ignore: false, rule: -1},
+	}
+	for i, c := range typeCases {
+		ignore, rule, err := f.ShouldIgnoreDMLType(c.rowType, "test", c.table)
+		require.NoError(t, err, i)
+		require.Equal(t, c.ignore, ignore, i)
+		require.Equal(t, c.rule, rule, i)
+	}
+
+	exprCases := []struct {
+		rowType commonEvent.RowType
+		preRow  chunk.Row
+		row     chunk.Row
+		ignore  bool
+		rule    int
+	}{
+		{rowType: commonEvent.RowTypeInsert, row: row1, ignore: false, rule: -1},
+		{rowType: commonEvent.RowTypeInsert, row: row2, ignore: true, rule: 2},
+		{rowType: commonEvent.RowTypeDelete, preRow: row2, ignore: false, rule: -1},
+		{rowType: commonEvent.RowTypeUpdate, preRow: row1, row: row2, ignore: true, rule: 2},
+		{rowType: commonEvent.RowTypeUpdate, preRow: row2, row: row1, ignore: false, rule: -1},
+	}
+	for i, c := range exprCases {
+		ignore, rule, err := f.ShouldIgnoreDML(c.rowType, c.preRow, c.row, tableInfo)
+		require.NoError(t, err, i)
+		require.Equal(t, c.ignore, ignore, i)
+		require.Equal(t, c.rule, rule, i)
+	}
+}
@@ -16,6 +16,7 @@ package filter
 import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -208,3 +209,38 @@ func (f *sqlEventFilter) shouldSkipDML(event *model.RowChangedEvent) (bool, erro
 	}
 	return false, nil
 }
+
+// shouldSkipRow skips the row change by its type,
+// it returns the index of the event filter rule which skips the row.
+func (f *sqlEventFilter) shouldSkipRow(rowType commonEvent.RowType, schema, table string) (bool, int, error) {
+	if len(f.rules) == 0 {
+		return false, -1, nil
+	}
+
+	var et bf.EventType
+	switch rowType {
+	case commonEvent.RowTypeInsert:
+		et = bf.InsertEvent
+	case commonEvent.RowTypeUpdate:
+		et = bf.UpdateEvent
+	case commonEvent.RowTypeDelete:
+		et = bf.DeleteEvent
+	default:
+		// It should never happen.
+		log.Warn("unknown row changed event type", zap.Int("rowType", int(rowType)))
+		return false, -1, nil
+	}
+	for idx, rule := range f.rules {
+		if !rule.tf.MatchTable(schema, table) {
+			continue
+		}
+		action, err := rule.bf.Filter(binlogFilterSchemaPlaceholder, binlogFilterTablePlaceholder, et, dmlQuery)
+		if err != nil {
+			return false, -1, cerror.WrapError(cerror.ErrFailedToFilterDML, err, schema+"."+table)
+		}
+		if action == bf.Ignore {
+			return true, idx, nil
+		}
+	}
+	return false, -1, nil
+}
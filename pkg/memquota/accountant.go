@@ -0,0 +1,250 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Component is a module of the changefeed which holds the memory of the events.
+type Component string
+
+const (
+	// EventCollector holds the events pending in the dynamic stream of the event collector.
+	EventCollector Component = "event-collector"
+	// Sink holds the dml events handed to the sink but not flushed to the downstream yet.
+	Sink Component = "sink"
+	// Encoder holds the messages encoded by the encoder group of the mq sink but not acked yet.
+	Encoder Component = "encoder"
+	// MySQLPreparedDMLs holds the sqls and args prepared by the mysql sink but not executed yet.
+	MySQLPreparedDMLs Component = "mysql-prepared-dmls"
+)
+
+// Components are all the components accounted by the accountant.
+var Components = []Component{EventCollector, Sink, Encoder, MySQLPreparedDMLs}
+
+// Usage is a snapshot of the memory usage of a changefeed.
+type Usage struct {
+	Quota      uint64
+	Components map[Component]uint64
+}
+
+// Used returns the total memory used by all components.
+func (u Usage) Used() uint64 {
+	var used uint64
+	for _, size := range u.Components {
+		used += size
+	}
+	return used
+}
+
+// ToPB converts the usage to the message reported to the maintainer.
+func (u Usage) ToPB() *heartbeatpb.MemoryUsage {
+	return &heartbeatpb.MemoryUsage{
+		Quota:             u.Quota,
+		EventCollector:    u.Components[EventCollector],
+		Sink:              u.Components[Sink],
+		Encoder:           u.Components[Encoder],
+		MysqlPreparedDmls: u.Components[MySQLPreparedDMLs],
+	}
+}
+
+// Accountant accounts the memory used by a changefeed on a node across all components,
+// and applies backpressure to the dispatchers when the usage exceeds the quota.
+//
+// The event collector bounds the events pending in its dynamic stream by the quota itself,
+// so its usage is pulled from the dynamic stream. The other components acquire and release
+// the memory they hold. When the total usage exceeds the quota, the dispatchers are not woken
+// to hand more events to the sink until the memory held by the downstream components is released.
+// A dispatcher is never held back if the downstream components hold nothing, so the changefeed
+// always makes progress even if the event collector alone reaches the quota.
+//
+// A nil accountant accounts nothing and never applies backpressure.
+type Accountant struct {
+	changefeedID common.ChangeFeedID
+	quota        int64
+
+	// usage is read-only after the accountant is created, the collector is not in it.
+	usage          map[Component]*atomic.Int64
+	collectorUsage atomic.Pointer[func() int64]
+
+	mu sync.Mutex
+	// waiters are the callbacks held back because the memory exceeds the quota.
+	waiters []func()
+	closed  bool
+
+	metricUsage        map[Component]prometheus.Gauge
+	metricBackpressure prometheus.Counter
+}
+
+// NewAccountant creates an accountant for the changefeed with the memory quota in bytes.
+func NewAccountant(changefeedID common.ChangeFeedID, quota uint64) *Accountant {
+	namespace, name := changefeedID.Namespace(), changefeedID.Name()
+	a := &Accountant{
+		changefeedID:       changefeedID,
+		quota:              int64(quota),
+		usage:              make(map[Component]*atomic.Int64, len(Components)),
+		metricUsage:        make(map[Component]prometheus.Gauge, len(Components)),
+		metricBackpressure: metrics.ChangefeedMemoryBackpressureCount.WithLabelValues(namespace, name),
+	}
+	for _, component := range Components {
+		if component != EventCollector {
+			a.usage[component] = &atomic.Int64{}
+		}
+		a.metricUsage[component] = metrics.ChangefeedMemoryUsageGauge.WithLabelValues(namespace, name, string(component))
+	}
+	metrics.ChangefeedMemoryQuotaGauge.WithLabelValues(namespace, name).Set(float64(quota))
+	return a
+}
+
+// Quota returns the memory quota of the changefeed in bytes.
+func (a *Accountant) Quota() uint64 {
+	if a == nil {
+		return 0
+	}
+	return uint64(a.quota)
+}
+
+// SetCollectorUsageFunc sets the function to get the memory used by the event collector.
+func (a *Accountant) SetCollectorUsageFunc(fn func() int64) {
+	if a == nil {
+		return
+	}
+	a.collectorUsage.Store(&fn)
+}
+
+// Acquire records the memory held by the component, it never blocks.
+// The event collector must not acquire, its usage is pulled by the function set by SetCollectorUsageFunc.
+func (a *Accountant) Acquire(component Component, size int64) {
+	if a == nil || size <= 0 {
+		return
+	}
+	a.usage[component].Add(size)
+	a.metricUsage[component].Add(float64(size))
+}
+
+// Release releases the memory held by the component, and runs the held back callbacks
+// if the usage drops below the quota.
+func (a *Accountant) Release(component Component, size int64) {
+	if a == nil || size <= 0 {
+		return
+	}
+	a.usage[component].Add(-size)
+	a.metricUsage[component].Sub(float64(size))
+	a.notify()
+}
+
+// RunWhenAvailable runs fn immediately if the memory doesn't exceed the quota,
+// otherwise fn is held back until enough memory is released.
+func (a *Accountant) RunWhenAvailable(fn func()) {
+	if a == nil || !a.overQuota() {
+		fn()
+		return
+	}
+	a.mu.Lock()
+	// check again with the lock held, so a concurrent release can't miss the callback
+	if a.closed || !a.overQuota() {
+		a.mu.Unlock()
+		fn()
+		return
+	}
+	a.waiters = append(a.waiters, fn)
+	a.mu.Unlock()
+	a.metricBackpressure.Inc()
+}
+
+func (a *Accountant) notify() {
+	a.mu.Lock()
+	if len(a.waiters) == 0 || a.overQuota() {
+		a.mu.Unlock()
+		return
+	}
+	waiters := a.waiters
+	a.waiters = nil
+	a.mu.Unlock()
+	for _, fn := range waiters {
+		fn()
+	}
+}
+
+func (a *Accountant) downstreamUsage() int64 {
+	var used int64
+	for _, usage := range a.usage {
+		used += usage.Load()
+	}
+	return used
+}
+
+func (a *Accountant) getCollectorUsage() int64 {
+	if fn := a.collectorUsage.Load(); fn != nil {
+		return (*fn)()
+	}
+	return 0
+}
+
+func (a *Accountant) overQuota() bool {
+	downstream := a.downstreamUsage()
+	if downstream <= 0 {
+		return false
+	}
+	return downstream+a.getCollectorUsage() >= a.quota
+}
+
+// Usage returns the memory usage of each component.
+func (a *Accountant) Usage() Usage {
+	if a == nil {
+		return Usage{}
+	}
+	usage := Usage{
+		Quota:      uint64(a.quota),
+		Components: make(map[Component]uint64, len(Components)),
+	}
+	for component, used := range a.usage {
+		usage.Components[component] = uint64(max(used.Load(), 0))
+	}
+	collector := max(a.getCollectorUsage(), 0)
+	usage.Components[EventCollector] = uint64(collector)
+	// the collector usage is pulled, so the metric is updated when the usage is queried
+	a.metricUsage[EventCollector].Set(float64(collector))
+	return usage
+}
+
+// Close runs the held back callbacks and cleans the metrics,
+// the accountant never applies backpressure after closed.
+func (a *Accountant) Close() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	a.closed = true
+	waiters := a.waiters
+	a.waiters = nil
+	a.mu.Unlock()
+	for _, fn := range waiters {
+		fn()
+	}
+
+	namespace, name := a.changefeedID.Namespace(), a.changefeedID.Name()
+	metrics.ChangefeedMemoryQuotaGauge.DeleteLabelValues(namespace, name)
+	metrics.ChangefeedMemoryBackpressureCount.DeleteLabelValues(namespace, name)
+	for _, component := range Components {
+		metrics.ChangefeedMemoryUsageGauge.DeleteLabelValues(namespace, name, string(component))
+	}
+}
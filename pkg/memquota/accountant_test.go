@@ -0,0 +1,86 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountantBackpressure(t *testing.T) {
+	a := NewAccountant(common.ChangefeedID4Test("test", "test"), 100)
+	defer a.Close()
+
+	collector := int64(0)
+	a.SetCollectorUsageFunc(func() int64 { return collector })
+
+	called := 0
+	a.RunWhenAvailable(func() { called++ })
+	require.Equal(t, 1, called)
+
+	// the collector alone never holds the dispatchers back
+	collector = 200
+	a.RunWhenAvailable(func() { called++ })
+	require.Equal(t, 2, called)
+
+	collector = 60
+	a.Acquire(Sink, 30)
+	a.Acquire(Encoder, 10)
+	a.RunWhenAvailable(func() { called++ })
+	require.Equal(t, 2, called)
+
+	a.Release(Encoder, 5)
+	require.Equal(t, 3, called)
+
+	a.Acquire(MySQLPreparedDMLs, 20)
+	a.RunWhenAvailable(func() { called++ })
+	a.RunWhenAvailable(func() { called++ })
+	require.Equal(t, 3, called)
+
+	// release the memory of the downstream components completely, even if the collector is over quota
+	collector = 200
+	a.Release(MySQLPreparedDMLs, 20)
+	require.Equal(t, 3, called)
+	a.Release(Sink, 30)
+	require.Equal(t, 3, called)
+	a.Release(Encoder, 5)
+	require.Equal(t, 5, called)
+
+	usage := a.Usage()
+	require.Equal(t, uint64(100), usage.Quota)
+	require.Equal(t, uint64(200), usage.Used())
+	require.Equal(t, uint64(200), usage.Components[EventCollector])
+	require.Equal(t, uint64(0), usage.Components[Sink])
+}
+
+func TestAccountantClose(t *testing.T) {
+	a := NewAccountant(common.ChangefeedID4Test("test", "test"), 10)
+	a.Acquire(Sink, 20)
+	called := 0
+	a.RunWhenAvailable(func() { called++ })
+	require.Equal(t, 0, called)
+	a.Close()
+	require.Equal(t, 1, called)
+	a.RunWhenAvailable(func() { called++ })
+	require.Equal(t, 2, called)
+
+	// a nil accountant accounts nothing
+	var nilAccountant *Accountant
+	nilAccountant.Acquire(Sink, 10)
+	nilAccountant.RunWhenAvailable(func() { called++ })
+	require.Equal(t, 3, called)
+	require.Equal(t, uint64(0), nilAccountant.Usage().Used())
+}
@@ -57,6 +57,10 @@ const (
 
 	TypeMessageError
 	TypeMessageHandShake
+
+	TypeResetDispatchersRequest
+
+	TypeErrorEvent
 )
 
 func (t IOType) String() string {
@@ -121,6 +125,10 @@ func (t IOType) String() string {
 		return "MessageHandShake"
 	case TypeCheckpointTsMessage:
 		return "CheckpointTsMessage"
+	case TypeResetDispatchersRequest:
+		return "ResetDispatchersRequest"
+	case TypeErrorEvent:
+		return "TypeErrorEvent"
 	default:
 	}
 	return "Unknown"
@@ -179,6 +187,18 @@ func (r RegisterDispatcherRequest) SyncPointEnabled() bool {
 	return r.EnableSyncPoint
 }
 
+func (r RegisterDispatcherRequest) BDRModeEnabled() bool {
+	return r.BdrMode
+}
+
+func (r RegisterDispatcherRequest) PreTableInfoEnabled() bool {
+	return r.NeedPreTableInfo
+}
+
+func (r RegisterDispatcherRequest) IntegrityCheckEnabled() bool {
+	return r.IntegrityCheck
+}
+
 func (r RegisterDispatcherRequest) GetSyncPointTs() uint64 {
 	return r.SyncPointTs
 }
@@ -191,6 +211,10 @@ func (r RegisterDispatcherRequest) IsOnlyReuse() bool {
 	return r.OnlyReuse
 }
 
+func (r RegisterDispatcherRequest) IsReplay() bool {
+	return r.Replay
+}
+
 type IOTypeT interface {
 	Unmarshal(data []byte) error
 	Marshal() (data []byte, err error)
@@ -255,6 +279,10 @@ func decodeIOType(ioType IOType, value []byte) (IOTypeT, error) {
 		m = &heartbeatpb.MaintainerBootstrapRequest{}
 	case TypeCheckpointTsMessage:
 		m = &heartbeatpb.CheckpointTsMessage{}
+	case TypeResetDispatchersRequest:
+		m = &heartbeatpb.ResetDispatchersRequest{}
+	case TypeErrorEvent:
+		m = &commonEvent.ErrorEvent{}
 	case TypeMessageError:
 		m = &MessageError{AppError: &apperror.AppError{}}
 	default:
@@ -339,6 +367,10 @@ func NewSingleTargetMessage(To node.ID, Topic string, Message IOTypeT) *TargetMe
 		ioType = TypeMaintainerCloseResponse
 	case *heartbeatpb.CheckpointTsMessage:
 		ioType = TypeCheckpointTsMessage
+	case *heartbeatpb.ResetDispatchersRequest:
+		ioType = TypeResetDispatchersRequest
+	case *commonEvent.ErrorEvent:
+		ioType = TypeErrorEvent
 	default:
 		panic("unknown io type")
 	}
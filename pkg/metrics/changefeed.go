@@ -105,6 +105,30 @@ var (
 			Help:      "Bucketed histogram of owner tick changefeed reactor time (s).",
 			Buckets:   prometheus.ExponentialBuckets(0.01 /* 10 ms */, 2, 18),
 		}, []string{"namespace", "changefeed"})
+
+	ChangefeedGCSafepointDistanceGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "gc_safepoint_distance",
+			Help:      "The distance between the checkpoint of changefeeds and the GC safepoint (s)",
+		}, []string{"namespace", "changefeed"})
+
+	ChangefeedGCTTLRemainingGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "gc_ttl_remaining",
+			Help:      "The remaining time before changefeeds exceed the GC TTL (s)",
+		}, []string{"namespace", "changefeed"})
+
+	ChangefeedGCHoldBackAlertGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "gc_hold_back_alert",
+			Help:      "Whether changefeeds hold back the GC safepoint longer than the gc policy threshold",
+		}, []string{"namespace", "changefeed"})
 )
 
 // InitMetrics registers all metrics used in owner
@@ -120,4 +144,7 @@ func InitChangefeedMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(HandleMaintainerRequsetCounter)
 	registry.MustRegister(ChangefeedStatusGauge)
 	registry.MustRegister(ChangefeedTickDuration)
+	registry.MustRegister(ChangefeedGCSafepointDistanceGauge)
+	registry.MustRegister(ChangefeedGCTTLRemainingGauge)
+	registry.MustRegister(ChangefeedGCHoldBackAlertGauge)
 }
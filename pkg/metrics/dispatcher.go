@@ -81,6 +81,14 @@ var (
 			Help:      "Checkpoint ts lag of event dispatcher manager(changefeed) in seconds",
 		}, []string{"namespace", "changefeed"})
 
+	TableMonitorCheckpointTsLagGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "dispatchermanager",
+			Name:      "table_checkpoint_ts_lag",
+			Help:      "Checkpoint ts lag of each table in seconds, only reported when the table monitor is enabled",
+		}, []string{"namespace", "changefeed", "table_id"})
+
 	HandleDispatcherRequsetCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "ticdc",
@@ -126,6 +134,35 @@ var (
 			Name:      "handle_event_duration",
 			Help:      "The duration of handling events",
 		})
+	EventCollectorEvictedDispatcherCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_collector",
+			Name:      "evicted_dispatcher_count",
+			Help:      "The number of dispatchers which need to pull data from upstream again because their data is evicted from event store",
+		}, []string{"namespace", "changefeed"})
+
+	ChangefeedMemoryQuotaGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "dispatchermanager",
+			Name:      "memory_quota",
+			Help:      "The memory quota of the changefeed in bytes",
+		}, []string{"namespace", "changefeed"})
+	ChangefeedMemoryUsageGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "dispatchermanager",
+			Name:      "memory_usage",
+			Help:      "The memory used by each component of the changefeed in bytes",
+		}, []string{"namespace", "changefeed", "component"})
+	ChangefeedMemoryBackpressureCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "dispatchermanager",
+			Name:      "memory_backpressure_count",
+			Help:      "The number of times the dispatchers are held back because the memory of the changefeed exceeds the quota",
+		}, []string{"namespace", "changefeed"})
 )
 
 func InitDispatcherMetrics(registry *prometheus.Registry) {
@@ -137,11 +174,16 @@ func InitDispatcherMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventDispatcherManagerResolvedTsLagGauge)
 	registry.MustRegister(EventDispatcherManagerCheckpointTsGauge)
 	registry.MustRegister(EventDispatcherManagerCheckpointTsLagGauge)
+	registry.MustRegister(TableMonitorCheckpointTsLagGauge)
 	registry.MustRegister(HandleDispatcherRequsetCounter)
 	registry.MustRegister(DispatcherReceivedEventCount)
 	registry.MustRegister(EventCollectorRegisteredDispatcherCount)
 	registry.MustRegister(EventCollectorReceivedEventLagDuration)
 	registry.MustRegister(EventCollectorResolvedTsLagGauge)
 	registry.MustRegister(EventCollectorHandleEventDuration)
+	registry.MustRegister(EventCollectorEvictedDispatcherCount)
+	registry.MustRegister(ChangefeedMemoryQuotaGauge)
+	registry.MustRegister(ChangefeedMemoryUsageGauge)
+	registry.MustRegister(ChangefeedMemoryBackpressureCount)
 
 }
@@ -108,6 +108,14 @@ var (
 			Name:      "pending_scan_task_count",
 			Help:      "The number of pending scan tasks",
 		})
+	// EventServiceSkippedRowCount is the metric that counts the rows skipped by the filters during the scan.
+	EventServiceSkippedRowCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_service",
+			Name:      "skipped_row_count",
+			Help:      "The number of rows skipped by the table, start ts and event filter rules during the scan",
+		}, []string{"namespace", "changefeed", "rule"})
 )
 
 // InitMetrics registers all metrics in this file.
@@ -124,4 +132,5 @@ func InitEventServiceMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventServiceScanTaskQueueDuration)
 	registry.MustRegister(EventServiceTaskHandleDuration)
 	registry.MustRegister(EventServicePendingScanTaskCount)
+	registry.MustRegister(EventServiceSkippedRowCount)
 }
@@ -141,6 +141,46 @@ var (
 		Help:      "Bucketed histogram of event store sorter iterator read duration",
 		Buckets:   prometheus.ExponentialBuckets(0.004, 2.0, 20),
 	}, []string{"type"})
+
+	EventStoreOnDiskDataSizeGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "on_disk_data_size",
+			Help:      "The amount of disk space used by event store in bytes.",
+		})
+
+	EventStoreDiskQuotaGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "disk_quota",
+			Help:      "The disk quota of event store in bytes, 0 means no limit.",
+		})
+
+	EventStoreEvictedSubscriptionCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "evicted_subscription_count",
+			Help:      "The number of subscriptions evicted from event store.",
+		}, []string{"reason"})
+
+	EventStoreSharedDispatcherCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "shared_dispatcher_count",
+			Help:      "The number of dispatchers served by existing subscriptions directly or after catching up.",
+		}, []string{"type"})
+
+	EventStoreEvictedBytes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "evicted_bytes",
+			Help:      "The approximate amount of unconsumed data evicted from event store in bytes.",
+		})
 )
 
 func InitEventStoreMetrics(registry *prometheus.Registry) {
@@ -159,4 +199,9 @@ func InitEventStoreMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventStoreWriteBatchSizeHist)
 	registry.MustRegister(EventStoreWriteRequestsCount)
 	registry.MustRegister(EventStoreReadDurationHistogram)
+	registry.MustRegister(EventStoreOnDiskDataSizeGauge)
+	registry.MustRegister(EventStoreDiskQuotaGauge)
+	registry.MustRegister(EventStoreEvictedSubscriptionCount)
+	registry.MustRegister(EventStoreEvictedBytes)
+	registry.MustRegister(EventStoreSharedDispatcherCount)
 }
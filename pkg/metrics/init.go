@@ -32,6 +32,7 @@ func InitMetrics(registry *prometheus.Registry) {
 	InitMaintainerMetrics(registry)
 	InitCoordinatorMetrics(registry)
 	InitLogPullerMetrics(registry)
+	InitLogCoordinatorMetrics(registry)
 	common.InitCommonMetrics(registry)
 	InitDynamicStreamMetrics(registry)
 }
@@ -0,0 +1,57 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	LogCoordinatorSubscriptionCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_coordinator",
+			Name:      "subscription_count",
+			Help:      "The number of subscriptions reported by the event store of each node",
+		}, []string{"node"})
+
+	LogCoordinatorDispatcherCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_coordinator",
+			Name:      "dispatcher_count",
+			Help:      "The number of dispatchers served by the event store of each node",
+		}, []string{"node"})
+
+	LogCoordinatorSubscriptionSharingRatio = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_coordinator",
+			Name:      "subscription_sharing_ratio",
+			Help:      "The average number of dispatchers served by a subscription in the cluster",
+		})
+
+	LogCoordinatorDuplicateSubscriptionCount = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_coordinator",
+			Name:      "duplicate_subscription_count",
+			Help:      "The number of subscriptions whose span is also subscribed by another subscription in the cluster",
+		})
+)
+
+func InitLogCoordinatorMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(LogCoordinatorSubscriptionCount)
+	registry.MustRegister(LogCoordinatorDispatcherCount)
+	registry.MustRegister(LogCoordinatorSubscriptionSharingRatio)
+	registry.MustRegister(LogCoordinatorDuplicateSubscriptionCount)
+}
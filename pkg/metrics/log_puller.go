@@ -24,10 +24,50 @@ var (
 			Name:      "resolved_ts_lag",
 			Help:      "The lag of resolved ts",
 		})
+	LogPullerStoreUnhealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_puller",
+			Name:      "store_unhealthy",
+			Help:      "Whether the circuit of the store is open because of consecutive stream failures",
+		}, []string{"client", "store"})
+	LogPullerStoreRegionCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_puller",
+			Name:      "store_region_count",
+			Help:      "The number of regions requested on the store",
+		}, []string{"client", "store"})
+	LogPullerStoreRTT = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_puller",
+			Name:      "store_rtt",
+			Help:      "The smoothed duration of establishing a stream to the store (s)",
+		}, []string{"client", "store"})
+	LogPullerStoreStreamRestartCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_puller",
+			Name:      "store_stream_restart_count",
+			Help:      "The number of stream restarts of the store",
+		}, []string{"client", "store"})
+	LogPullerStoreRegionErrorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_puller",
+			Name:      "store_region_error_count",
+			Help:      "The number of region errors returned by the store",
+		}, []string{"client", "store"})
 )
 
 func InitLogPullerMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(LogPullerPrewriteCacheRowNum)
 	registry.MustRegister(LogPullerMatcherCount)
 	registry.MustRegister(LogPullerResolvedTsLag)
+	registry.MustRegister(LogPullerStoreUnhealthy)
+	registry.MustRegister(LogPullerStoreRegionCount)
+	registry.MustRegister(LogPullerStoreRTT)
+	registry.MustRegister(LogPullerStoreStreamRestartCounter)
+	registry.MustRegister(LogPullerStoreRegionErrorCounter)
 }
@@ -74,6 +74,15 @@ var (
 			Name:      "execution_error",
 			Help:      "Total count of execution errors.",
 		}, []string{"namespace", "changefeed", "type"}) // type is for `sinkType`
+
+	// ChecksumMismatchCounter is the counter of the rows whose checksum mismatch.
+	ChecksumMismatchCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "checksum_mismatch_count",
+			Help:      "Total count of the rows whose checksum mismatch.",
+		}, []string{"namespace", "changefeed", "type"}) // type is for `sinkType`
 )
 
 // ---------- Metrics for txn sink and backends. ---------- //
@@ -208,6 +217,7 @@ func InitSinkMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventSizeHistogram)
 	registry.MustRegister(ExecutionErrorCounter)
 	registry.MustRegister(ExecDMLEventCounter)
+	registry.MustRegister(ChecksumMismatchCounter)
 
 	// txn sink metrics
 	registry.MustRegister(ConflictDetectDuration)
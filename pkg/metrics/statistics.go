@@ -43,6 +43,7 @@ func NewStatistics(
 	statistics.metricEventSizeHis = EventSizeHistogram.WithLabelValues(namespcae, changefeedID)
 	statistics.metricExecErrCnt = ExecutionErrorCounter.WithLabelValues(namespcae, changefeedID, s)
 	statistics.metricExecDMLCnt = ExecDMLEventCounter.WithLabelValues(namespcae, changefeedID)
+	statistics.metricChecksumMismatchCnt = ChecksumMismatchCounter.WithLabelValues(namespcae, changefeedID, s)
 	return statistics
 }
 
@@ -65,6 +66,8 @@ type Statistics struct {
 	metricExecErrCnt prometheus.Counter
 
 	metricExecDMLCnt prometheus.Counter
+	// Counter for the rows whose checksum mismatch.
+	metricChecksumMismatchCnt prometheus.Counter
 }
 
 // ObserveRows stats all received `RowChangedEvent`s.
@@ -75,6 +78,11 @@ func (b *Statistics) ObserveRows(events []*commonEvent.DMLEvent) {
 	}
 }
 
+// RecordChecksumMismatch stats the rows whose checksum mismatch.
+func (b *Statistics) RecordChecksumMismatch(count int) {
+	b.metricChecksumMismatchCnt.Add(float64(count))
+}
+
 // RecordBatchExecution stats batch executors which return (batchRowCount, error).
 func (b *Statistics) RecordBatchExecution(executor func() (int, int64, error)) error {
 	batchSize, batchWriteBytes, err := executor()
@@ -108,4 +116,5 @@ func (b *Statistics) Close() {
 	ExecutionErrorCounter.DeleteLabelValues(namespace, changefeedID)
 	TotalWriteBytesCounter.DeleteLabelValues(namespace, changefeedID)
 	ExecDMLEventCounter.DeleteLabelValues(namespace, changefeedID)
+	ChecksumMismatchCounter.DeleteLabelValues(namespace, changefeedID, b.sinkType)
 }
@@ -16,8 +16,10 @@ package node
 import (
 	"context"
 
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
 )
 
 // Coordinator is the master of the ticdc cluster,
@@ -34,8 +36,18 @@ type Coordinator interface {
 	ListChangefeeds(ctx context.Context) ([]*config.ChangeFeedInfo, []*config.ChangeFeedStatus, error)
 	// GetChangefeed returns a changefeed
 	GetChangefeed(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) (*config.ChangeFeedInfo, *config.ChangeFeedStatus, error)
+	// GetChangefeedErrorHistory returns the recent error decisions of a changefeed
+	GetChangefeedErrorHistory(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) ([]config.ErrorDecision, error)
+	// GetChangefeedPlacementViolations returns the violations of the placement rules of a changefeed
+	GetChangefeedPlacementViolations(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) ([]string, error)
+	// GetChangefeedMemoryUsage returns the memory usage of a changefeed summed over all nodes
+	GetChangefeedMemoryUsage(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) (*heartbeatpb.MemoryUsage, error)
+	// GetChangefeedGCStatus returns the status of a changefeed against the GC safepoint
+	GetChangefeedGCStatus(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) (*gc.ChangefeedGCStatus, error)
 	// CreateChangefeed creates a new changefeed
 	CreateChangefeed(ctx context.Context, info *config.ChangeFeedInfo) error
+	// CreateChangefeeds creates a batch of changefeeds, either all of them are created or none of them
+	CreateChangefeeds(ctx context.Context, infos []*config.ChangeFeedInfo) ([]error, error)
 	// RemoveChangefeed gets a changefeed
 	RemoveChangefeed(ctx context.Context, id common.ChangeFeedID) (uint64, error)
 	// PauseChangefeed pauses a changefeed
@@ -44,4 +56,6 @@ type Coordinator interface {
 	ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64) error
 	// UpdateChangefeed updates a changefeed
 	UpdateChangefeed(ctx context.Context, change *config.ChangeFeedInfo) error
+	// ResetDispatchers makes the dispatchers of the spans replay events from the reset ts
+	ResetDispatchers(ctx context.Context, id common.ChangeFeedID, spans []*heartbeatpb.TableSpan, resetTs uint64) error
 }
@@ -50,6 +50,9 @@ type Info struct {
 
 	// Epoch represents how many times the node has been restarted.
 	Epoch uint64 `json:"epoch"`
+
+	// Labels are the labels of the node, they are used by the placement rules.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 func NewInfo(addr string, deployPath string) *Info {
@@ -63,6 +66,11 @@ func NewInfo(addr string, deployPath string) *Info {
 	}
 }
 
+// GetID returns the capture ID of the node.
+func (c *Info) GetID() model.CaptureID {
+	return model.CaptureID(c.ID)
+}
+
 // Marshal using json.Marshal.
 func (c *Info) Marshal() ([]byte, error) {
 	data, err := json.Marshal(c)
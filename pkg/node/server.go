@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tiflow/cdc/model"
 	pd "github.com/tikv/pd/client"
 )
@@ -38,4 +39,5 @@ type Server interface {
 
 	GetPdClient() pd.Client
 	GetEtcdClient() etcd.CDCEtcdClient
+	GetKVStorage() kv.Storage
 }
@@ -0,0 +1,194 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"sort"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/node"
+	"go.uber.org/zap"
+)
+
+const (
+	// costPerEventSize is the event size per second equals to the cost of an idle task,
+	// so a task with 1MiB/s throughput is as costly as 5 idle tasks.
+	costPerEventSize = 256 * 1024
+	// imbalanceRatio is the tolerance of the load imbalance, the nodes are treated as balanced
+	// if the max load is not larger than the average load by this ratio.
+	imbalanceRatio = 0.1
+	// overloadedRatio is the resource usage ratio that a node is treated as overloaded,
+	// an overloaded node is never chosen as the target of the load-weighted balance.
+	overloadedRatio = 0.8
+)
+
+// NodeLoad is the resource usage reported by a node.
+type NodeLoad struct {
+	// CPUUsage is the cpu usage ratio of the node, in [0, 1].
+	CPUUsage float64
+	// MemoryUsage is the memory usage ratio of the node to its memory limit, in [0, 1].
+	MemoryUsage float64
+}
+
+// overloaded returns true if the cpu or memory usage of the node is too high.
+func (l NodeLoad) overloaded() bool {
+	return l.CPUUsage >= overloadedRatio || l.MemoryUsage >= overloadedRatio
+}
+
+// CostModel calculates the cost of the tasks and the nodes for the load-weighted balance.
+type CostModel[T Replication] struct {
+	// EventSizePerSecond returns the observed throughput of the task.
+	EventSizePerSecond func(T) float64
+	// NodeLoads are the resource usages reported by the nodes, it can be nil.
+	NodeLoads map[node.ID]NodeLoad
+}
+
+// TaskCost returns the cost of the task, an idle task costs 1,
+// and the cost increases with the observed throughput.
+func (m *CostModel[T]) TaskCost(task T) float64 {
+	cost := 1.0
+	if m.EventSizePerSecond != nil {
+		cost += m.EventSizePerSecond(task) / costPerEventSize
+	}
+	return cost
+}
+
+// NodeCosts returns the sum of the task cost per node, the absent active nodes are added with 0 cost.
+func (m *CostModel[T]) NodeCosts(activeNodes map[node.ID]*node.Info, tasks []T) map[node.ID]float64 {
+	costs := make(map[node.ID]float64, len(activeNodes))
+	for nodeID := range activeNodes {
+		costs[nodeID] = 0
+	}
+	for _, task := range tasks {
+		nodeID := task.GetNodeID()
+		if _, ok := costs[nodeID]; ok {
+			costs[nodeID] += m.TaskCost(task)
+		}
+	}
+	return costs
+}
+
+func (m *CostModel[T]) overloaded(nodeID node.ID) bool {
+	load, ok := m.NodeLoads[nodeID]
+	return ok && load.overloaded()
+}
+
+// CheckLoadBalanceStatus checks the load balance status of the nodes,
+// returns true if the max load exceeds the average load over the tolerance.
+func CheckLoadBalanceStatus(nodeCosts map[node.ID]float64) bool {
+	if len(nodeCosts) <= 1 {
+		return false
+	}
+	total, maxCost := 0.0, 0.0
+	for _, cost := range nodeCosts {
+		total += cost
+		if cost > maxCost {
+			maxCost = cost
+		}
+	}
+	avg := total / float64(len(nodeCosts))
+	return maxCost > avg*(1+imbalanceRatio)
+}
+
+// BalanceByLoad balances the running task by the load per node.
+// It moves tasks from the node with the highest load to the node with the lowest load,
+// until the nodes are balanced or the batch size is reached. The overloaded nodes
+// are never chosen as the target.
+func BalanceByLoad[T Replication](
+	batchSize int,
+	activeNodes map[node.ID]*node.Info,
+	replicating []T,
+	model *CostModel[T],
+	move func(T, node.ID) bool,
+) (movedSize int) {
+	nodeCosts := model.NodeCosts(activeNodes, replicating)
+	if !CheckLoadBalanceStatus(nodeCosts) {
+		return 0
+	}
+	nodeTasks := make(map[node.ID][]T, len(activeNodes))
+	for _, task := range replicating {
+		nodeID := task.GetNodeID()
+		if _, ok := nodeCosts[nodeID]; ok {
+			nodeTasks[nodeID] = append(nodeTasks[nodeID], task)
+		}
+	}
+	// sort the tasks by cost in descending order, so the costly tasks are tried first
+	for _, tasks := range nodeTasks {
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return model.TaskCost(tasks[i]) > model.TaskCost(tasks[j])
+		})
+	}
+
+	total := 0.0
+	for _, cost := range nodeCosts {
+		total += cost
+	}
+	avg := total / float64(len(nodeCosts))
+
+	for movedSize < batchSize {
+		var victim, target node.ID
+		for nodeID, cost := range nodeCosts {
+			if victim == "" || cost > nodeCosts[victim] ||
+				(cost == nodeCosts[victim] && nodeID < victim) {
+				victim = nodeID
+			}
+			if model.overloaded(nodeID) {
+				continue
+			}
+			if target == "" || cost < nodeCosts[target] ||
+				(cost == nodeCosts[target] && nodeID < target) {
+				target = nodeID
+			}
+		}
+		if target == "" || victim == target || nodeCosts[victim] <= avg*(1+imbalanceRatio) {
+			break
+		}
+
+		// choose the task which makes the loads of the victim and target closest,
+		// a task is movable only if the target load is still lower than the victim
+		// load after the move.
+		gap := nodeCosts[victim] - nodeCosts[target]
+		chosen, bestDiff := -1, gap
+		for i, task := range nodeTasks[victim] {
+			cost := model.TaskCost(task)
+			if cost >= gap {
+				continue
+			}
+			diff := gap - 2*cost
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff < bestDiff {
+				chosen, bestDiff = i, diff
+			}
+		}
+		if chosen < 0 {
+			break
+		}
+		task := nodeTasks[victim][chosen]
+		nodeTasks[victim] = append(nodeTasks[victim][:chosen], nodeTasks[victim][chosen+1:]...)
+		if !move(task, target) {
+			continue
+		}
+		cost := model.TaskCost(task)
+		nodeCosts[victim] -= cost
+		nodeCosts[target] += cost
+		movedSize++
+	}
+
+	log.Info("load balance done",
+		zap.Int("movedSize", movedSize),
+		zap.Any("nodeCosts", nodeCosts))
+	return movedSize
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
+	"go.uber.org/zap"
+)
+
+// FilterNodes returns the nodes allowed by the placement rules,
+// the returned map is empty if no node matches.
+func FilterNodes(nodes map[node.ID]*node.Info, placement *config.PlacementConfig) map[node.ID]*node.Info {
+	if placement.IsEmpty() {
+		return nodes
+	}
+	matched := make(map[node.ID]*node.Info, len(nodes))
+	for id, info := range nodes {
+		if placement.Match(info.Labels) {
+			matched[id] = info
+		}
+	}
+	return matched
+}
+
+// PlacementGroup is the tasks which are allowed to be placed on the same nodes.
+type PlacementGroup[T Replication] struct {
+	Nodes map[node.ID]*node.Info
+	Tasks []T
+}
+
+// GroupByPlacement groups the tasks by the nodes allowed by their placement rules,
+// so the tasks of a group can be balanced among the nodes of the group. The tasks
+// without placement rules, or whose placement rules match no node, can be placed
+// on all nodes.
+func GroupByPlacement[T Replication](
+	nodes map[node.ID]*node.Info,
+	tasks []T,
+	placement func(T) *config.PlacementConfig,
+) []*PlacementGroup[T] {
+	groups := make(map[string]*PlacementGroup[T])
+	var result []*PlacementGroup[T]
+	for _, task := range tasks {
+		allowed := FilterNodes(nodes, placement(task))
+		if len(allowed) == 0 {
+			allowed = nodes
+		}
+		ids := make([]string, 0, len(allowed))
+		for id := range allowed {
+			ids = append(ids, string(id))
+		}
+		sort.Strings(ids)
+		key := strings.Join(ids, ",")
+		group, ok := groups[key]
+		if !ok {
+			group = &PlacementGroup[T]{Nodes: allowed}
+			groups[key] = group
+			result = append(result, group)
+		}
+		group.Tasks = append(group.Tasks, task)
+	}
+	return result
+}
+
+// leastLoadedNode returns the allowed node with the minimum task size,
+// returns false if no node is allowed.
+func leastLoadedNode(nodeTasks map[node.ID]int, allowed func(node.ID) bool) (node.ID, bool) {
+	var target node.ID
+	found := false
+	for id, size := range nodeTasks {
+		if !allowed(id) {
+			continue
+		}
+		if !found || size < nodeTasks[target] || (size == nodeTasks[target] && id < target) {
+			target, found = id, true
+		}
+	}
+	return target, found
+}
+
+// PlacementSchedule schedules the absent tasks to the least loaded node allowed by the task,
+// the task is skipped if no node is allowed.
+func PlacementSchedule[T Replication](
+	availableSize int,
+	absent []T,
+	nodeTasks map[node.ID]int,
+	allowed func(T, node.ID) bool,
+	schedule func(T, node.ID) bool,
+) {
+	if len(nodeTasks) == 0 {
+		log.Warn("no node available, skip")
+		return
+	}
+	taskSize := 0
+	for _, task := range absent {
+		target, ok := leastLoadedNode(nodeTasks, func(id node.ID) bool { return allowed(task, id) })
+		if !ok {
+			continue
+		}
+		// the operator is pushed successfully
+		if schedule(task, target) {
+			nodeTasks[target]++
+			taskSize++
+		}
+		if taskSize >= availableSize {
+			break
+		}
+	}
+}
+
+// EvictMisplaced moves the tasks running on the nodes not allowed by the task
+// to the least loaded allowed node, the task is kept if no node is allowed.
+func EvictMisplaced[T Replication](
+	batchSize int,
+	replicating []T,
+	nodeTasks map[node.ID]int,
+	allowed func(T, node.ID) bool,
+	move func(T, node.ID) bool,
+) (movedSize int) {
+	for _, task := range replicating {
+		if movedSize >= batchSize {
+			break
+		}
+		origin := task.GetNodeID()
+		if allowed(task, origin) {
+			continue
+		}
+		target, ok := leastLoadedNode(nodeTasks, func(id node.ID) bool { return allowed(task, id) })
+		if !ok {
+			continue
+		}
+		if move(task, target) {
+			nodeTasks[target]++
+			nodeTasks[origin]--
+			movedSize++
+		}
+	}
+	if movedSize > 0 {
+		log.Info("evict misplaced tasks done", zap.Int("movedSize", movedSize))
+	}
+	return movedSize
+}
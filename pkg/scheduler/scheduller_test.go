@@ -14,8 +14,10 @@
 package scheduler
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/stretchr/testify/require"
 )
@@ -52,3 +54,180 @@ func TestCheckBalanceStatus(t *testing.T) {
 		"node3": {ID: "node3"},
 	}))
 }
+
+type mockTask struct {
+	nodeID             node.ID
+	eventSizePerSecond float64
+}
+
+func (t *mockTask) GetNodeID() node.ID {
+	return t.nodeID
+}
+
+func newMockCostModel(loads map[node.ID]NodeLoad) *CostModel[*mockTask] {
+	return &CostModel[*mockTask]{
+		EventSizePerSecond: func(t *mockTask) float64 { return t.eventSizePerSecond },
+		NodeLoads:          loads,
+	}
+}
+
+// simulateBalance runs the load-weighted balance until no task is moved.
+func simulateBalance(t *testing.T, nodes map[node.ID]*node.Info, tasks []*mockTask, model *CostModel[*mockTask]) int {
+	moved := 0
+	for round := 0; round < 100; round++ {
+		n := BalanceByLoad(10, nodes, tasks, model, func(task *mockTask, target node.ID) bool {
+			require.NotEqual(t, task.nodeID, target)
+			task.nodeID = target
+			return true
+		})
+		if n == 0 {
+			return moved
+		}
+		moved += n
+	}
+	require.FailNow(t, "the balance is not converged")
+	return moved
+}
+
+func TestBalanceByLoad(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1"},
+		"node2": {ID: "node2"},
+	}
+	// node1 has five busy tasks and node2 has fifty idle tasks,
+	// they are balanced by task count, but not by load.
+	var tasks []*mockTask
+	for i := 0; i < 5; i++ {
+		tasks = append(tasks, &mockTask{nodeID: "node1", eventSizePerSecond: 8 * 1024 * 1024})
+	}
+	for i := 0; i < 50; i++ {
+		tasks = append(tasks, &mockTask{nodeID: "node2"})
+	}
+	model := newMockCostModel(nil)
+	require.True(t, CheckLoadBalanceStatus(model.NodeCosts(nodes, tasks)))
+
+	moved := simulateBalance(t, nodes, tasks, model)
+	require.Greater(t, moved, 0)
+	costs := model.NodeCosts(nodes, tasks)
+	require.False(t, CheckLoadBalanceStatus(costs), fmt.Sprintf("%v", costs))
+	// the busy tasks are spread on both nodes
+	busy := map[node.ID]int{}
+	for _, task := range tasks {
+		if task.eventSizePerSecond > 0 {
+			busy[task.nodeID]++
+		}
+	}
+	require.Len(t, busy, 2)
+
+	// the balanced nodes are not changed
+	require.Equal(t, 0, simulateBalance(t, nodes, tasks, model))
+}
+
+func TestBalanceByLoadNewNode(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1"},
+		"node2": {ID: "node2"},
+		"node3": {ID: "node3"},
+	}
+	var tasks []*mockTask
+	for i := 0; i < 30; i++ {
+		tasks = append(tasks, &mockTask{nodeID: node.ID(fmt.Sprintf("node%d", i%2+1)), eventSizePerSecond: float64(i) * 64 * 1024})
+	}
+	model := newMockCostModel(nil)
+	simulateBalance(t, nodes, tasks, model)
+	costs := model.NodeCosts(nodes, tasks)
+	require.False(t, CheckLoadBalanceStatus(costs), fmt.Sprintf("%v", costs))
+	require.Greater(t, costs["node3"], 0.0)
+}
+
+func TestBalanceByLoadOverloadedNode(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1"},
+		"node2": {ID: "node2"},
+		"node3": {ID: "node3"},
+	}
+	var tasks []*mockTask
+	for i := 0; i < 9; i++ {
+		tasks = append(tasks, &mockTask{nodeID: "node1", eventSizePerSecond: 1024 * 1024})
+	}
+	// node3 is idle but its memory is nearly exhausted, so it can not accept any task
+	model := newMockCostModel(map[node.ID]NodeLoad{
+		"node3": {CPUUsage: 0.1, MemoryUsage: 0.9},
+	})
+	simulateBalance(t, nodes, tasks, model)
+	costs := model.NodeCosts(nodes, tasks)
+	require.Equal(t, 0.0, costs["node3"])
+	require.InDelta(t, costs["node1"], costs["node2"], model.TaskCost(tasks[0]))
+}
+
+func TestPlacementSchedule(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1", Labels: map[string]string{"zone": "a"}},
+		"node2": {ID: "node2", Labels: map[string]string{"zone": "a", "tenant": "noisy"}},
+		"node3": {ID: "node3", Labels: map[string]string{"zone": "b"}},
+	}
+	placement := &config.PlacementConfig{
+		NodeAffinity:     map[string]string{"zone": "a"},
+		NodeAntiAffinity: map[string]string{"tenant": "noisy"},
+	}
+	matched := FilterNodes(nodes, placement)
+	require.Len(t, matched, 1)
+	require.Contains(t, matched, node.ID("node1"))
+	require.Len(t, FilterNodes(nodes, nil), 3)
+
+	allowed := func(_ *mockTask, id node.ID) bool {
+		_, ok := matched[id]
+		return ok
+	}
+	nodeTasks := map[node.ID]int{"node1": 0, "node2": 0, "node3": 0}
+	absent := []*mockTask{{}, {}, {}}
+	PlacementSchedule(10, absent, nodeTasks, allowed, func(task *mockTask, id node.ID) bool {
+		task.nodeID = id
+		return true
+	})
+	for _, task := range absent {
+		require.Equal(t, node.ID("node1"), task.nodeID)
+	}
+	require.Equal(t, 3, nodeTasks["node1"])
+
+	// the tasks on node2 and node3 are evicted to node1, limited by the batch size
+	replicating := []*mockTask{{nodeID: "node2"}, {nodeID: "node3"}, {nodeID: "node1"}}
+	nodeTasks = map[node.ID]int{"node1": 1, "node2": 1, "node3": 1}
+	move := func(task *mockTask, id node.ID) bool {
+		task.nodeID = id
+		return true
+	}
+	require.Equal(t, 1, EvictMisplaced(1, replicating, nodeTasks, allowed, move))
+	require.Equal(t, 1, EvictMisplaced(10, replicating, nodeTasks, allowed, move))
+	for _, task := range replicating {
+		require.Equal(t, node.ID("node1"), task.nodeID)
+	}
+	require.Equal(t, 0, EvictMisplaced(10, replicating, nodeTasks, allowed, move))
+}
+
+func TestGroupByPlacement(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1", Labels: map[string]string{"zone": "a"}},
+		"node2": {ID: "node2", Labels: map[string]string{"zone": "a"}},
+		"node3": {ID: "node3", Labels: map[string]string{"zone": "b"}},
+	}
+	zoneA := &config.PlacementConfig{NodeAffinity: map[string]string{"zone": "a"}}
+	zoneC := &config.PlacementConfig{NodeAffinity: map[string]string{"zone": "c"}}
+	placements := make(map[*mockTask]*config.PlacementConfig)
+	tasks := []*mockTask{{nodeID: "node1"}, {nodeID: "node1"}, {nodeID: "node2"}, {nodeID: "node3"}}
+	placements[tasks[0]] = zoneA
+	placements[tasks[1]] = zoneC
+	placements[tasks[2]] = zoneA
+
+	groups := GroupByPlacement(nodes, tasks, func(task *mockTask) *config.PlacementConfig {
+		return placements[task]
+	})
+	require.Len(t, groups, 2)
+	// the tasks of the same placement rules are grouped together
+	require.Len(t, groups[0].Nodes, 2)
+	require.NotContains(t, groups[0].Nodes, node.ID("node3"))
+	require.Equal(t, []*mockTask{tasks[0], tasks[2]}, groups[0].Tasks)
+	// the task whose placement rules match no node can be placed on all nodes
+	require.Len(t, groups[1].Nodes, 3)
+	require.Equal(t, []*mockTask{tasks[1], tasks[3]}, groups[1].Tasks)
+}
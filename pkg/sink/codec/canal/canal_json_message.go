@@ -13,12 +13,17 @@
 
 package canal
 
+import newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+
 // import (
 // 	"github.com/pingcap/tiflow/cdc/model"
 // 	canal "github.com/pingcap/tiflow/proto/canal"
 // )
 
-const tidbWaterMarkType = "TIDB_WATERMARK"
+const (
+	tidbWaterMarkType    = "TIDB_WATERMARK"
+	tidbSchemaChangeType = "TIDB_SCHEMA_CHANGE"
+)
 
 // // The TiCDC Canal-JSON implementation extend the official format with a TiDB extension field.
 // // canalJSONMessageInterface is used to support this without affect the original format.
@@ -126,8 +131,12 @@ type JSONMessage struct {
 type tidbExtension struct {
 	CommitTs           uint64 `json:"commitTs,omitempty"`
 	WatermarkTs        uint64 `json:"watermarkTs,omitempty"`
+	WatermarkEpoch     uint64 `json:"watermarkEpoch,omitempty"`
+	WatermarkSeq       uint64 `json:"watermarkSeq,omitempty"`
 	OnlyHandleKey      bool   `json:"onlyHandleKey,omitempty"`
 	ClaimCheckLocation string `json:"claimCheckLocation,omitempty"`
+	// SchemaChange is only set in the TIDB_SCHEMA_CHANGE message.
+	SchemaChange *newcommon.SchemaChange `json:"schemaChange,omitempty"`
 }
 
 type canalJSONMessageWithTiDBExtension struct {
@@ -418,7 +418,7 @@ func (c *JSONRowEventEncoder) newJSONMessageForDDL(e *commonEvent.DDLEvent) cana
 }
 
 func (c *JSONRowEventEncoder) newJSONMessage4CheckpointEvent(
-	ts uint64,
+	ts uint64, epoch uint64, seq uint64,
 ) *canalJSONMessageWithTiDBExtension {
 	return &canalJSONMessageWithTiDBExtension{
 		JSONMessage: &JSONMessage{
@@ -428,17 +428,22 @@ func (c *JSONRowEventEncoder) newJSONMessage4CheckpointEvent(
 			ExecutionTime: convertToCanalTs(ts),
 			BuildTime:     time.Now().UnixNano() / int64(time.Millisecond), // converts to milliseconds
 		},
-		Extensions: &tidbExtension{WatermarkTs: ts},
+		Extensions: &tidbExtension{WatermarkTs: ts, WatermarkEpoch: epoch, WatermarkSeq: seq},
 	}
 }
 
 // EncodeCheckpointEvent implements the RowEventEncoder interface
 func (c *JSONRowEventEncoder) EncodeCheckpointEvent(ts uint64) (*ticommon.Message, error) {
+	return c.EncodeWatermarkEvent(ts, 0, 0)
+}
+
+// EncodeWatermarkEvent implements the WatermarkEventEncoder interface
+func (c *JSONRowEventEncoder) EncodeWatermarkEvent(ts uint64, epoch uint64, seq uint64) (*ticommon.Message, error) {
 	if !c.config.EnableTiDBExtension {
 		return nil, nil
 	}
 
-	msg := c.newJSONMessage4CheckpointEvent(ts)
+	msg := c.newJSONMessage4CheckpointEvent(ts, epoch, seq)
 	value, err := json.Marshal(msg)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrCanalEncodeFailed, err)
@@ -607,6 +612,53 @@ func (c *JSONRowEventEncoder) EncodeDDLEvent(e *commonEvent.DDLEvent) (*ticommon
 	}, nil
 }
 
+// EncodeSchemaChangeEvent implements the SchemaChangeEventEncoder interface.
+// The schema change of each table is carried in the TiDB extension field of a TIDB_SCHEMA_CHANGE message,
+// which is sent even if the TiDB extension is disabled, same as the watermark message.
+func (c *JSONRowEventEncoder) EncodeSchemaChangeEvent(e *commonEvent.DDLEvent) ([]*ticommon.Message, error) {
+	changes := newcommon.NewSchemaChanges(e)
+	messages := make([]*ticommon.Message, 0, len(changes))
+	for _, change := range changes {
+		table := change.GetTable()
+		message := &canalJSONMessageWithTiDBExtension{
+			JSONMessage: &JSONMessage{
+				ID:            0,
+				Schema:        table.Schema,
+				Table:         table.Table,
+				IsDDL:         false,
+				EventType:     tidbSchemaChangeType,
+				ExecutionTime: convertToCanalTs(e.GetCommitTs()),
+				BuildTime:     time.Now().UnixMilli(),
+			},
+			Extensions: &tidbExtension{
+				CommitTs:     e.GetCommitTs(),
+				SchemaChange: change,
+			},
+		}
+		value, err := json.Marshal(message)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrCanalEncodeFailed, err)
+		}
+		value, err = newcommon.Compress(
+			c.config.ChangefeedID, c.config.LargeMessageHandle.LargeMessageHandleCompression, value,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		messages = append(messages, &ticommon.Message{
+			Key:      nil,
+			Value:    value,
+			Type:     model.MessageTypeDDL,
+			Protocol: config.ProtocolCanalJSON,
+			Table:    &table.Table,
+			Schema:   &table.Schema,
+			Ts:       e.GetCommitTs(),
+		})
+	}
+	return messages, nil
+}
+
 func (b *JSONRowEventEncoder) Clean() {
 	if b.claimCheck != nil {
 		b.claimCheck.CleanMetrics()
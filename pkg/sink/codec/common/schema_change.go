@@ -0,0 +1,132 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"strings"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+// SchemaChangeColumn is the column definition in the schema change message.
+type SchemaChangeColumn struct {
+	// ID is the column id, which is not changed when the column is renamed.
+	ID         int64       `json:"id"`
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Nullable   bool        `json:"nullable"`
+	Default    interface{} `json:"default,omitempty"`
+	PrimaryKey bool        `json:"primaryKey,omitempty"`
+}
+
+// SchemaChangeTable is the table definition in the schema change message.
+type SchemaChangeTable struct {
+	Schema  string               `json:"schema"`
+	Table   string               `json:"table"`
+	TableID int64                `json:"tableID"`
+	Columns []SchemaChangeColumn `json:"columns"`
+}
+
+// SchemaChange is the structured message describing how the schema of a table is changed by a DDL,
+// so the consumers can evolve their schemas without parsing the DDL query.
+type SchemaChange struct {
+	Type     string `json:"type"`
+	Query    string `json:"query"`
+	CommitTs uint64 `json:"commitTs"`
+	// Before is nil if the table does not exist before the DDL.
+	Before *SchemaChangeTable `json:"before,omitempty"`
+	// After is nil if the table is dropped by the DDL, such as drop table and drop view.
+	After *SchemaChangeTable `json:"after,omitempty"`
+}
+
+// GetTable returns the table changed by the DDL, it's the table after the DDL if it exists.
+func (c *SchemaChange) GetTable() *SchemaChangeTable {
+	if c.After != nil {
+		return c.After
+	}
+	return c.Before
+}
+
+// NewSchemaChanges builds the schema change messages from the DDL event, one for each table
+// changed by the DDL, such as each table created by a create tables DDL.
+// It returns nil if the DDL does not change any table, such as create database.
+func NewSchemaChanges(e *commonEvent.DDLEvent) []*SchemaChange {
+	ddlType := e.GetDDLType()
+	if len(e.MultipleTableInfos) > 0 {
+		queries := strings.Split(e.Query, ";")
+		changes := make([]*SchemaChange, 0, len(e.MultipleTableInfos))
+		for i, tableInfo := range e.MultipleTableInfos {
+			query := e.Query
+			if len(queries) == len(e.MultipleTableInfos) {
+				query = queries[i]
+			}
+			changes = append(changes, &SchemaChange{
+				Type:     ddlType.String(),
+				Query:    query,
+				CommitTs: e.FinishedTs,
+				After:    newSchemaChangeTable(tableInfo),
+			})
+		}
+		return changes
+	}
+
+	before, after := e.PreTableInfo, e.TableInfo
+	switch ddlType {
+	case timodel.ActionDropTable, timodel.ActionDropView:
+		// the table info of the drop DDL is the dropped table
+		if before == nil {
+			before = after
+		}
+		after = nil
+	default:
+	}
+	if before == nil && after == nil {
+		return nil
+	}
+	return []*SchemaChange{{
+		Type:     ddlType.String(),
+		Query:    e.Query,
+		CommitTs: e.FinishedTs,
+		Before:   newSchemaChangeTable(before),
+		After:    newSchemaChangeTable(after),
+	}}
+}
+
+func newSchemaChangeTable(tableInfo *common.TableInfo) *SchemaChangeTable {
+	if tableInfo == nil {
+		return nil
+	}
+	table := &SchemaChangeTable{
+		Schema:  tableInfo.GetSchemaName(),
+		Table:   tableInfo.GetTableName(),
+		TableID: tableInfo.TableName.TableID,
+	}
+	for _, col := range tableInfo.GetColumns() {
+		if !common.IsColCDCVisible(col) {
+			continue
+		}
+		table.Columns = append(table.Columns, SchemaChangeColumn{
+			ID:         col.ID,
+			Name:       col.Name.O,
+			Type:       col.GetTypeDesc(),
+			Nullable:   !mysql.HasNotNullFlag(col.GetFlag()),
+			Default:    common.GetColumnDefaultValue(col),
+			PrimaryKey: mysql.HasPriKeyFlag(col.GetFlag()),
+		})
+	}
+	return table
+}
@@ -44,6 +44,25 @@ type EventEncoder interface {
 	Clean()
 }
 
+// WatermarkEventEncoder is implemented by the encoders which support carrying
+// the epoch and the sequence number in the checkpoint event.
+type WatermarkEventEncoder interface {
+	// EncodeWatermarkEvent encodes a checkpoint event with the epoch and the sequence number.
+	// The sequence number is contiguous for all watermarks sent to the same topic in an epoch,
+	// and it restarts from 1 in a new epoch, so the consumers can detect missing or duplicated
+	// watermarks by comparing the sequence numbers of the same epoch.
+	EncodeWatermarkEvent(ts uint64, epoch uint64, seq uint64) (*ticommon.Message, error)
+}
+
+// SchemaChangeEventEncoder is implemented by the encoders which support encoding
+// the structured schema change of a DDL, including the full column definitions
+// of the table before and after the DDL.
+type SchemaChangeEventEncoder interface {
+	// EncodeSchemaChangeEvent encodes the schema change of the DDL event into one message
+	// for each table changed by the DDL, it returns no message if the DDL changes no table.
+	EncodeSchemaChangeEvent(e *commonEvent.DDLEvent) ([]*ticommon.Message, error)
+}
+
 // IsColumnValueEqual checks whether the preValue and updatedValue are equal.
 func IsColumnValueEqual(preValue, updatedValue interface{}) bool {
 	if preValue == nil || updatedValue == nil {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -158,7 +159,8 @@ func (g *encoderGroup) runEncoder(ctx context.Context, idx int) error {
 			for _, event := range future.events {
 				err := g.rowEventEncoders[idx].AppendRowChangedEvent(ctx, future.Key.Topic, event)
 				if err != nil {
-					return errors.Trace(err)
+					return errors.Trace(apperror.WrapTableError(err,
+						event.TableInfo.GetSchemaName(), event.TableInfo.GetTableName()))
 				}
 			}
 			future.Messages = g.rowEventEncoders[idx].Build()
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"strconv"
 
+	"github.com/goccy/go-json"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/common/columnselector"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
@@ -124,8 +125,46 @@ func encodeDDLEvent(e *commonEvent.DDLEvent, config *newcommon.Config) ([]byte,
 		return nil, nil, err
 	}
 
-	key := keyBuf.Bytes()
+	key, value := encodeBatchMessage(keyBuf.Bytes(), value)
+	return key, value, nil
+}
+
+// messageTypeSchemaChange is the open protocol message type of the schema change event,
+// which is not defined in the model.MessageType.
+const messageTypeSchemaChange = 4
 
+// encodeSchemaChange encodes the structured schema change of a table changed by the DDL.
+// The key is the same as the DDL event of the table except the message type,
+// and the value is the JSON encoded schema change.
+func encodeSchemaChange(change *newcommon.SchemaChange, config *newcommon.Config) ([]byte, []byte, error) {
+	table := change.GetTable()
+	keyBuf := &bytes.Buffer{}
+	keyWriter := util.BorrowJSONWriter(keyBuf)
+	keyWriter.WriteObject(func() {
+		keyWriter.WriteUint64Field("ts", change.CommitTs)
+		keyWriter.WriteStringField("scm", table.Schema)
+		keyWriter.WriteStringField("tbl", table.Table)
+		keyWriter.WriteIntField("t", messageTypeSchemaChange)
+	})
+	util.ReturnJSONWriter(keyWriter)
+
+	value, err := json.Marshal(change)
+	if err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrEncodeFailed, err)
+	}
+	value, err = newcommon.Compress(
+		config.ChangefeedID, config.LargeMessageHandle.LargeMessageHandleCompression, value,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, value := encodeBatchMessage(keyBuf.Bytes(), value)
+	return key, value, nil
+}
+
+// encodeBatchMessage frames the key and value as a batch message contains only one event.
+func encodeBatchMessage(key, value []byte) ([]byte, []byte) {
 	var keyLenByte [8]byte
 	var valueLenByte [8]byte
 	var versionByte [8]byte
@@ -143,16 +182,21 @@ func encodeDDLEvent(e *commonEvent.DDLEvent, config *newcommon.Config) ([]byte,
 	valueOutput.Write(valueLenByte[:])
 	valueOutput.Write(value)
 
-	return keyOutput.Bytes(), valueOutput.Bytes(), nil
+	return keyOutput.Bytes(), valueOutput.Bytes()
 }
 
-func encodeResolvedTs(ts uint64) ([]byte, []byte, error) {
+// encodeResolvedTs encodes the resolved ts event, the epoch and the seq are only written if the seq is not zero.
+func encodeResolvedTs(ts uint64, epoch uint64, seq uint64) ([]byte, []byte, error) {
 	keyBuf := &bytes.Buffer{}
 	keyWriter := util.BorrowJSONWriter(keyBuf)
 
 	keyWriter.WriteObject(func() {
 		keyWriter.WriteUint64Field("ts", ts)
 		keyWriter.WriteIntField("t", int(model.MessageTypeResolved))
+		if seq != 0 {
+			keyWriter.WriteUint64Field("epoch", epoch)
+			keyWriter.WriteUint64Field("seq", seq)
+		}
 	})
 
 	util.ReturnJSONWriter(keyWriter)
@@ -213,7 +213,7 @@ func TestDDLEvent(t *testing.T) {
 }
 
 func TestResolvedTsEvent(t *testing.T) {
-	key, value, err := encodeResolvedTs(12345678)
+	key, value, err := encodeResolvedTs(12345678, 0, 0)
 	require.NoError(t, err)
 
 	require.Equal(t, `{"ts":12345678,"t":3}`, string(key)[16:])
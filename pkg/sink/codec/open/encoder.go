@@ -216,9 +216,33 @@ func (d *BatchEncoder) EncodeDDLEvent(e *commonEvent.DDLEvent) (*ticommon.Messag
 	}, nil
 }
 
+// EncodeSchemaChangeEvent implements the SchemaChangeEventEncoder interface
+func (d *BatchEncoder) EncodeSchemaChangeEvent(e *commonEvent.DDLEvent) ([]*ticommon.Message, error) {
+	changes := newcommon.NewSchemaChanges(e)
+	messages := make([]*ticommon.Message, 0, len(changes))
+	for _, change := range changes {
+		key, value, err := encodeSchemaChange(change, d.config)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		messages = append(messages, &ticommon.Message{
+			Key:      key,
+			Value:    value,
+			Type:     model.MessageTypeDDL,
+			Protocol: config.ProtocolOpen,
+		})
+	}
+	return messages, nil
+}
+
 // EncodeCheckpointEvent implements the RowEventEncoder interface
 func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*ticommon.Message, error) {
-	key, value, err := encodeResolvedTs(ts)
+	return d.EncodeWatermarkEvent(ts, 0, 0)
+}
+
+// EncodeWatermarkEvent implements the WatermarkEventEncoder interface
+func (d *BatchEncoder) EncodeWatermarkEvent(ts uint64, epoch uint64, seq uint64) (*ticommon.Message, error) {
+	key, value, err := encodeResolvedTs(ts, epoch, seq)
 
 	if err != nil {
 		return nil, errors.Trace(err)
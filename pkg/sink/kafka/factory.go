@@ -163,6 +163,8 @@ type saramaAsyncProducer struct {
 	producer     sarama.AsyncProducer
 	changefeedID commonType.ChangeFeedID
 	failpointCh  chan error
+	// txnProducer is not nil if the exactly-once mode is enabled.
+	txnProducer *transactionalProducer
 }
 
 func (p *saramaAsyncProducer) Close() {
@@ -252,6 +254,22 @@ func (p *saramaAsyncProducer) AsyncRunCallback(
 	}
 }
 
+// CommitCheckpoint implements the TransactionalProducer interface.
+func (p *saramaAsyncProducer) CommitCheckpoint(topic string, checkpointTs uint64) error {
+	if p.txnProducer == nil {
+		return cerror.ErrKafkaInvalidConfig.GenWithStack("exactly-once is not enabled")
+	}
+	return p.txnProducer.commit(topic, checkpointTs)
+}
+
+// GetCommittedCheckpoint implements the TransactionalProducer interface.
+func (p *saramaAsyncProducer) GetCommittedCheckpoint(topic string) (uint64, error) {
+	if p.txnProducer == nil {
+		return 0, cerror.ErrKafkaInvalidConfig.GenWithStack("exactly-once is not enabled")
+	}
+	return p.txnProducer.committedCheckpoint(topic)
+}
+
 // AsyncSend is the input channel for the user to write messages to that they
 // wish to send.
 func (p *saramaAsyncProducer) AsyncSend(ctx context.Context, topic string, partition int32, message *common.Message) error {
@@ -262,6 +280,9 @@ func (p *saramaAsyncProducer) AsyncSend(ctx context.Context, topic string, parti
 		Value:     sarama.ByteEncoder(message.Value),
 		Metadata:  message.Callback,
 	}
+	if p.txnProducer != nil {
+		return p.txnProducer.send(ctx, msg)
+	}
 	select {
 	case <-ctx.Done():
 		return errors.Trace(ctx.Err())
@@ -71,12 +71,19 @@ func (f *MockFactory) AsyncProducer(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if f.o.ExactlyOnce {
+		enableTransaction(config, f.changefeedID)
+	}
 	t := ctx.Value("testing.T").(*testing.T)
 	asyncProducer := mocks.NewAsyncProducer(t, config)
-	return &MockSaramaAsyncProducer{
+	producer := &MockSaramaAsyncProducer{
 		AsyncProducer: asyncProducer,
 		failpointCh:   failpointCh,
-	}, nil
+	}
+	if f.o.ExactlyOnce {
+		producer.txnProducer = newTransactionalProducer(asyncProducer, nil, f.changefeedID)
+	}
+	return producer, nil
 }
 
 // MetricsCollector returns the metric collector
@@ -129,6 +136,10 @@ func (m *MockSaramaSyncProducer) Close() {
 type MockSaramaAsyncProducer struct {
 	AsyncProducer *mocks.AsyncProducer
 	failpointCh   chan error
+	txnProducer   *transactionalProducer
+	// committedCheckpoint is the checkpoint ts of the last committed transaction,
+	// the mock producer doesn't keep it in kafka.
+	committedCheckpoint uint64
 
 	closed bool
 }
@@ -164,6 +175,26 @@ func (p *MockSaramaAsyncProducer) AsyncRunCallback(
 	}
 }
 
+// CommitCheckpoint implements the TransactionalProducer interface.
+func (p *MockSaramaAsyncProducer) CommitCheckpoint(topic string, checkpointTs uint64) error {
+	if p.txnProducer == nil {
+		return cerror.ErrKafkaInvalidConfig.GenWithStack("exactly-once is not enabled")
+	}
+	if err := p.txnProducer.commit(topic, checkpointTs); err != nil {
+		return err
+	}
+	p.committedCheckpoint = checkpointTs
+	return nil
+}
+
+// GetCommittedCheckpoint implements the TransactionalProducer interface.
+func (p *MockSaramaAsyncProducer) GetCommittedCheckpoint(_ string) (uint64, error) {
+	if p.txnProducer == nil {
+		return 0, cerror.ErrKafkaInvalidConfig.GenWithStack("exactly-once is not enabled")
+	}
+	return p.committedCheckpoint, nil
+}
+
 // AsyncSend implement the AsyncProducer interface.
 func (p *MockSaramaAsyncProducer) AsyncSend(ctx context.Context, topic string, partition int32, message *common.Message) error {
 	msg := &sarama.ProducerMessage{
@@ -173,6 +204,9 @@ func (p *MockSaramaAsyncProducer) AsyncSend(ctx context.Context, topic string, p
 		Value:     sarama.ByteEncoder(message.Value),
 		Metadata:  message.Callback,
 	}
+	if p.txnProducer != nil {
+		return p.txnProducer.send(ctx, msg)
+	}
 	select {
 	case <-ctx.Done():
 		return errors.Trace(ctx.Err())
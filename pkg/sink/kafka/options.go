@@ -20,6 +20,7 @@ import (
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/security"
 	tikafka "github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
 
@@ -126,6 +127,7 @@ type urlConfig struct {
 	Cert                         *string `form:"cert"`
 	Key                          *string `form:"key"`
 	InsecureSkipVerify           *bool   `form:"insecure-skip-verify"`
+	ExactlyOnce                  *bool   `form:"exactly-once"`
 }
 
 // Options stores user specified configurations
@@ -158,6 +160,11 @@ type Options struct {
 	DialTimeout  time.Duration
 	WriteTimeout time.Duration
 	ReadTimeout  time.Duration
+
+	// ExactlyOnce enables the idempotent and transactional DML producer,
+	// the messages are only visible to the `read_committed` consumers
+	// after the transaction containing them is committed.
+	ExactlyOnce bool
 }
 
 // NewOptions returns a default Kafka configuration
@@ -296,6 +303,22 @@ func (o *Options) Apply(changefeedID common.ChangeFeedID,
 		o.RequiredAcks = r
 	}
 
+	if urlParameter.ExactlyOnce != nil {
+		o.ExactlyOnce = *urlParameter.ExactlyOnce
+	}
+	if o.ExactlyOnce {
+		// The v2 client is built on kafka-go, which always encodes the record batches
+		// without the producer id and epoch, so they can't be written in a transaction.
+		if sinkConfig != nil && util.GetOrZero(sinkConfig.EnableKafkaSinkV2) {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"exactly-once is not supported by the kafka sink v2 client")
+		}
+		if o.RequiredAcks != WaitForAll {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"exactly-once requires required-acks to be %d", WaitForAll)
+		}
+	}
+
 	err = o.applySASL(urlParameter, sinkConfig)
 	if err != nil {
 		return err
@@ -343,6 +366,7 @@ func mergeConfig(
 		dest.Cert = fileConifg.Cert
 		dest.Key = fileConifg.Key
 		dest.InsecureSkipVerify = fileConifg.InsecureSkipVerify
+		dest.ExactlyOnce = fileConifg.ExactlyOnce
 	}
 	if err := mergo.Merge(dest, urlParameters, mergo.WithOverride); err != nil {
 		return nil, err
@@ -119,6 +119,9 @@ func (f *saramaFactory) AsyncProducer(
 		return nil, err
 	}
 	config.MetricRegistry = f.registry
+	if f.option.ExactlyOnce {
+		enableTransaction(config, f.changefeedID)
+	}
 
 	client, err := sarama.NewClient(f.option.BrokerEndpoints, config)
 	if err != nil {
@@ -128,12 +131,21 @@ func (f *saramaFactory) AsyncProducer(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &saramaAsyncProducer{
+	producer := &saramaAsyncProducer{
 		client:       client,
 		producer:     p,
 		changefeedID: f.changefeedID,
 		failpointCh:  failpointCh,
-	}, nil
+	}
+	if f.option.ExactlyOnce {
+		// the admin shares the client with the producer, it's closed along with the client.
+		admin, err := sarama.NewClusterAdminFromClient(client)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		producer.txnProducer = newTransactionalProducer(p, admin, f.changefeedID)
+	}
+	return producer, nil
 }
 
 func (f *saramaFactory) MetricsCollector(
@@ -0,0 +1,150 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"github.com/pingcap/errors"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// TransactionalProducer is implemented by the async producer in the exactly-once mode.
+// The messages are sent in the transactions, and each transaction is committed along with
+// the checkpoint ts, all events whose commit ts is not larger than it are in the committed
+// transactions, and the events larger than it are not.
+type TransactionalProducer interface {
+	// CommitCheckpoint commits the ongoing transaction along with the checkpoint ts,
+	// the checkpoint ts is kept as the offset metadata of the topic in the transaction.
+	CommitCheckpoint(topic string, checkpointTs uint64) error
+	// GetCommittedCheckpoint returns the checkpoint ts of the last committed transaction,
+	// it returns 0 if no transaction is committed.
+	GetCommittedCheckpoint(topic string) (uint64, error)
+}
+
+// TransactionalID returns the transactional id of the changefeed. It only depends on the
+// changefeed id, so the producer of the changefeed moved to another capture fences the
+// previous one, and its unfinished transaction is aborted.
+func TransactionalID(changefeedID commonType.ChangeFeedID) string {
+	return fmt.Sprintf("ticdc-%s-%s", changefeedID.Namespace(), changefeedID.Name())
+}
+
+// enableTransaction makes the sarama producer idempotent and transactional.
+func enableTransaction(config *sarama.Config, changefeedID commonType.ChangeFeedID) {
+	config.Producer.Idempotent = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Net.MaxOpenRequests = 1
+	config.Producer.Transaction.ID = TransactionalID(changefeedID)
+}
+
+// transactionalProducer sends the messages of an async producer in kafka transactions.
+// The transaction is committed by the sink at the checkpoint, and the checkpoint is
+// committed as the offset of the changefeed's consumer group in the same transaction,
+// so it can be read after restarting to skip the events already committed.
+type transactionalProducer struct {
+	producer sarama.AsyncProducer
+	// admin is used to read the committed checkpoint, it's nil in test.
+	admin sarama.ClusterAdmin
+	// groupID is the consumer group keeping the checkpoint, it's the transactional id.
+	groupID string
+
+	// mu serializes sending messages and committing the transaction.
+	mu sync.Mutex
+}
+
+func newTransactionalProducer(
+	producer sarama.AsyncProducer, admin sarama.ClusterAdmin, changefeedID commonType.ChangeFeedID,
+) *transactionalProducer {
+	return &transactionalProducer{
+		producer: producer,
+		admin:    admin,
+		groupID:  TransactionalID(changefeedID),
+	}
+}
+
+// send begins a transaction if there is no ongoing one and sends the message in it.
+func (t *transactionalProducer) send(ctx context.Context, msg *sarama.ProducerMessage) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.beginIfNeeded(); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case t.producer.Input() <- msg:
+	}
+	return nil
+}
+
+func (t *transactionalProducer) beginIfNeeded() error {
+	if t.producer.TxnStatus()&sarama.ProducerTxnFlagInTransaction != 0 {
+		return nil
+	}
+	if err := t.producer.BeginTxn(); err != nil {
+		return cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, err)
+	}
+	return nil
+}
+
+// commit commits the ongoing transaction with the checkpoint ts, it waits for all messages
+// sent before to be acknowledged.
+func (t *transactionalProducer) commit(topic string, checkpointTs uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.beginIfNeeded(); err != nil {
+		return err
+	}
+	metadata := strconv.FormatUint(checkpointTs, 10)
+	offsets := map[string][]*sarama.PartitionOffsetMetadata{
+		topic: {{Partition: 0, Offset: 0, Metadata: &metadata}},
+	}
+	if err := t.producer.AddOffsetsToTxn(offsets, t.groupID); err != nil {
+		return cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, err)
+	}
+	if err := t.producer.CommitTxn(); err != nil {
+		return cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, err)
+	}
+	return nil
+}
+
+// committedCheckpoint reads the checkpoint ts of the last committed transaction. The
+// unfinished transaction of the previous producer is aborted when the producer is created,
+// so only the committed checkpoint is visible.
+func (t *transactionalProducer) committedCheckpoint(topic string) (uint64, error) {
+	resp, err := t.admin.ListConsumerGroupOffsets(t.groupID, map[string][]int32{topic: {0}})
+	if err != nil {
+		return 0, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+	}
+	block := resp.GetBlock(topic, 0)
+	if block == nil {
+		return 0, nil
+	}
+	if block.Err != sarama.ErrNoError {
+		return 0, cerror.WrapError(cerror.ErrKafkaInvalidConfig, block.Err)
+	}
+	if block.Offset < 0 || block.Metadata == "" {
+		return 0, nil
+	}
+	checkpointTs, err := strconv.ParseUint(block.Metadata, 10, 64)
+	if err != nil {
+		return 0, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+	}
+	return checkpointTs, nil
+}
@@ -23,6 +23,8 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columntransformer"
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -100,7 +102,20 @@ type MysqlConfig struct {
 	// write source exists when the downstream is TiDB and version is greater than or equal to v6.5.0.
 	IsWriteSourceExisted bool
 
-	SourceID        uint64
+	SourceID uint64
+	// BDRMode is true if the changefeed is a part of the bidirectional replication
+	BDRMode bool
+	// EnableTableMonitor is true if the checkpoint of each table is written to the downstream
+	EnableTableMonitor bool
+	// Integrity is used to verify the row checksum before writing to the downstream
+	Integrity *ticonfig.Config
+	// ColumnTransformers transform the column values before writing to the downstream
+	ColumnTransformers *columntransformer.ColumnTransformers
+	// TxnAtomicity is the atomicity level of the upstream transactions.
+	// In table level, the rows of an upstream transaction for a table are always committed
+	// in one downstream transaction. In none level, a huge transaction can be split into
+	// multiple downstream transactions with at most MaxTxnRow rows.
+	TxnAtomicity    ticonfig.AtomicityLevel
 	BatchDMLEnable  bool
 	MultiStmtEnable bool
 	CachePrepStmts  bool
@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
@@ -58,6 +59,8 @@ type MysqlWriter struct {
 	ddlTsTableInit   bool
 	tableSchemaStore *util.TableSchemaStore
 
+	tableMonitorTableInit bool
+
 	// implement stmtCache to improve performance, especially when the downstream is TiDB
 	stmtCache *lru.Cache
 	// Indicate if the CachePrepStmts should be enabled or not
@@ -65,9 +68,11 @@ type MysqlWriter struct {
 	maxAllowedPacket int64
 
 	statistics *metrics.Statistics
+	// accountant accounts the memory of the prepared dmls, it's nil for the writer which doesn't write dmls
+	accountant *memquota.Accountant
 }
 
-func NewMysqlWriter(ctx context.Context, db *sql.DB, cfg *MysqlConfig, changefeedID common.ChangeFeedID, statistics *metrics.Statistics) *MysqlWriter {
+func NewMysqlWriter(ctx context.Context, db *sql.DB, cfg *MysqlConfig, changefeedID common.ChangeFeedID, statistics *metrics.Statistics, accountant *memquota.Accountant) *MysqlWriter {
 	return &MysqlWriter{
 		ctx:                    ctx,
 		db:                     db,
@@ -80,6 +85,7 @@ func NewMysqlWriter(ctx context.Context, db *sql.DB, cfg *MysqlConfig, changefee
 		maxAllowedPacket:       cfg.MaxAllowedPacket,
 		stmtCache:              cfg.stmtCache,
 		statistics:             statistics,
+		accountant:             accountant,
 	}
 }
 
@@ -241,6 +247,76 @@ func (w *MysqlWriter) SendSyncPointEvent(event *commonEvent.SyncPointEvent) erro
 	return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to write syncpoint table; Commit Fail;"))
 }
 
+// FlushTableCheckpoints writes the checkpoint of each table to the table monitor table,
+// so the freshness of the tables can be measured in the downstream, including the idle tables.
+func (w *MysqlWriter) FlushTableCheckpoints(checkpoints []util.TableCheckpoint) error {
+	if len(checkpoints) == 0 {
+		return nil
+	}
+	if !w.tableMonitorTableInit {
+		// create table monitor table if not exist
+		err := w.CreateTableMonitorTable()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.tableMonitorTableInit = true
+	}
+
+	changefeedID := w.ChangefeedID.String()
+	ticdcClusterID := config.GetGlobalServerConfig().ClusterID
+
+	// INSERT INTO `tidb_cdc`.`table_monitor_v1` (ticdc_cluster_id, changefeed, table_id, checkpoint_ts) values(...) ON DUPLICATE KEY UPDATE checkpoint_ts=VALUES(checkpoint_ts), updated_at=CURRENT_TIMESTAMP;
+	var builder strings.Builder
+	builder.WriteString("INSERT INTO ")
+	builder.WriteString(filter.TiCDCSystemSchema)
+	builder.WriteString(".")
+	builder.WriteString(filter.TableMonitorTable)
+	builder.WriteString(" (ticdc_cluster_id, changefeed, table_id, checkpoint_ts) VALUES ")
+	for idx, checkpoint := range checkpoints {
+		builder.WriteString("('")
+		builder.WriteString(ticdcClusterID)
+		builder.WriteString("', '")
+		builder.WriteString(changefeedID)
+		builder.WriteString("', ")
+		builder.WriteString(strconv.FormatInt(checkpoint.TableID, 10))
+		builder.WriteString(", '")
+		builder.WriteString(strconv.FormatUint(checkpoint.CheckpointTs, 10))
+		builder.WriteString("')")
+		if idx < len(checkpoints)-1 {
+			builder.WriteString(", ")
+		}
+	}
+	builder.WriteString(" ON DUPLICATE KEY UPDATE checkpoint_ts=VALUES(checkpoint_ts), updated_at=CURRENT_TIMESTAMP;")
+	query := builder.String()
+
+	_, err := w.db.ExecContext(w.ctx, query)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to write table monitor table; Query is %s", query)))
+	}
+	return nil
+}
+
+// RemoveTableCheckpoints removes the checkpoints of the tables in the changefeed from the table monitor table.
+func (w *MysqlWriter) RemoveTableCheckpoints() error {
+	var builder strings.Builder
+	builder.WriteString("DELETE FROM ")
+	builder.WriteString(filter.TiCDCSystemSchema)
+	builder.WriteString(".")
+	builder.WriteString(filter.TableMonitorTable)
+	builder.WriteString(" WHERE ticdc_cluster_id = '")
+	builder.WriteString(config.GetGlobalServerConfig().ClusterID)
+	builder.WriteString("' and changefeed = '")
+	builder.WriteString(w.ChangefeedID.String())
+	builder.WriteString("'")
+	query := builder.String()
+
+	_, err := w.db.ExecContext(w.ctx, query)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to delete table monitor item; Query is %s", query)))
+	}
+	return nil
+}
+
 func (w *MysqlWriter) SendDDLTs(event *commonEvent.DDLEvent) error {
 	tx, err := w.db.BeginTx(w.ctx, nil)
 	if err != nil {
@@ -541,6 +617,21 @@ func (w *MysqlWriter) CreateSyncTable() error {
 	return w.CreateTable(database, filter.SyncPointTable, query)
 }
 
+func (w *MysqlWriter) CreateTableMonitorTable() error {
+	database := filter.TiCDCSystemSchema
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		ticdc_cluster_id varchar (255),
+		changefeed varchar(255),
+		table_id bigint(21),
+		checkpoint_ts varchar(18),
+		updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (ticdc_cluster_id, changefeed, table_id)
+	);`
+	query = fmt.Sprintf(query, filter.TableMonitorTable)
+	return w.CreateTable(database, filter.TableMonitorTable, query)
+}
+
 func (w *MysqlWriter) asyncExecAddIndexDDLIfTimeout(event *commonEvent.DDLEvent) error {
 	done := make(chan error, 1)
 	// wait for 2 seconds at most
@@ -690,6 +781,7 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 			zap.Uint64("firstRowReplicatingTs", event.ReplicatingTs),
 			zap.Bool("safeMode", w.cfg.SafeMode))
 
+		event.Rewind()
 		for {
 			row, ok := event.GetNextRow()
 			if !ok {
@@ -717,6 +809,7 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 			if query != "" {
 				dmls.sqls = append(dmls.sqls, query)
 				dmls.values = append(dmls.values, args)
+				dmls.rowCounts = append(dmls.rowCounts, 1)
 			}
 		}
 	}
@@ -726,6 +819,16 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 
 func (w *MysqlWriter) Flush(events []*commonEvent.DMLEvent, workerNum int) error {
 	w.statistics.ObserveRows(events)
+	for _, event := range events {
+		if err := util.VerifyRowChecksum(w.ChangefeedID, event, w.cfg.Integrity, w.statistics); err != nil {
+			return errors.Trace(err)
+		}
+		if w.cfg.ColumnTransformers != nil {
+			if err := w.cfg.ColumnTransformers.Apply(event); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
 	dmls, err := w.prepareDMLs(events)
 	if err != nil {
 		return errors.Trace(err)
@@ -735,10 +838,15 @@ func (w *MysqlWriter) Flush(events []*commonEvent.DMLEvent, workerNum int) error
 	if dmls.rowCount == 0 {
 		return nil
 	}
+	size := dmls.memorySize()
+	w.accountant.Acquire(memquota.MySQLPreparedDMLs, size)
+	defer w.accountant.Release(memquota.MySQLPreparedDMLs, size)
 
 	if !w.cfg.DryRun {
-		if err := w.execDMLWithMaxRetries(dmls); err != nil {
-			return errors.Trace(err)
+		for _, batch := range w.splitDMLs(dmls) {
+			if err := w.execDMLWithMaxRetries(batch); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	} else {
 		w.statistics.RecordBatchExecution(func() (int, int64, error) {
@@ -754,6 +862,41 @@ func (w *MysqlWriter) Flush(events []*commonEvent.DMLEvent, workerNum int) error
 	return nil
 }
 
+// splitDMLs splits the dmls into multiple batches with at most MaxTxnRow rows,
+// each batch is executed in a separate downstream transaction.
+// A single sql is never split even if it carries more than MaxTxnRow rows.
+// The dmls are never split if the atomicity level is table, so the rows of an upstream
+// transaction are always committed in one downstream transaction.
+func (w *MysqlWriter) splitDMLs(dmls *preparedDMLs) []*preparedDMLs {
+	maxTxnRow := w.cfg.MaxTxnRow
+	if !w.cfg.TxnAtomicity.ShouldSplitTxn() || maxTxnRow <= 0 || dmls.rowCount <= maxTxnRow {
+		return []*preparedDMLs{dmls}
+	}
+	batches := make([]*preparedDMLs, 0, (dmls.rowCount+maxTxnRow-1)/maxTxnRow)
+	appendBatch := func(start, end, rows int) {
+		batches = append(batches, &preparedDMLs{
+			sqls:            dmls.sqls[start:end],
+			values:          dmls.values[start:end],
+			rowCounts:       dmls.rowCounts[start:end],
+			rowCount:        rows,
+			approximateSize: dmls.approximateSize * int64(rows) / int64(dmls.rowCount),
+			startTs:         dmls.startTs,
+		})
+	}
+	start, rows := 0, 0
+	for i, count := range dmls.rowCounts {
+		if rows > 0 && rows+count > maxTxnRow {
+			appendBatch(start, i, rows)
+			start, rows = i, 0
+		}
+		rows += count
+	}
+	if start < len(dmls.sqls) {
+		appendBatch(start, len(dmls.sqls), rows)
+	}
+	return batches
+}
+
 func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 	if len(dmls.sqls) != len(dmls.values) {
 		return cerror.ErrUnexpected.FastGenByArgs(fmt.Sprintf("unexpected number of sqls and values, sqls is %s, values is %s", dmls.sqls, dmls.values))
@@ -3,13 +3,16 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/stretchr/testify/require"
 )
@@ -24,7 +27,7 @@ func newTestMysqlWriter(t *testing.T) (*MysqlWriter, *sql.DB, sqlmock.Sqlmock) {
 	}
 	changefeedID := common.ChangefeedID4Test("test", "test")
 	statistics := metrics.NewStatistics(changefeedID, "mysqlSink")
-	writer := NewMysqlWriter(ctx, db, cfg, changefeedID, statistics)
+	writer := NewMysqlWriter(ctx, db, cfg, changefeedID, statistics, nil)
 
 	return writer, db, mock
 }
@@ -68,6 +71,96 @@ func TestMysqlWriter_FlushDML(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Test the huge transaction is split into multiple downstream transactions only
+// when the transaction atomicity is none.
+func TestMysqlWriter_FlushDMLWithTxnAtomicity(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.MaxTxnRow = 2
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int primary key, name varchar(32));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	newEvent := func(id int) *commonEvent.DMLEvent {
+		dmlEvent := helper.DML2Event("test", "t",
+			fmt.Sprintf("insert into t values (%d, 'a')", id),
+			fmt.Sprintf("insert into t values (%d, 'b')", id+1),
+			fmt.Sprintf("insert into t values (%d, 'c')", id+2))
+		dmlEvent.CommitTs = 2
+		dmlEvent.ReplicatingTs = 1
+		return dmlEvent
+	}
+
+	// none atomicity, the transaction is split by MaxTxnRow
+	writer.cfg.TxnAtomicity = config.AtomicityLevel("none")
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "a", 2, "b").
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(3, "c").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{newEvent(1)}, 0))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// table atomicity, the transaction is written in one downstream transaction
+	writer.cfg.TxnAtomicity = config.AtomicityLevel("table")
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(4, "a", 5, "b", 6, "c").
+		WillReturnResult(sqlmock.NewResult(3, 3))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{newEvent(4)}, 0))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// unset atomicity is treated as table atomicity
+	writer.cfg.TxnAtomicity = config.AtomicityLevel("")
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(7, "a", 8, "b", 9, "c").
+		WillReturnResult(sqlmock.NewResult(3, 3))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{newEvent(7)}, 0))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test the dmls are split by the rows carried by the sqls instead of the number of sqls.
+func TestMysqlWriter_SplitDMLsByRowCount(t *testing.T) {
+	writer, db, _ := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.MaxTxnRow = 4
+	writer.cfg.TxnAtomicity = config.AtomicityLevel("none")
+
+	dmls := &preparedDMLs{
+		sqls:            []string{"a", "b", "c", "d", "e"},
+		values:          [][]interface{}{{1}, {2}, {3}, {4}, {5}},
+		rowCounts:       []int{1, 2, 5, 1, 1},
+		rowCount:        10,
+		approximateSize: 100,
+	}
+	batches := writer.splitDMLs(dmls)
+	require.Len(t, batches, 3)
+	require.Equal(t, []string{"a", "b"}, batches[0].sqls)
+	require.Equal(t, 3, batches[0].rowCount)
+	require.Equal(t, int64(30), batches[0].approximateSize)
+	// a sql with more than MaxTxnRow rows is never split
+	require.Equal(t, []string{"c"}, batches[1].sqls)
+	require.Equal(t, 5, batches[1].rowCount)
+	require.Equal(t, []string{"d", "e"}, batches[2].sqls)
+	require.Equal(t, 2, batches[2].rowCount)
+
+	writer.cfg.MaxTxnRow = 10
+	require.Len(t, writer.splitDMLs(dmls), 1)
+}
+
 // Test flush ddl event
 // Ensure the ddl query will be write to the databases
 // and the ddl_ts_v1 table will be updated with the ddl_ts and table_id
@@ -226,3 +319,45 @@ func TestMysqlWriter_RemoveDDLTsTable(t *testing.T) {
 	err := writer.RemoveDDLTsItem()
 	require.NoError(t, err)
 }
+
+func TestMysqlWriter_FlushTableCheckpoints(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+
+	// nothing to write
+	err := writer.FlushTableCheckpoints(nil)
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("USE tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS table_monitor_v1
+		(
+			ticdc_cluster_id varchar (255),
+			changefeed varchar(255),
+			table_id bigint(21),
+			checkpoint_ts varchar(18),
+			updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (ticdc_cluster_id, changefeed, table_id)
+		);`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("INSERT INTO tidb_cdc.table_monitor_v1 (ticdc_cluster_id, changefeed, table_id, checkpoint_ts) VALUES ('default', 'test/test', 1, '10'), ('default', 'test/test', 2, '20') ON DUPLICATE KEY UPDATE checkpoint_ts=VALUES(checkpoint_ts), updated_at=CURRENT_TIMESTAMP;").WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = writer.FlushTableCheckpoints([]util.TableCheckpoint{
+		{TableID: 1, CheckpointTs: 10},
+		{TableID: 2, CheckpointTs: 20},
+	})
+	require.NoError(t, err)
+
+	// the table is only created once
+	mock.ExpectExec("INSERT INTO tidb_cdc.table_monitor_v1 (ticdc_cluster_id, changefeed, table_id, checkpoint_ts) VALUES ('default', 'test/test', 1, '30') ON DUPLICATE KEY UPDATE checkpoint_ts=VALUES(checkpoint_ts), updated_at=CURRENT_TIMESTAMP;").WillReturnResult(sqlmock.NewResult(1, 1))
+	err = writer.FlushTableCheckpoints([]util.TableCheckpoint{{TableID: 1, CheckpointTs: 30}})
+	require.NoError(t, err)
+
+	mock.ExpectExec("DELETE FROM tidb_cdc.table_monitor_v1 WHERE ticdc_cluster_id = 'default' and changefeed = 'test/test'").WillReturnResult(sqlmock.NewResult(1, 1))
+	err = writer.RemoveTableCheckpoints()
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err)
+}
@@ -26,13 +26,25 @@ import (
 )
 
 type preparedDMLs struct {
-	sqls            []string
-	values          [][]interface{}
+	sqls   []string
+	values [][]interface{}
+	// rowCounts is the number of rows carried by each sql,
+	// a batch dml statement carries more than one row.
+	rowCounts       []int
 	rowCount        int
 	approximateSize int64
 	startTs         []uint64
 }
 
+// memorySize returns the approximate memory held by the sqls and args.
+func (d *preparedDMLs) memorySize() int64 {
+	size := d.approximateSize
+	for _, sql := range d.sqls {
+		size += int64(len(sql))
+	}
+	return size
+}
+
 var dmlsPool = sync.Pool{
 	New: func() interface{} {
 		return &preparedDMLs{
@@ -46,6 +58,7 @@ var dmlsPool = sync.Pool{
 func (d *preparedDMLs) reset() {
 	d.sqls = d.sqls[:0]
 	d.values = d.values[:0]
+	d.rowCounts = d.rowCounts[:0]
 	d.startTs = d.startTs[:0]
 	d.rowCount = 0
 	d.approximateSize = 0
@@ -0,0 +1,67 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// VerifyRowChecksum checks the row checksums of the DML event before it is written to the downstream.
+// The checksum of each row is verified against the decoded column values when the row is mounted,
+// and it's calculated again here by the rows to be written, so the corruption after the rows are mounted
+// is also detected. The corrupted rows are logged and counted, an error is returned if the corruption
+// handle level is error, otherwise the rows are still written to the downstream.
+func VerifyRowChecksum(
+	changefeedID commonType.ChangeFeedID,
+	event *commonEvent.DMLEvent,
+	integrityConfig *config.Config,
+	statistics *metrics.Statistics,
+) error {
+	if integrityConfig == nil || !integrityConfig.Enabled() || event.Checksum == nil {
+		return nil
+	}
+	// the rows are decoded in the local time zone by the event service.
+	corrupted, err := event.VerifyChecksum(time.Local)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(corrupted) == 0 {
+		return nil
+	}
+	for _, i := range corrupted {
+		checksum := event.Checksum[i]
+		log.Warn("row checksum mismatch, the data may be corrupted",
+			zap.Stringer("changefeedID", changefeedID),
+			zap.String("table", event.TableInfo.TableName.String()),
+			zap.Uint64("commitTs", event.CommitTs),
+			zap.Int("rowIndex", i),
+			zap.Uint32("current", checksum.Current),
+			zap.Uint32("previous", checksum.Previous))
+	}
+	statistics.RecordChecksumMismatch(len(corrupted))
+	if integrityConfig.ErrorHandle() {
+		return cerror.ErrCorruptedDataMutation.GenWithStackByArgs(
+			changefeedID.Namespace(), changefeedID.Name())
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/pkg/integrity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyRowChecksum(t *testing.T) {
+	changefeedID := common.NewChangeFeedIDWithName("test")
+	statistics := metrics.NewStatistics(changefeedID, "TxnSink")
+	event := &commonEvent.DMLEvent{
+		TableInfo: common.WrapTableInfo(1, "test", &timodel.TableInfo{ID: 1, Name: pmodel.NewCIStr("t")}),
+		Length:    2,
+		RowTypes:  []commonEvent.RowType{commonEvent.RowTypeInsert, commonEvent.RowTypeDelete},
+		Checksum: []*commonEvent.RowChecksum{
+			{Checksum: integrity.Checksum{Current: 1, Version: 1}},
+			{Checksum: integrity.Checksum{Current: 2, Previous: 3, Version: 1, Corrupted: true}},
+		},
+	}
+
+	// the integrity check is disabled
+	require.NoError(t, VerifyRowChecksum(changefeedID, event, nil, statistics))
+	require.NoError(t, VerifyRowChecksum(changefeedID, event, &config.Config{
+		IntegrityCheckLevel:   config.CheckLevelNone,
+		CorruptionHandleLevel: config.CorruptionHandleLevelError,
+	}, statistics))
+
+	require.NoError(t, VerifyRowChecksum(changefeedID, event, &config.Config{
+		IntegrityCheckLevel:   config.CheckLevelCorrectness,
+		CorruptionHandleLevel: config.CorruptionHandleLevelWarn,
+	}, statistics))
+	err := VerifyRowChecksum(changefeedID, event, &config.Config{
+		IntegrityCheckLevel:   config.CheckLevelCorrectness,
+		CorruptionHandleLevel: config.CorruptionHandleLevelError,
+	}, statistics)
+	require.ErrorContains(t, err, "corrupted data mutation")
+
+	// no row is corrupted
+	event.Checksum[1].Corrupted = false
+	require.NoError(t, VerifyRowChecksum(changefeedID, event, &config.Config{
+		IntegrityCheckLevel:   config.CheckLevelCorrectness,
+		CorruptionHandleLevel: config.CorruptionHandleLevelError,
+	}, statistics))
+}
+
+func TestVerifyRowChecksumAfterMounted(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("set global tidb_enable_row_level_checksum = 1")
+	defer helper.Tk().MustExec("set global tidb_enable_row_level_checksum = 0")
+	// the checksum is enabled only for the new sessions
+	helper.Tk().RefreshSession()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	tableInfo := helper.GetTableInfo(job)
+
+	mounter := commonEvent.NewChecksumMounter(time.Local)
+	dmlEvent := commonEvent.NewDMLEvent(common.NewDispatcherID(), tableInfo.TableName.TableID, 1, 2, tableInfo)
+	for _, sql := range []string{"insert into t values (1, 'aaaa')", "insert into t values (2, 'bbbb')"} {
+		rawKV := helper.DML2RawKv("test", "t", sql)[0]
+		require.NoError(t, dmlEvent.AppendRow(rawKV, mounter.DecodeToChunk, nil))
+	}
+	require.Len(t, dmlEvent.Checksum, 2)
+
+	// the event is transferred from the event service to the sink
+	data, err := dmlEvent.Marshal()
+	require.NoError(t, err)
+	event := &commonEvent.DMLEvent{}
+	require.NoError(t, event.Unmarshal(data))
+	require.NoError(t, event.AssembleRows(tableInfo))
+
+	changefeedID := common.NewChangeFeedIDWithName("test")
+	statistics := metrics.NewStatistics(changefeedID, "TxnSink")
+	integrityConfig := &config.Config{
+		IntegrityCheckLevel:   config.CheckLevelCorrectness,
+		CorruptionHandleLevel: config.CorruptionHandleLevelError,
+	}
+	require.NoError(t, VerifyRowChecksum(changefeedID, event, integrityConfig, statistics))
+
+	// the value of the second row is changed after it's mounted
+	chk := chunk.NewChunkWithCapacity(tableInfo.GetFieldSlice(), 2)
+	chk.AppendInt64(0, 1)
+	chk.AppendString(1, "aaaa")
+	chk.AppendInt64(0, 2)
+	chk.AppendString(1, "bbbc")
+	event.Rows.SetCol(1, chk.Column(1))
+	err = VerifyRowChecksum(changefeedID, event, integrityConfig, statistics)
+	require.ErrorContains(t, err, "corrupted data mutation")
+}
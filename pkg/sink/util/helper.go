@@ -327,3 +327,10 @@ func IsMysqlCompatibleBackend(sinkURIStr string) (bool, error) {
 	scheme := sink.GetScheme(sinkURI)
 	return sink.IsMySQLCompatibleScheme(scheme), nil
 }
+
+// TableCheckpoint is the checkpoint of a table in the changefeed,
+// all the data of the table before CheckpointTs has been written to the downstream.
+type TableCheckpoint struct {
+	TableID      int64
+	CheckpointTs uint64
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/log"
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+// gcSafepointUpdateInterval is the minimum interval that CDC can update gc safepoint
+var gcSafepointUpdateInterval = 1 * time.Minute
+
+// ChangefeedGCStatus is the status of a changefeed against the GC safepoint.
+type ChangefeedGCStatus struct {
+	// GCSafePoint is the min service GC safepoint in PD observed by the last update.
+	GCSafePoint uint64
+	// Distance is how far the checkpoint is ahead of the GC safepoint,
+	// it's negative if the data needed by the changefeed is already GCed.
+	Distance time.Duration
+	// HoldBack is how long the changefeed holds back the GC safepoint,
+	// that is the lag of the checkpoint to the PD time.
+	HoldBack time.Duration
+	// TTLRemaining is the duration before the hold back exceeds the GC TTL.
+	TTLRemaining time.Duration
+}
+
+// Manager is an interface for gc manager
+type Manager interface {
+	// TryUpdateGCSafePoint tries to update TiCDC service GC safepoint.
+	// Manager may skip update when it thinks it is too frequent.
+	// Set `forceUpdate` to force Manager update.
+	TryUpdateGCSafePoint(ctx context.Context, checkpointTs uint64, forceUpdate bool) error
+	// GetChangefeedGCStatus returns the status of the changefeed with the checkpointTs
+	// against the GC safepoint.
+	GetChangefeedGCStatus(checkpointTs uint64) ChangefeedGCStatus
+}
+
+type gcManager struct {
+	gcServiceID string
+	// legacyServiceID is the service ID used by the previous versions, its service GC
+	// safepoint is removed after the first successful update under gcServiceID.
+	legacyServiceID string
+	pdClient        pd.Client
+	pdClock         pdutil.Clock
+	gcTTL           int64
+
+	lastUpdatedTime   time.Time
+	lastSucceededTime time.Time
+	lastSafePointTs   atomic.Uint64
+}
+
+// NewManager creates a new Manager, gcTTL is the TTL of the service GC safepoint in seconds.
+// The service GC safepoint of legacyServiceID is removed once the safepoint is kept under
+// gcServiceID, it's skipped if legacyServiceID is empty or equals to gcServiceID.
+func NewManager(gcServiceID, legacyServiceID string, pdClient pd.Client, pdClock pdutil.Clock, gcTTL int64) Manager {
+	if legacyServiceID == gcServiceID {
+		legacyServiceID = ""
+	}
+	return &gcManager{
+		gcServiceID:       gcServiceID,
+		legacyServiceID:   legacyServiceID,
+		pdClient:          pdClient,
+		pdClock:           pdClock,
+		lastSucceededTime: time.Now(),
+		gcTTL:             gcTTL,
+	}
+}
+
+func (m *gcManager) TryUpdateGCSafePoint(
+	ctx context.Context, checkpointTs uint64, forceUpdate bool,
+) error {
+	if time.Since(m.lastUpdatedTime) < gcSafepointUpdateInterval && !forceUpdate {
+		return nil
+	}
+	m.lastUpdatedTime = time.Now()
+
+	actual, err := SetServiceGCSafepoint(
+		ctx, m.pdClient, m.gcServiceID, m.gcTTL, checkpointTs)
+	if err != nil {
+		log.Warn("updateGCSafePoint failed",
+			zap.Uint64("safePointTs", checkpointTs),
+			zap.Error(err))
+		if time.Since(m.lastSucceededTime) >= time.Second*time.Duration(m.gcTTL) {
+			return cerrors.ErrUpdateServiceSafepointFailed.Wrap(err)
+		}
+		return nil
+	}
+	if actual == checkpointTs {
+		log.Info("update gc safe point success", zap.Uint64("gcSafePointTs", checkpointTs))
+	}
+	if actual > checkpointTs {
+		log.Warn("update gc safe point failed, the gc safe point is larger than checkpointTs",
+			zap.Uint64("actual", actual), zap.Uint64("checkpointTs", checkpointTs))
+	}
+	m.lastSafePointTs.Store(actual)
+	m.lastSucceededTime = time.Now()
+	m.removeLegacyGCSafePoint(ctx)
+	return nil
+}
+
+// removeLegacyGCSafePoint removes the service GC safepoint of the legacy service ID,
+// it's retried on the next update if the removal fails.
+func (m *gcManager) removeLegacyGCSafePoint(ctx context.Context) {
+	if m.legacyServiceID == "" {
+		return
+	}
+	if err := RemoveServiceGCSafepoint(ctx, m.pdClient, m.legacyServiceID); err != nil {
+		log.Warn("remove the legacy service gc safepoint failed, retry later",
+			zap.String("serviceID", m.legacyServiceID), zap.Error(err))
+		return
+	}
+	log.Info("remove the legacy service gc safepoint success",
+		zap.String("serviceID", m.legacyServiceID))
+	m.legacyServiceID = ""
+}
+
+func (m *gcManager) GetChangefeedGCStatus(checkpointTs uint64) ChangefeedGCStatus {
+	checkpointTime := oracle.GetTimeFromTS(checkpointTs)
+	status := ChangefeedGCStatus{GCSafePoint: m.lastSafePointTs.Load()}
+	if status.GCSafePoint > 0 {
+		status.Distance = checkpointTime.Sub(oracle.GetTimeFromTS(status.GCSafePoint))
+	}
+	status.HoldBack = m.pdClock.CurrentTime().Sub(checkpointTime)
+	if status.HoldBack < 0 {
+		status.HoldBack = 0
+	}
+	status.TTLRemaining = time.Duration(m.gcTTL)*time.Second - status.HoldBack
+	return status
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/pkg/pdutil"
+	tigc "github.com/pingcap/tiflow/pkg/txnutil/gc"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestGCManager(t *testing.T) {
+	now := time.Now()
+	// another service holds the gc safepoint at 10 minutes ago
+	minServiceGCTs := oracle.GoTimeToTS(now.Add(-10 * time.Minute))
+	var ttl int64
+	pdClient := &tigc.MockPDClient{
+		UpdateServiceGCSafePointFunc: func(_ context.Context, serviceID string, gcTTL int64, safePoint uint64) (uint64, error) {
+			require.Equal(t, "ticdc-test", serviceID)
+			ttl = gcTTL
+			return min(minServiceGCTs, safePoint), nil
+		},
+	}
+	m := NewManager("ticdc-test", "", pdClient, pdutil.NewClock4Test(), 3600)
+
+	checkpointTs := oracle.GoTimeToTS(now.Add(-5 * time.Minute))
+	require.NoError(t, m.TryUpdateGCSafePoint(context.Background(), checkpointTs-1, false))
+	require.Equal(t, int64(3600), ttl)
+
+	status := m.GetChangefeedGCStatus(checkpointTs)
+	require.Equal(t, minServiceGCTs, status.GCSafePoint)
+	require.InDelta(t, 5*time.Minute, status.Distance, float64(time.Second))
+	require.InDelta(t, 5*time.Minute, status.HoldBack, float64(time.Second))
+	require.InDelta(t, 55*time.Minute, status.TTLRemaining, float64(time.Second))
+
+	// the checkpoint is behind the gc safepoint
+	status = m.GetChangefeedGCStatus(oracle.GoTimeToTS(now.Add(-2 * time.Hour)))
+	require.Less(t, status.Distance, time.Duration(0))
+	require.Less(t, status.TTLRemaining, time.Duration(0))
+}
+
+func TestGCManagerRemoveLegacySafePoint(t *testing.T) {
+	var removed []string
+	failed := true
+	pdClient := &tigc.MockPDClient{
+		UpdateServiceGCSafePointFunc: func(_ context.Context, serviceID string, gcTTL int64, safePoint uint64) (uint64, error) {
+			if serviceID == "ticdc-test" {
+				if failed {
+					return 0, errors.New("mock error")
+				}
+				return safePoint, nil
+			}
+			require.Equal(t, "legacy", serviceID)
+			require.Zero(t, gcTTL)
+			removed = append(removed, serviceID)
+			return safePoint, nil
+		},
+	}
+	m := NewManager("ticdc-test", "legacy", pdClient, pdutil.NewClock4Test(), 3600)
+	ctx := context.Background()
+	checkpointTs := oracle.GoTimeToTS(time.Now())
+
+	// the legacy safepoint is kept if the update under the new service ID fails
+	require.NoError(t, m.TryUpdateGCSafePoint(ctx, checkpointTs, true))
+	require.Empty(t, removed)
+
+	// the legacy safepoint is removed only once after the update succeeds
+	failed = false
+	require.NoError(t, m.TryUpdateGCSafePoint(ctx, checkpointTs, true))
+	require.NoError(t, m.TryUpdateGCSafePoint(ctx, checkpointTs, true))
+	require.Equal(t, []string{"legacy"}, removed)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/ticdc/coordinator/changefeed"
 	logcoordinator "github.com/pingcap/ticdc/logservice/coordinator"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/etcd"
 	"github.com/pingcap/tiflow/cdc/model"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -126,8 +127,11 @@ func (e *elector) campaignCoordinator(ctx context.Context) error {
 
 		co := coordinator.New(e.svr.info,
 			e.svr.pdClient, e.svr.PDClock, changefeed.NewEtcdBackend(e.svr.EtcdClient),
+			e.svr.EtcdClient.GetGCServiceID(),
+			// the cluster ID was used as the gc service ID by the previous versions
 			e.svr.EtcdClient.GetClusterID(),
-			coordinatorVersion, 10000, time.Minute)
+			coordinatorVersion, 10000, time.Minute,
+			config.GetGlobalServerConfig().Debug.Scheduler.BalanceStrategy)
 		e.svr.setCoordinator(co)
 		err = co.Run(ctx)
 		e.svr.coordinator.AsyncStop()
@@ -126,9 +126,15 @@ func (c *server) initialize(ctx context.Context) error {
 		appcontext.MessageCenter,
 		appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter).OnNodeChanges)
 
+	// the source id is used to mark the data written by this cluster in bdr mode
+	sourceID, err := pdutil.GetSourceID(ctx, c.pdClient)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	conf := config.GetGlobalServerConfig()
 	schemaStore := schemastore.New(ctx, conf.DataDir, c.pdClient, c.RegionCache, c.PDClock, c.KVStorage)
-	eventStore := eventstore.New(ctx, conf.DataDir, c.pdClient, c.RegionCache, c.PDClock, c.KVStorage)
+	eventStore := eventstore.New(ctx, conf.DataDir, c.pdClient, c.RegionCache, c.PDClock, c.KVStorage, conf.Debug.EventStore)
 	eventService := eventservice.New(eventStore, schemaStore)
 	c.subModules = []common.SubModule{
 		nodeManager,
@@ -137,7 +143,7 @@ func (c *server) initialize(ctx context.Context) error {
 		NewHttpServer(c, c.tcpServer.HTTP1Listener()),
 		NewGrpcServer(c.tcpServer.GrpcListener()),
 		maintainer.NewMaintainerManager(c.info, conf.Debug.Scheduler,
-			c.pdAPIClient, c.pdClient, c.RegionCache),
+			c.pdAPIClient, c.pdClient, c.RegionCache, sourceID),
 		eventStore,
 		eventService,
 	}
@@ -253,6 +259,10 @@ func (c *server) GetPdClient() pd.Client {
 	return c.pdClient
 }
 
+func (c *server) GetKVStorage() kv.Storage {
+	return c.KVStorage
+}
+
 // GetCoordinatorInfo return the controller server info of current TiCDC cluster
 func (c *server) GetCoordinatorInfo(ctx context.Context) (*node.Info, error) {
 	_, captureInfos, err := c.EtcdClient.GetCaptures(ctx)
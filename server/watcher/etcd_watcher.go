@@ -48,11 +48,16 @@ func NewEtcdWatcher(etcdClient etcd.CDCEtcdClient, session *concurrency.Session,
 func (w *EtcdWatcher) RunEtcdWorker(
 	ctx context.Context,
 	reactor tiorchestrator.Reactor,
-	reactorState *tiorchestrator.GlobalReactorState,
+	reactorState tiorchestrator.ReactorState,
 	timerInterval time.Duration,
 ) error {
 	log.Info("start to run etcd worker", zap.String("role", w.role))
-	reactorState.Role = w.role
+	switch state := reactorState.(type) {
+	case *tiorchestrator.GlobalReactorState:
+		state.Role = w.role
+	case *nodeReactorState:
+		state.Role = w.role
+	}
 	etcdWorker, err := orchestrator.NewEtcdWorker(w.etcdClient,
 		w.baseKey, reactor, reactorState, &migrate.NoOpMigrator{})
 	if err != nil {
@@ -15,6 +15,7 @@ package watcher
 
 import (
 	"context"
+	"maps"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"github.com/pingcap/tiflow/pkg/orchestrator/util"
 	"go.etcd.io/etcd/client/v3/concurrency"
 )
 
@@ -70,7 +72,14 @@ func (c *NodeManager) Tick(
 	_ context.Context,
 	raw orchestrator.ReactorState,
 ) (orchestrator.ReactorState, error) {
-	state := raw.(*orchestrator.GlobalReactorState)
+	var state *nodeReactorState
+	switch s := raw.(type) {
+	case *nodeReactorState:
+		state = s
+	case *orchestrator.GlobalReactorState:
+		// the state without labels
+		state = &nodeReactorState{GlobalReactorState: s}
+	}
 	// find changes
 	changed := false
 	allNodes := make(map[node.ID]*node.Info, len(state.Captures))
@@ -83,10 +92,21 @@ func (c *NodeManager) Tick(
 	}
 
 	for _, capture := range state.Captures {
-		if _, exist := oldMap[node.ID(capture.ID)]; !exist {
+		old, exist := oldMap[node.ID(capture.ID)]
+		if !exist {
+			changed = true
+		}
+		info := node.CaptureInfoToNodeInfo(capture)
+		info.Labels = state.labels[capture.ID]
+		if exist && !maps.Equal(old.Labels, info.Labels) {
 			changed = true
 		}
-		allNodes[node.ID(capture.ID)] = node.CaptureInfoToNodeInfo(capture)
+		allNodes[node.ID(capture.ID)] = info
+	}
+	for captureID := range state.labels {
+		if _, exist := state.Captures[captureID]; !exist {
+			delete(state.labels, captureID)
+		}
 	}
 	c.nodes.Store(&allNodes)
 	if changed {
@@ -98,7 +118,7 @@ func (c *NodeManager) Tick(
 			handler(allNodes)
 		}
 	}
-	return state, nil
+	return raw, nil
 }
 
 // GetAliveNodes get all alive captures, the caller mustn't modify the returned map
@@ -115,10 +135,50 @@ func (c *NodeManager) Run(ctx context.Context) error {
 		"capture-manager")
 
 	return watcher.RunEtcdWorker(ctx, c,
-		orchestrator.NewGlobalState(c.etcdClient.GetClusterID(),
+		newNodeReactorState(c.etcdClient.GetClusterID(),
 			cfg.CaptureSessionTTL), time.Millisecond*50)
 }
 
+// nodeReactorState is the GlobalReactorState which also keeps the labels of the nodes,
+// the labels are dropped by the GlobalReactorState since model.CaptureInfo has no such field.
+type nodeReactorState struct {
+	*orchestrator.GlobalReactorState
+	labels map[model.CaptureID]map[string]string
+}
+
+func newNodeReactorState(clusterID string, captureSessionTTL int) *nodeReactorState {
+	return &nodeReactorState{
+		GlobalReactorState: orchestrator.NewGlobalState(clusterID, captureSessionTTL),
+		labels:             make(map[model.CaptureID]map[string]string),
+	}
+}
+
+// Update implements the ReactorState interface
+func (s *nodeReactorState) Update(key util.EtcdKey, value []byte, isInit bool) error {
+	if err := s.GlobalReactorState.Update(key, value, isInit); err != nil {
+		return err
+	}
+	k := new(etcd.CDCKey)
+	if err := k.Parse(s.ClusterID, key.String()); err != nil || k.Tp != etcd.CDCKeyTypeCapture {
+		return nil
+	}
+	// the removed capture is kept by the GlobalReactorState for a while,
+	// so its labels are pruned in the Tick.
+	if value == nil {
+		return nil
+	}
+	var info node.Info
+	if err := info.Unmarshal(value); err != nil {
+		return err
+	}
+	if len(info.Labels) == 0 {
+		delete(s.labels, k.CaptureID)
+		return nil
+	}
+	s.labels[k.CaptureID] = info.Labels
+	return nil
+}
+
 func (c *NodeManager) RegisterNodeChangeHandler(name node.ID, handler NodeChangeHandler) {
 	c.nodeChangeHandlers.Lock()
 	defer c.nodeChangeHandlers.Unlock()
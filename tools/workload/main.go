@@ -14,16 +14,19 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"flag"
 	"fmt"
 	"math/rand"
+	"os"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"workload/schema"
+	"workload/verify"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/pingcap/errors"
@@ -50,6 +53,9 @@ var (
 	totalCount uint64
 	total      uint64
 	totalError uint64
+	totalDDL   uint64
+	// totalDDLError is the number of the failed ddls, counted apart from the dml errors
+	totalDDLError uint64
 
 	workloadType string
 
@@ -60,17 +66,31 @@ var (
 	largeRowSize  int
 	largeRowRatio float64
 
+	ddlQps         int
+	partitionCount int
+
 	action              string
 	percentageForUpdate int
 
 	dbNum    int
 	dbPrefix string
+
+	verifyCfg          verify.Config
+	enableVerify       bool
+	kafkaAddrs         string
+	downstreamHost     string
+	downstreamPort     int
+	downstreamUser     string
+	downstreamPassword string
 )
 
 const (
 	bank     = "bank"
 	sysbench = "sysbench"
 	largeRow = "large_row"
+	ddlStorm = "ddl_storm"
+	// partitionTable is the workload of tables with a large number of partitions
+	partitionTable = "partition_table"
 )
 
 func init() {
@@ -84,7 +104,7 @@ func init() {
 	flag.IntVar(&percentageForUpdate, "percentage-for-update", 0, "percentage for update: [0, 100]")
 	flag.BoolVar(&skipCreateTable, "skip-create-table", false, "do not create tables")
 	flag.StringVar(&action, "action", "prepare", "action of the workload: [prepare, insert, update, delete, write, cleanup]")
-	flag.StringVar(&workloadType, "workload-type", "sysbench", "workload type: [bank, sysbench, express, common, one, bigtable, large_row, wallet]")
+	flag.StringVar(&workloadType, "workload-type", "sysbench", "workload type: [bank, sysbench, express, common, one, bigtable, large_row, wallet, ddl_storm, partition_table]")
 	flag.StringVar(&dbHost, "database-host", "127.0.0.1", "database host")
 	flag.StringVar(&dbUser, "database-user", "root", "database user")
 	flag.StringVar(&dbPassword, "database-password", "", "database password")
@@ -97,6 +117,24 @@ func init() {
 	flag.IntVar(&rowSize, "row-size", 10240, "the size of each row")
 	flag.IntVar(&largeRowSize, "large-row-size", 1024*1024, "the size of the large row")
 	flag.Float64Var(&largeRowRatio, "large-ratio", 0.0, "large row ratio in the each transaction")
+	// For ddl_storm and partition_table workload
+	flag.IntVar(&ddlQps, "ddl-qps", 1, "the number of ddls executed per second, 0 means no ddl")
+	flag.IntVar(&partitionCount, "partition-count", 1024, "the partition count of each table of the partition_table workload")
+	// For verifying the data replicated by the changefeed after the workload is done
+	flag.BoolVar(&enableVerify, "verify", false, "verify the data replicated by the changefeed after the workload is done, exit with 1 if failed")
+	flag.StringVar(&verifyCfg.Mode, "verify-mode", verify.ModeCheckpoint, "verify mode: [checkpoint, syncpoint], checkpoint mode requires no other writes to the upstream during verifying")
+	flag.DurationVar(&verifyCfg.Timeout, "verify-timeout", 10*time.Minute, "the timeout of waiting for the changefeed and comparing the data")
+	flag.IntVar(&verifyCfg.MaxDiffRows, "verify-max-diff-rows", 10, "the max number of different rows reported for each table")
+	flag.StringVar(&verifyCfg.CDCAddr, "cdc-addr", "127.0.0.1:8300", "the address of the TiCDC server")
+	flag.StringVar(&verifyCfg.ChangefeedID, "changefeed-id", "", "the id of the changefeed to verify, in the default namespace")
+	flag.StringVar(&verifyCfg.DownstreamType, "downstream-type", verify.DownstreamMySQL, "downstream type: [mysql, kafka]")
+	flag.StringVar(&downstreamHost, "downstream-host", "127.0.0.1", "downstream database host")
+	flag.IntVar(&downstreamPort, "downstream-port", 3306, "downstream database port")
+	flag.StringVar(&downstreamUser, "downstream-user", "root", "downstream database user")
+	flag.StringVar(&downstreamPassword, "downstream-password", "", "downstream database password")
+	flag.StringVar(&kafkaAddrs, "kafka-addr", "127.0.0.1:9092", "the addresses of the kafka brokers, separated by comma")
+	flag.StringVar(&verifyCfg.KafkaTopic, "kafka-topic", "", "the kafka topic of the changefeed")
+	flag.StringVar(&verifyCfg.KafkaProtocol, "kafka-protocol", "canal-json", "the protocol of the kafka messages: [canal-json]")
 	flag.Parse()
 }
 
@@ -149,6 +187,10 @@ func main() {
 	case largeRow:
 		fmt.Println("use large_row workload")
 		workload = schema.NewLargeRowWorkload(rowSize, largeRowSize, largeRowRatio)
+	case ddlStorm:
+		workload = schema.NewDDLStormWorkload()
+	case partitionTable:
+		workload = schema.NewPartitionTableWorkload(partitionCount)
 	default:
 		log.Panic("unsupported workload type", zap.String("workload", workloadType))
 	}
@@ -173,6 +215,7 @@ func main() {
 			}
 			group.Wait()
 		}
+		runVerify(dbs)
 		return
 	}
 
@@ -182,8 +225,18 @@ func main() {
 
 	log.Info("start running workload",
 		zap.String("workload_type", workloadType), zap.Int("rps", rps), zap.Float64("large-ratio", largeRowRatio),
-		zap.Int("qps", qps), zap.String("action", action),
+		zap.Int("qps", qps), zap.Int("ddlQps", ddlQps), zap.String("action", action),
 	)
+	// the ddls run until the dmls are done
+	ddlStop := make(chan struct{})
+	ddlGroup := &sync.WaitGroup{}
+	if ddlWorkload, ok := workload.(schema.DDLWorkload); ok && ddlQps > 0 {
+		ddlGroup.Add(1)
+		go func() {
+			defer ddlGroup.Done()
+			doDDL(dbs, ddlWorkload, ddlStop)
+		}()
+	}
 	if action == "insert" || action == "write" {
 		group.Add(qpsForInsert)
 		for i := 0; i < qpsForInsert; i++ {
@@ -210,11 +263,63 @@ func main() {
 	}
 
 	group.Wait()
+	close(ddlStop)
+	ddlGroup.Wait()
+	runVerify(dbs)
 	for _, db := range dbs {
 		db.Close()
 	}
 }
 
+// runVerify compares the data of all databases of the workload between upstream and downstream,
+// and exits the process with 1 if they are different.
+func runVerify(dbs []*sql.DB) {
+	if !enableVerify {
+		return
+	}
+	if verifyCfg.ChangefeedID == "" {
+		log.Panic("changefeed-id is required to verify")
+	}
+	schemas := []string{dbName}
+	if dbPrefix != "" {
+		schemas = schemas[:0]
+		for i := 0; i < dbNum; i++ {
+			schemas = append(schemas, fmt.Sprintf("%s%d", dbPrefix, i+1))
+		}
+	}
+
+	var downstream *sql.DB
+	switch verifyCfg.DownstreamType {
+	case verify.DownstreamMySQL:
+		db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/?charset=utf8mb4&parseTime=True&loc=Local&maxAllowedPacket=1073741824", downstreamUser, downstreamPassword, downstreamHost, downstreamPort))
+		if err != nil {
+			log.Panic("create the downstream sql client failed", zap.Error(err))
+		}
+		defer db.Close()
+		downstream = db
+	case verify.DownstreamKafka:
+		if verifyCfg.KafkaTopic == "" {
+			log.Panic("kafka-topic is required to verify kafka downstream")
+		}
+		verifyCfg.KafkaAddrs = strings.Split(kafkaAddrs, ",")
+	default:
+		log.Panic("unsupported downstream type", zap.String("downstreamType", verifyCfg.DownstreamType))
+	}
+
+	report, err := verify.Run(context.Background(), &verifyCfg, dbs[0], downstream, schemas)
+	if err != nil {
+		log.Error("verify failed", zap.Error(err))
+		fmt.Printf("verify result: FAIL, error: %s\n", err)
+		os.Exit(1)
+	}
+	report.Print(os.Stdout)
+	if !report.Passed() {
+		log.Error("verify failed, upstream and downstream data are different")
+		os.Exit(1)
+	}
+	log.Info("verify passed")
+}
+
 // initTables create tables if not exists
 func initTables(db *sql.DB, workload schema.Workload) error {
 	var tableNum atomic.Int32
@@ -308,6 +413,30 @@ func doInsert(dbs []*sql.DB, workload schema.Workload) {
 	}
 }
 
+func doDDL(dbs []*sql.DB, workload schema.DDLWorkload, stop chan struct{}) {
+	// the interval is 0 if the qps is larger than 1e9, which panics the ticker
+	interval := max(time.Second/time.Duration(ddlQps), time.Nanosecond)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+		i := rand.Intn(dbNum)
+		j := rand.Intn(tableCount) + tableStartIndex
+		for _, ddl := range workload.BuildDDLSql(j) {
+			if _, err := dbs[i].Exec(ddl); err != nil {
+				log.Info("ddl error", zap.Error(err), zap.String("sql", ddl))
+				atomic.AddUint64(&totalDDLError, 1)
+				break
+			}
+		}
+		atomic.AddUint64(&totalDDL, 1)
+	}
+}
+
 func exceInsert(db *sql.DB, sql string, workload schema.Workload, n int) error {
 	_, err := db.Exec(sql)
 	if err != nil {
@@ -341,6 +470,8 @@ func printTPS() {
 		log.Info("metric",
 			zap.Uint64("total", total),
 			zap.Uint64("totalErr", totalError),
+			zap.Uint64("totalDDL", atomic.LoadUint64(&totalDDL)),
+			zap.Uint64("totalDDLErr", atomic.LoadUint64(&totalDDLError)),
 			zap.Float64("qps", qps),
 			zap.Float64("errQps", errQps),
 			zap.Float64("tps", qps*float64(rps)),
@@ -0,0 +1,93 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+const createDDLStormTable = `
+create table if not exists ddl_storm_%d (
+id bigint not null,
+k bigint not null default '0',
+c varchar(64) not null default '',
+primary key (id)
+)
+`
+
+// ddlStormColumns are the columns added and dropped by the ddl storm workload,
+// the dmls never touch them, so they are valid no matter which ddls are executed.
+var ddlStormColumns = []string{"col_a", "col_b", "col_c", "col_d"}
+
+// DDLStormWorkload runs frequent ddls, such as add / drop column, add / drop index,
+// truncate table and rename table, interleaved with dmls on the same tables.
+type DDLStormWorkload struct{}
+
+func NewDDLStormWorkload() DDLWorkload {
+	return &DDLStormWorkload{}
+}
+
+func getDDLStormTableName(n int) string {
+	return fmt.Sprintf("ddl_storm_%d", n)
+}
+
+// BuildCreateTableStatement returns the create-table sql of the table n
+func (d *DDLStormWorkload) BuildCreateTableStatement(n int) string {
+	return fmt.Sprintf(createDDLStormTable, n)
+}
+
+// BuildInsertSql returns the insert sql statement of the tableN, insert rowCount records
+func (d *DDLStormWorkload) BuildInsertSql(tableN int, rowCount int) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("insert into %s (id, k, c) values", getDDLStormTableName(tableN)))
+	for r := 0; r < rowCount; r++ {
+		if r > 0 {
+			buf.WriteString(",")
+		}
+		n := rand.Int63()
+		buf.WriteString(fmt.Sprintf("(%d, %d, 'abcdefghijklmnopqrstuvwxyz')", n, n))
+	}
+	return buf.String()
+}
+
+// BuildUpdateSql return the update sql statement based on the update option
+func (d *DDLStormWorkload) BuildUpdateSql(opt UpdateOption) string {
+	return fmt.Sprintf("update %s set k = k + 1 where id >= %d order by id limit %d",
+		getDDLStormTableName(opt.Table), rand.Int63n(math.MaxInt64/2), opt.RowCount)
+}
+
+// BuildDDLSql returns a random ddl of the table n, the ddls are idempotent
+// so they can be executed in any order.
+func (d *DDLStormWorkload) BuildDDLSql(tableN int) []string {
+	tableName := getDDLStormTableName(tableN)
+	column := ddlStormColumns[rand.Intn(len(ddlStormColumns))]
+	switch rand.Intn(6) {
+	case 0:
+		return []string{fmt.Sprintf("alter table %s add column if not exists %s varchar(32) default 'ddl_storm'", tableName, column)}
+	case 1:
+		return []string{fmt.Sprintf("alter table %s drop column if exists %s", tableName, column)}
+	case 2:
+		return []string{fmt.Sprintf("alter table %s add index if not exists k_%d (k)", tableName, tableN)}
+	case 3:
+		return []string{fmt.Sprintf("alter table %s drop index if exists k_%d", tableName, tableN)}
+	case 4:
+		return []string{fmt.Sprintf("truncate table %s", tableName)}
+	default:
+		// rename the table and rename it back in one statement, so the dmls never see a missing table
+		return []string{fmt.Sprintf("rename table %s to %s_renamed, %s_renamed to %s", tableName, tableName, tableName, tableName)}
+	}
+}
@@ -22,6 +22,14 @@ type Workload interface {
 	BuildUpdateSql(opt UpdateOption) string
 }
 
+// DDLWorkload is a workload which also generates DDLs,
+// the DDLs are executed concurrently with the DMLs of the workload.
+type DDLWorkload interface {
+	Workload
+	// BuildDDLSql returns the ddl statements of the table n, they should be executed in order
+	BuildDDLSql(tableN int) []string
+}
+
 type UpdateOption struct {
 	Table           int
 	RowCount        int
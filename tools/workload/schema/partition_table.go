@@ -0,0 +1,94 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// PartitionTableWorkload writes tables with a large number of range partitions,
+// the rows are spread over all partitions and the ddls truncate random partitions.
+type PartitionTableWorkload struct {
+	partitionCount int
+	// the range of id in each partition
+	interval int64
+}
+
+func NewPartitionTableWorkload(partitionCount int) DDLWorkload {
+	if partitionCount <= 0 {
+		partitionCount = 1
+	}
+	return &PartitionTableWorkload{
+		partitionCount: partitionCount,
+		interval:       math.MaxInt64 / int64(partitionCount),
+	}
+}
+
+func getPartitionTableName(n int) string {
+	return fmt.Sprintf("partition_table_%d", n)
+}
+
+// BuildCreateTableStatement returns the create-table sql of the table n
+func (p *PartitionTableWorkload) BuildCreateTableStatement(n int) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf(`create table if not exists %s (
+id bigint not null,
+k bigint not null default '0',
+c char(30) not null default '',
+pad char(20) not null default '',
+primary key (id)
+) partition by range (id) (`, getPartitionTableName(n)))
+	for i := 0; i < p.partitionCount-1; i++ {
+		buf.WriteString(fmt.Sprintf("partition p%d values less than (%d),", i, int64(i+1)*p.interval))
+	}
+	buf.WriteString(fmt.Sprintf("partition p%d values less than maxvalue)", p.partitionCount-1))
+
+	log.Info("partition table workload, create the table",
+		zap.Int("table", n), zap.Int("partitionCount", p.partitionCount))
+	return buf.String()
+}
+
+// BuildInsertSql returns the insert sql statement of the tableN, insert rowCount records
+func (p *PartitionTableWorkload) BuildInsertSql(tableN int, rowCount int) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("insert into %s (id, k, c, pad) values", getPartitionTableName(tableN)))
+	for r := 0; r < rowCount; r++ {
+		if r > 0 {
+			buf.WriteString(",")
+		}
+		n := rand.Int63()
+		buf.WriteString(fmt.Sprintf("(%d, %d, 'abcdefghijklmnopsrstuvwxyzabcd', 'abcdefghijklmnopsrst')", n, n))
+	}
+	return buf.String()
+}
+
+// BuildUpdateSql return the update sql statement based on the update option
+func (p *PartitionTableWorkload) BuildUpdateSql(opt UpdateOption) string {
+	// update the rows of a random partition
+	start := rand.Int63n(int64(p.partitionCount)) * p.interval
+	return fmt.Sprintf("update %s set k = k + 1 where id >= %d order by id limit %d",
+		getPartitionTableName(opt.Table), start, opt.RowCount)
+}
+
+// BuildDDLSql returns the ddl to truncate a random partition of the table n
+func (p *PartitionTableWorkload) BuildDDLSql(tableN int) []string {
+	return []string{fmt.Sprintf("alter table %s truncate partition p%d",
+		getPartitionTableName(tableN), rand.Intn(p.partitionCount))}
+}
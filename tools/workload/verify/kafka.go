@@ -0,0 +1,234 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+const protocolCanalJSON = "canal-json"
+
+// canalJSONMessage is the part of the canal-json message used to rebuild the table image.
+type canalJSONMessage struct {
+	Database string               `json:"database"`
+	Table    string               `json:"table"`
+	IsDDL    bool                 `json:"isDdl"`
+	Type     string               `json:"type"`
+	Data     []map[string]*string `json:"data"`
+	Old      []map[string]*string `json:"old"`
+	// ExecutionTime is the commit time of the transaction in milliseconds.
+	ExecutionTime int64 `json:"es"`
+	// TiDB is set only if the tidb extension is enabled.
+	TiDB *struct {
+		CommitTs uint64 `json:"commitTs"`
+	} `json:"_tidb"`
+}
+
+// commitTs returns the commit ts of the message, only the physical part of it is
+// available if the tidb extension is disabled.
+func (m *canalJSONMessage) commitTs() uint64 {
+	if m.TiDB != nil && m.TiDB.CommitTs != 0 {
+		return m.TiDB.CommitTs
+	}
+	return uint64(m.ExecutionTime) << 18
+}
+
+// consumeKafka consumes all messages of the topic written before now, and rebuilds the rows of the tables
+// by replaying the row changes. The result is keyed by the full name of the table and then the row key.
+// It must be called after the checkpoint of the changefeed is reached, so all changes are in the topic.
+func consumeKafka(ctx context.Context, cfg *Config, tables []*table) (map[string]map[string][]string, error) {
+	if cfg.KafkaProtocol != protocolCanalJSON {
+		return nil, errors.Errorf("kafka protocol %s is not supported, only %s is supported", cfg.KafkaProtocol, protocolCanalJSON)
+	}
+	tableMap := make(map[string]*table, len(tables))
+	result := make(map[string]map[string][]string, len(tables))
+	for _, t := range tables {
+		tableMap[t.fullName()] = t
+		result[t.fullName()] = make(map[string][]string)
+	}
+
+	client, err := sarama.NewClient(cfg.KafkaAddrs, sarama.NewConfig())
+	if err != nil {
+		return nil, errors.Annotate(err, "create kafka client failed")
+	}
+	defer client.Close()
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer consumer.Close()
+
+	partitions, err := client.Partitions(cfg.KafkaTopic)
+	if err != nil {
+		return nil, errors.Annotatef(err, "get partitions of topic %s failed", cfg.KafkaTopic)
+	}
+	var (
+		total    int
+		messages []*canalJSONMessage
+	)
+	for _, partition := range partitions {
+		count, err := consumePartition(ctx, client, consumer, cfg.KafkaTopic, partition, tableMap, &messages)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		total += count
+	}
+	replayCanalJSON(messages, tableMap, result)
+	log.Info("consume kafka topic done", zap.String("topic", cfg.KafkaTopic),
+		zap.Int("partitions", len(partitions)), zap.Int("messages", total),
+		zap.Int("rowMessages", len(messages)))
+	return result, nil
+}
+
+// replayCanalJSON applies the row changes in the order of their commit ts. The changes of a row
+// may be sent to different partitions, e.g. the primary key is updated, so the messages of
+// all partitions are sorted together. The order in a partition is kept for the same commit ts.
+func replayCanalJSON(messages []*canalJSONMessage, tables map[string]*table, result map[string]map[string][]string) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].commitTs() < messages[j].commitTs()
+	})
+	for _, msg := range messages {
+		applyCanalJSON(msg, tables, result)
+	}
+}
+
+// consumePartition consumes the partition until the high watermark got at the beginning,
+// the row changes of the tables are appended to the messages.
+func consumePartition(
+	ctx context.Context, client sarama.Client, consumer sarama.Consumer, topic string, partition int32,
+	tables map[string]*table, messages *[]*canalJSONMessage,
+) (int, error) {
+	oldest, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	newest, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if newest <= oldest {
+		return 0, nil
+	}
+	pc, err := consumer.ConsumePartition(topic, partition, oldest)
+	if err != nil {
+		return 0, errors.Annotatef(err, "consume partition %d failed", partition)
+	}
+	defer pc.Close()
+
+	count := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return count, errors.Annotatef(ctx.Err(), "consume partition %d", partition)
+		case err := <-pc.Errors():
+			return count, errors.Trace(err)
+		case msg := <-pc.Messages():
+			count++
+			canalMsg, err := decodeCanalJSON(msg.Value)
+			if err != nil {
+				return count, errors.Annotatef(err, "decode message at partition %d offset %d", partition, msg.Offset)
+			}
+			if _, ok := tables[canalMsg.Database+"."+canalMsg.Table]; ok && !canalMsg.IsDDL {
+				*messages = append(*messages, canalMsg)
+			}
+			if msg.Offset >= newest-1 {
+				return count, nil
+			}
+		}
+	}
+}
+
+func decodeCanalJSON(value []byte) (*canalJSONMessage, error) {
+	msg := &canalJSONMessage{}
+	if err := json.Unmarshal(value, msg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return msg, nil
+}
+
+// applyCanalJSON applies the row changes in the message to the rows of the table.
+func applyCanalJSON(msg *canalJSONMessage, tables map[string]*table, result map[string]map[string][]string) {
+	if msg.IsDDL {
+		return
+	}
+	t, ok := tables[msg.Database+"."+msg.Table]
+	if !ok {
+		return
+	}
+	rows := result[t.fullName()]
+	switch strings.ToUpper(msg.Type) {
+	case "INSERT":
+		for _, data := range msg.Data {
+			row := t.canalJSONRow(data)
+			rows[t.rowKey(row)] = row
+		}
+	case "UPDATE":
+		for i, data := range msg.Data {
+			row := t.canalJSONRow(data)
+			// the old values only contain the updated columns, remove the old row if the key is changed
+			if i < len(msg.Old) {
+				oldRow := make([]string, len(row))
+				copy(oldRow, row)
+				for j, column := range t.columns {
+					if v, ok := msg.Old[i][column]; ok {
+						oldRow[j] = t.canalJSONValue(j, v)
+					}
+				}
+				delete(rows, t.rowKey(oldRow))
+			}
+			rows[t.rowKey(row)] = row
+		}
+	case "DELETE":
+		for _, data := range msg.Data {
+			delete(rows, t.rowKey(t.canalJSONRow(data)))
+		}
+	default:
+		// other types, such as the watermark, don't change the data
+	}
+}
+
+func (t *table) canalJSONRow(data map[string]*string) []string {
+	row := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		v, ok := data[column]
+		if !ok {
+			row[i] = "<missing>"
+			continue
+		}
+		row[i] = t.canalJSONValue(i, v)
+	}
+	return row
+}
+
+// compareKafkaTable compares the upstream rows with the rows rebuilt from the kafka messages.
+func compareKafkaTable(
+	ctx context.Context, upstream *sql.DB, t *table, upstreamTs uint64,
+	downRows map[string][]string, maxDiffRows int, result *TableResult,
+) error {
+	upRows, err := readUpstreamRows(ctx, upstream, t, upstreamTs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	diffRows(upRows, downRows, maxDiffRows, result)
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTable() *table {
+	return &table{
+		schema:    "test",
+		name:      "t",
+		columns:   []string{"id", "name", "data", "f", "ts"},
+		types:     []string{"int", "varchar", "blob", "float", "datetime"},
+		pkOffsets: []int{0},
+	}
+}
+
+func TestApplyCanalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		rows     map[string][]string
+	}{
+		{
+			name: "insert",
+			messages: []string{
+				`{"database":"test","table":"t","type":"INSERT","es":1,"data":[{"id":"1","name":"a","data":"ÿ\u0001","f":"1.100000023841858","ts":"2024-01-01 00:00:00.100"},{"id":"2","name":null,"data":null,"f":null,"ts":null}]}`,
+			},
+			rows: map[string][]string{
+				"1": {"1", "a", "ff01", "1.1", "2024-01-01 00:00:00.1"},
+				"2": {"2", "NULL", "NULL", "NULL", "NULL"},
+			},
+		},
+		{
+			name: "update and delete",
+			messages: []string{
+				`{"database":"test","table":"t","type":"INSERT","es":1,"data":[{"id":"1","name":"a","data":"","f":"1","ts":"2024-01-01 00:00:00"},{"id":"2","name":"b","data":"","f":"2","ts":"2024-01-01 00:00:00"}]}`,
+				`{"database":"test","table":"t","type":"UPDATE","es":2,"data":[{"id":"1","name":"c","data":"","f":"1","ts":"2024-01-01 00:00:00"}],"old":[{"name":"a"}]}`,
+				`{"database":"test","table":"t","type":"DELETE","es":3,"data":[{"id":"2","name":"b","data":"","f":"2","ts":"2024-01-01 00:00:00"}]}`,
+			},
+			rows: map[string][]string{
+				"1": {"1", "c", "", "1", "2024-01-01 00:00:00"},
+			},
+		},
+		{
+			name: "update the primary key",
+			messages: []string{
+				`{"database":"test","table":"t","type":"INSERT","es":1,"data":[{"id":"1","name":"a","data":"","f":"1","ts":"2024-01-01 00:00:00"}]}`,
+				`{"database":"test","table":"t","type":"UPDATE","es":2,"data":[{"id":"3","name":"a","data":"","f":"1","ts":"2024-01-01 00:00:00"}],"old":[{"id":"1"}]}`,
+			},
+			rows: map[string][]string{
+				"3": {"3", "a", "", "1", "2024-01-01 00:00:00"},
+			},
+		},
+		{
+			name: "other tables, ddl and watermark are ignored",
+			messages: []string{
+				`{"database":"test","table":"t2","type":"INSERT","es":1,"data":[{"id":"1"}]}`,
+				`{"database":"test","table":"t","isDdl":true,"type":"CREATE","es":1}`,
+				`{"database":"test","table":"t","type":"TIDB_WATERMARK","es":1}`,
+			},
+			rows: map[string][]string{},
+		},
+		{
+			name: "missing column",
+			messages: []string{
+				`{"database":"test","table":"t","type":"INSERT","es":1,"data":[{"id":"1","name":"a"}]}`,
+			},
+			rows: map[string][]string{
+				"1": {"1", "a", "<missing>", "<missing>", "<missing>"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tbl := newTestTable()
+			tables := map[string]*table{tbl.fullName(): tbl}
+			result := map[string]map[string][]string{tbl.fullName(): {}}
+			for _, value := range tc.messages {
+				msg, err := decodeCanalJSON([]byte(value))
+				require.NoError(t, err)
+				applyCanalJSON(msg, tables, result)
+			}
+			require.Equal(t, tc.rows, result[tbl.fullName()])
+		})
+	}
+
+	_, err := decodeCanalJSON([]byte("{"))
+	require.Error(t, err)
+}
+
+func TestReplayCanalJSON(t *testing.T) {
+	tbl := newTestTable()
+	tables := map[string]*table{tbl.fullName(): tbl}
+	result := map[string]map[string][]string{tbl.fullName(): {}}
+
+	// the primary key is updated, and the new row is sent to another partition
+	// which is consumed before the partition of the old row.
+	partition1 := []string{
+		`{"database":"test","table":"t","type":"UPDATE","es":2,"_tidb":{"commitTs":200},"data":[{"id":"2","name":"a","data":"","f":"1","ts":"2024-01-01 00:00:00"}],"old":[{"id":"1"}]}`,
+	}
+	partition2 := []string{
+		`{"database":"test","table":"t","type":"INSERT","es":1,"_tidb":{"commitTs":100},"data":[{"id":"1","name":"a","data":"","f":"1","ts":"2024-01-01 00:00:00"}]}`,
+		`{"database":"test","table":"t","type":"INSERT","es":1,"_tidb":{"commitTs":100},"data":[{"id":"3","name":"b","data":"","f":"1","ts":"2024-01-01 00:00:00"}]}`,
+		`{"database":"test","table":"t","type":"DELETE","es":1,"_tidb":{"commitTs":100},"data":[{"id":"3","name":"b","data":"","f":"1","ts":"2024-01-01 00:00:00"}]}`,
+	}
+	var messages []*canalJSONMessage
+	for _, value := range append(partition1, partition2...) {
+		msg, err := decodeCanalJSON([]byte(value))
+		require.NoError(t, err)
+		messages = append(messages, msg)
+	}
+	replayCanalJSON(messages, tables, result)
+	require.Equal(t, map[string][]string{
+		"2": {"2", "a", "", "1", "2024-01-01 00:00:00"},
+	}, result[tbl.fullName()])
+}
+
+func TestCanalJSONCommitTs(t *testing.T) {
+	msg, err := decodeCanalJSON([]byte(`{"es":1000,"_tidb":{"commitTs":100}}`))
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), msg.commitTs())
+	msg, err = decodeCanalJSON([]byte(`{"es":1000}`))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000)<<18, msg.commitTs())
+}
@@ -0,0 +1,258 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+type table struct {
+	schema  string
+	name    string
+	columns []string
+	// the mysql data types of the columns, such as int, varchar and blob
+	types []string
+	// the offsets of the primary key columns in columns
+	pkOffsets []int
+}
+
+func (t *table) fullName() string {
+	return t.schema + "." + t.name
+}
+
+func (t *table) quotedName() string {
+	return fmt.Sprintf("`%s`.`%s`", t.schema, t.name)
+}
+
+func (t *table) selectColumns() string {
+	quoted := make([]string, 0, len(t.columns))
+	for _, column := range t.columns {
+		quoted = append(quoted, "`"+column+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// rowKey returns the primary key of the row, which is used to match the rows of upstream and downstream.
+func (t *table) rowKey(row []string) string {
+	values := make([]string, 0, len(t.pkOffsets))
+	for _, offset := range t.pkOffsets {
+		values = append(values, row[offset])
+	}
+	return strings.Join(values, ",")
+}
+
+// listTables returns all base tables of the schemas with their columns and primary keys.
+func listTables(ctx context.Context, db *sql.DB, schemas []string) ([]*table, error) {
+	var tables []*table
+	for _, schema := range schemas {
+		rows, err := db.QueryContext(ctx,
+			"select table_name from information_schema.tables where table_schema = ? and table_type = 'BASE TABLE' order by table_name", schema)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var names []string
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				rows.Close()
+				return nil, errors.Trace(err)
+			}
+			names = append(names, name)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, name := range names {
+			t, err := getTable(ctx, db, schema, name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			tables = append(tables, t)
+		}
+	}
+	return tables, nil
+}
+
+func getTable(ctx context.Context, db *sql.DB, schema, name string) (*table, error) {
+	t := &table{schema: schema, name: name}
+	rows, err := db.QueryContext(ctx,
+		"select column_name, column_key, data_type from information_schema.columns where table_schema = ? and table_name = ? order by ordinal_position",
+		schema, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var column, key, dataType string
+		if err := rows.Scan(&column, &key, &dataType); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if key == "PRI" {
+			t.pkOffsets = append(t.pkOffsets, len(t.columns))
+		}
+		t.columns = append(t.columns, column)
+		t.types = append(t.types, strings.ToLower(dataType))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(t.pkOffsets) == 0 {
+		// without a primary key, the whole row is the key
+		log.Warn("table has no primary key, compare by the whole row", zap.String("table", t.fullName()))
+		for i := range t.columns {
+			t.pkOffsets = append(t.pkOffsets, i)
+		}
+	}
+	return t, nil
+}
+
+// withSnapshot runs fn on a connection which reads the snapshot at ts, ts 0 means reading the latest data.
+func withSnapshot(ctx context.Context, db *sql.DB, ts uint64, fn func(conn *sql.Conn) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer conn.Close()
+	if ts != 0 {
+		if _, err := conn.ExecContext(ctx, "set @@tidb_snapshot = ?", ts); err != nil {
+			return errors.Annotatef(err, "set snapshot %d failed", ts)
+		}
+		// the connection returns to the pool after closed, so the snapshot must be reset
+		defer func() { _, _ = conn.ExecContext(context.Background(), "set @@tidb_snapshot = ''") }()
+	}
+	return fn(conn)
+}
+
+type tableChecksum struct {
+	count    int
+	checksum uint64
+}
+
+// checksum calculates the checksum of the table in the database, NULL and empty string are distinguished
+// by appending the ISNULL flags of all columns.
+func checksum(ctx context.Context, conn *sql.Conn, t *table) (tableChecksum, error) {
+	fields := make([]string, 0, 2*len(t.columns))
+	for _, column := range t.columns {
+		fields = append(fields, "`"+column+"`")
+	}
+	for _, column := range t.columns {
+		fields = append(fields, "ISNULL(`"+column+"`)")
+	}
+	query := fmt.Sprintf("select count(*), ifnull(bit_xor(cast(crc32(concat_ws(',', %s)) as unsigned)), 0) from %s",
+		strings.Join(fields, ", "), t.quotedName())
+	var result tableChecksum
+	if err := conn.QueryRowContext(ctx, query).Scan(&result.count, &result.checksum); err != nil {
+		return result, errors.Annotatef(err, "checksum table %s failed", t.fullName())
+	}
+	return result, nil
+}
+
+// readRows reads all rows of the table, NULL values are read as "NULL",
+// other values are normalized by their types.
+func readRows(ctx context.Context, conn *sql.Conn, t *table) (map[string][]string, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("select %s from %s", t.selectColumns(), t.quotedName()))
+	if err != nil {
+		return nil, errors.Annotatef(err, "read table %s failed", t.fullName())
+	}
+	defer rows.Close()
+	result := make(map[string][]string)
+	values := make([]sql.NullString, len(t.columns))
+	dest := make([]interface{}, len(t.columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, errors.Trace(err)
+		}
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = t.mysqlValue(i, v)
+		}
+		result[t.rowKey(row)] = row
+	}
+	return result, errors.Trace(rows.Err())
+}
+
+func readUpstreamRows(ctx context.Context, db *sql.DB, t *table, ts uint64) (map[string][]string, error) {
+	var rows map[string][]string
+	err := withSnapshot(ctx, db, ts, func(conn *sql.Conn) error {
+		var err error
+		rows, err = readRows(ctx, conn, t)
+		return err
+	})
+	return rows, err
+}
+
+// compareMySQLTable compares the checksum of the table first, and only reads the rows to find
+// the diffs when the checksums are different.
+func compareMySQLTable(
+	ctx context.Context, upstream, downstream *sql.DB, t *table, report *Report, maxDiffRows int, result *TableResult,
+) error {
+	var upChecksum, downChecksum tableChecksum
+	err := withSnapshot(ctx, upstream, report.UpstreamTs, func(conn *sql.Conn) error {
+		var err error
+		upChecksum, err = checksum(ctx, conn, t)
+		return err
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = withSnapshot(ctx, downstream, report.DownstreamTs, func(conn *sql.Conn) error {
+		var err error
+		downChecksum, err = checksum(ctx, conn, t)
+		return err
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if upChecksum == downChecksum {
+		result.UpstreamRows, result.DownstreamRows = upChecksum.count, downChecksum.count
+		return nil
+	}
+	log.Warn("table checksum mismatch, compare rows",
+		zap.String("table", t.fullName()),
+		zap.Int("upstreamCount", upChecksum.count), zap.Uint64("upstreamChecksum", upChecksum.checksum),
+		zap.Int("downstreamCount", downChecksum.count), zap.Uint64("downstreamChecksum", downChecksum.checksum))
+
+	upRows, err := readUpstreamRows(ctx, upstream, t, report.UpstreamTs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var downRows map[string][]string
+	err = withSnapshot(ctx, downstream, report.DownstreamTs, func(conn *sql.Conn) error {
+		var err error
+		downRows, err = readRows(ctx, conn, t)
+		return err
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	diffRows(upRows, downRows, maxDiffRows, result)
+	if result.DiffCount == 0 {
+		// the checksum is calculated from the values rendered by the databases,
+		// the same values may be rendered differently, such as the floats.
+		log.Warn("table checksum mismatch but the normalized rows are the same",
+			zap.String("table", t.fullName()))
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		name   string
+		table  *table
+		query  string
+		rows   *sqlmock.Rows
+		err    error
+		result tableChecksum
+	}{
+		{
+			name:  "single column",
+			table: &table{schema: "test", name: "t", columns: []string{"id"}},
+			query: "select count(*), ifnull(bit_xor(cast(crc32(concat_ws(',', `id`, ISNULL(`id`))) as unsigned)), 0) from `test`.`t`",
+			rows:  sqlmock.NewRows([]string{"count", "checksum"}).AddRow(3, 12345),
+			result: tableChecksum{
+				count:    3,
+				checksum: 12345,
+			},
+		},
+		{
+			name:  "null flags of all columns",
+			table: &table{schema: "test", name: "t", columns: []string{"id", "name"}},
+			query: "select count(*), ifnull(bit_xor(cast(crc32(concat_ws(',', `id`, `name`, ISNULL(`id`), ISNULL(`name`))) as unsigned)), 0) from `test`.`t`",
+			rows:  sqlmock.NewRows([]string{"count", "checksum"}).AddRow(0, 0),
+		},
+		{
+			name:  "query failed",
+			table: &table{schema: "test", name: "t", columns: []string{"id"}},
+			query: "select count(*), ifnull(bit_xor(cast(crc32(concat_ws(',', `id`, ISNULL(`id`))) as unsigned)), 0) from `test`.`t`",
+			err:   errors.New("table not exists"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			expect := mock.ExpectQuery(regexp.QuoteMeta(tc.query))
+			if tc.err != nil {
+				expect.WillReturnError(tc.err)
+			} else {
+				expect.WillReturnRows(tc.rows)
+			}
+
+			conn, err := db.Conn(context.Background())
+			require.NoError(t, err)
+			defer conn.Close()
+			result, err := checksum(context.Background(), conn, tc.table)
+			if tc.err != nil {
+				require.ErrorContains(t, err, "checksum table test.t failed")
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.result, result)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReadRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	tbl := &table{
+		schema:    "test",
+		name:      "t",
+		columns:   []string{"id", "data", "b", "f", "d", "ts"},
+		types:     []string{"int", "varbinary", "bit", "float", "double", "timestamp"},
+		pkOffsets: []int{0},
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("select `id`, `data`, `b`, `f`, `d`, `ts` from `test`.`t`")).
+		WillReturnRows(sqlmock.NewRows(tbl.columns).
+			AddRow("1", []byte{0xff, 0x01}, []byte{0x01, 0x02}, "1.1", "1e+20", "2024-01-01 00:00:00.500000").
+			AddRow("2", nil, nil, nil, nil, nil))
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+	rows, err := readRows(context.Background(), conn, tbl)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		"1": {"1", "ff01", "258", "1.1", "1e+20", "2024-01-01 00:00:00.5"},
+		"2": {"2", "NULL", "NULL", "NULL", "NULL", "NULL"},
+	}, rows)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNormalizeValue(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		tp        string
+		mysql     sql.NullString
+		canalJSON *string
+		expected  string
+	}{
+		{tp: "varchar", mysql: sql.NullString{}, canalJSON: nil, expected: "NULL"},
+		{tp: "varchar", mysql: sql.NullString{String: "", Valid: true}, canalJSON: str(""), expected: ""},
+		{tp: "blob", mysql: sql.NullString{String: "\xe4\xb8\xad", Valid: true}, canalJSON: str("ä¸­"), expected: "e4b8ad"},
+		{tp: "binary", mysql: sql.NullString{String: "a\x00", Valid: true}, canalJSON: str("a\u0000"), expected: "6100"},
+		{tp: "bit", mysql: sql.NullString{String: "\x00\x05", Valid: true}, canalJSON: str("5"), expected: "5"},
+		{tp: "float", mysql: sql.NullString{String: "3.14", Valid: true}, canalJSON: str("3.140000104904175"), expected: "3.14"},
+		{tp: "double", mysql: sql.NullString{String: "0.1", Valid: true}, canalJSON: str("0.10"), expected: "0.1"},
+		{tp: "datetime", mysql: sql.NullString{String: "2024-01-01 00:00:00.000", Valid: true}, canalJSON: str("2024-01-01 00:00:00"), expected: "2024-01-01 00:00:00"},
+		{tp: "time", mysql: sql.NullString{String: "12:00:00.120", Valid: true}, canalJSON: str("12:00:00.12"), expected: "12:00:00.12"},
+		{tp: "decimal", mysql: sql.NullString{String: "1.20", Valid: true}, canalJSON: str("1.20"), expected: "1.20"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.tp, func(t *testing.T) {
+			tbl := &table{columns: []string{"c"}, types: []string{tc.tp}}
+			require.Equal(t, tc.expected, tbl.mysqlValue(0, tc.mysql))
+			require.Equal(t, tc.expected, tbl.canalJSONValue(0, tc.canalJSON))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const nullValue = "NULL"
+
+// The values of the same row are rendered differently by the databases and the canal-json
+// messages, they are normalized by the column types before being compared:
+//   - binary values are hex encoded, canal-json encodes the bytes in ISO-8859-1.
+//   - bit values are unsigned integers, mysql returns the bytes in big endian.
+//   - float values are rendered in the shortest form of the precision.
+//   - the trailing zeros of the fractional seconds are removed.
+
+func isBinaryType(tp string) bool {
+	switch tp {
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		return true
+	default:
+	}
+	return false
+}
+
+// mysqlValue normalizes the value read from the database.
+func (t *table) mysqlValue(i int, v sql.NullString) string {
+	if !v.Valid {
+		return nullValue
+	}
+	tp := t.types[i]
+	switch {
+	case isBinaryType(tp):
+		return hex.EncodeToString([]byte(v.String))
+	case tp == "bit":
+		var buf [8]byte
+		raw := []byte(v.String)
+		if len(raw) > len(buf) {
+			return v.String
+		}
+		copy(buf[len(buf)-len(raw):], raw)
+		return strconv.FormatUint(binary.BigEndian.Uint64(buf[:]), 10)
+	default:
+	}
+	return normalizeText(tp, v.String)
+}
+
+// canalJSONValue normalizes the value in the canal-json message.
+func (t *table) canalJSONValue(i int, v *string) string {
+	if v == nil {
+		return nullValue
+	}
+	tp := t.types[i]
+	if isBinaryType(tp) {
+		raw := make([]byte, 0, len(*v))
+		for _, r := range *v {
+			if r > 0xff {
+				// not encoded in ISO-8859-1, keep it as is to report the diff
+				return *v
+			}
+			raw = append(raw, byte(r))
+		}
+		return hex.EncodeToString(raw)
+	}
+	return normalizeText(tp, *v)
+}
+
+func normalizeText(tp, v string) string {
+	switch tp {
+	case "float", "double":
+		bitSize := 64
+		if tp == "float" {
+			bitSize = 32
+		}
+		f, err := strconv.ParseFloat(v, bitSize)
+		if err != nil {
+			return v
+		}
+		return strconv.FormatFloat(f, 'g', -1, bitSize)
+	case "datetime", "timestamp", "time":
+		if strings.Contains(v, ".") {
+			v = strings.TrimRight(v, "0")
+			v = strings.TrimSuffix(v, ".")
+		}
+	default:
+	}
+	return v
+}
@@ -0,0 +1,248 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+const (
+	// ModeCheckpoint compares the tables after the checkpoint of the changefeed passes the target ts,
+	// the upstream must not be written during verifying.
+	ModeCheckpoint = "checkpoint"
+	// ModeSyncpoint compares the snapshots of the upstream and downstream recorded by a syncpoint,
+	// it only works for TiDB downstream with syncpoint enabled.
+	ModeSyncpoint = "syncpoint"
+
+	DownstreamMySQL = "mysql"
+	DownstreamKafka = "kafka"
+)
+
+// Config is the config to verify the data replicated by a changefeed.
+type Config struct {
+	// CDCAddr is the address of a TiCDC server to query the changefeed status.
+	CDCAddr      string
+	ChangefeedID string
+	Mode         string
+	Timeout      time.Duration
+	// MaxDiffRows is the max number of different rows reported for each table.
+	MaxDiffRows int
+
+	DownstreamType string
+	KafkaAddrs     []string
+	KafkaTopic     string
+	KafkaProtocol  string
+}
+
+// RowDiff is a row which is different between upstream and downstream,
+// the values are nil if the row doesn't exist in one side.
+type RowDiff struct {
+	Key        string
+	Upstream   []string
+	Downstream []string
+}
+
+// TableResult is the result of verifying a table.
+type TableResult struct {
+	Schema         string
+	Table          string
+	Columns        []string
+	UpstreamRows   int
+	DownstreamRows int
+	Diffs          []RowDiff
+	// DiffCount is the total number of different rows, only MaxDiffRows of them are in Diffs.
+	DiffCount int
+	Err       error
+}
+
+func (r *TableResult) passed() bool {
+	return r.Err == nil && r.DiffCount == 0
+}
+
+// Report is the result of verifying all tables.
+type Report struct {
+	UpstreamTs   uint64
+	DownstreamTs uint64
+	Tables       []*TableResult
+}
+
+// Passed returns true if all tables are the same between upstream and downstream.
+func (r *Report) Passed() bool {
+	for _, table := range r.Tables {
+		if !table.passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Print writes the report in a human-readable format.
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "verify upstream ts %d, downstream ts %d\n", r.UpstreamTs, r.DownstreamTs)
+	for _, table := range r.Tables {
+		status := "PASS"
+		if !table.passed() {
+			status = "FAIL"
+		}
+		fmt.Fprintf(w, "[%s] %s.%s upstream rows: %d, downstream rows: %d, diff rows: %d\n",
+			status, table.Schema, table.Table, table.UpstreamRows, table.DownstreamRows, table.DiffCount)
+		if table.Err != nil {
+			fmt.Fprintf(w, "  error: %s\n", table.Err)
+		}
+		for _, diff := range table.Diffs {
+			fmt.Fprintf(w, "  key %s\n    upstream:   %s\n    downstream: %s\n",
+				diff.Key, formatRow(table.Columns, diff.Upstream), formatRow(table.Columns, diff.Downstream))
+		}
+	}
+	if r.Passed() {
+		fmt.Fprintln(w, "verify result: PASS")
+	} else {
+		fmt.Fprintln(w, "verify result: FAIL")
+	}
+}
+
+func formatRow(columns []string, values []string) string {
+	if values == nil {
+		return "<missing>"
+	}
+	fields := make([]string, 0, len(values))
+	for i, v := range values {
+		fields = append(fields, fmt.Sprintf("%s=%s", columns[i], v))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// Run waits for the changefeed to replicate all data written to the upstream schemas,
+// then compares all tables of the schemas between upstream and downstream.
+// The downstream is nil if the downstream type is kafka.
+func Run(ctx context.Context, cfg *Config, upstream *sql.DB, downstream *sql.DB, schemas []string) (*Report, error) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	targetTs, err := currentTs(ctx, upstream)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	log.Info("start to verify", zap.String("changefeed", cfg.ChangefeedID),
+		zap.String("mode", cfg.Mode), zap.Uint64("targetTs", targetTs))
+
+	report := &Report{UpstreamTs: targetTs}
+	switch cfg.Mode {
+	case ModeCheckpoint:
+		if err := waitCheckpoint(ctx, cfg, targetTs); err != nil {
+			return nil, errors.Trace(err)
+		}
+	case ModeSyncpoint:
+		if cfg.DownstreamType != DownstreamMySQL {
+			return nil, errors.Errorf("syncpoint mode is not supported by %s downstream", cfg.DownstreamType)
+		}
+		report.UpstreamTs, report.DownstreamTs, err = waitSyncpoint(ctx, cfg, downstream, targetTs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.Errorf("unknown verify mode %s", cfg.Mode)
+	}
+
+	tables, err := listTables(ctx, upstream, schemas)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var kafkaRows map[string]map[string][]string
+	if cfg.DownstreamType == DownstreamKafka {
+		kafkaRows, err = consumeKafka(ctx, cfg, tables)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	for _, table := range tables {
+		result := &TableResult{Schema: table.schema, Table: table.name, Columns: table.columns}
+		switch cfg.DownstreamType {
+		case DownstreamMySQL:
+			result.Err = compareMySQLTable(ctx, upstream, downstream, table, report, cfg.MaxDiffRows, result)
+		case DownstreamKafka:
+			result.Err = compareKafkaTable(ctx, upstream, table, report.UpstreamTs, kafkaRows[table.fullName()], cfg.MaxDiffRows, result)
+		default:
+			return nil, errors.Errorf("unknown downstream type %s", cfg.DownstreamType)
+		}
+		log.Info("verify table done",
+			zap.String("schema", table.schema), zap.String("table", table.name),
+			zap.Bool("passed", result.passed()), zap.Int("diffCount", result.DiffCount), zap.Error(result.Err))
+		report.Tables = append(report.Tables, result)
+	}
+	return report, nil
+}
+
+func currentTs(ctx context.Context, db *sql.DB) (uint64, error) {
+	var ts uint64
+	// a new transaction is needed to get the current ts
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := tx.QueryRowContext(ctx, "select @@tidb_current_ts").Scan(&ts); err != nil {
+		return 0, errors.Annotate(err, "get upstream current ts failed")
+	}
+	return ts, nil
+}
+
+// diffRows compares the rows of upstream and downstream, and reports at most maxDiffRows of the diffs.
+func diffRows(upstream, downstream map[string][]string, maxDiffRows int, result *TableResult) {
+	result.UpstreamRows, result.DownstreamRows = len(upstream), len(downstream)
+	keys := make([]string, 0, len(upstream))
+	for key := range upstream {
+		keys = append(keys, key)
+	}
+	for key := range downstream {
+		if _, ok := upstream[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		up, down := upstream[key], downstream[key]
+		if up != nil && down != nil && equalRow(up, down) {
+			continue
+		}
+		result.DiffCount++
+		if len(result.Diffs) < maxDiffRows {
+			result.Diffs = append(result.Diffs, RowDiff{Key: key, Upstream: up, Downstream: down})
+		}
+	}
+}
+
+func equalRow(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
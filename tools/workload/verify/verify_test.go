@@ -0,0 +1,88 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRows(t *testing.T) {
+	tests := []struct {
+		name        string
+		upstream    map[string][]string
+		downstream  map[string][]string
+		maxDiffRows int
+		diffCount   int
+		diffs       []RowDiff
+	}{
+		{
+			name:        "same rows",
+			upstream:    map[string][]string{"1": {"1", "a"}, "2": {"2", "NULL"}},
+			downstream:  map[string][]string{"1": {"1", "a"}, "2": {"2", "NULL"}},
+			maxDiffRows: 10,
+		},
+		{
+			name:        "empty tables",
+			upstream:    map[string][]string{},
+			downstream:  map[string][]string{},
+			maxDiffRows: 10,
+		},
+		{
+			name:        "different values",
+			upstream:    map[string][]string{"1": {"1", "a"}, "2": {"2", ""}},
+			downstream:  map[string][]string{"1": {"1", "b"}, "2": {"2", "NULL"}},
+			maxDiffRows: 10,
+			diffCount:   2,
+			diffs: []RowDiff{
+				{Key: "1", Upstream: []string{"1", "a"}, Downstream: []string{"1", "b"}},
+				{Key: "2", Upstream: []string{"2", ""}, Downstream: []string{"2", "NULL"}},
+			},
+		},
+		{
+			name:        "missing rows on both sides",
+			upstream:    map[string][]string{"1": {"1", "a"}, "2": {"2", "b"}},
+			downstream:  map[string][]string{"2": {"2", "b"}, "3": {"3", "c"}},
+			maxDiffRows: 10,
+			diffCount:   2,
+			diffs: []RowDiff{
+				{Key: "1", Upstream: []string{"1", "a"}},
+				{Key: "3", Downstream: []string{"3", "c"}},
+			},
+		},
+		{
+			name:        "diffs are limited",
+			upstream:    map[string][]string{"1": {"1"}, "2": {"2"}, "3": {"3"}},
+			downstream:  map[string][]string{},
+			maxDiffRows: 2,
+			diffCount:   3,
+			diffs: []RowDiff{
+				{Key: "1", Upstream: []string{"1"}},
+				{Key: "2", Upstream: []string{"2"}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := &TableResult{}
+			diffRows(tc.upstream, tc.downstream, tc.maxDiffRows, result)
+			require.Equal(t, len(tc.upstream), result.UpstreamRows)
+			require.Equal(t, len(tc.downstream), result.DownstreamRows)
+			require.Equal(t, tc.diffCount, result.DiffCount)
+			require.Equal(t, tc.diffs, result.Diffs)
+			require.Equal(t, tc.diffCount == 0, result.passed())
+		})
+	}
+}
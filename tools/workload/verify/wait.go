@@ -0,0 +1,142 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+const (
+	waitInterval = 2 * time.Second
+	// syncpointTable is the table the changefeed writes the ts-map to when syncpoint is enabled.
+	syncpointTable = "tidb_cdc.syncpoint_v1"
+	// defaultNamespace is the namespace of changefeeds created without one.
+	defaultNamespace = "default"
+)
+
+// changefeedStatus is the part of the changefeed info returned by the TiCDC open api.
+type changefeedStatus struct {
+	State        string `json:"state"`
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+	Error        *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func getChangefeedStatus(ctx context.Context, cfg *Config) (*changefeedStatus, error) {
+	addr := cfg.CDCAddr
+	if !strings.HasPrefix(addr, "http://") && !strings.HasPrefix(addr, "https://") {
+		addr = "http://" + addr
+	}
+	url := fmt.Sprintf("%s/api/v2/changefeeds/%s", strings.TrimSuffix(addr, "/"), cfg.ChangefeedID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("get changefeed %s failed, status: %d, body: %s", cfg.ChangefeedID, resp.StatusCode, body)
+	}
+	status := &changefeedStatus{}
+	if err := json.Unmarshal(body, status); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return status, nil
+}
+
+// waitCheckpoint waits until the checkpoint of the changefeed is not less than targetTs.
+func waitCheckpoint(ctx context.Context, cfg *Config, targetTs uint64) error {
+	ticker := time.NewTicker(waitInterval)
+	defer ticker.Stop()
+	for {
+		status, err := getChangefeedStatus(ctx, cfg)
+		if err != nil {
+			// the server may be restarting, just retry until timeout
+			log.Warn("get changefeed status failed", zap.String("changefeed", cfg.ChangefeedID), zap.Error(err))
+		} else {
+			switch status.State {
+			case "failed", "stopped", "removed", "finished":
+				msg := ""
+				if status.Error != nil {
+					msg = status.Error.Message
+				}
+				return errors.Errorf("changefeed %s is %s, error: %s", cfg.ChangefeedID, status.State, msg)
+			}
+			if status.CheckpointTs >= targetTs {
+				log.Info("changefeed checkpoint reached",
+					zap.Uint64("checkpointTs", status.CheckpointTs), zap.Uint64("targetTs", targetTs))
+				return nil
+			}
+			log.Info("wait changefeed checkpoint",
+				zap.String("state", status.State),
+				zap.Uint64("checkpointTs", status.CheckpointTs), zap.Uint64("targetTs", targetTs))
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Annotatef(ctx.Err(), "wait checkpoint of changefeed %s to reach %d", cfg.ChangefeedID, targetTs)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitSyncpoint waits until the changefeed writes a syncpoint whose primary ts is not less than targetTs,
+// and returns the primary ts and secondary ts of the syncpoint.
+func waitSyncpoint(ctx context.Context, cfg *Config, downstream *sql.DB, targetTs uint64) (uint64, uint64, error) {
+	// the syncpoint table records the changefeed as namespace/id
+	changefeed := defaultNamespace + "/" + cfg.ChangefeedID
+	query := fmt.Sprintf("select primary_ts, secondary_ts from %s where changefeed = ? and primary_ts >= ? order by primary_ts limit 1", syncpointTable)
+
+	ticker := time.NewTicker(waitInterval)
+	defer ticker.Stop()
+	for {
+		var primaryTs, secondaryTs uint64
+		err := downstream.QueryRowContext(ctx, query, changefeed, targetTs).Scan(&primaryTs, &secondaryTs)
+		switch {
+		case err == nil:
+			log.Info("changefeed syncpoint reached",
+				zap.Uint64("primaryTs", primaryTs), zap.Uint64("secondaryTs", secondaryTs), zap.Uint64("targetTs", targetTs))
+			return primaryTs, secondaryTs, nil
+		case err == sql.ErrNoRows:
+			log.Info("wait changefeed syncpoint", zap.String("changefeed", changefeed), zap.Uint64("targetTs", targetTs))
+		default:
+			// the syncpoint table may not be created yet
+			log.Warn("query syncpoint failed", zap.String("changefeed", changefeed), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return 0, 0, errors.Annotatef(ctx.Err(), "wait syncpoint of changefeed %s to reach %d", changefeed, targetTs)
+		case <-ticker.C:
+		}
+	}
+}
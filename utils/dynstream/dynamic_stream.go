@@ -355,6 +355,13 @@ func (d *dynamicStreamImpl[A, P, T, D, H]) GetMetrics() Metrics {
 	return m
 }
 
+func (d *dynamicStreamImpl[A, P, T, D, H]) GetAreaPendingSize(area A) int64 {
+	if d.memControl == nil {
+		return 0
+	}
+	return d.memControl.getAreaPendingSize(area)
+}
+
 // Make the scheduler to balance immediately. Only used for test.
 func (d *dynamicStreamImpl[A, P, T, D, H]) reportAndSchedule(rule ruleType, period time.Duration) {
 	rs := &reportAndScheduleCmd{rule: rule, period: period}
@@ -159,6 +159,9 @@ type DynamicStream[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] inter
 	// SetAreaSettings sets the settings of the area. An area uses the default settings if it is not set.
 	// This method can be called at any time. But to avoid the memory leak, setting on a area without existing paths is a no-op.
 	SetAreaSettings(area A, settings AreaSettings)
+	// GetAreaPendingSize returns the total size of the pending events of the area.
+	// Return zero if Option.EnableMemoryControl is false.
+	GetAreaPendingSize(area A) int64
 
 	GetMetrics() Metrics
 }
@@ -310,6 +310,16 @@ func (m *memControl[A, P, T, D, H]) removePathFromArea(path *pathInfo[A, P, T, D
 	}
 }
 
+// getAreaPendingSize returns the total size of the pending events of the area.
+func (m *memControl[A, P, T, D, H]) getAreaPendingSize(area A) int64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if as, ok := m.areaStatMap[area]; ok {
+		return as.totalPendingSize.Load()
+	}
+	return 0
+}
+
 // FIXME/TODO: We use global metric here, which is not good for multiple streams.
 func (m *memControl[A, P, T, D, H]) updateMetrics() {
 	m.mutex.Lock()
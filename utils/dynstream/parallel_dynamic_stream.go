@@ -18,6 +18,14 @@ func newParallelDynamicStream[A Area, P Path, T Event, D Dest, H Handler[A, P, T
 	for range streamCount {
 		s.dynamicStreams = append(s.dynamicStreams, newDynamicStreamImpl(handler, option, s.feedbackChan))
 	}
+	if option.EnableMemoryControl {
+		// All streams share the same memControl, so the max pending size of an area
+		// bounds the area across all streams instead of each stream.
+		memControl := newMemControl[A, P, T, D, H]()
+		for _, ds := range s.dynamicStreams {
+			ds.memControl = memControl
+		}
+	}
 	return s
 }
 
@@ -81,3 +89,8 @@ func (s *parallelDynamicStream[A, P, T, D, H]) GetMetrics() Metrics {
 	}
 	return metrics
 }
+
+func (s *parallelDynamicStream[A, P, T, D, H]) GetAreaPendingSize(area A) int64 {
+	// the memControl is shared by all streams
+	return s.dynamicStreams[0].GetAreaPendingSize(area)
+}